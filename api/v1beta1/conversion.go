@@ -0,0 +1,1003 @@
+/*
+ * conversion.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// deprecatedFieldsKey is the key in the ConversionDataAnnotation that contains the original values of the fields that
+// were changed by the migration of the deprecated fields.
+const deprecatedFieldsKey = "deprecatedFields"
+
+// ConvertTo converts this FoundationDBCluster to the Hub version (v1beta2).
+func (cluster *FoundationDBCluster) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1beta2.FoundationDBCluster)
+	if !ok {
+		return fmt.Errorf("unsupported conversion target: %T", dstRaw)
+	}
+
+	// The deprecated fields are moved to their replacements before the conversion, otherwise they would only be stored
+	// in the conversion annotation and the operator would ignore them.
+	src := cluster.DeepCopy()
+	err := migrateDeprecatedFields(src)
+	if err != nil {
+		return err
+	}
+
+	// The deprecated flags for removals and exclusions are represented by the timestamps in v1beta2, so they are
+	// cleared before the conversion and not stored in the conversion annotation.
+	removed := make([]bool, len(src.Status.ProcessGroups))
+	excluded := make([]bool, len(src.Status.ProcessGroups))
+	for idx, processGroup := range src.Status.ProcessGroups {
+		if processGroup == nil {
+			continue
+		}
+
+		removed[idx] = processGroup.Remove
+		excluded[idx] = processGroup.Excluded
+		processGroup.Remove = false
+		processGroup.Excluded = false
+	}
+
+	err = convertObject(src, dst)
+	if err != nil {
+		return err
+	}
+
+	// If the timestamp is missing we use the creation timestamp of the cluster, as we don't know when the process group
+	// was marked.
+	for idx := range src.Status.ProcessGroups {
+		if idx >= len(dst.Status.ProcessGroups) || dst.Status.ProcessGroups[idx] == nil {
+			continue
+		}
+
+		if removed[idx] && dst.Status.ProcessGroups[idx].RemovalTimestamp == nil {
+			dst.Status.ProcessGroups[idx].RemovalTimestamp = cluster.CreationTimestamp.DeepCopy()
+		}
+
+		if excluded[idx] && dst.Status.ProcessGroups[idx].ExclusionTimestamp == nil {
+			dst.Status.ProcessGroups[idx].ExclusionTimestamp = cluster.CreationTimestamp.DeepCopy()
+		}
+	}
+
+	return storeDeprecatedFields(cluster, dst)
+}
+
+// ConvertFrom converts the Hub version (v1beta2) to this FoundationDBCluster. All fields of v1beta2 have a
+// representation under the same name in v1beta1, so the deprecated v1beta1 fields that were migrated by ConvertTo are
+// reported in their replacement fields. If the replacement fields were not changed since the last conversion from
+// v1beta1, the original values of the deprecated fields and their replacements are restored.
+func (cluster *FoundationDBCluster) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1beta2.FoundationDBCluster)
+	if !ok {
+		return fmt.Errorf("unsupported conversion source: %T", srcRaw)
+	}
+
+	err := cluster.convertFrom(src)
+	if err != nil {
+		return err
+	}
+
+	return restoreDeprecatedFields(src, cluster)
+}
+
+// convertFrom converts the Hub version (v1beta2) to this FoundationDBCluster without restoring the deprecated fields.
+func (cluster *FoundationDBCluster) convertFrom(src *v1beta2.FoundationDBCluster) error {
+	err := convertObject(src, cluster)
+	if err != nil {
+		return err
+	}
+
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup == nil {
+			continue
+		}
+
+		processGroup.Remove = processGroup.RemovalTimestamp != nil && !processGroup.RemovalTimestamp.IsZero()
+		processGroup.Excluded = processGroup.ExclusionTimestamp != nil && !processGroup.ExclusionTimestamp.IsZero()
+	}
+
+	return nil
+}
+
+// storeDeprecatedFields stores the original values of all fields that are changed by the migration of the deprecated
+// fields in the ConversionDataAnnotation of the destination. The values that a conversion of the destination back to
+// v1beta1 results in are stored next to them, so ConvertFrom can detect if the replacement fields were changed in
+// v1beta2 in the meantime.
+func storeDeprecatedFields(cluster *FoundationDBCluster, dst *v1beta2.FoundationDBCluster) error {
+	converted := &FoundationDBCluster{}
+	err := converted.convertFrom(dst)
+	if err != nil {
+		return err
+	}
+
+	originalContent, err := toContent(cluster)
+	if err != nil {
+		return err
+	}
+
+	convertedContent, err := toContent(converted)
+	if err != nil {
+		return err
+	}
+
+	original := getChangedFields(originalContent, convertedContent)
+	if len(original) == 0 {
+		return nil
+	}
+
+	data := map[string]interface{}{}
+	annotations := dst.GetAnnotations()
+	if annotation, ok := annotations[ConversionDataAnnotation]; ok {
+		err = json.Unmarshal([]byte(annotation), &data)
+		if err != nil {
+			return err
+		}
+	}
+
+	data[deprecatedFieldsKey] = map[string]interface{}{
+		"original":  original,
+		"converted": getChangedFields(convertedContent, originalContent),
+	}
+
+	annotation, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[ConversionDataAnnotation] = string(annotation)
+	dst.SetAnnotations(annotations)
+
+	return nil
+}
+
+// restoreDeprecatedFields restores the original values of the fields that were changed by the migration of the
+// deprecated fields, if they were stored in the ConversionDataAnnotation of the source. The values are only restored
+// if the converted cluster still has the values from the time the deprecated fields were migrated, otherwise the
+// values from v1beta2 are kept.
+func restoreDeprecatedFields(src *v1beta2.FoundationDBCluster, cluster *FoundationDBCluster) error {
+	annotation, ok := src.GetAnnotations()[ConversionDataAnnotation]
+	if !ok {
+		return nil
+	}
+
+	var data struct {
+		DeprecatedFields *struct {
+			Original  map[string]interface{} `json:"original"`
+			Converted map[string]interface{} `json:"converted"`
+		} `json:"deprecatedFields"`
+	}
+	err := json.Unmarshal([]byte(annotation), &data)
+	if err != nil {
+		return err
+	}
+
+	if data.DeprecatedFields == nil {
+		return nil
+	}
+
+	content, err := toMap(cluster)
+	if err != nil {
+		return err
+	}
+
+	if !fieldsMatch(content, data.DeprecatedFields.Converted) {
+		return nil
+	}
+
+	setFields(content, data.DeprecatedFields.Original)
+	restored := &FoundationDBCluster{}
+	err = fromMap(content, restored)
+	if err != nil {
+		return err
+	}
+
+	*cluster = *restored
+
+	return nil
+}
+
+// migrateDeprecatedFields moves the values of the deprecated fields of the cluster to the fields that replaced them.
+// The replacement fields take precedence, the deprecated values are only used to fill the gaps. Deprecated fields
+// without a replacement are kept and will be stored in the conversion annotation.
+func migrateDeprecatedFields(cluster *FoundationDBCluster) error {
+	spec := &cluster.Spec
+
+	spec.ProcessGroupsToRemove = appendMissingIDs(spec.ProcessGroupsToRemove, spec.InstancesToRemove)
+	spec.InstancesToRemove = nil
+
+	// The pending removals in the spec are keyed by the Pod name, which is the process group ID prefixed with the
+	// cluster name.
+	pendingRemovals := make([]string, 0, len(spec.PendingRemovals))
+	for podName := range spec.PendingRemovals {
+		pendingRemovals = append(pendingRemovals, strings.TrimPrefix(podName, cluster.Name+"-"))
+	}
+	sort.Strings(pendingRemovals)
+	spec.ProcessGroupsToRemove = appendMissingIDs(spec.ProcessGroupsToRemove, pendingRemovals)
+	spec.PendingRemovals = nil
+
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup == nil {
+			continue
+		}
+
+		removalState, ok := cluster.Status.PendingRemovals[processGroup.ProcessGroupID]
+		if !ok {
+			continue
+		}
+
+		processGroup.Remove = true
+		processGroup.Excluded = processGroup.Excluded || removalState.ExclusionComplete
+		delete(cluster.Status.PendingRemovals, processGroup.ProcessGroupID)
+	}
+
+	if len(cluster.Status.PendingRemovals) == 0 {
+		cluster.Status.PendingRemovals = nil
+	}
+
+	spec.ProcessGroupsToRemoveWithoutExclusion = appendMissingIDs(spec.ProcessGroupsToRemoveWithoutExclusion, spec.InstancesToRemoveWithoutExclusion)
+	spec.InstancesToRemoveWithoutExclusion = nil
+
+	if spec.ProcessGroupIDPrefix == "" {
+		spec.ProcessGroupIDPrefix = spec.InstanceIDPrefix
+	}
+	spec.InstanceIDPrefix = ""
+
+	if spec.UpdatePodsByReplacement && spec.AutomationOptions.PodUpdateStrategy == "" {
+		spec.AutomationOptions.PodUpdateStrategy = PodUpdateStrategyReplacement
+	}
+	spec.UpdatePodsByReplacement = false
+
+	if spec.AutomationOptions.DeletePods != nil && !*spec.AutomationOptions.DeletePods && spec.AutomationOptions.DeletionMode == "" {
+		spec.AutomationOptions.DeletionMode = PodUpdateModeNone
+	}
+	spec.AutomationOptions.DeletePods = nil
+
+	if spec.Routing.HeadlessService == nil {
+		spec.Routing.HeadlessService = spec.Services.Headless
+	}
+	if spec.Routing.PublicIPSource == nil {
+		spec.Routing.PublicIPSource = spec.Services.PublicIPSource
+	}
+	spec.Services = ServiceConfig{}
+
+	for _, overrides := range []*ContainerOverrides{&spec.MainContainer, &spec.SidecarContainer} {
+		if overrides.ImageName != "" {
+			overrides.ImageConfigs = append(overrides.ImageConfigs, ImageConfig{BaseImage: overrides.ImageName})
+		}
+		overrides.ImageName = ""
+	}
+
+	versions := make([]string, 0, len(spec.SidecarVersions))
+	for version := range spec.SidecarVersions {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+	for _, version := range versions {
+		spec.SidecarContainer.ImageConfigs = append(spec.SidecarContainer.ImageConfigs, ImageConfig{
+			Version:   version,
+			TagSuffix: fmt.Sprintf("-%d", spec.SidecarVersions[version]),
+		})
+	}
+	spec.SidecarVersions = nil
+
+	if spec.SidecarVersion > 0 {
+		spec.SidecarContainer.ImageConfigs = append(spec.SidecarContainer.ImageConfigs, ImageConfig{
+			TagSuffix: fmt.Sprintf("-%d", spec.SidecarVersion),
+		})
+	}
+	spec.SidecarVersion = 0
+
+	if spec.Processes == nil {
+		spec.Processes = map[ProcessClass]ProcessSettings{}
+	}
+
+	for processClass, settings := range spec.Processes {
+		if settings.VolumeClaimTemplate == nil {
+			settings.VolumeClaimTemplate = settings.VolumeClaim
+		}
+		settings.VolumeClaim = nil
+		spec.Processes[processClass] = settings
+	}
+
+	generalSettings := spec.Processes[ProcessClassGeneral]
+	err := migrateDeprecatedProcessSettings(spec, &generalSettings)
+	if err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(generalSettings, ProcessSettings{}) {
+		spec.Processes[ProcessClassGeneral] = generalSettings
+	}
+
+	if len(spec.Processes) == 0 {
+		spec.Processes = nil
+	}
+
+	if cluster.Status.RunningVersion == "" {
+		cluster.Status.RunningVersion = spec.RunningVersion
+	}
+	spec.RunningVersion = ""
+
+	if cluster.Status.ConnectionString == "" {
+		cluster.Status.ConnectionString = spec.ConnectionString
+	}
+	spec.ConnectionString = ""
+
+	cluster.Status.Configured = cluster.Status.Configured || spec.Configured
+	spec.Configured = false
+
+	return nil
+}
+
+// migrateDeprecatedProcessSettings moves the deprecated Pod and volume fields of the cluster spec into the general
+// process settings.
+func migrateDeprecatedProcessSettings(spec *FoundationDBClusterSpec, settings *ProcessSettings) error {
+	if settings.PodTemplate == nil && spec.PodTemplate != nil {
+		settings.PodTemplate = spec.PodTemplate.DeepCopy()
+	}
+	spec.PodTemplate = nil
+
+	if len(settings.CustomParameters) == 0 {
+		settings.CustomParameters = spec.CustomParameters
+	}
+	spec.CustomParameters = nil
+
+	if settings.VolumeClaimTemplate == nil && spec.VolumeClaim != nil {
+		settings.VolumeClaimTemplate = spec.VolumeClaim.DeepCopy()
+	}
+	spec.VolumeClaim = nil
+
+	if spec.StorageClass != nil || spec.VolumeSize != "" {
+		if settings.VolumeClaimTemplate == nil {
+			settings.VolumeClaimTemplate = &corev1.PersistentVolumeClaim{}
+		}
+
+		if settings.VolumeClaimTemplate.Spec.StorageClassName == nil {
+			settings.VolumeClaimTemplate.Spec.StorageClassName = spec.StorageClass
+		}
+
+		if spec.VolumeSize != "" {
+			if settings.VolumeClaimTemplate.Spec.Resources.Requests == nil {
+				settings.VolumeClaimTemplate.Spec.Resources.Requests = corev1.ResourceList{}
+			}
+
+			if _, ok := settings.VolumeClaimTemplate.Spec.Resources.Requests[corev1.ResourceStorage]; !ok {
+				size, err := resource.ParseQuantity(spec.VolumeSize)
+				if err != nil {
+					return fmt.Errorf("could not parse volumeSize %s: %w", spec.VolumeSize, err)
+				}
+				settings.VolumeClaimTemplate.Spec.Resources.Requests[corev1.ResourceStorage] = size
+			}
+		}
+	}
+	spec.StorageClass = nil
+	spec.VolumeSize = ""
+
+	hasPodFields := len(spec.PodLabels) > 0 || spec.Resources != nil || len(spec.InitContainers) > 0 ||
+		len(spec.Containers) > 0 || len(spec.Volumes) > 0 || spec.PodSecurityContext != nil ||
+		spec.AutomountServiceAccountToken != nil || hasDeprecatedContainerFields(spec.MainContainer) ||
+		hasDeprecatedContainerFields(spec.SidecarContainer)
+	if !hasPodFields {
+		return nil
+	}
+
+	if settings.PodTemplate == nil {
+		settings.PodTemplate = &corev1.PodTemplateSpec{}
+	}
+	podTemplate := settings.PodTemplate
+
+	for key, value := range spec.PodLabels {
+		if podTemplate.Labels == nil {
+			podTemplate.Labels = map[string]string{}
+		}
+
+		if _, ok := podTemplate.Labels[key]; !ok {
+			podTemplate.Labels[key] = value
+		}
+	}
+	spec.PodLabels = nil
+
+	podTemplate.Spec.InitContainers = appendMissingContainers(podTemplate.Spec.InitContainers, spec.InitContainers)
+	spec.InitContainers = nil
+
+	podTemplate.Spec.Containers = appendMissingContainers(podTemplate.Spec.Containers, spec.Containers)
+	spec.Containers = nil
+
+	for _, volume := range spec.Volumes {
+		found := false
+		for _, existing := range podTemplate.Spec.Volumes {
+			if existing.Name == volume.Name {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes, volume)
+		}
+	}
+	spec.Volumes = nil
+
+	if podTemplate.Spec.SecurityContext == nil {
+		podTemplate.Spec.SecurityContext = spec.PodSecurityContext
+	}
+	spec.PodSecurityContext = nil
+
+	if podTemplate.Spec.AutomountServiceAccountToken == nil {
+		podTemplate.Spec.AutomountServiceAccountToken = spec.AutomountServiceAccountToken
+	}
+	spec.AutomountServiceAccountToken = nil
+
+	if spec.Resources != nil {
+		mainContainer := getOrAddContainer(podTemplate, v1beta2.MainContainerName)
+		if mainContainer.Resources.Limits == nil && mainContainer.Resources.Requests == nil {
+			mainContainer.Resources = *spec.Resources
+		}
+	}
+	spec.Resources = nil
+
+	migrateDeprecatedContainerFields(&spec.MainContainer, podTemplate, v1beta2.MainContainerName)
+	migrateDeprecatedContainerFields(&spec.SidecarContainer, podTemplate, v1beta2.SidecarContainerName)
+
+	return nil
+}
+
+// hasDeprecatedContainerFields returns true if any of the deprecated container overrides are set.
+func hasDeprecatedContainerFields(overrides ContainerOverrides) bool {
+	return len(overrides.Env) > 0 || len(overrides.VolumeMounts) > 0 || overrides.SecurityContext != nil
+}
+
+// migrateDeprecatedContainerFields moves the deprecated container overrides into the container with the provided name
+// in the Pod template.
+func migrateDeprecatedContainerFields(overrides *ContainerOverrides, podTemplate *corev1.PodTemplateSpec, containerName string) {
+	if len(overrides.Env) == 0 && len(overrides.VolumeMounts) == 0 && overrides.SecurityContext == nil {
+		return
+	}
+
+	container := getOrAddContainer(podTemplate, containerName)
+	for _, envVar := range overrides.Env {
+		found := false
+		for _, existing := range container.Env {
+			if existing.Name == envVar.Name {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			container.Env = append(container.Env, envVar)
+		}
+	}
+	overrides.Env = nil
+
+	for _, volumeMount := range overrides.VolumeMounts {
+		found := false
+		for _, existing := range container.VolumeMounts {
+			if existing.MountPath == volumeMount.MountPath {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			container.VolumeMounts = append(container.VolumeMounts, volumeMount)
+		}
+	}
+	overrides.VolumeMounts = nil
+
+	if container.SecurityContext == nil {
+		container.SecurityContext = overrides.SecurityContext
+	}
+	overrides.SecurityContext = nil
+}
+
+// getOrAddContainer returns the container with the provided name from the Pod template. If the container doesn't
+// exist, it will be added.
+func getOrAddContainer(podTemplate *corev1.PodTemplateSpec, containerName string) *corev1.Container {
+	for idx := range podTemplate.Spec.Containers {
+		if podTemplate.Spec.Containers[idx].Name == containerName {
+			return &podTemplate.Spec.Containers[idx]
+		}
+	}
+
+	podTemplate.Spec.Containers = append(podTemplate.Spec.Containers, corev1.Container{Name: containerName})
+
+	return &podTemplate.Spec.Containers[len(podTemplate.Spec.Containers)-1]
+}
+
+// appendMissingContainers appends all containers that are not already present by name.
+func appendMissingContainers(containers []corev1.Container, additional []corev1.Container) []corev1.Container {
+	for _, container := range additional {
+		found := false
+		for _, existing := range containers {
+			if existing.Name == container.Name {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			containers = append(containers, container)
+		}
+	}
+
+	return containers
+}
+
+// appendMissingIDs appends all process group IDs that are not already present.
+func appendMissingIDs(ids []string, additional []string) []string {
+	for _, id := range additional {
+		found := false
+		for _, existing := range ids {
+			if existing == id {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// ConvertTo converts this FoundationDBBackup to the Hub version (v1beta2).
+func (backup *FoundationDBBackup) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1beta2.FoundationDBBackup)
+	if !ok {
+		return fmt.Errorf("unsupported conversion target: %T", dstRaw)
+	}
+
+	err := convertObject(backup, dst)
+	if err != nil {
+		return err
+	}
+
+	// The deprecated blobstore fields in the spec are only used as a fallback in v1beta1.
+	if backup.Spec.AccountName == "" && backup.Spec.BackupName == "" && backup.Spec.Bucket == "" {
+		return nil
+	}
+
+	if dst.Spec.BlobStoreConfiguration == nil {
+		dst.Spec.BlobStoreConfiguration = &v1beta2.BlobStoreConfiguration{
+			AccountName: backup.Spec.AccountName,
+		}
+	}
+
+	if dst.Spec.BlobStoreConfiguration.BackupName == "" {
+		dst.Spec.BlobStoreConfiguration.BackupName = backup.Spec.BackupName
+	}
+
+	if dst.Spec.BlobStoreConfiguration.Bucket == "" {
+		dst.Spec.BlobStoreConfiguration.Bucket = backup.Spec.Bucket
+	}
+
+	return nil
+}
+
+// ConvertFrom converts the Hub version (v1beta2) to this FoundationDBBackup.
+func (backup *FoundationDBBackup) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1beta2.FoundationDBBackup)
+	if !ok {
+		return fmt.Errorf("unsupported conversion source: %T", srcRaw)
+	}
+
+	return convertObject(src, backup)
+}
+
+// ConvertTo converts this FoundationDBRestore to the Hub version (v1beta2).
+func (restore *FoundationDBRestore) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1beta2.FoundationDBRestore)
+	if !ok {
+		return fmt.Errorf("unsupported conversion target: %T", dstRaw)
+	}
+
+	err := convertObject(restore, dst)
+	if err != nil {
+		return err
+	}
+
+	if dst.Spec.BlobStoreConfiguration == nil && restore.Spec.BackupURL != "" {
+		dst.Spec.BlobStoreConfiguration = parseBlobStoreURL(restore.Spec.BackupURL)
+	}
+
+	return nil
+}
+
+// ConvertFrom converts the Hub version (v1beta2) to this FoundationDBRestore.
+func (restore *FoundationDBRestore) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1beta2.FoundationDBRestore)
+	if !ok {
+		return fmt.Errorf("unsupported conversion source: %T", srcRaw)
+	}
+
+	return convertObject(src, restore)
+}
+
+// parseBlobStoreURL parses a backup URL in the format "blobstore://<account>/<backup>?bucket=<bucket>" into a
+// BlobStoreConfiguration. If the URL cannot be parsed, nil will be returned.
+func parseBlobStoreURL(backupURL string) *v1beta2.BlobStoreConfiguration {
+	trimmed := strings.TrimPrefix(backupURL, "blobstore://")
+	if trimmed == backupURL {
+		return nil
+	}
+
+	accountName, rest, found := strings.Cut(trimmed, "/")
+	if !found || accountName == "" {
+		return nil
+	}
+
+	backupName, rawQuery, _ := strings.Cut(rest, "?")
+	configuration := &v1beta2.BlobStoreConfiguration{
+		AccountName: accountName,
+		BackupName:  backupName,
+	}
+
+	if rawQuery == "" {
+		return configuration
+	}
+
+	for _, parameter := range strings.Split(rawQuery, "&") {
+		key, value, _ := strings.Cut(parameter, "=")
+		if key == "bucket" {
+			bucket, err := url.QueryUnescape(value)
+			if err != nil {
+				return nil
+			}
+			configuration.Bucket = bucket
+			continue
+		}
+
+		configuration.URLParameters = append(configuration.URLParameters, v1beta2.URLParameter(parameter))
+	}
+
+	return configuration
+}
+
+// convertObject converts the source object into the destination object. Both objects must represent the same kind in
+// different API versions. All fields of the source object that cannot be represented in the destination version are
+// stored in the ConversionDataAnnotation of the destination object. Fields that were stored in the
+// ConversionDataAnnotation of the source object during a previous conversion will be restored, so that a round trip
+// between the API versions is lossless.
+func convertObject(src client.Object, dst client.Object) error {
+	srcContent, err := toMap(src)
+	if err != nil {
+		return err
+	}
+
+	// The type information of the destination object must not be changed.
+	delete(srcContent, "apiVersion")
+	delete(srcContent, "kind")
+
+	var restoredContent map[string]interface{}
+	if data, ok := src.GetAnnotations()[ConversionDataAnnotation]; ok {
+		err = json.Unmarshal([]byte(data), &restoredContent)
+		if err != nil {
+			return err
+		}
+
+		// The original values of the deprecated fields are restored separately.
+		delete(restoredContent, deprecatedFieldsKey)
+	}
+
+	err = fromMap(srcContent, dst)
+	if err != nil {
+		return err
+	}
+
+	dstContent, err := toMap(dst)
+	if err != nil {
+		return err
+	}
+
+	// We only keep track of the spec and the status, the metadata is the same in all versions.
+	delete(srcContent, "metadata")
+	missingContent := getMissingFields(srcContent, dstContent)
+
+	if len(restoredContent) > 0 {
+		addMissingFields(dstContent, restoredContent)
+		err = fromMap(dstContent, dst)
+		if err != nil {
+			return err
+		}
+	}
+
+	annotations := dst.GetAnnotations()
+	delete(annotations, ConversionDataAnnotation)
+
+	if len(missingContent) > 0 {
+		data, err := json.Marshal(missingContent)
+		if err != nil {
+			return err
+		}
+
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[ConversionDataAnnotation] = string(data)
+	}
+
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	dst.SetAnnotations(annotations)
+
+	return nil
+}
+
+// toMap returns the JSON representation of the object as a map.
+func toMap(object interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+
+	content := map[string]interface{}{}
+	err = json.Unmarshal(data, &content)
+
+	return content, err
+}
+
+// fromMap decodes the JSON representation in the map into the object.
+func fromMap(content map[string]interface{}, object interface{}) error {
+	data, err := json.Marshal(content)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, object)
+}
+
+// toContent returns the JSON representation of the spec and the status of the object as a map.
+func toContent(object interface{}) (map[string]interface{}, error) {
+	content, err := toMap(object)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(content, "apiVersion")
+	delete(content, "kind")
+	delete(content, "metadata")
+
+	return content, nil
+}
+
+// getMissingFields returns all fields with a non-empty value from the source that are missing in the destination.
+// Lists are compared element by element, so fields that are missing in the elements of a list are returned as a list
+// with the same length, where elements without missing fields are nil.
+func getMissingFields(src map[string]interface{}, dst map[string]interface{}) map[string]interface{} {
+	missing := map[string]interface{}{}
+
+	for key, srcValue := range src {
+		if isEmptyValue(srcValue) {
+			continue
+		}
+
+		dstValue, ok := dst[key]
+		if !ok {
+			missing[key] = srcValue
+			continue
+		}
+
+		nested := getMissingNestedFields(srcValue, dstValue)
+		if nested != nil {
+			missing[key] = nested
+		}
+	}
+
+	return missing
+}
+
+// getMissingNestedFields returns the missing fields of the elements of a map or a list. If the values are neither maps
+// nor lists or no fields are missing, nil will be returned.
+func getMissingNestedFields(srcValue interface{}, dstValue interface{}) interface{} {
+	switch typedValue := srcValue.(type) {
+	case map[string]interface{}:
+		dstMap, ok := dstValue.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		nested := getMissingFields(typedValue, dstMap)
+		if len(nested) == 0 {
+			return nil
+		}
+
+		return nested
+	case []interface{}:
+		dstList, ok := dstValue.([]interface{})
+		if !ok || len(typedValue) != len(dstList) {
+			return nil
+		}
+
+		nested := make([]interface{}, len(typedValue))
+		hasMissingFields := false
+		for idx, element := range typedValue {
+			nested[idx] = getMissingNestedFields(element, dstList[idx])
+			if nested[idx] != nil {
+				hasMissingFields = true
+			}
+		}
+
+		if !hasMissingFields {
+			return nil
+		}
+
+		return nested
+	}
+
+	return nil
+}
+
+// addMissingFields adds all fields from the content to the destination that are not already present in the
+// destination. Fields for the elements of a list are only added if the list in the destination has the same length,
+// otherwise the list was changed and the fields can't be assigned to the elements.
+func addMissingFields(dst map[string]interface{}, content map[string]interface{}) {
+	for key, value := range content {
+		dstValue, ok := dst[key]
+		if !ok {
+			dst[key] = value
+			continue
+		}
+
+		addMissingNestedFields(dstValue, value)
+	}
+}
+
+// addMissingNestedFields adds the missing fields to the elements of a map or a list.
+func addMissingNestedFields(dstValue interface{}, value interface{}) {
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		dstMap, ok := dstValue.(map[string]interface{})
+		if ok {
+			addMissingFields(dstMap, typedValue)
+		}
+	case []interface{}:
+		dstList, ok := dstValue.([]interface{})
+		if !ok || len(typedValue) != len(dstList) {
+			return
+		}
+
+		for idx, element := range typedValue {
+			if element != nil {
+				addMissingNestedFields(dstList[idx], element)
+			}
+		}
+	}
+}
+
+// getChangedFields returns the values of all fields from the source that differ from the destination. Fields that
+// only exist in the destination are returned with a nil value. Lists are compared as a whole.
+func getChangedFields(src map[string]interface{}, dst map[string]interface{}) map[string]interface{} {
+	changed := map[string]interface{}{}
+
+	for key, srcValue := range src {
+		dstValue := dst[key]
+
+		srcMap, srcIsMap := srcValue.(map[string]interface{})
+		dstMap, dstIsMap := dstValue.(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			nested := getChangedFields(srcMap, dstMap)
+			if len(nested) > 0 {
+				changed[key] = nested
+			}
+			continue
+		}
+
+		if !reflect.DeepEqual(srcValue, dstValue) {
+			changed[key] = srcValue
+		}
+	}
+
+	for key := range dst {
+		if _, ok := src[key]; !ok {
+			changed[key] = nil
+		}
+	}
+
+	return changed
+}
+
+// fieldsMatch returns true if all fields in the expected values have the same value in the content. A nil value
+// matches a missing field.
+func fieldsMatch(content map[string]interface{}, expected map[string]interface{}) bool {
+	for key, expectedValue := range expected {
+		contentValue := content[key]
+
+		expectedMap, expectedIsMap := expectedValue.(map[string]interface{})
+		contentMap, contentIsMap := contentValue.(map[string]interface{})
+		if expectedIsMap && contentIsMap {
+			if !fieldsMatch(contentMap, expectedMap) {
+				return false
+			}
+			continue
+		}
+
+		if !reflect.DeepEqual(contentValue, expectedValue) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// setFields sets all fields from the values in the content. Nested maps are merged, all other values are replaced.
+// Fields with a nil value are removed from the content.
+func setFields(content map[string]interface{}, values map[string]interface{}) {
+	for key, value := range values {
+		if value == nil {
+			delete(content, key)
+			continue
+		}
+
+		valueMap, valueIsMap := value.(map[string]interface{})
+		contentMap, contentIsMap := content[key].(map[string]interface{})
+		if valueIsMap && contentIsMap {
+			setFields(contentMap, valueMap)
+			continue
+		}
+
+		content[key] = value
+	}
+}
+
+// isEmptyValue returns true if the value is null or a map that only contains empty values. Empty structs are serialized
+// as empty maps, so they would otherwise always be reported as missing. Other zero values like false are kept, as they
+// could be set explicitly in a pointer field.
+func isEmptyValue(value interface{}) bool {
+	switch typedValue := value.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		for _, nestedValue := range typedValue {
+			if !isEmptyValue(nestedValue) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// Ensure that all types implement the conversion interface.
+var _ conversion.Convertible = &FoundationDBCluster{}
+var _ conversion.Convertible = &FoundationDBBackup{}
+var _ conversion.Convertible = &FoundationDBRestore{}
@@ -0,0 +1,898 @@
+/*
+ * conversion_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("[api] conversion", func() {
+	timestamp := metav1.NewTime(time.Unix(1672531200, 0))
+
+	When("converting a FoundationDBCluster", func() {
+		DescribeTable("should not lose any data during a round trip from v1beta2",
+			func(cluster *v1beta2.FoundationDBCluster) {
+				spoke := &FoundationDBCluster{}
+				Expect(spoke.ConvertFrom(cluster.DeepCopy())).NotTo(HaveOccurred())
+
+				hub := &v1beta2.FoundationDBCluster{}
+				Expect(spoke.ConvertTo(hub)).NotTo(HaveOccurred())
+				Expect(hub).To(Equal(cluster))
+			},
+			Entry("an empty cluster",
+				&v1beta2.FoundationDBCluster{}),
+			Entry("a cluster with the default fields",
+				&v1beta2.FoundationDBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo",
+						Namespace: "default",
+						Labels: map[string]string{
+							"test": "label",
+						},
+					},
+					Spec: v1beta2.FoundationDBClusterSpec{
+						DatabaseConfiguration: v1beta2.DatabaseConfiguration{
+							RedundancyMode: v1beta2.RedundancyModeDouble,
+							StorageEngine:  v1beta2.StorageEngineSSD2,
+						},
+						Version: "6.2.20",
+						ProcessCounts: v1beta2.ProcessCounts{
+							Storage: 5,
+						},
+						Processes: map[v1beta2.ProcessClass]v1beta2.ProcessSettings{
+							v1beta2.ProcessClassGeneral: {
+								CustomParameters: v1beta2.FoundationDBCustomParameters{
+									"knob_disable_posix_kernel_aio=1",
+								},
+								PodTemplate: &corev1.PodTemplateSpec{
+									Spec: corev1.PodSpec{
+										Containers: []corev1.Container{
+											{
+												Name: v1beta2.MainContainerName,
+												Resources: corev1.ResourceRequirements{
+													Limits: corev1.ResourceList{
+														corev1.ResourceCPU: resource.MustParse("1"),
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}),
+			Entry("a cluster with fields that only exist in v1beta2",
+				&v1beta2.FoundationDBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo",
+						Namespace: "default",
+					},
+					Spec: v1beta2.FoundationDBClusterSpec{
+						Version: "7.1.27",
+						DatabaseConfiguration: v1beta2.DatabaseConfiguration{
+							RoleCounts: v1beta2.RoleCounts{
+								CommitProxies: 4,
+								GrvProxies:    2,
+							},
+						},
+						ProcessGroupsToRemove: []v1beta2.ProcessGroupID{"storage-1"},
+						AutomationOptions: v1beta2.FoundationDBClusterAutomationOptions{
+							UseManagementAPI:          pointer.Bool(true),
+							UseLocalitiesForExclusion: pointer.Bool(true),
+							IgnoreLogGroupsForUpgrade: []string{"test"},
+							MaintenanceModeOptions: v1beta2.MaintenanceModeOptions{
+								UseMaintenanceModeChecker: pointer.Bool(true),
+							},
+						},
+						Buggify: v1beta2.BuggifyConfig{
+							CrashLoopContainers: []v1beta2.CrashLoopContainerObject{
+								{
+									ContainerName: v1beta2.MainContainerName,
+									Targets:       []v1beta2.ProcessGroupID{"storage-1"},
+								},
+							},
+							IgnoreDuringRestart: []v1beta2.ProcessGroupID{"storage-2"},
+						},
+					},
+					Status: v1beta2.FoundationDBClusterStatus{
+						DesiredProcessGroups:    10,
+						ReconciledProcessGroups: 9,
+						MaintenanceModeInfo: v1beta2.MaintenanceModeInfo{
+							StartTimestamp: &timestamp,
+							ZoneID:         "zone1",
+						},
+						ProcessGroups: []*v1beta2.ProcessGroupStatus{
+							{
+								ProcessGroupID:     "storage-1",
+								ProcessClass:       v1beta2.ProcessClassStorage,
+								Addresses:          []string{"1.1.1.1"},
+								RemovalTimestamp:   &timestamp,
+								ExclusionTimestamp: &timestamp,
+							},
+							{
+								ProcessGroupID: "storage-2",
+								ProcessClass:   v1beta2.ProcessClassStorage,
+								Addresses:      []string{"1.1.1.2"},
+							},
+						},
+					},
+				}),
+		)
+
+		DescribeTable("should not lose any data during a round trip from v1beta1",
+			func(cluster *FoundationDBCluster) {
+				hub := &v1beta2.FoundationDBCluster{}
+				Expect(cluster.DeepCopy().ConvertTo(hub)).NotTo(HaveOccurred())
+
+				spoke := &FoundationDBCluster{}
+				Expect(spoke.ConvertFrom(hub)).NotTo(HaveOccurred())
+				Expect(spoke).To(Equal(cluster))
+			},
+			Entry("an empty cluster",
+				&FoundationDBCluster{}),
+			Entry("a reconciled cluster",
+				createReconciledCluster()),
+			Entry("a cluster with process settings",
+				createClusterWithProcessSettings()),
+			Entry("a cluster with fields that have no representation in v1beta2",
+				&FoundationDBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "foo",
+						Namespace: "default",
+					},
+					Spec: FoundationDBClusterSpec{
+						Version:        "6.2.20",
+						NextInstanceID: 5,
+						AutomationOptions: FoundationDBClusterAutomationOptions{
+							EnforceFullReplicationForDeletion: pointer.Bool(true),
+						},
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassGeneral: {
+								AllowTagOverride: pointer.Bool(true),
+								CustomParameters: FoundationDBCustomParameters{
+									"knob_disable_posix_kernel_aio=1",
+								},
+							},
+						},
+						MainContainer: ContainerOverrides{
+							EnableTLS: true,
+						},
+					},
+					Status: FoundationDBClusterStatus{
+						NeedsSidecarConfInConfigMap: true,
+						ProcessGroups: []*ProcessGroupStatus{
+							{
+								ProcessGroupID:     "storage-1",
+								ProcessClass:       ProcessClassStorage,
+								Remove:             true,
+								RemovalTimestamp:   &timestamp,
+								Excluded:           true,
+								ExclusionTimestamp: &timestamp,
+							},
+						},
+					},
+				}),
+			Entry("a cluster with deprecated fields",
+				&FoundationDBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "foo",
+						Namespace:         "default",
+						CreationTimestamp: timestamp,
+					},
+					Spec: FoundationDBClusterSpec{
+						Version:               "6.2.20",
+						RunningVersion:        "6.2.20",
+						InstanceIDPrefix:      "dc1",
+						InstancesToRemove:     []string{"storage-1", "storage-2"},
+						ProcessGroupsToRemove: []string{"storage-2"},
+						PendingRemovals: map[string]string{
+							"foo-stateless-1": "1.1.1.1",
+						},
+						UpdatePodsByReplacement: true,
+						SidecarVersion:          2,
+						PodTemplate: &corev1.PodTemplateSpec{
+							ObjectMeta: metav1.ObjectMeta{
+								Labels: map[string]string{
+									"test": "label",
+								},
+							},
+						},
+						Services: ServiceConfig{
+							Headless: pointer.Bool(true),
+						},
+					},
+					Status: FoundationDBClusterStatus{
+						PendingRemovals: map[string]PendingRemovalState{
+							"storage-1": {
+								ExclusionComplete: true,
+							},
+						},
+						ProcessGroups: []*ProcessGroupStatus{
+							{
+								ProcessGroupID: "storage-1",
+								ProcessClass:   ProcessClassStorage,
+							},
+							{
+								ProcessGroupID: "storage-2",
+								ProcessClass:   ProcessClassStorage,
+								Remove:         true,
+							},
+						},
+					},
+				}),
+			Entry("a cluster with deprecated volume fields",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						StorageClass: pointer.String("local"),
+						VolumeSize:   "16G",
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassStorage: {
+								VolumeClaim: &corev1.PersistentVolumeClaim{
+									ObjectMeta: metav1.ObjectMeta{
+										Name: "storage",
+									},
+								},
+							},
+						},
+					},
+				}),
+		)
+
+		DescribeTable("should migrate the deprecated fields",
+			func(cluster *FoundationDBCluster, expected *v1beta2.FoundationDBCluster) {
+				hub := &v1beta2.FoundationDBCluster{}
+				Expect(cluster.DeepCopy().ConvertTo(hub)).NotTo(HaveOccurred())
+				// The original values of the deprecated fields are stored in the conversion annotation.
+				Expect(hub.Annotations).To(HaveKey(ConversionDataAnnotation))
+				Expect(hub.Spec).To(Equal(expected.Spec))
+				Expect(hub.Status).To(Equal(expected.Status))
+
+				// The original values must be restored, so a round trip from v1beta1 is lossless.
+				spoke := &FoundationDBCluster{}
+				Expect(spoke.ConvertFrom(hub.DeepCopy())).NotTo(HaveOccurred())
+				Expect(spoke).To(Equal(cluster))
+
+				// If the replacements were changed in v1beta2, they must be used instead of the original values, so
+				// the next conversion results in the same v1beta2 cluster.
+				hub.Spec.Version = "7.1.27"
+				expected.Spec.Version = "7.1.27"
+				spoke = &FoundationDBCluster{}
+				Expect(spoke.ConvertFrom(hub.DeepCopy())).NotTo(HaveOccurred())
+
+				roundTrip := &v1beta2.FoundationDBCluster{}
+				Expect(spoke.ConvertTo(roundTrip)).NotTo(HaveOccurred())
+				Expect(roundTrip.Spec).To(Equal(expected.Spec))
+				Expect(roundTrip.Status).To(Equal(expected.Status))
+			},
+			Entry("the process groups to remove",
+				&FoundationDBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "foo",
+					},
+					Spec: FoundationDBClusterSpec{
+						InstancesToRemove:                 []string{"storage-1", "storage-2"},
+						ProcessGroupsToRemove:             []string{"storage-2"},
+						InstancesToRemoveWithoutExclusion: []string{"log-1"},
+						PendingRemovals: map[string]string{
+							"foo-stateless-1": "1.1.1.1",
+						},
+					},
+				},
+				&v1beta2.FoundationDBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "foo",
+					},
+					Spec: v1beta2.FoundationDBClusterSpec{
+						ProcessGroupsToRemove:                 []v1beta2.ProcessGroupID{"storage-2", "storage-1", "stateless-1"},
+						ProcessGroupsToRemoveWithoutExclusion: []v1beta2.ProcessGroupID{"log-1"},
+					},
+				}),
+			Entry("the instance ID prefix",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						InstanceIDPrefix: "dc1",
+					},
+				},
+				&v1beta2.FoundationDBCluster{
+					Spec: v1beta2.FoundationDBClusterSpec{
+						ProcessGroupIDPrefix: "dc1",
+					},
+				}),
+			Entry("the instance ID prefix when the process group ID prefix is set",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						InstanceIDPrefix:     "dc1",
+						ProcessGroupIDPrefix: "dc2",
+					},
+				},
+				&v1beta2.FoundationDBCluster{
+					Spec: v1beta2.FoundationDBClusterSpec{
+						ProcessGroupIDPrefix: "dc2",
+					},
+				}),
+			Entry("the update strategy and deletion mode",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						UpdatePodsByReplacement: true,
+						AutomationOptions: FoundationDBClusterAutomationOptions{
+							DeletePods: pointer.Bool(false),
+						},
+					},
+				},
+				&v1beta2.FoundationDBCluster{
+					Spec: v1beta2.FoundationDBClusterSpec{
+						AutomationOptions: v1beta2.FoundationDBClusterAutomationOptions{
+							PodUpdateStrategy: v1beta2.PodUpdateStrategyReplacement,
+							DeletionMode:      v1beta2.PodUpdateModeNone,
+						},
+					},
+				}),
+			Entry("the service configuration",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Services: ServiceConfig{
+							Headless: pointer.Bool(true),
+						},
+					},
+				},
+				&v1beta2.FoundationDBCluster{
+					Spec: v1beta2.FoundationDBClusterSpec{
+						Routing: v1beta2.RoutingConfig{
+							HeadlessService: pointer.Bool(true),
+						},
+					},
+				}),
+			Entry("the image names and sidecar versions",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						SidecarVersions: map[string]int{
+							"6.2.20": 2,
+							"6.2.15": 1,
+						},
+						SidecarVersion: 3,
+						MainContainer: ContainerOverrides{
+							ImageName: "foundationdb/foundationdb",
+						},
+					},
+				},
+				&v1beta2.FoundationDBCluster{
+					Spec: v1beta2.FoundationDBClusterSpec{
+						MainContainer: v1beta2.ContainerOverrides{
+							ImageConfigs: []v1beta2.ImageConfig{
+								{BaseImage: "foundationdb/foundationdb"},
+							},
+						},
+						SidecarContainer: v1beta2.ContainerOverrides{
+							ImageConfigs: []v1beta2.ImageConfig{
+								{Version: "6.2.15", TagSuffix: "-1"},
+								{Version: "6.2.20", TagSuffix: "-2"},
+								{TagSuffix: "-3"},
+							},
+						},
+					},
+				}),
+			Entry("the Pod fields",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						PodTemplate: &corev1.PodTemplateSpec{
+							ObjectMeta: metav1.ObjectMeta{
+								Labels: map[string]string{
+									"test": "label",
+								},
+							},
+						},
+						PodLabels: map[string]string{
+							"test":  "ignored",
+							"other": "label",
+						},
+						Resources: &corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("1"),
+							},
+						},
+						Volumes: []corev1.Volume{
+							{Name: "extra"},
+						},
+						AutomountServiceAccountToken: pointer.Bool(false),
+						CustomParameters: FoundationDBCustomParameters{
+							"knob_disable_posix_kernel_aio=1",
+						},
+						SidecarContainer: ContainerOverrides{
+							Env: []corev1.EnvVar{
+								{Name: "TEST", Value: "value"},
+							},
+						},
+					},
+				},
+				&v1beta2.FoundationDBCluster{
+					Spec: v1beta2.FoundationDBClusterSpec{
+						Processes: map[v1beta2.ProcessClass]v1beta2.ProcessSettings{
+							v1beta2.ProcessClassGeneral: {
+								CustomParameters: v1beta2.FoundationDBCustomParameters{
+									"knob_disable_posix_kernel_aio=1",
+								},
+								PodTemplate: &corev1.PodTemplateSpec{
+									ObjectMeta: metav1.ObjectMeta{
+										Labels: map[string]string{
+											"test":  "label",
+											"other": "label",
+										},
+									},
+									Spec: corev1.PodSpec{
+										Volumes: []corev1.Volume{
+											{Name: "extra"},
+										},
+										AutomountServiceAccountToken: pointer.Bool(false),
+										Containers: []corev1.Container{
+											{
+												Name: v1beta2.MainContainerName,
+												Resources: corev1.ResourceRequirements{
+													Limits: corev1.ResourceList{
+														corev1.ResourceCPU: resource.MustParse("1"),
+													},
+												},
+											},
+											{
+												Name: v1beta2.SidecarContainerName,
+												Env: []corev1.EnvVar{
+													{Name: "TEST", Value: "value"},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				}),
+			Entry("the volume fields",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						StorageClass: pointer.String("local"),
+						VolumeSize:   "16G",
+						Processes: map[ProcessClass]ProcessSettings{
+							ProcessClassStorage: {
+								VolumeClaim: &corev1.PersistentVolumeClaim{
+									ObjectMeta: metav1.ObjectMeta{
+										Name: "storage",
+									},
+								},
+							},
+						},
+					},
+				},
+				&v1beta2.FoundationDBCluster{
+					Spec: v1beta2.FoundationDBClusterSpec{
+						Processes: map[v1beta2.ProcessClass]v1beta2.ProcessSettings{
+							v1beta2.ProcessClassGeneral: {
+								VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+									Spec: corev1.PersistentVolumeClaimSpec{
+										StorageClassName: pointer.String("local"),
+										Resources: corev1.ResourceRequirements{
+											Requests: corev1.ResourceList{
+												corev1.ResourceStorage: resource.MustParse("16G"),
+											},
+										},
+									},
+								},
+							},
+							v1beta2.ProcessClassStorage: {
+								VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+									ObjectMeta: metav1.ObjectMeta{
+										Name: "storage",
+									},
+								},
+							},
+						},
+					},
+				}),
+			Entry("the pending removals in the status",
+				&FoundationDBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						CreationTimestamp: timestamp,
+					},
+					Spec: FoundationDBClusterSpec{
+						RunningVersion: "6.2.20",
+						Configured:     true,
+					},
+					Status: FoundationDBClusterStatus{
+						PendingRemovals: map[string]PendingRemovalState{
+							"storage-1": {
+								ExclusionComplete: true,
+							},
+						},
+						ProcessGroups: []*ProcessGroupStatus{
+							{
+								ProcessGroupID: "storage-1",
+							},
+						},
+					},
+				},
+				&v1beta2.FoundationDBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						CreationTimestamp: timestamp,
+					},
+					Status: v1beta2.FoundationDBClusterStatus{
+						RunningVersion: "6.2.20",
+						Configured:     true,
+						ProcessGroups: []*v1beta2.ProcessGroupStatus{
+							{
+								ProcessGroupID:     "storage-1",
+								RemovalTimestamp:   &timestamp,
+								ExclusionTimestamp: &timestamp,
+							},
+						},
+					},
+				}),
+		)
+
+		When("converting a cluster with fields that only exist in v1beta1", func() {
+			var hub *v1beta2.FoundationDBCluster
+
+			BeforeEach(func() {
+				cluster := &FoundationDBCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "foo",
+						Namespace:         "default",
+						CreationTimestamp: timestamp,
+					},
+					Spec: FoundationDBClusterSpec{
+						Version:        "6.2.20",
+						NextInstanceID: 5,
+					},
+					Status: FoundationDBClusterStatus{
+						ProcessGroups: []*ProcessGroupStatus{
+							{
+								ProcessGroupID: "storage-1",
+								Remove:         true,
+								Excluded:       true,
+							},
+						},
+					},
+				}
+
+				hub = &v1beta2.FoundationDBCluster{}
+				Expect(cluster.ConvertTo(hub)).NotTo(HaveOccurred())
+			})
+
+			It("should store the fields in the conversion annotation", func() {
+				Expect(hub.Annotations).To(HaveKey(ConversionDataAnnotation))
+
+				var data map[string]interface{}
+				Expect(json.Unmarshal([]byte(hub.Annotations[ConversionDataAnnotation]), &data)).NotTo(HaveOccurred())
+				Expect(data).To(HaveKeyWithValue("spec", map[string]interface{}{"nextInstanceID": float64(5)}))
+				// The timestamps are set during the conversion, so the original process groups are stored.
+				Expect(data).To(HaveKey(deprecatedFieldsKey))
+			})
+
+			It("should set the removal and exclusion timestamp", func() {
+				Expect(hub.Status.ProcessGroups).To(HaveLen(1))
+				Expect(hub.Status.ProcessGroups[0].IsMarkedForRemoval()).To(BeTrue())
+				Expect(hub.Status.ProcessGroups[0].IsExcluded()).To(BeTrue())
+			})
+		})
+
+		When("getting the missing fields of lists", func() {
+			It("should compare the elements of the lists", func() {
+				src := map[string]interface{}{
+					"processGroups": []interface{}{
+						map[string]interface{}{"processGroupID": "storage-1", "only": "src"},
+						map[string]interface{}{"processGroupID": "storage-2"},
+					},
+				}
+				dst := map[string]interface{}{
+					"processGroups": []interface{}{
+						map[string]interface{}{"processGroupID": "storage-1"},
+						map[string]interface{}{"processGroupID": "storage-2"},
+					},
+				}
+
+				missing := getMissingFields(src, dst)
+				Expect(missing).To(Equal(map[string]interface{}{
+					"processGroups": []interface{}{
+						map[string]interface{}{"only": "src"},
+						nil,
+					},
+				}))
+
+				addMissingFields(dst, missing)
+				Expect(dst).To(Equal(src))
+			})
+
+			It("should not add the fields if the length of the list was changed", func() {
+				dst := map[string]interface{}{
+					"processGroups": []interface{}{
+						map[string]interface{}{"processGroupID": "storage-2"},
+					},
+				}
+
+				addMissingFields(dst, map[string]interface{}{
+					"processGroups": []interface{}{
+						map[string]interface{}{"only": "src"},
+						nil,
+					},
+				})
+				Expect(dst).To(Equal(map[string]interface{}{
+					"processGroups": []interface{}{
+						map[string]interface{}{"processGroupID": "storage-2"},
+					},
+				}))
+			})
+		})
+
+		When("converting a cluster that was marked for removal in v1beta2", func() {
+			It("should set the deprecated fields", func() {
+				cluster := &FoundationDBCluster{}
+				Expect(cluster.ConvertFrom(&v1beta2.FoundationDBCluster{
+					Status: v1beta2.FoundationDBClusterStatus{
+						ProcessGroups: []*v1beta2.ProcessGroupStatus{
+							{
+								ProcessGroupID:     "storage-1",
+								RemovalTimestamp:   &timestamp,
+								ExclusionTimestamp: &timestamp,
+							},
+							{
+								ProcessGroupID: "storage-2",
+							},
+						},
+					},
+				})).NotTo(HaveOccurred())
+
+				Expect(cluster.Status.ProcessGroups).To(HaveLen(2))
+				Expect(cluster.Status.ProcessGroups[0].Remove).To(BeTrue())
+				Expect(cluster.Status.ProcessGroups[0].Excluded).To(BeTrue())
+				Expect(cluster.Status.ProcessGroups[1].Remove).To(BeFalse())
+				Expect(cluster.Status.ProcessGroups[1].Excluded).To(BeFalse())
+			})
+		})
+	})
+
+	When("converting a FoundationDBBackup", func() {
+		DescribeTable("should not lose any data during a round trip from v1beta2",
+			func(backup *v1beta2.FoundationDBBackup) {
+				spoke := &FoundationDBBackup{}
+				Expect(spoke.ConvertFrom(backup.DeepCopy())).NotTo(HaveOccurred())
+
+				hub := &v1beta2.FoundationDBBackup{}
+				Expect(spoke.ConvertTo(hub)).NotTo(HaveOccurred())
+				Expect(hub).To(Equal(backup))
+			},
+			Entry("an empty backup",
+				&v1beta2.FoundationDBBackup{}),
+			Entry("a backup with a blobstore configuration",
+				&v1beta2.FoundationDBBackup{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "mybackup",
+					},
+					Spec: v1beta2.FoundationDBBackupSpec{
+						ClusterName: "mycluster",
+						BlobStoreConfiguration: &v1beta2.BlobStoreConfiguration{
+							AccountName: "account@account",
+							BackupName:  "test",
+							Bucket:      "my-bucket",
+							URLParameters: []v1beta2.URLParameter{
+								"secure_connection=0",
+							},
+						},
+						MainContainer: v1beta2.ContainerOverrides{
+							EnableTLS: true,
+						},
+					},
+				}),
+		)
+
+		It("should use the deprecated blobstore fields", func() {
+			backup := &FoundationDBBackup{
+				Spec: FoundationDBBackupSpec{
+					AccountName: "account@account",
+					BackupName:  "test",
+					Bucket:      "my-bucket",
+				},
+			}
+
+			hub := &v1beta2.FoundationDBBackup{}
+			Expect(backup.ConvertTo(hub)).NotTo(HaveOccurred())
+			Expect(hub.Spec.BlobStoreConfiguration).To(Equal(&v1beta2.BlobStoreConfiguration{
+				AccountName: "account@account",
+				BackupName:  "test",
+				Bucket:      "my-bucket",
+			}))
+			Expect(hub.BackupURL()).To(Equal(backup.BackupURL()))
+		})
+	})
+
+	When("converting a FoundationDBRestore", func() {
+		DescribeTable("should not lose any data during a round trip from v1beta2",
+			func(restore *v1beta2.FoundationDBRestore) {
+				spoke := &FoundationDBRestore{}
+				Expect(spoke.ConvertFrom(restore.DeepCopy())).NotTo(HaveOccurred())
+
+				hub := &v1beta2.FoundationDBRestore{}
+				Expect(spoke.ConvertTo(hub)).NotTo(HaveOccurred())
+				Expect(hub).To(Equal(restore))
+			},
+			Entry("an empty restore",
+				&v1beta2.FoundationDBRestore{}),
+			Entry("a restore with a blobstore configuration",
+				&v1beta2.FoundationDBRestore{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "myrestore",
+					},
+					Spec: v1beta2.FoundationDBRestoreSpec{
+						DestinationClusterName: "mycluster",
+						BlobStoreConfiguration: &v1beta2.BlobStoreConfiguration{
+							AccountName: "account@account",
+							BackupName:  "test",
+						},
+						KeyRanges: []v1beta2.FoundationDBKeyRange{
+							{
+								Start: "a",
+								End:   "b",
+							},
+						},
+					},
+				}),
+		)
+
+		DescribeTable("should parse the backup URL",
+			func(backupURL string, expected *v1beta2.BlobStoreConfiguration) {
+				restore := &FoundationDBRestore{
+					Spec: FoundationDBRestoreSpec{
+						BackupURL: backupURL,
+					},
+				}
+
+				hub := &v1beta2.FoundationDBRestore{}
+				Expect(restore.ConvertTo(hub)).NotTo(HaveOccurred())
+				Expect(hub.Spec.BlobStoreConfiguration).To(Equal(expected))
+
+				if expected != nil {
+					Expect(hub.BackupURL()).To(Equal(backupURL))
+				}
+			},
+			Entry("a URL with a bucket",
+				"blobstore://account@account/test?bucket=fdb-backups",
+				&v1beta2.BlobStoreConfiguration{
+					AccountName: "account@account",
+					BackupName:  "test",
+					Bucket:      "fdb-backups",
+				}),
+			Entry("a URL with additional parameters",
+				"blobstore://account@account/test?bucket=fdb-backups&secure_connection=0",
+				&v1beta2.BlobStoreConfiguration{
+					AccountName: "account@account",
+					BackupName:  "test",
+					Bucket:      "fdb-backups",
+					URLParameters: []v1beta2.URLParameter{
+						"secure_connection=0",
+					},
+				}),
+			Entry("a URL with a different scheme",
+				"file:///var/backup",
+				nil),
+		)
+	})
+})
+
+// createReconciledCluster returns a cluster that has been reconciled for the current generation.
+func createReconciledCluster() *FoundationDBCluster {
+	return &FoundationDBCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "sample-cluster",
+			Namespace:  "default",
+			Generation: 2,
+		},
+		Spec: FoundationDBClusterSpec{
+			Version:               Versions.Default.String(),
+			DatabaseConfiguration: DatabaseConfiguration{},
+		},
+		Status: FoundationDBClusterStatus{
+			Health: ClusterHealth{
+				Available: true,
+				Healthy:   true,
+			},
+			RequiredAddresses: RequiredAddressSet{
+				NonTLS: true,
+			},
+			DatabaseConfiguration: DatabaseConfiguration{
+				RedundancyMode: RedundancyModeDouble,
+				StorageEngine:  "ssd-2",
+				UsableRegions:  1,
+				RoleCounts: RoleCounts{
+					Logs:       3,
+					Proxies:    3,
+					Resolvers:  1,
+					LogRouters: -1,
+					RemoteLogs: -1,
+				},
+			},
+			Generations: ClusterGenerationStatus{
+				Reconciled: 1,
+			},
+			ProcessCounts: ProcessCounts{
+				Storage:   3,
+				Stateless: 9,
+				Log:       4,
+			},
+			ProcessGroups: []*ProcessGroupStatus{
+				{ProcessGroupID: "storage-1", ProcessClass: "storage"},
+				{ProcessGroupID: "storage-2", ProcessClass: "storage"},
+				{ProcessGroupID: "storage-3", ProcessClass: "storage"},
+				{ProcessGroupID: "stateless-1", ProcessClass: "stateless"},
+				{ProcessGroupID: "stateless-2", ProcessClass: "stateless"},
+				{ProcessGroupID: "stateless-3", ProcessClass: "stateless"},
+				{ProcessGroupID: "stateless-4", ProcessClass: "stateless"},
+				{ProcessGroupID: "stateless-5", ProcessClass: "stateless"},
+				{ProcessGroupID: "stateless-6", ProcessClass: "stateless"},
+				{ProcessGroupID: "stateless-7", ProcessClass: "stateless"},
+				{ProcessGroupID: "stateless-8", ProcessClass: "stateless"},
+				{ProcessGroupID: "stateless-9", ProcessClass: "stateless"},
+				{ProcessGroupID: "log-1", ProcessClass: "log"},
+				{ProcessGroupID: "log-2", ProcessClass: "log"},
+				{ProcessGroupID: "log-3", ProcessClass: "log"},
+				{ProcessGroupID: "log-4", ProcessClass: "log"},
+			},
+			Configured: true,
+		},
+	}
+}
+
+// createClusterWithProcessSettings returns a cluster with custom process settings for multiple process classes.
+func createClusterWithProcessSettings() *FoundationDBCluster {
+	return &FoundationDBCluster{
+		Spec: FoundationDBClusterSpec{
+			Processes: map[ProcessClass]ProcessSettings{
+				ProcessClassGeneral: {
+					PodTemplate: &corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{"test-label": "label1"},
+						},
+					},
+					CustomParameters: FoundationDBCustomParameters{"test_knob=value1"},
+				},
+				ProcessClassStorage: {
+					PodTemplate: &corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{"test-label": "label2"},
+						},
+					},
+				},
+				ProcessClassStateless: {
+					PodTemplate: &corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: map[string]string{"test-label": "label3"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
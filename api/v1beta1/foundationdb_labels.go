@@ -33,6 +33,11 @@ const (
 	// timestamp when we saw an outdated config map.
 	OutdatedConfigMapKey = "foundationdb.org/outdated-config-map-seen"
 
+	// ConversionDataAnnotation provides the annotation name we use to store the
+	// fields of a resource that cannot be represented in the other API version
+	// during a conversion.
+	ConversionDataAnnotation = "foundationdb.org/conversion-data"
+
 	// BackupDeploymentLabel provides the label we use to connect backup
 	// deployments to a cluster.
 	BackupDeploymentLabel = "foundationdb.org/backup-for"
@@ -32,4 +32,11 @@ const (
 
 	// NoneFaultDomainKey represents the none fault domain, where every Pod is a fault domain.
 	NoneFaultDomainKey = "foundationdb.org/none"
+
+	// KubernetesClusterFaultDomainKey represents the fault domain of a cluster that is spread across multiple
+	// Kubernetes clusters, where every Kubernetes cluster is a fault domain.
+	KubernetesClusterFaultDomainKey = "foundationdb.org/kubernetes-cluster"
+
+	// SidecarAPITokenSecretKey represents the key in the Secret that contains the token for the sidecar API.
+	SidecarAPITokenSecretKey = "token"
 )
@@ -33,16 +33,24 @@ import (
 type DatabaseConfiguration struct {
 	// RedundancyMode defines the core replication factor for the database.
 	// +kubebuilder:validation:Optional
-	// +kubebuilder:validation:Enum=single;double;triple
+	// +kubebuilder:validation:Enum=single;double;triple;three_data_hall
 	// +kubebuilder:default:double
 	RedundancyMode RedundancyMode `json:"redundancy_mode,omitempty"`
 
 	// StorageEngine defines the storage engine the database uses.
 	// +kubebuilder:validation:Optional
-	// +kubebuilder:validation:Enum=ssd;ssd-1;ssd-2;memory;memory-1;memory-2;ssd-redwood-1-experimental;ssd-rocksdb-experimental;ssd-rocksdb-v1;ssd-sharded-rocksdb;memory-radixtree-beta;custom
+	// +kubebuilder:validation:Enum=ssd;ssd-1;ssd-2;memory;memory-1;memory-2;ssd-redwood-1-experimental;ssd-redwood-1;ssd-rocksdb-experimental;ssd-rocksdb-v1;ssd-sharded-rocksdb;memory-radixtree-beta;custom
 	// +kubebuilder:default:=ssd-2
 	StorageEngine StorageEngine `json:"storage_engine,omitempty"`
 
+	// StorageMigrationType defines how FDB migrates the storage servers to a
+	// new storage engine. If the storage migration mode of the cluster is
+	// Wiggle, the operator sets this to disabled, so the storage servers are
+	// only migrated by replacing the process groups.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=disabled;aggressive;gradual
+	StorageMigrationType StorageMigrationType `json:"storage_migration_type,omitempty"`
+
 	// UsableRegions defines how many regions the database should store data in.
 	UsableRegions int `json:"usable_regions,omitempty"`
 
@@ -159,6 +167,72 @@ func (configuration *DatabaseConfiguration) FailOver() DatabaseConfiguration {
 	return *newConfiguration
 }
 
+// GetPrimaryDataCenter returns the main data center with the highest priority. If no regions are defined an empty
+// string will be returned.
+func (configuration DatabaseConfiguration) GetPrimaryDataCenter() string {
+	primary := ""
+	highestPriority := -1
+	for _, region := range configuration.Regions {
+		id, priority := getMainDataCenter(region)
+		if id == "" || priority <= highestPriority {
+			continue
+		}
+
+		primary = id
+		highestPriority = priority
+	}
+
+	return primary
+}
+
+// IsMainDataCenter returns true if the provided data center is the main (non-satellite) data center of a region.
+func (configuration DatabaseConfiguration) IsMainDataCenter(dataCenterID string) bool {
+	for _, region := range configuration.Regions {
+		id, _ := getMainDataCenter(region)
+		if id == dataCenterID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FailOverTo returns a new DatabaseConfiguration where the provided main data center has the highest priority. The
+// priorities of the current primary and the provided data center will be swapped, all other data centers keep their
+// priorities. If the provided data center is not a main data center or is already the primary, the configuration
+// will not be changed.
+func (configuration DatabaseConfiguration) FailOverTo(dataCenterID string) DatabaseConfiguration {
+	newConfiguration := configuration.DeepCopy()
+	primary := configuration.GetPrimaryDataCenter()
+	if primary == "" || primary == dataCenterID || !configuration.IsMainDataCenter(dataCenterID) {
+		return *newConfiguration
+	}
+
+	priorities := configuration.getRegionPriorities()
+	primaryPriority := priorities[primary]
+	targetPriority := priorities[dataCenterID]
+	// If both data centers have the same priority swapping them wouldn't change the primary.
+	if targetPriority == primaryPriority {
+		primaryPriority++
+	}
+
+	for regionIndex, region := range newConfiguration.Regions {
+		for dataCenterIndex, dataCenter := range region.DataCenters {
+			if dataCenter.Satellite != 0 {
+				continue
+			}
+
+			if dataCenter.ID == primary {
+				newConfiguration.Regions[regionIndex].DataCenters[dataCenterIndex].Priority = targetPriority
+			} else if dataCenter.ID == dataCenterID {
+				newConfiguration.Regions[regionIndex].DataCenters[dataCenterIndex].Priority = primaryPriority
+			}
+		}
+	}
+
+	return *newConfiguration
+}
+
 // NormalizeConfiguration ensures a standardized format and defaults when
 // comparing database configuration in the cluster spec with database
 // configuration in the cluster status.
@@ -265,7 +339,8 @@ func (configuration DatabaseConfiguration) getRegion(id string, priority int) Re
 // The default Storage value will be 2F + 1, where F is the cluster's fault
 // tolerance.
 //
-// The default Logs value will be 3.
+// The default Logs value will be 3, or 4 for the three_data_hall redundancy
+// mode.
 //
 // The default Proxies value will be 3.
 //
@@ -284,7 +359,12 @@ func (configuration *DatabaseConfiguration) GetRoleCountsWithDefaults(version Ve
 		counts.Storage = 2*faultTolerance + 1
 	}
 	if counts.Logs == 0 {
-		counts.Logs = 3
+		// The three_data_hall redundancy mode stores 4 copies of the logs across 2 data halls.
+		if configuration.RedundancyMode == RedundancyModeThreeDataHall {
+			counts.Logs = 4
+		} else {
+			counts.Logs = 3
+		}
 	}
 
 	if version.HasSeparatedProxies() {
@@ -627,6 +707,10 @@ func (configuration DatabaseConfiguration) GetConfigurationString(version string
 
 	configurationString += configuration.GetProxiesString(fdbVersion)
 
+	if configuration.StorageMigrationType != "" && fdbVersion.SupportsStorageMigrationType() {
+		configurationString += fmt.Sprintf(" storage_migration_type=%s", configuration.StorageMigrationType)
+	}
+
 	flags := configuration.VersionFlags.Map()
 	for flag, value := range flags {
 		if value != 0 {
@@ -678,7 +762,7 @@ func DesiredFaultTolerance(redundancyMode RedundancyMode) int {
 		return 0
 	case RedundancyModeDouble, RedundancyModeUnset:
 		return 1
-	case RedundancyModeTriple:
+	case RedundancyModeTriple, RedundancyModeThreeDataHall:
 		return 2
 	default:
 		return 0
@@ -692,7 +776,7 @@ func MinimumFaultDomains(redundancyMode RedundancyMode) int {
 		return 1
 	case RedundancyModeDouble, RedundancyModeUnset:
 		return 2
-	case RedundancyModeTriple:
+	case RedundancyModeTriple, RedundancyModeThreeDataHall:
 		return 3
 	default:
 		return 1
@@ -714,6 +798,8 @@ const (
 	RedundancyModeOneSatelliteSingle RedundancyMode = "one_satellite_single"
 	// RedundancyModeOneSatelliteDouble  defines the replication factor one_satellite_double.
 	RedundancyModeOneSatelliteDouble RedundancyMode = "one_satellite_double"
+	// RedundancyModeThreeDataHall defines the replication factor three_data_hall.
+	RedundancyModeThreeDataHall RedundancyMode = "three_data_hall"
 	// RedundancyModeUnset defines the replication factor unset.
 	RedundancyModeUnset RedundancyMode = ""
 )
@@ -739,6 +825,25 @@ const (
 	StorageEngineShardedRocksDB StorageEngine = "ssd-sharded-rocksdb"
 	// StorageEngineRedwood1Experimental defines the storage engine ssd-redwood-1-experimental.
 	StorageEngineRedwood1Experimental StorageEngine = "ssd-redwood-1-experimental"
+	// StorageEngineRedwood1 defines the storage engine ssd-redwood-1.
+	StorageEngineRedwood1 StorageEngine = "ssd-redwood-1"
+)
+
+// StorageMigrationType defines how FDB migrates the storage servers to a new
+// storage engine.
+// +kubebuilder:validation:MaxLength=32
+type StorageMigrationType string
+
+const (
+	// StorageMigrationTypeDisabled prevents FDB from migrating the storage
+	// servers to a new storage engine.
+	StorageMigrationTypeDisabled StorageMigrationType = "disabled"
+	// StorageMigrationTypeAggressive lets FDB migrate the storage servers as
+	// fast as possible.
+	StorageMigrationTypeAggressive StorageMigrationType = "aggressive"
+	// StorageMigrationTypeGradual lets FDB migrate the storage servers with
+	// the perpetual storage wiggle.
+	StorageMigrationTypeGradual StorageMigrationType = "gradual"
 )
 
 // RoleCounts represents the roles whose counts can be customized.
@@ -896,6 +1001,15 @@ func (counts *ProcessCounts) DecreaseCount(name ProcessClass, amount int) {
 	}
 }
 
+// SetCount sets one of the process counts based on the name.
+func (counts *ProcessCounts) SetCount(name ProcessClass, count int) {
+	index, present := processClassIndices[name]
+	if present {
+		countValue := reflect.ValueOf(counts)
+		countValue.Elem().Field(index).SetInt(int64(count))
+	}
+}
+
 // CountsAreSatisfied checks whether the current counts of processes satisfy
 // a desired set of counts.
 func (counts ProcessCounts) CountsAreSatisfied(currentCounts ProcessCounts) bool {
@@ -69,6 +69,20 @@ var _ = Describe("DatabaseConfiguration", func() {
 				Expect(newConfig.GetConfigurationString(Versions.Default.String())).To(Equal("triple ssd usable_regions=1 logs=3 resolvers=1 log_routers=0 remote_logs=0 proxies=3 regions=[{\\\"datacenters\\\":[{\\\"id\\\":\\\"primary\\\",\\\"priority\\\":1}]}]"))
 			})
 		})
+
+		When("the configuration string is calculated with a storage migration type", func() {
+			BeforeEach(func() {
+				config.StorageMigrationType = StorageMigrationTypeDisabled
+			})
+
+			It("should add the storage migration type if the version supports it", func() {
+				Expect(config.GetConfigurationString(Versions.SupportsStorageMigration.String())).To(ContainSubstring(" storage_migration_type=disabled "))
+			})
+
+			It("should not add the storage migration type if the version doesn't support it", func() {
+				Expect(config.GetConfigurationString("6.3.24")).NotTo(ContainSubstring("storage_migration_type"))
+			})
+		})
 	})
 
 	When("a multi dc cluster is provided", func() {
@@ -156,6 +170,54 @@ var _ = Describe("DatabaseConfiguration", func() {
 				Expect(newConfig.GetConfigurationString(Versions.Default.String())).To(Equal("triple ssd usable_regions=1 logs=3 resolvers=1 log_routers=0 remote_logs=0 proxies=3 regions=[{\\\"datacenters\\\":[{\\\"id\\\":\\\"primary\\\"},{\\\"id\\\":\\\"primary-sat\\\",\\\"priority\\\":1,\\\"satellite\\\":1}],\\\"satellite_logs\\\":3,\\\"satellite_redundancy_mode\\\":\\\"one_satellite_single\\\"},{\\\"datacenters\\\":[{\\\"id\\\":\\\"remote\\\",\\\"priority\\\":1},{\\\"id\\\":\\\"remote-sat\\\",\\\"priority\\\":1,\\\"satellite\\\":1}],\\\"satellite_logs\\\":3,\\\"satellite_redundancy_mode\\\":\\\"one_satellite_double\\\"}]"))
 			})
 		})
+
+		When("getting the primary data center", func() {
+			It("should return the main data center with the highest priority", func() {
+				Expect(config.GetPrimaryDataCenter()).To(Equal("primary"))
+			})
+		})
+
+		When("checking if a data center is a main data center", func() {
+			It("should only return true for main data centers", func() {
+				Expect(config.IsMainDataCenter("primary")).To(BeTrue())
+				Expect(config.IsMainDataCenter("remote")).To(BeTrue())
+				Expect(config.IsMainDataCenter("primary-sat")).To(BeFalse())
+				Expect(config.IsMainDataCenter("unknown")).To(BeFalse())
+			})
+		})
+
+		When("failing over to a data center", func() {
+			It("should swap the priorities of the main data centers", func() {
+				newConfig := config.FailOverTo("remote")
+				Expect(newConfig.GetPrimaryDataCenter()).To(Equal("remote"))
+				Expect(newConfig.Regions[0].DataCenters[0]).To(Equal(DataCenter{ID: "primary", Priority: 0}))
+				Expect(newConfig.Regions[0].DataCenters[1]).To(Equal(DataCenter{ID: "primary-sat", Priority: 1, Satellite: 1}))
+				Expect(newConfig.Regions[1].DataCenters[0]).To(Equal(DataCenter{ID: "remote", Priority: 1}))
+				Expect(newConfig.Regions[1].DataCenters[1]).To(Equal(DataCenter{ID: "remote-sat", Priority: 1, Satellite: 1}))
+				Expect(config.GetPrimaryDataCenter()).To(Equal("primary"))
+			})
+
+			It("should not change the configuration for the current primary", func() {
+				Expect(config.FailOverTo("primary")).To(Equal(*config))
+			})
+
+			It("should not change the configuration for a satellite", func() {
+				Expect(config.FailOverTo("remote-sat")).To(Equal(*config))
+			})
+
+			When("both main data centers have the same priority", func() {
+				BeforeEach(func() {
+					config.Regions[1].DataCenters[0].Priority = 1
+				})
+
+				It("should give the target data center a higher priority", func() {
+					newConfig := config.FailOverTo("remote")
+					Expect(newConfig.GetPrimaryDataCenter()).To(Equal("remote"))
+					Expect(newConfig.Regions[0].DataCenters[0].Priority).To(Equal(1))
+					Expect(newConfig.Regions[1].DataCenters[0].Priority).To(Equal(2))
+				})
+			})
+		})
 	})
 
 	When("using ProcessCounts", func() {
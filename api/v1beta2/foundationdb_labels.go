@@ -37,6 +37,14 @@ const (
 	// deployments to a cluster.
 	BackupDeploymentLabel = "foundationdb.org/backup-for"
 
+	// DRDeploymentLabel provides the label we use to connect DR agent
+	// deployments to a DR.
+	DRDeploymentLabel = "foundationdb.org/dr-for"
+
+	// MultiRegionClusterLabel provides the label we use to connect the
+	// clusters of the data centers to a multi-region cluster.
+	MultiRegionClusterLabel = "foundationdb.org/multi-region-cluster"
+
 	// PublicIPSourceAnnotation is an annotation key that specifies where a pod
 	// gets its public IP from.
 	PublicIPSourceAnnotation = "foundationdb.org/public-ip-source"
@@ -69,6 +77,10 @@ const (
 	// the DC ID.
 	FDBLocalityDCIDKey = "dcid"
 
+	// FDBLocalityDataHallKey represents the key in the locality map that holds
+	// the data hall.
+	FDBLocalityDataHallKey = "data_hall"
+
 	// FDBLocalityDNSNameKey represents the key in the locality map that holds
 	// the DNS name for the pod.
 	FDBLocalityDNSNameKey = "dns_name"
@@ -108,6 +108,58 @@ type FoundationDBStatusClusterInfo struct {
 
 	// ConnectionString represents the connection string in the cluster status json output.
 	ConnectionString string `json:"connection_string,omitempty"`
+
+	// DatacenterLag provides information about how far the remote data centers are behind the primary data center.
+	DatacenterLag FoundationDBStatusDataCenterLag `json:"datacenter_lag,omitempty"`
+
+	// LatencyProbe provides the latencies measured by the cluster controller.
+	LatencyProbe FoundationDBStatusLatencyProbe `json:"latency_probe,omitempty"`
+
+	// Qos provides information about the rate limiting of the ratekeeper.
+	Qos FoundationDBStatusQosInfo `json:"qos,omitempty"`
+}
+
+// FoundationDBStatusLatencyProbe provides the latencies of the probe transactions.
+type FoundationDBStatusLatencyProbe struct {
+	// CommitSeconds provides the latency of a commit in seconds.
+	CommitSeconds float64 `json:"commit_seconds,omitempty"`
+
+	// ReadSeconds provides the latency of a read in seconds.
+	ReadSeconds float64 `json:"read_seconds,omitempty"`
+
+	// TransactionStartSeconds provides the latency to start a transaction in seconds.
+	TransactionStartSeconds float64 `json:"transaction_start_seconds,omitempty"`
+}
+
+// FoundationDBStatusQosInfo provides information about the rate limiting of the ratekeeper.
+type FoundationDBStatusQosInfo struct {
+	// PerformanceLimitedBy provides the reason why the ratekeeper limits the transaction rate.
+	PerformanceLimitedBy FoundationDBStatusPerformanceLimitedBy `json:"performance_limited_by,omitempty"`
+
+	// WorstQueueBytesLogServer provides the largest queue of a log server in bytes.
+	WorstQueueBytesLogServer int `json:"worst_queue_bytes_log_server,omitempty"`
+
+	// WorstQueueBytesStorageServer provides the largest queue of a storage server in bytes.
+	WorstQueueBytesStorageServer int `json:"worst_queue_bytes_storage_server,omitempty"`
+}
+
+// FoundationDBStatusPerformanceLimitedBy provides the reason why the ratekeeper limits the transaction rate.
+type FoundationDBStatusPerformanceLimitedBy struct {
+	// Name provides a machine-readable identifier for the reason, e.g. "workload" if the
+	// transaction rate is not limited.
+	Name string `json:"name,omitempty"`
+
+	// Description provides a human-readable description of the reason.
+	Description string `json:"description,omitempty"`
+}
+
+// FoundationDBStatusDataCenterLag provides information about the lag of the log routers in the remote data centers.
+type FoundationDBStatusDataCenterLag struct {
+	// Seconds provides the lag in seconds.
+	Seconds float64 `json:"seconds,omitempty"`
+
+	// Versions provides the lag in versions.
+	Versions int64 `json:"versions,omitempty"`
 }
 
 // FaultTolerance provides information about the fault tolerance status
@@ -148,6 +200,27 @@ type FoundationDBStatusProcessInfo struct {
 
 	// Messages contains error messages from that fdbserver process instance
 	Messages []FoundationDBStatusProcessMessage `json:"messages,omitempty"`
+
+	// CPU contains the CPU usage of the process.
+	CPU FoundationDBStatusProcessCPUStatistics `json:"cpu,omitempty"`
+
+	// Memory contains the memory usage of the process.
+	Memory FoundationDBStatusProcessMemoryStatistics `json:"memory,omitempty"`
+}
+
+// FoundationDBStatusProcessCPUStatistics contains the minimal information about the CPU usage of a process.
+type FoundationDBStatusProcessCPUStatistics struct {
+	// UsageCores defines the number of CPU cores used by the process.
+	UsageCores float64 `json:"usage_cores,omitempty"`
+}
+
+// FoundationDBStatusProcessMemoryStatistics contains the minimal information about the memory usage of a process.
+type FoundationDBStatusProcessMemoryStatistics struct {
+	// UsedBytes defines the number of bytes used by the process.
+	UsedBytes int `json:"used_bytes,omitempty"`
+
+	// LimitBytes defines the memory limit of the process.
+	LimitBytes int `json:"limit_bytes,omitempty"`
 }
 
 // FoundationDBStatusProcessMessage represents an error message in the status json
@@ -169,6 +242,34 @@ type FoundationDBStatusProcessRoleInfo struct {
 	StoredBytes int `json:"stored_bytes,omitempty"`
 	// ID represent the role ID.
 	ID string `json:"id,omitempty"`
+	// InputBytes defines the number of bytes that were received by this role.
+	InputBytes FoundationDBStatusCounter `json:"input_bytes,omitempty"`
+	// DurableBytes defines the number of bytes that were made durable by this role.
+	DurableBytes FoundationDBStatusCounter `json:"durable_bytes,omitempty"`
+	// StorageMetadata provides additional information about a storage role.
+	StorageMetadata FoundationDBStatusStorageMetadata `json:"storage_metadata,omitempty"`
+}
+
+// FoundationDBStatusStorageMetadata contains the minimal information of the storage metadata in the process status.
+type FoundationDBStatusStorageMetadata struct {
+	// StorageEngine defines the storage engine that is used by the storage role. This is only reported by newer
+	// versions of FDB.
+	StorageEngine StorageEngine `json:"storage_engine,omitempty"`
+}
+
+// FoundationDBStatusCounter contains the minimal information of a counter in the process status.
+type FoundationDBStatusCounter struct {
+	// Counter defines the total value of the counter.
+	Counter int `json:"counter,omitempty"`
+}
+
+// GetQueueBytes returns the number of bytes that were received by the role but are not yet durable.
+func (role FoundationDBStatusProcessRoleInfo) GetQueueBytes() int {
+	if role.InputBytes.Counter < role.DurableBytes.Counter {
+		return 0
+	}
+
+	return role.InputBytes.Counter - role.DurableBytes.Counter
 }
 
 // FoundationDBStatusDataStatistics provides information about the data in
@@ -103,10 +103,14 @@ var _ = Describe("FoundationDBStatus", func() {
 							},
 							Version:       "6.2.15",
 							UptimeSeconds: 2955.58,
+							CPU:           FoundationDBStatusProcessCPUStatistics{UsageCores: 0.0370445},
+							Memory:        FoundationDBStatusProcessMemoryStatistics{UsedBytes: 510480384, LimitBytes: 8589934592},
 							Roles: []FoundationDBStatusProcessRoleInfo{
 								{
-									Role: string(ProcessRoleLog),
-									ID:   "c686af4e20478a38",
+									Role:         string(ProcessRoleLog),
+									ID:           "c686af4e20478a38",
+									InputBytes:   FoundationDBStatusCounter{Counter: 18381},
+									DurableBytes: FoundationDBStatusCounter{Counter: 18191},
 								},
 							},
 							Messages: []FoundationDBStatusProcessMessage{},
@@ -127,14 +131,18 @@ var _ = Describe("FoundationDBStatus", func() {
 							},
 							Version:       "6.2.15",
 							UptimeSeconds: 2475.33,
+							CPU:           FoundationDBStatusProcessCPUStatistics{UsageCores: 0.0494183},
+							Memory:        FoundationDBStatusProcessMemoryStatistics{UsedBytes: 357195776, LimitBytes: 8589934592},
 							Roles: []FoundationDBStatusProcessRoleInfo{
 								{
 									Role: string(ProcessRoleProxy),
 									ID:   "1e20b57ea43f9aa9",
 								},
 								{
-									Role: string(ProcessRoleStorage),
-									ID:   "6b11d7bb5c720b38",
+									Role:         string(ProcessRoleStorage),
+									ID:           "6b11d7bb5c720b38",
+									InputBytes:   FoundationDBStatusCounter{Counter: 46608},
+									DurableBytes: FoundationDBStatusCounter{Counter: 46608},
 								},
 							},
 							Messages: []FoundationDBStatusProcessMessage{},
@@ -155,14 +163,18 @@ var _ = Describe("FoundationDBStatus", func() {
 							},
 							Version:       "6.2.15",
 							UptimeSeconds: 2951.17,
+							CPU:           FoundationDBStatusProcessCPUStatistics{UsageCores: 0.0496311},
+							Memory:        FoundationDBStatusProcessMemoryStatistics{UsedBytes: 492015616, LimitBytes: 8589934592},
 							Roles: []FoundationDBStatusProcessRoleInfo{
 								{
 									Role: string(ProcessRoleProxy),
 									ID:   "780a7ea7433362a3",
 								},
 								{
-									Role: string(ProcessRoleStorage),
-									ID:   "c8e7fa2179a80035",
+									Role:         string(ProcessRoleStorage),
+									ID:           "c8e7fa2179a80035",
+									InputBytes:   FoundationDBStatusCounter{Counter: 1021596},
+									DurableBytes: FoundationDBStatusCounter{Counter: 1019590},
 								},
 							},
 							Messages: []FoundationDBStatusProcessMessage{},
@@ -183,14 +195,18 @@ var _ = Describe("FoundationDBStatus", func() {
 							},
 							Version:       "6.2.15",
 							UptimeSeconds: 710.119,
+							CPU:           FoundationDBStatusProcessCPUStatistics{UsageCores: 0.0553955},
+							Memory:        FoundationDBStatusProcessMemoryStatistics{UsedBytes: 510365696, LimitBytes: 8589934592},
 							Roles: []FoundationDBStatusProcessRoleInfo{
 								{
 									Role: string(ProcessRoleClusterController),
 									ID:   "6feba05132f0bdf7",
 								},
 								{
-									Role: string(ProcessRoleLog),
-									ID:   "863f6c6abfd9f1be",
+									Role:         string(ProcessRoleLog),
+									ID:           "863f6c6abfd9f1be",
+									InputBytes:   FoundationDBStatusCounter{Counter: 296},
+									DurableBytes: FoundationDBStatusCounter{Counter: 296},
 								},
 							},
 							Messages: []FoundationDBStatusProcessMessage{},
@@ -211,6 +227,8 @@ var _ = Describe("FoundationDBStatus", func() {
 							},
 							Version:       "6.2.15",
 							UptimeSeconds: 1095.18,
+							CPU:           FoundationDBStatusProcessCPUStatistics{UsageCores: 0.0185648},
+							Memory:        FoundationDBStatusProcessMemoryStatistics{UsedBytes: 498348032, LimitBytes: 8589934592},
 							Roles: []FoundationDBStatusProcessRoleInfo{
 								{
 									Role: string(ProcessRoleCoordinator),
@@ -238,6 +256,8 @@ var _ = Describe("FoundationDBStatus", func() {
 							},
 							Version:       "6.2.15",
 							UptimeSeconds: 880.18,
+							CPU:           FoundationDBStatusProcessCPUStatistics{UsageCores: 0.0932934},
+							Memory:        FoundationDBStatusProcessMemoryStatistics{UsedBytes: 521166848, LimitBytes: 8589934592},
 							Roles: []FoundationDBStatusProcessRoleInfo{
 								{
 									Role: string(ProcessRoleMaster),
@@ -255,8 +275,10 @@ var _ = Describe("FoundationDBStatus", func() {
 									Role: string(ProcessRoleCoordinator),
 								},
 								{
-									Role: string(ProcessRoleLog),
-									ID:   "ec250c522d647c95",
+									Role:         string(ProcessRoleLog),
+									ID:           "ec250c522d647c95",
+									InputBytes:   FoundationDBStatusCounter{Counter: 18381},
+									DurableBytes: FoundationDBStatusCounter{Counter: 18191},
 								},
 							},
 							Messages: []FoundationDBStatusProcessMessage{},
@@ -277,6 +299,8 @@ var _ = Describe("FoundationDBStatus", func() {
 							},
 							Version:       "6.2.15",
 							UptimeSeconds: 2650.5,
+							CPU:           FoundationDBStatusProcessCPUStatistics{UsageCores: 0.057441799999999994},
+							Memory:        FoundationDBStatusProcessMemoryStatistics{UsedBytes: 492867584, LimitBytes: 8589934592},
 							Roles: []FoundationDBStatusProcessRoleInfo{
 								{
 									Role: string(ProcessRoleCoordinator),
@@ -286,8 +310,10 @@ var _ = Describe("FoundationDBStatus", func() {
 									ID:   "768542f56d94c64f",
 								},
 								{
-									Role: string(ProcessRoleStorage),
-									ID:   "06a581cc09ed3fb9",
+									Role:         string(ProcessRoleStorage),
+									ID:           "06a581cc09ed3fb9",
+									InputBytes:   FoundationDBStatusCounter{Counter: 890158},
+									DurableBytes: FoundationDBStatusCounter{Counter: 890158},
 								},
 							},
 							Messages: []FoundationDBStatusProcessMessage{},
@@ -479,6 +505,19 @@ var _ = Describe("FoundationDBStatus", func() {
 					RecoveryState: RecoveryState{
 						Name: "fully_recovered",
 					},
+					LatencyProbe: FoundationDBStatusLatencyProbe{
+						CommitSeconds:           0.00480127,
+						ReadSeconds:             0.0005724430000000001,
+						TransactionStartSeconds: 0.00218654,
+					},
+					Qos: FoundationDBStatusQosInfo{
+						PerformanceLimitedBy: FoundationDBStatusPerformanceLimitedBy{
+							Name:        "workload",
+							Description: "The database is not being saturated by the workload.",
+						},
+						WorstQueueBytesLogServer:     190,
+						WorstQueueBytesStorageServer: 2006,
+					},
 				},
 			}))
 		})
@@ -489,13 +528,14 @@ var _ = Describe("FoundationDBStatus", func() {
 			IncompatibleConnections: []string{},
 			ConnectionString:        "test_cluster:aHeD9ocNXOUxi0dyzU3k7Bhg53SpyrBV@10.1.18.253:4501,10.1.18.254:4501,10.1.19.0:4501",
 			DatabaseConfiguration: DatabaseConfiguration{
-				RedundancyMode:  "double",
-				StorageEngine:   StorageEngineSSD2,
-				UsableRegions:   1,
-				Regions:         nil,
-				ExcludedServers: make([]ExcludedServers, 0),
-				RoleCounts:      RoleCounts{Storage: 0, Logs: 3, Proxies: 3, CommitProxies: 2, GrvProxies: 1, Resolvers: 1, LogRouters: -1, RemoteLogs: -1},
-				VersionFlags:    VersionFlags{LogSpill: 2, LogVersion: 0},
+				RedundancyMode:       "double",
+				StorageEngine:        StorageEngineSSD2,
+				StorageMigrationType: StorageMigrationTypeDisabled,
+				UsableRegions:        1,
+				Regions:              nil,
+				ExcludedServers:      make([]ExcludedServers, 0),
+				RoleCounts:           RoleCounts{Storage: 0, Logs: 3, Proxies: 3, CommitProxies: 2, GrvProxies: 1, Resolvers: 1, LogRouters: -1, RemoteLogs: -1},
+				VersionFlags:         VersionFlags{LogSpill: 2, LogVersion: 0},
 			},
 			Processes: map[ProcessGroupID]FoundationDBStatusProcessInfo{
 				"eb48ada3a682e86363f06aa89e1041fa": {
@@ -514,6 +554,8 @@ var _ = Describe("FoundationDBStatus", func() {
 					},
 					Version:       "7.1.0-rc1",
 					UptimeSeconds: 85.0026,
+					CPU:           FoundationDBStatusProcessCPUStatistics{UsageCores: 0.036252700000000006},
+					Memory:        FoundationDBStatusProcessMemoryStatistics{UsedBytes: 189898752, LimitBytes: 8589934592},
 					Roles: []FoundationDBStatusProcessRoleInfo{
 						{Role: string(ProcessRoleCoordinator)},
 						{
@@ -521,8 +563,10 @@ var _ = Describe("FoundationDBStatus", func() {
 							ID:   "0de7f5c5e549cad1",
 						},
 						{
-							Role: string(ProcessRoleStorage),
-							ID:   "9941616400759d37",
+							Role:         string(ProcessRoleStorage),
+							ID:           "9941616400759d37",
+							InputBytes:   FoundationDBStatusCounter{Counter: 77854},
+							DurableBytes: FoundationDBStatusCounter{Counter: 75858},
 						},
 					},
 					Messages: []FoundationDBStatusProcessMessage{},
@@ -543,11 +587,15 @@ var _ = Describe("FoundationDBStatus", func() {
 					},
 					Version:       "7.1.0-rc1",
 					UptimeSeconds: 85.0031,
+					CPU:           FoundationDBStatusProcessCPUStatistics{UsageCores: 0.0126458},
+					Memory:        FoundationDBStatusProcessMemoryStatistics{UsedBytes: 196194304, LimitBytes: 8589934592},
 					Roles: []FoundationDBStatusProcessRoleInfo{
 						{Role: string(ProcessRoleCoordinator)},
 						{
-							Role: string(ProcessClassStorage),
-							ID:   "389c23d59a646e52",
+							Role:         string(ProcessClassStorage),
+							ID:           "389c23d59a646e52",
+							InputBytes:   FoundationDBStatusCounter{Counter: 77854},
+							DurableBytes: FoundationDBStatusCounter{Counter: 75858},
 						},
 						{
 							Role: string(ProcessRoleResolver),
@@ -572,6 +620,8 @@ var _ = Describe("FoundationDBStatus", func() {
 					},
 					Version:       "7.1.0-rc1",
 					UptimeSeconds: 85.0029,
+					CPU:           FoundationDBStatusProcessCPUStatistics{UsageCores: 0.016351300000000003},
+					Memory:        FoundationDBStatusProcessMemoryStatistics{UsedBytes: 196325376, LimitBytes: 8589934592},
 					Roles: []FoundationDBStatusProcessRoleInfo{
 						{Role: string(ProcessRoleCoordinator)},
 						{
@@ -579,8 +629,10 @@ var _ = Describe("FoundationDBStatus", func() {
 							ID:   "0eb90e4a0ece85b3",
 						},
 						{
-							Role: string(ProcessRoleStorage),
-							ID:   "b5e42e100018bf11",
+							Role:         string(ProcessRoleStorage),
+							ID:           "b5e42e100018bf11",
+							InputBytes:   FoundationDBStatusCounter{Counter: 1106},
+							DurableBytes: FoundationDBStatusCounter{Counter: 1106},
 						},
 					},
 					Messages: []FoundationDBStatusProcessMessage{},
@@ -601,6 +653,8 @@ var _ = Describe("FoundationDBStatus", func() {
 					},
 					Version:       "7.1.0-rc1",
 					UptimeSeconds: 85.0027,
+					CPU:           FoundationDBStatusProcessCPUStatistics{UsageCores: 0.0418108},
+					Memory:        FoundationDBStatusProcessMemoryStatistics{UsedBytes: 141787136, LimitBytes: 8589934592},
 					Roles: []FoundationDBStatusProcessRoleInfo{
 						{
 							Role: string(ProcessRoleMaster),
@@ -633,6 +687,8 @@ var _ = Describe("FoundationDBStatus", func() {
 					},
 					Version:       "7.1.0-rc1",
 					UptimeSeconds: 85.0029,
+					CPU:           FoundationDBStatusProcessCPUStatistics{UsageCores: 0.011798900000000001},
+					Memory:        FoundationDBStatusProcessMemoryStatistics{UsedBytes: 142704640, LimitBytes: 8589934592},
 					Roles: []FoundationDBStatusProcessRoleInfo{
 						{
 							Role: string(ProcessClassClusterController),
@@ -657,10 +713,14 @@ var _ = Describe("FoundationDBStatus", func() {
 					},
 					Version:       "7.1.0-rc1",
 					UptimeSeconds: 85.0029,
+					CPU:           FoundationDBStatusProcessCPUStatistics{UsageCores: 0.012726600000000001},
+					Memory:        FoundationDBStatusProcessMemoryStatistics{UsedBytes: 216772608, LimitBytes: 8589934592},
 					Roles: []FoundationDBStatusProcessRoleInfo{
 						{
-							Role: string(ProcessRoleLog),
-							ID:   "2c66a861b33b2697",
+							Role:         string(ProcessRoleLog),
+							ID:           "2c66a861b33b2697",
+							InputBytes:   FoundationDBStatusCounter{Counter: 1512},
+							DurableBytes: FoundationDBStatusCounter{Counter: 255},
 						},
 					},
 					Messages: []FoundationDBStatusProcessMessage{},
@@ -681,10 +741,14 @@ var _ = Describe("FoundationDBStatus", func() {
 					},
 					Version:       "7.1.0-rc1",
 					UptimeSeconds: 85.003,
+					CPU:           FoundationDBStatusProcessCPUStatistics{UsageCores: 0.0137228},
+					Memory:        FoundationDBStatusProcessMemoryStatistics{UsedBytes: 197763072, LimitBytes: 8589934592},
 					Roles: []FoundationDBStatusProcessRoleInfo{
 						{
-							Role: string(ProcessRoleLog),
-							ID:   "56cf105980ec2b07",
+							Role:         string(ProcessRoleLog),
+							ID:           "56cf105980ec2b07",
+							InputBytes:   FoundationDBStatusCounter{Counter: 14551},
+							DurableBytes: FoundationDBStatusCounter{Counter: 3264},
 						},
 					},
 					Messages: []FoundationDBStatusProcessMessage{},
@@ -705,10 +769,14 @@ var _ = Describe("FoundationDBStatus", func() {
 					},
 					Version:       "7.1.0-rc1",
 					UptimeSeconds: 85.0027,
+					CPU:           FoundationDBStatusProcessCPUStatistics{UsageCores: 0.0140474},
+					Memory:        FoundationDBStatusProcessMemoryStatistics{UsedBytes: 210481152, LimitBytes: 8589934592},
 					Roles: []FoundationDBStatusProcessRoleInfo{
 						{
-							Role: string(ProcessRoleLog),
-							ID:   "31754d1d7d8d6f05",
+							Role:         string(ProcessRoleLog),
+							ID:           "31754d1d7d8d6f05",
+							InputBytes:   FoundationDBStatusCounter{Counter: 15459},
+							DurableBytes: FoundationDBStatusCounter{Counter: 3315},
 						},
 					},
 					Messages: []FoundationDBStatusProcessMessage{},
@@ -852,6 +920,19 @@ var _ = Describe("FoundationDBStatus", func() {
 				SecondsSinceLastRecovered: 76.8155,
 			},
 			Generation: 2,
+			LatencyProbe: FoundationDBStatusLatencyProbe{
+				CommitSeconds:           0.00458646,
+				ReadSeconds:             0.000394344,
+				TransactionStartSeconds: 0.00389361,
+			},
+			Qos: FoundationDBStatusQosInfo{
+				PerformanceLimitedBy: FoundationDBStatusPerformanceLimitedBy{
+					Name:        "workload",
+					Description: "The database is not being saturated by the workload.",
+				},
+				WorstQueueBytesLogServer:     12144,
+				WorstQueueBytesStorageServer: 1996,
+			},
 		}
 
 		It("should parse all values correctly", func() {
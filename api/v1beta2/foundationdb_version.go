@@ -190,10 +190,18 @@ func (version Version) IsStorageEngineSupported(storageEngine StorageEngine) boo
 		return version.IsAtLeast(Versions.SupportsShardedRocksDB)
 	} else if storageEngine == StorageEngineRedwood1Experimental {
 		return version.IsAtLeast(Versions.SupportsRedwood1Experimental)
+	} else if storageEngine == StorageEngineRedwood1 {
+		return version.IsAtLeast(Versions.SupportsRedwood1)
 	}
 	return true
 }
 
+// SupportsStorageMigrationType returns true if the version supports the storage_migration_type
+// configuration, which prevents that FDB migrates the storage servers to a new storage engine on its own.
+func (version Version) SupportsStorageMigrationType() bool {
+	return version.IsAtLeast(Versions.SupportsStorageMigration)
+}
+
 // IsReleaseCandidate returns true if the version is a release candidate or not
 func (version Version) IsReleaseCandidate() bool {
 	return version.ReleaseCandidate > 0
@@ -209,6 +217,12 @@ func (version Version) SupportsRecoveryState() bool {
 	return version.IsAtLeast(Versions.SupportsRecoveryState)
 }
 
+// SupportsManagementAPI returns true if the version of FDB supports the management API in the special key space for
+// exclusions, coordinators and maintenance zones.
+func (version Version) SupportsManagementAPI() bool {
+	return version.IsAtLeast(Versions.SupportsManagementAPI)
+}
+
 // Versions provides a shorthand for known versions.
 // This is only to be used in testing.
 var Versions = struct {
@@ -219,9 +233,12 @@ var Versions = struct {
 	SupportsIsPresent,
 	SupportsShardedRocksDB,
 	SupportsRedwood1Experimental,
+	SupportsRedwood1,
+	SupportsStorageMigration,
 	IncompatibleVersion,
 	PreviousPatchVersion,
 	SupportsRecoveryState,
+	SupportsManagementAPI,
 	Default Version
 }{
 	Default:                      Version{Major: 6, Minor: 2, Patch: 21},
@@ -234,5 +251,8 @@ var Versions = struct {
 	SupportsIsPresent:            Version{Major: 7, Minor: 1, Patch: 4},
 	SupportsShardedRocksDB:       Version{Major: 7, Minor: 2, Patch: 0},
 	SupportsRedwood1Experimental: Version{Major: 7, Minor: 0, Patch: 0},
+	SupportsRedwood1:             Version{Major: 7, Minor: 3, Patch: 0},
+	SupportsStorageMigration:     Version{Major: 7, Minor: 0, Patch: 0},
 	SupportsRecoveryState:        Version{Major: 7, Minor: 1, Patch: 22},
+	SupportsManagementAPI:        Version{Major: 7, Minor: 1, Patch: 0},
 }
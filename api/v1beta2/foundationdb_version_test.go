@@ -156,4 +156,33 @@ var _ = Describe("[api] FDBVersion", func() {
 		)
 
 	})
+
+	DescribeTable("checking if the version supports the management API",
+		func(version Version, expected bool) {
+			Expect(version.SupportsManagementAPI()).To(Equal(expected))
+		},
+		Entry("when the version is 6.2.20", Version{Major: 6, Minor: 2, Patch: 20}, false),
+		Entry("when the version is 7.0.0", Version{Major: 7, Minor: 0, Patch: 0}, false),
+		Entry("when the version is a 7.1.0 release candidate", Version{Major: 7, Minor: 1, Patch: 0, ReleaseCandidate: 1}, false),
+		Entry("when the version is 7.1.0", Version{Major: 7, Minor: 1, Patch: 0}, true),
+		Entry("when the version is 7.2.0", Version{Major: 7, Minor: 2, Patch: 0}, true),
+	)
+
+	DescribeTable("checking if the version supports the storage migration type",
+		func(version Version, expected bool) {
+			Expect(version.SupportsStorageMigrationType()).To(Equal(expected))
+		},
+		Entry("when the version is 6.3.24", Version{Major: 6, Minor: 3, Patch: 24}, false),
+		Entry("when the version is 7.0.0", Version{Major: 7, Minor: 0, Patch: 0}, true),
+		Entry("when the version is 7.1.0", Version{Major: 7, Minor: 1, Patch: 0}, true),
+	)
+
+	DescribeTable("checking if the storage engine is supported",
+		func(version Version, storageEngine StorageEngine, expected bool) {
+			Expect(version.IsStorageEngineSupported(storageEngine)).To(Equal(expected))
+		},
+		Entry("ssd-2 on 6.2.20", Version{Major: 6, Minor: 2, Patch: 20}, StorageEngineSSD2, true),
+		Entry("ssd-redwood-1 on 7.1.0", Version{Major: 7, Minor: 1, Patch: 0}, StorageEngineRedwood1, false),
+		Entry("ssd-redwood-1 on 7.3.0", Version{Major: 7, Minor: 3, Patch: 0}, StorageEngineRedwood1, true),
+	)
 })
@@ -0,0 +1,246 @@
+/*
+ * foundationdbautoscaler_types.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta2
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=fdbautoscaler
+// +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterName",description="Name of the cluster",priority=0
+// +kubebuilder:printcolumn:name="Class",type="string",JSONPath=".spec.processClass",description="Process class that is scaled",priority=0
+// +kubebuilder:printcolumn:name="Min",type="integer",JSONPath=".spec.minReplicas",description="Minimum number of process groups",priority=0
+// +kubebuilder:printcolumn:name="Max",type="integer",JSONPath=".spec.maxReplicas",description="Maximum number of process groups",priority=0
+// +kubebuilder:printcolumn:name="Desired",type="integer",JSONPath=".spec.replicas",description="Desired number of process groups",priority=0
+// +kubebuilder:printcolumn:name="Current",type="integer",JSONPath=".status.replicas",description="Current number of process groups",priority=0
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:storageversion
+
+// FoundationDBAutoscaler is the Schema for the foundationdbautoscalers API. A FoundationDBAutoscaler manages the
+// process count of a single stateless or log process class of a FoundationDBCluster.
+type FoundationDBAutoscaler struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FoundationDBAutoscalerSpec   `json:"spec,omitempty"`
+	Status FoundationDBAutoscalerStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// FoundationDBAutoscalerList contains a list of FoundationDBAutoscaler objects
+type FoundationDBAutoscalerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FoundationDBAutoscaler `json:"items"`
+}
+
+// FoundationDBAutoscalerSpec describes the desired state of the autoscaler.
+type FoundationDBAutoscalerSpec struct {
+	// ClusterName defines the name of the FoundationDBCluster that should be scaled.
+	// +kubebuilder:validation:MaxLength=100
+	ClusterName string `json:"clusterName"`
+
+	// ProcessClass defines the process class that should be scaled.
+	// +kubebuilder:validation:Enum=stateless;log;proxy;commit_proxy;grv_proxy
+	ProcessClass ProcessClass `json:"processClass"`
+
+	// MinReplicas defines the minimum number of process groups for the process class.
+	// +kubebuilder:validation:Minimum=1
+	MinReplicas int `json:"minReplicas"`
+
+	// MaxReplicas defines the maximum number of process groups for the process class.
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int `json:"maxReplicas"`
+
+	// Replicas defines the desired number of process groups for the process class. This value is set through the
+	// scale subresource, e.g. by a HorizontalPodAutoscaler, and is never changed by the operator. If set, it takes
+	// precedence over the recommendation based on the signals. The value will always be bound by MinReplicas and
+	// MaxReplicas. If unset and no signals are defined, the process count of the cluster will not be changed.
+	// +kubebuilder:validation:Minimum=0
+	Replicas *int `json:"replicas,omitempty"`
+
+	// Signals defines the thresholds of the database signals that will be used by the operator to recommend the
+	// number of process groups for the process class. The recommendation is stored in the status and is only
+	// applied if Replicas is unset. If unset, the operator will only apply the Replicas, e.g. set by a
+	// HorizontalPodAutoscaler.
+	Signals *AutoscalerSignals `json:"signals,omitempty"`
+
+	// StepSize defines how many process groups will be added or removed in a single scaling operation based on
+	// the signals. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	StepSize *int `json:"stepSize,omitempty"`
+
+	// ScaleUpCooldownSeconds defines the minimum duration in seconds between the last scaling operation and a
+	// scale up based on the signals. Defaults to 300.
+	// +kubebuilder:validation:Minimum=0
+	ScaleUpCooldownSeconds *int `json:"scaleUpCooldownSeconds,omitempty"`
+
+	// ScaleDownCooldownSeconds defines the minimum duration in seconds between the last scaling operation and a
+	// scale down based on the signals. Defaults to 900.
+	// +kubebuilder:validation:Minimum=0
+	ScaleDownCooldownSeconds *int `json:"scaleDownCooldownSeconds,omitempty"`
+}
+
+// AutoscalerSignals defines the thresholds of the signals from the machine-readable status that trigger a scaling
+// operation. The process class will be scaled up if any of the thresholds is exceeded and it will be scaled down if
+// all signals are below the scale down threshold.
+type AutoscalerSignals struct {
+	// MaxCommitLatencyMilliseconds defines the maximum commit latency measured by the latency probe.
+	// +kubebuilder:validation:Minimum=1
+	MaxCommitLatencyMilliseconds *int `json:"maxCommitLatencyMilliseconds,omitempty"`
+
+	// MaxLogQueueBytes defines the maximum queue size of the worst log server.
+	// +kubebuilder:validation:Minimum=1
+	MaxLogQueueBytes *int `json:"maxLogQueueBytes,omitempty"`
+
+	// LimitingReasons defines the ratekeeper limiting reasons, e.g. log_server_write_queue, that will trigger a
+	// scale up. A process class will never be scaled down while the ratekeeper is limited by one of those reasons.
+	// +kubebuilder:validation:MaxItems=20
+	LimitingReasons []string `json:"limitingReasons,omitempty"`
+
+	// ScaleDownThresholdPercentage defines the percentage of the thresholds that all signals must be below before
+	// the process class will be scaled down. Defaults to 50.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	ScaleDownThresholdPercentage *int `json:"scaleDownThresholdPercentage,omitempty"`
+}
+
+// FoundationDBAutoscalerStatus describes the current state of the autoscaler.
+type FoundationDBAutoscalerStatus struct {
+	// Replicas defines the current number of process groups for the process class that are not marked for removal.
+	Replicas int `json:"replicas,omitempty"`
+
+	// Selector defines the label selector for the Pods of the process class, this is used by the
+	// HorizontalPodAutoscaler.
+	Selector string `json:"selector,omitempty"`
+
+	// RecommendedReplicas defines the number of process groups for the process class that the operator recommends
+	// based on the signals.
+	RecommendedReplicas *int `json:"recommendedReplicas,omitempty"`
+
+	// LastScaleTime defines when the process count of the cluster was changed the last time.
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+
+	// Message provides additional information about the last scaling decision.
+	Message string `json:"message,omitempty"`
+}
+
+// GetStepSize returns the number of process groups that will be added or removed in a single scaling operation,
+// defaults to 1.
+func (autoscaler *FoundationDBAutoscaler) GetStepSize() int {
+	if autoscaler.Spec.StepSize == nil {
+		return 1
+	}
+
+	return *autoscaler.Spec.StepSize
+}
+
+// GetScaleUpCooldown returns the minimum duration between the last scaling operation and a scale up, defaults to
+// 5 minutes.
+func (autoscaler *FoundationDBAutoscaler) GetScaleUpCooldown() time.Duration {
+	if autoscaler.Spec.ScaleUpCooldownSeconds == nil {
+		return 5 * time.Minute
+	}
+
+	return time.Duration(*autoscaler.Spec.ScaleUpCooldownSeconds) * time.Second
+}
+
+// GetScaleDownCooldown returns the minimum duration between the last scaling operation and a scale down, defaults
+// to 15 minutes.
+func (autoscaler *FoundationDBAutoscaler) GetScaleDownCooldown() time.Duration {
+	if autoscaler.Spec.ScaleDownCooldownSeconds == nil {
+		return 15 * time.Minute
+	}
+
+	return time.Duration(*autoscaler.Spec.ScaleDownCooldownSeconds) * time.Second
+}
+
+// GetScaleDownThresholdPercentage returns the percentage of the thresholds that all signals must be below before
+// the process class will be scaled down, defaults to 50.
+func (autoscaler *FoundationDBAutoscaler) GetScaleDownThresholdPercentage() int {
+	if autoscaler.Spec.Signals == nil || autoscaler.Spec.Signals.ScaleDownThresholdPercentage == nil {
+		return 50
+	}
+
+	return *autoscaler.Spec.Signals.ScaleDownThresholdPercentage
+}
+
+// GetDesiredReplicas returns the desired number of process groups for the process class. The replicas set through
+// the scale subresource take precedence over the recommendation based on the signals. If neither is defined this
+// will return nil.
+func (autoscaler *FoundationDBAutoscaler) GetDesiredReplicas() *int {
+	if autoscaler.Spec.Replicas != nil {
+		return autoscaler.Spec.Replicas
+	}
+
+	if autoscaler.Spec.Signals == nil {
+		return nil
+	}
+
+	return autoscaler.Status.RecommendedReplicas
+}
+
+// BoundReplicas returns the provided replicas bound by the minimum and maximum replicas. The result will never be
+// below requiredReplicas, the number of processes that is needed to recruit the roles of the process class.
+func (autoscaler *FoundationDBAutoscaler) BoundReplicas(replicas int, requiredReplicas int) int {
+	if replicas < autoscaler.Spec.MinReplicas {
+		replicas = autoscaler.Spec.MinReplicas
+	}
+
+	if replicas > autoscaler.Spec.MaxReplicas {
+		replicas = autoscaler.Spec.MaxReplicas
+	}
+
+	if replicas < requiredReplicas {
+		return requiredReplicas
+	}
+
+	return replicas
+}
+
+// Validate checks if the autoscaler spec is valid.
+func (autoscaler *FoundationDBAutoscaler) Validate() error {
+	if autoscaler.Spec.ClusterName == "" {
+		return fmt.Errorf("clusterName must be defined")
+	}
+
+	if autoscaler.Spec.MinReplicas > autoscaler.Spec.MaxReplicas {
+		return fmt.Errorf("minReplicas %d must not be greater than maxReplicas %d", autoscaler.Spec.MinReplicas, autoscaler.Spec.MaxReplicas)
+	}
+
+	switch autoscaler.Spec.ProcessClass {
+	case ProcessClassStateless, ProcessClassLog, ProcessClassProxy, ProcessClassCommitProxy, ProcessClassGrvProxy:
+		return nil
+	}
+
+	return fmt.Errorf("process class %s cannot be scaled by the autoscaler", autoscaler.Spec.ProcessClass)
+}
+
+func init() {
+	SchemeBuilder.Register(&FoundationDBAutoscaler{}, &FoundationDBAutoscalerList{})
+}
@@ -0,0 +1,115 @@
+/*
+ * foundationdbautoscaler_types_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta2
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("[api] FoundationDBAutoscaler", func() {
+	var autoscaler *FoundationDBAutoscaler
+
+	BeforeEach(func() {
+		autoscaler = &FoundationDBAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "default",
+			},
+			Spec: FoundationDBAutoscalerSpec{
+				ClusterName:  "test-cluster",
+				ProcessClass: ProcessClassStateless,
+				MinReplicas:  2,
+				MaxReplicas:  10,
+			},
+		}
+	})
+
+	When("getting the defaults", func() {
+		It("should return the default values", func() {
+			Expect(autoscaler.GetStepSize()).To(Equal(1))
+			Expect(autoscaler.GetScaleUpCooldown()).To(Equal(5 * time.Minute))
+			Expect(autoscaler.GetScaleDownCooldown()).To(Equal(15 * time.Minute))
+			Expect(autoscaler.GetScaleDownThresholdPercentage()).To(Equal(50))
+		})
+
+		When("the values are defined", func() {
+			BeforeEach(func() {
+				autoscaler.Spec.StepSize = pointer.Int(2)
+				autoscaler.Spec.ScaleUpCooldownSeconds = pointer.Int(60)
+				autoscaler.Spec.ScaleDownCooldownSeconds = pointer.Int(120)
+				autoscaler.Spec.Signals = &AutoscalerSignals{
+					ScaleDownThresholdPercentage: pointer.Int(25),
+				}
+			})
+
+			It("should return the defined values", func() {
+				Expect(autoscaler.GetStepSize()).To(Equal(2))
+				Expect(autoscaler.GetScaleUpCooldown()).To(Equal(1 * time.Minute))
+				Expect(autoscaler.GetScaleDownCooldown()).To(Equal(2 * time.Minute))
+				Expect(autoscaler.GetScaleDownThresholdPercentage()).To(Equal(25))
+			})
+		})
+	})
+
+	DescribeTable("getting the desired replicas", func(replicas *int, signals *AutoscalerSignals, recommendedReplicas *int, expected *int) {
+		autoscaler.Spec.Replicas = replicas
+		autoscaler.Spec.Signals = signals
+		autoscaler.Status.RecommendedReplicas = recommendedReplicas
+		Expect(autoscaler.GetDesiredReplicas()).To(Equal(expected))
+	},
+		Entry("no replicas and no signals", nil, nil, nil, nil),
+		Entry("replicas from the scale subresource", pointer.Int(5), nil, nil, pointer.Int(5)),
+		Entry("recommendation from the signals", nil, &AutoscalerSignals{}, pointer.Int(4), pointer.Int(4)),
+		Entry("replicas and recommendation", pointer.Int(5), &AutoscalerSignals{}, pointer.Int(4), pointer.Int(5)),
+		Entry("recommendation without signals", nil, nil, pointer.Int(4), nil),
+	)
+
+	DescribeTable("bounding the replicas", func(replicas int, requiredReplicas int, expected int) {
+		Expect(autoscaler.BoundReplicas(replicas, requiredReplicas)).To(Equal(expected))
+	},
+		Entry("below the minimum", 1, 0, 2),
+		Entry("within the bounds", 5, 0, 5),
+		Entry("above the maximum", 11, 0, 10),
+		Entry("below the required replicas", 3, 4, 4),
+		Entry("required replicas above the maximum", 11, 12, 12),
+	)
+
+	DescribeTable("validating the autoscaler", func(spec FoundationDBAutoscalerSpec, expected string) {
+		autoscaler.Spec = spec
+		err := autoscaler.Validate()
+		if expected == "" {
+			Expect(err).NotTo(HaveOccurred())
+			return
+		}
+
+		Expect(err).To(MatchError(expected))
+	},
+		Entry("valid spec", FoundationDBAutoscalerSpec{ClusterName: "test", ProcessClass: ProcessClassLog, MinReplicas: 3, MaxReplicas: 5}, ""),
+		Entry("missing cluster name", FoundationDBAutoscalerSpec{ProcessClass: ProcessClassLog, MinReplicas: 3, MaxReplicas: 5}, "clusterName must be defined"),
+		Entry("min greater than max", FoundationDBAutoscalerSpec{ClusterName: "test", ProcessClass: ProcessClassLog, MinReplicas: 6, MaxReplicas: 5}, "minReplicas 6 must not be greater than maxReplicas 5"),
+		Entry("storage process class", FoundationDBAutoscalerSpec{ClusterName: "test", ProcessClass: ProcessClassStorage, MinReplicas: 3, MaxReplicas: 5}, "process class storage cannot be scaled by the autoscaler"),
+	)
+})
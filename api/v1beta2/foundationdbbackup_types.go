@@ -18,6 +18,7 @@ package v1beta2
 
 import (
 	"fmt"
+	"path"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -30,6 +31,7 @@ import (
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Generation",type="integer",JSONPath=".metadata.generation",description="Latest generation of the spec",priority=0
 // +kubebuilder:printcolumn:name="Reconciled",type="integer",JSONPath=".status.generations.reconciled",description="Last reconciled generation of the spec",priority=0
+// +kubebuilder:printcolumn:name="Tag",type="string",JSONPath=".status.backupDetails.tag",description="Tag of the backup in the cluster",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:storageversion
 
@@ -72,6 +74,14 @@ type FoundationDBBackupSpec struct {
 	// This is measured in seconds. The default is 864,000, or 10 days.
 	SnapshotPeriodSeconds *int `json:"snapshotPeriodSeconds,omitempty"`
 
+	// BackupTag defines the tag of the backup in the cluster. Multiple
+	// backups can run against the same cluster as long as every backup uses
+	// a different tag.
+	// The default is "default".
+	// +kubebuilder:validation:MaxLength=100
+	// +kubebuilder:validation:Pattern:=`^[A-Za-z0-9_-]+$`
+	BackupTag string `json:"backupTag,omitempty"`
+
 	// BackupDeploymentMetadata allows customizing labels and annotations on the
 	// deployment for the backup agents.
 	BackupDeploymentMetadata *metav1.ObjectMeta `json:"backupDeploymentMetadata,omitempty"`
@@ -95,12 +105,49 @@ type FoundationDBBackupSpec struct {
 	// This is the configuration of the target blobstore for this backup.
 	BlobStoreConfiguration *BlobStoreConfiguration `json:"blobStoreConfiguration,omitempty"`
 
+	// FileSystemConfiguration defines a file system destination for this
+	// backup, e.g. a shared persistent volume. This field is mutually
+	// exclusive with BlobStoreConfiguration. File system destinations are
+	// currently rejected, as the operator can't access the persistent volume.
+	FileSystemConfiguration *FileSystemConfiguration `json:"fileSystemConfiguration,omitempty"`
+
 	// MainContainer defines customization for the foundationdb container.
 	MainContainer ContainerOverrides `json:"mainContainer,omitempty"`
 
 	// SidecarContainer defines customization for the
 	// foundationdb-kubernetes-sidecar container.
 	SidecarContainer ContainerOverrides `json:"sidecarContainer,omitempty"`
+
+	// RetentionPolicy defines which restorable points of the backup should be
+	// kept in the blob store. If unset the operator will not check the
+	// restorable points and will never expire backup data.
+	RetentionPolicy *BackupRetentionPolicy `json:"retentionPolicy,omitempty"`
+}
+
+// BackupRetentionPolicy defines which restorable points of a backup should be
+// kept in the blob store.
+type BackupRetentionPolicy struct {
+	// RestorablePoints defines how many of the newest restorable snapshots
+	// should be kept. Older backup data will be expired. The snapshot period
+	// defines how often a new restorable point is created, e.g. a snapshot
+	// period of 86400 seconds together with 7 restorable points will keep 7
+	// daily restorable points.
+	// If unset, no backup data will be expired and the restorable points will
+	// only be reported in the status.
+	// +kubebuilder:validation:Minimum=1
+	RestorablePoints *int `json:"restorablePoints,omitempty"`
+
+	// IntervalSeconds defines how often the operator checks the restorable
+	// points and expires old backup data. The next check will run
+	// IntervalSeconds after the previous check has finished.
+	// The default is 3600, or 1 hour.
+	// +kubebuilder:validation:Minimum=60
+	IntervalSeconds *int `json:"intervalSeconds,omitempty"`
+
+	// DeleteWhenStopped defines if all data of the backup should be deleted
+	// from the blob store once the backup is stopped.
+	// The default is false.
+	DeleteWhenStopped *bool `json:"deleteWhenStopped,omitempty"`
 }
 
 // FoundationDBBackupStatus describes the current status of the backup for a cluster.
@@ -120,11 +167,50 @@ type FoundationDBBackupStatus struct {
 	// Generations provides information about the latest generation to be
 	// reconciled, or to reach other stages in reconciliation.
 	Generations BackupGenerationStatus `json:"generations,omitempty"`
+
+	// Retention provides information about the restorable points of the
+	// backup and the data that was expired by the retention policy.
+	Retention *BackupRetentionStatus `json:"retention,omitempty"`
+}
+
+// BackupRetentionStatus provides information about the restorable points of
+// the backup and the data that was expired by the retention policy.
+type BackupRetentionStatus struct {
+	// RestorablePoints lists the restorable snapshots of the backup, ordered
+	// from the oldest to the newest snapshot.
+	RestorablePoints []BackupRestorablePoint `json:"restorablePoints,omitempty"`
+
+	// MaxRestorableVersion provides the latest version the backup can be
+	// restored to.
+	MaxRestorableVersion *BackupRestorablePoint `json:"maxRestorableVersion,omitempty"`
+
+	// ExpiredBeforeVersion provides the version before which the backup data
+	// was expired by the operator.
+	ExpiredBeforeVersion int64 `json:"expiredBeforeVersion,omitempty"`
+
+	// DataDeleted indicates whether all data of the backup was deleted,
+	// because the backup was stopped.
+	DataDeleted bool `json:"dataDeleted,omitempty"`
+
+	// LastCheckTimestamp provides the timestamp when the operator checked the
+	// restorable points for the last time.
+	LastCheckTimestamp *metav1.Time `json:"lastCheckTimestamp,omitempty"`
+}
+
+// BackupRestorablePoint describes a version the backup can be restored to.
+type BackupRestorablePoint struct {
+	// Version provides the FoundationDB version the backup can be restored to.
+	Version int64 `json:"version"`
+
+	// Timestamp provides the time of the version, as reported by the backup
+	// describe command.
+	Timestamp string `json:"timestamp,omitempty"`
 }
 
 // FoundationDBBackupStatusBackupDetails provides information about the state
 // of the backup in the cluster.
 type FoundationDBBackupStatusBackupDetails struct {
+	Tag                   string `json:"tag,omitempty"`
 	URL                   string `json:"url,omitempty"`
 	Running               bool   `json:"running,omitempty"`
 	Paused                bool   `json:"paused,omitempty"`
@@ -198,6 +284,27 @@ type BlobStoreConfiguration struct {
 	URLParameters []URLParameter `json:"urlParameters,omitempty"`
 }
 
+// FileSystemConfiguration describes a backup destination on a file system
+// that is mounted from a persistent volume claim.
+type FileSystemConfiguration struct {
+	// The name for the backup directory, relative to the mount path.
+	// If empty defaults to .metadata.name.
+	// +kubebuilder:validation:MaxLength=1024
+	BackupName string `json:"backupName,omitempty"`
+
+	// PersistentVolumeClaimName defines the name of the persistent volume
+	// claim that contains the backup data. If multiple backup agents are
+	// running, the volume must support the ReadWriteMany access mode.
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Required
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName"`
+
+	// MountPath defines the path where the persistent volume is mounted.
+	// The default is /var/fdb/backups.
+	// +kubebuilder:validation:MaxLength=1024
+	MountPath string `json:"mountPath,omitempty"`
+}
+
 // ShouldRun determines whether a backup should be running.
 func (backup *FoundationDBBackup) ShouldRun() bool {
 	return backup.Spec.BackupState == "" || backup.Spec.BackupState == BackupStateRunning || backup.Spec.BackupState == BackupStatePaused
@@ -211,7 +318,7 @@ func (backup *FoundationDBBackup) ShouldBePaused() bool {
 // Bucket gets the bucket this backup will use.
 // This will fill in a default value if the bucket in the spec is empty.
 func (backup *FoundationDBBackup) Bucket() string {
-	if backup.Spec.BlobStoreConfiguration.Bucket == "" {
+	if backup.Spec.BlobStoreConfiguration == nil || backup.Spec.BlobStoreConfiguration.Bucket == "" {
 		return "fdb-backups"
 	}
 
@@ -221,18 +328,92 @@ func (backup *FoundationDBBackup) Bucket() string {
 // BackupName gets the name of the backup in the destination.
 // This will fill in a default value if the backup name in the spec is empty.
 func (backup *FoundationDBBackup) BackupName() string {
-	if backup.Spec.BlobStoreConfiguration.BackupName == "" {
+	if backup.Spec.FileSystemConfiguration != nil {
+		if backup.Spec.FileSystemConfiguration.BackupName == "" {
+			return backup.ObjectMeta.Name
+		}
+
+		return backup.Spec.FileSystemConfiguration.BackupName
+	}
+
+	if backup.Spec.BlobStoreConfiguration == nil || backup.Spec.BlobStoreConfiguration.BackupName == "" {
 		return backup.ObjectMeta.Name
 	}
 
 	return backup.Spec.BlobStoreConfiguration.BackupName
 }
 
+// BackupTag gets the tag of the backup in the cluster.
+// This will fill in a default value if the backup tag in the spec is empty.
+func (backup *FoundationDBBackup) BackupTag() string {
+	if backup.Spec.BackupTag == "" {
+		return "default"
+	}
+
+	return backup.Spec.BackupTag
+}
+
 // BackupURL gets the destination url of the backup.
 func (backup *FoundationDBBackup) BackupURL() string {
+	if backup.Spec.FileSystemConfiguration != nil {
+		return backup.Spec.FileSystemConfiguration.getURL(backup.BackupName())
+	}
+
+	if backup.Spec.BlobStoreConfiguration == nil {
+		return ""
+	}
+
 	return backup.Spec.BlobStoreConfiguration.getURL(backup.BackupName(), backup.Bucket())
 }
 
+// MatchesURL checks if the provided URL belongs to the destination of this
+// backup. For file system destinations fdbbackup writes the backup into a new
+// backup-<timestamp> directory below the destination URL.
+func (backup *FoundationDBBackup) MatchesURL(url string) bool {
+	backupURL := backup.BackupURL()
+	if url == backupURL {
+		return true
+	}
+
+	return backup.Spec.FileSystemConfiguration != nil && strings.HasPrefix(url, backupURL+"/backup-")
+}
+
+// OwnsBackupTag checks if the backup in the cluster that runs under the tag of
+// this backup was started by this backup. The tag is only reported in the
+// status if the backup owns it.
+func (backup *FoundationDBBackup) OwnsBackupTag() bool {
+	return backup.Status.BackupDetails != nil && backup.Status.BackupDetails.Tag == backup.BackupTag()
+}
+
+// ContainerURL gets the URL of the backup container that contains the data
+// of the current backup. For blob store destinations this is the backup URL,
+// for file system destinations this is the backup-<timestamp> directory
+// reported in the status.
+func (backup *FoundationDBBackup) ContainerURL() string {
+	if backup.Status.BackupDetails != nil && backup.Status.BackupDetails.URL != "" && backup.MatchesURL(backup.Status.BackupDetails.URL) {
+		return backup.Status.BackupDetails.URL
+	}
+
+	return backup.BackupURL()
+}
+
+// ValidateDestination checks that exactly one supported destination is
+// defined for the backup.
+func (backup *FoundationDBBackup) ValidateDestination() error {
+	return validateDestination(backup.Spec.BlobStoreConfiguration, backup.Spec.FileSystemConfiguration)
+}
+
+// ValidateRetentionPolicy checks that the retention policy can be applied to
+// the destination of the backup. The operator can't access the data of file
+// system destinations, as the volume is only mounted into the backup agents.
+func (backup *FoundationDBBackup) ValidateRetentionPolicy() error {
+	if backup.Spec.RetentionPolicy != nil && backup.Spec.FileSystemConfiguration != nil {
+		return fmt.Errorf("retentionPolicy is not supported for backups with a fileSystemConfiguration")
+	}
+
+	return nil
+}
+
 // SnapshotPeriodSeconds gets the period between snapshots for a backup.
 func (backup *FoundationDBBackup) SnapshotPeriodSeconds() int {
 	return pointer.IntDeref(backup.Spec.SnapshotPeriodSeconds, 864000)
@@ -261,6 +442,115 @@ type FoundationDBLiveBackupStatusState struct {
 	Running bool `json:"Running,omitempty"`
 }
 
+// FoundationDBBackupDescription describes the data of a backup in the blob
+// store, as provided by the backup describe command.
+type FoundationDBBackupDescription struct {
+	// URL provides the URL of the backup.
+	URL string `json:"URL,omitempty"`
+
+	// Restorable determines whether the backup can be restored.
+	Restorable bool `json:"Restorable,omitempty"`
+
+	// Snapshots provides the snapshots of the backup, ordered from the oldest
+	// to the newest snapshot.
+	Snapshots []FoundationDBBackupDescriptionSnapshot `json:"Snapshots,omitempty"`
+
+	// MinRestorableVersion provides the earliest version the backup can be
+	// restored to.
+	MinRestorableVersion *FoundationDBBackupDescriptionVersion `json:"MinRestorableVersion,omitempty"`
+
+	// MaxRestorableVersion provides the latest version the backup can be
+	// restored to.
+	MaxRestorableVersion *FoundationDBBackupDescriptionVersion `json:"MaxRestorableVersion,omitempty"`
+}
+
+// FoundationDBBackupDescriptionSnapshot describes a single snapshot of a
+// backup.
+type FoundationDBBackupDescriptionSnapshot struct {
+	// Start provides the version when the snapshot was started.
+	Start FoundationDBBackupDescriptionVersion `json:"Start,omitempty"`
+
+	// End provides the version when the snapshot was completed.
+	End FoundationDBBackupDescriptionVersion `json:"End,omitempty"`
+
+	// Restorable determines whether the backup can be restored to the end
+	// version of this snapshot.
+	Restorable bool `json:"Restorable,omitempty"`
+
+	// TotalBytes provides the size of the snapshot.
+	TotalBytes int64 `json:"TotalBytes,omitempty"`
+}
+
+// FoundationDBBackupDescriptionVersion describes a version in the backup
+// description.
+type FoundationDBBackupDescriptionVersion struct {
+	// Version provides the FoundationDB version.
+	Version int64 `json:"Version,omitempty"`
+
+	// Timestamp provides the time of the version, this is only present if
+	// the version timestamps were requested.
+	Timestamp string `json:"Timestamp,omitempty"`
+}
+
+// GetRestorablePoints returns the end versions of all restorable snapshots,
+// ordered from the oldest to the newest snapshot.
+func (description *FoundationDBBackupDescription) GetRestorablePoints() []BackupRestorablePoint {
+	points := make([]BackupRestorablePoint, 0, len(description.Snapshots))
+	for _, snapshot := range description.Snapshots {
+		if !snapshot.Restorable {
+			continue
+		}
+
+		points = append(points, BackupRestorablePoint{
+			Version:   snapshot.End.Version,
+			Timestamp: snapshot.End.Timestamp,
+		})
+	}
+
+	return points
+}
+
+// GetExpirationVersion returns the version before which the backup data can
+// be expired to keep the provided number of restorable snapshots. If the
+// backup has not more restorable snapshots than the provided number, 0 will
+// be returned.
+func (description *FoundationDBBackupDescription) GetExpirationVersion(restorablePoints int) int64 {
+	restorableSnapshots := make([]FoundationDBBackupDescriptionSnapshot, 0, len(description.Snapshots))
+	for _, snapshot := range description.Snapshots {
+		if snapshot.Restorable {
+			restorableSnapshots = append(restorableSnapshots, snapshot)
+		}
+	}
+
+	if restorablePoints <= 0 || len(restorableSnapshots) <= restorablePoints {
+		return 0
+	}
+
+	// All data before the start of the oldest snapshot that should be kept can
+	// be expired.
+	return restorableSnapshots[len(restorableSnapshots)-restorablePoints].Start.Version
+}
+
+// GetRetentionIntervalSeconds returns the interval between the checks of the
+// restorable points, the default is 3600 seconds.
+func (backup *FoundationDBBackup) GetRetentionIntervalSeconds() int {
+	if backup.Spec.RetentionPolicy == nil {
+		return 3600
+	}
+
+	return pointer.IntDeref(backup.Spec.RetentionPolicy.IntervalSeconds, 3600)
+}
+
+// ShouldDeleteDataWhenStopped determines whether the backup data should be
+// deleted once the backup is stopped.
+func (backup *FoundationDBBackup) ShouldDeleteDataWhenStopped() bool {
+	if backup.Spec.RetentionPolicy == nil {
+		return false
+	}
+
+	return pointer.BoolDeref(backup.Spec.RetentionPolicy.DeleteWhenStopped, false)
+}
+
 // GetDesiredAgentCount determines how many backup agents we should run
 // for a cluster.
 func (backup *FoundationDBBackup) GetDesiredAgentCount() int {
@@ -330,6 +620,40 @@ func (configuration *BlobStoreConfiguration) getURL(backup string, bucket string
 	return fmt.Sprintf("blobstore://%s/%s?bucket=%s%s", configuration.AccountName, backup, bucket, sb.String())
 }
 
+// GetMountPath returns the path where the persistent volume is mounted, the
+// default is /var/fdb/backups.
+func (configuration *FileSystemConfiguration) GetMountPath() string {
+	if configuration.MountPath == "" {
+		return "/var/fdb/backups"
+	}
+
+	return configuration.MountPath
+}
+
+func (configuration *FileSystemConfiguration) getURL(backup string) string {
+	return fmt.Sprintf("file://%s", path.Join(configuration.GetMountPath(), backup))
+}
+
+// validateDestination checks that exactly one of the provided destinations is
+// defined. File system destinations are rejected, as fdbbackup and fdbrestore
+// open the backup container from the operator Pod, which doesn't have the
+// persistent volume mounted.
+func validateDestination(blobStoreConfiguration *BlobStoreConfiguration, fileSystemConfiguration *FileSystemConfiguration) error {
+	if blobStoreConfiguration != nil && fileSystemConfiguration != nil {
+		return fmt.Errorf("only one of blobStoreConfiguration and fileSystemConfiguration can be defined")
+	}
+
+	if blobStoreConfiguration == nil && fileSystemConfiguration == nil {
+		return fmt.Errorf("either blobStoreConfiguration or fileSystemConfiguration must be defined")
+	}
+
+	if fileSystemConfiguration != nil {
+		return fmt.Errorf("fileSystemConfiguration is not supported, as the operator can't access the persistent volume")
+	}
+
+	return nil
+}
+
 // BucketName gets the bucket this backup will use.
 // This will fill in a default value if the bucket in the spec is empty.
 func (configuration *BlobStoreConfiguration) BucketName() string {
@@ -224,6 +224,113 @@ var _ = Describe("[api] FoundationDBBackup", func() {
 		})
 	})
 
+	When("getting the restorable points of a backup description", func() {
+		var description *FoundationDBBackupDescription
+
+		BeforeEach(func() {
+			description = &FoundationDBBackupDescription{
+				Snapshots: []FoundationDBBackupDescriptionSnapshot{
+					{Start: FoundationDBBackupDescriptionVersion{Version: 100}, End: FoundationDBBackupDescriptionVersion{Version: 200, Timestamp: "2023/01/01.00:00:00+0000"}, Restorable: true},
+					{Start: FoundationDBBackupDescriptionVersion{Version: 300}, End: FoundationDBBackupDescriptionVersion{Version: 400, Timestamp: "2023/01/02.00:00:00+0000"}, Restorable: true},
+					{Start: FoundationDBBackupDescriptionVersion{Version: 500}, End: FoundationDBBackupDescriptionVersion{Version: 600, Timestamp: "2023/01/03.00:00:00+0000"}, Restorable: true},
+					{Start: FoundationDBBackupDescriptionVersion{Version: 700}, End: FoundationDBBackupDescriptionVersion{Version: 800}, Restorable: false},
+				},
+			}
+		})
+
+		It("should only return the restorable snapshots", func() {
+			Expect(description.GetRestorablePoints()).To(Equal([]BackupRestorablePoint{
+				{Version: 200, Timestamp: "2023/01/01.00:00:00+0000"},
+				{Version: 400, Timestamp: "2023/01/02.00:00:00+0000"},
+				{Version: 600, Timestamp: "2023/01/03.00:00:00+0000"},
+			}))
+		})
+
+		It("should return the start version of the oldest snapshot to keep", func() {
+			Expect(description.GetExpirationVersion(1)).To(BeNumerically("==", 500))
+			Expect(description.GetExpirationVersion(2)).To(BeNumerically("==", 300))
+			Expect(description.GetExpirationVersion(3)).To(BeZero())
+			Expect(description.GetExpirationVersion(0)).To(BeZero())
+		})
+	})
+
+	When("getting the retention settings", func() {
+		It("should return the defaults without a retention policy", func() {
+			Expect(backup.GetRetentionIntervalSeconds()).To(Equal(3600))
+			Expect(backup.ShouldDeleteDataWhenStopped()).To(BeFalse())
+		})
+
+		It("should return the values of the retention policy", func() {
+			interval := 600
+			deleteWhenStopped := true
+			backup.Spec.RetentionPolicy = &BackupRetentionPolicy{
+				IntervalSeconds:   &interval,
+				DeleteWhenStopped: &deleteWhenStopped,
+			}
+			Expect(backup.GetRetentionIntervalSeconds()).To(Equal(600))
+			Expect(backup.ShouldDeleteDataWhenStopped()).To(BeTrue())
+		})
+	})
+
+	When("getting the backup tag", func() {
+		It("should return the backup tag", func() {
+			Expect(backup.BackupTag()).To(Equal("default"))
+
+			backup.Spec.BackupTag = "offsite"
+			Expect(backup.BackupTag()).To(Equal("offsite"))
+		})
+	})
+
+	When("using a file system destination", func() {
+		BeforeEach(func() {
+			backup.Spec.BlobStoreConfiguration = nil
+			backup.Spec.FileSystemConfiguration = &FileSystemConfiguration{
+				PersistentVolumeClaimName: "backup-pvc",
+			}
+		})
+
+		It("should generate a file URL below the mount path", func() {
+			Expect(backup.BackupURL()).To(Equal("file:///var/fdb/backups/" + backup.Name))
+
+			backup.Spec.FileSystemConfiguration.MountPath = "/mnt/backups/"
+			backup.Spec.FileSystemConfiguration.BackupName = "test"
+			Expect(backup.BackupURL()).To(Equal("file:///mnt/backups/test"))
+		})
+
+		It("should match the backup directories created by fdbbackup", func() {
+			Expect(backup.MatchesURL(backup.BackupURL())).To(BeTrue())
+			Expect(backup.MatchesURL(backup.BackupURL() + "/backup-2023-01-02-15-04-05.123456")).To(BeTrue())
+			Expect(backup.MatchesURL(backup.BackupURL() + "-other")).To(BeFalse())
+		})
+
+		It("should use the backup directory from the status as container URL", func() {
+			Expect(backup.ContainerURL()).To(Equal(backup.BackupURL()))
+
+			backup.Status.BackupDetails = &FoundationDBBackupStatusBackupDetails{
+				URL: backup.BackupURL() + "/backup-2023-01-02-15-04-05.123456",
+			}
+			Expect(backup.ContainerURL()).To(Equal(backup.BackupURL() + "/backup-2023-01-02-15-04-05.123456"))
+		})
+
+		It("should validate the destination", func() {
+			Expect(backup.ValidateDestination()).To(MatchError("fileSystemConfiguration is not supported, as the operator can't access the persistent volume"))
+
+			backup.Spec.BlobStoreConfiguration = &BlobStoreConfiguration{AccountName: "test@test"}
+			Expect(backup.ValidateDestination()).To(MatchError("only one of blobStoreConfiguration and fileSystemConfiguration can be defined"))
+
+			backup.Spec.BlobStoreConfiguration = nil
+			backup.Spec.FileSystemConfiguration = nil
+			Expect(backup.ValidateDestination()).To(MatchError("either blobStoreConfiguration or fileSystemConfiguration must be defined"))
+		})
+
+		It("should reject a retention policy", func() {
+			Expect(backup.ValidateRetentionPolicy()).To(Succeed())
+
+			backup.Spec.RetentionPolicy = &BackupRetentionPolicy{}
+			Expect(backup.ValidateRetentionPolicy()).To(MatchError("retentionPolicy is not supported for backups with a fileSystemConfiguration"))
+		})
+	})
+
 	When("getting the backup URL", func() {
 		DescribeTable("should generate the correct backup URL",
 			func(backup FoundationDBBackup, expected string) {
@@ -27,6 +27,8 @@ import (
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 )
@@ -146,6 +148,13 @@ type FoundationDBClusterSpec struct {
 	// DataHall defines the data hall where these processes are running.
 	DataHall string `json:"dataHall,omitempty"`
 
+	// DataHallNodeLabel defines the node label that contains the data hall of
+	// a node. If the three_data_hall redundancy mode is used, the Pods will
+	// only be scheduled on nodes where this label matches the DataHall.
+	// The default is topology.kubernetes.io/zone.
+	// +kubebuilder:validation:MaxLength=100
+	DataHallNodeLabel string `json:"dataHallNodeLabel,omitempty"`
+
 	// AutomationOptions defines customization for enabling or disabling certain
 	// operations in the operator.
 	AutomationOptions FoundationDBClusterAutomationOptions `json:"automationOptions,omitempty"`
@@ -285,13 +294,183 @@ type FoundationDBClusterStatus struct {
 	// MaintenenanceModeInfo contains information regarding process groups in maintenance mode
 	MaintenanceModeInfo MaintenanceModeInfo `json:"maintenanceModeInfo,omitempty"`
 
+	// TaintedNodeMaintenanceZones contains the zones the operator put into maintenance mode because of a tainted
+	// node. The operator will not put those zones into maintenance mode again as long as the node is tainted.
+	// +kubebuilder:validation:MaxItems=100
+	TaintedNodeMaintenanceZones []string `json:"taintedNodeMaintenanceZones,omitempty"`
+
 	// DesiredProcessGroups reflects the number of expected running process groups.
 	DesiredProcessGroups int `json:"desiredProcessGroups,omitempty"`
 
 	// ReconciledProcessGroups reflects the number of process groups that have no condition and are not marked for removal.
 	ReconciledProcessGroups int `json:"reconciledProcessGroups,omitempty"`
+
+	// HasProcessGroupResources defines if the process groups are stored in
+	// FoundationDBProcessGroup resources. The operator deletes those resources
+	// once the process groups were moved back into the cluster status.
+	HasProcessGroupResources bool `json:"hasProcessGroupResources,omitempty"`
+
+	// Upgrade provides information about the progress of a version change
+	// of the cluster.
+	Upgrade *UpgradeStatus `json:"upgrade,omitempty"`
+
+	// Rollout provides information about the progress of a staged Pod
+	// update rollout.
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+
+	// ExclusionBacklog provides information about the process groups that
+	// are waiting to be excluded because the exclusion budget is exhausted.
+	ExclusionBacklog *ExclusionBacklog `json:"exclusionBacklog,omitempty"`
+
+	// LastHotspotReplacement defines when the operator replaced the last
+	// process group because of a hotspot.
+	LastHotspotReplacement *metav1.Time `json:"lastHotspotReplacement,omitempty"`
+
+	// ResourceRecommendations provides the recommended resource requests
+	// for the main container per process class.
+	ResourceRecommendations []ResourceRecommendation `json:"resourceRecommendations,omitempty"`
+
+	// StorageEngineMigration provides information about the progress of the
+	// migration of the storage servers to the configured storage engine.
+	StorageEngineMigration *StorageEngineMigrationStatus `json:"storageEngineMigration,omitempty"`
+
+	// PrimaryDataCenterOverride defines the primary data center that was
+	// selected by a failover. The override is applied on top of the region
+	// priorities in the spec.
+	PrimaryDataCenterOverride *PrimaryDataCenterOverride `json:"primaryDataCenterOverride,omitempty"`
+}
+
+// PrimaryDataCenterOverride defines a primary data center that was selected
+// by a failover and that takes precedence over the primary data center of the
+// spec. The override is only applied as long as the spec defines the same
+// primary data center as when the failover was started, so changing the
+// primary data center in the spec replaces the override.
+type PrimaryDataCenterOverride struct {
+	// DataCenter defines the main data center that should act as primary.
+	DataCenter string `json:"dataCenter"`
+
+	// SpecPrimaryDataCenter defines the primary data center of the spec when
+	// the override was created.
+	SpecPrimaryDataCenter string `json:"specPrimaryDataCenter"`
+}
+
+// GetPrimaryDataCenter returns the data center of the override if the
+// provided primary data center of the spec matches the primary data center
+// of the spec when the override was created. Otherwise the provided primary
+// data center is returned.
+func (override *PrimaryDataCenterOverride) GetPrimaryDataCenter(specPrimaryDataCenter string) string {
+	if override == nil || override.SpecPrimaryDataCenter != specPrimaryDataCenter {
+		return specPrimaryDataCenter
+	}
+
+	return override.DataCenter
+}
+
+// StorageEngineMigrationStatus provides information about the progress of a
+// storage engine migration, based on the storage engines that are reported
+// by the storage servers in the machine-readable status.
+type StorageEngineMigrationStatus struct {
+	// StorageEngine defines the storage engine the storage servers are
+	// migrated to.
+	StorageEngine StorageEngine `json:"storageEngine,omitempty"`
+
+	// MigratedStorageServers defines how many storage servers use the new
+	// storage engine.
+	MigratedStorageServers int `json:"migratedStorageServers,omitempty"`
+
+	// TotalStorageServers defines how many storage servers reported their
+	// storage engine.
+	TotalStorageServers int `json:"totalStorageServers,omitempty"`
+
+	// MigratedPercentage defines the percentage of storage servers that use
+	// the new storage engine.
+	MigratedPercentage int `json:"migratedPercentage,omitempty"`
+}
+
+// ResourceRecommendation provides the recommended resource requests for the
+// main container of a process class and the usage that was observed in the
+// current window.
+type ResourceRecommendation struct {
+	// ProcessClass defines the process class of the recommendation.
+	ProcessClass ProcessClass `json:"processClass"`
+
+	// CPU defines the recommended CPU request.
+	CPU *resource.Quantity `json:"cpu,omitempty"`
+
+	// Memory defines the recommended memory request.
+	Memory *resource.Quantity `json:"memory,omitempty"`
+
+	// ObservedSince defines since when the usage of the process class is
+	// observed. A recommendation is only calculated once the usage was
+	// observed for the whole window.
+	ObservedSince metav1.Time `json:"observedSince,omitempty"`
+
+	// Samples contains the highest usage of a Pod for every part of the
+	// observation window. The window is divided into 24 parts and samples
+	// that are older than the window are removed.
+	// +kubebuilder:validation:MaxItems=25
+	Samples []ResourceUsageSample `json:"samples,omitempty"`
+}
+
+// ResourceUsageSample provides the highest usage of a Pod of a process class
+// that was observed in a part of the observation window.
+type ResourceUsageSample struct {
+	// Timestamp defines the start of the part of the observation window.
+	Timestamp metav1.Time `json:"timestamp"`
+
+	// CPU defines the highest CPU usage of a Pod.
+	CPU resource.Quantity `json:"cpu,omitempty"`
+
+	// Memory defines the highest memory usage of a Pod.
+	Memory resource.Quantity `json:"memory,omitempty"`
+}
+
+// ExclusionBacklog contains the process groups that should be excluded but
+// are delayed by the exclusion budget.
+type ExclusionBacklog struct {
+	// ProcessGroups defines the process groups that are waiting to be
+	// excluded.
+	ProcessGroups []ProcessGroupID `json:"processGroups,omitempty"`
+
+	// Reason describes why the exclusions are delayed.
+	Reason string `json:"reason,omitempty"`
 }
 
+// RolloutStatus contains information about the progress of a staged Pod
+// update rollout.
+type RolloutStatus struct {
+	// Generation defines the generation of the cluster spec that is rolled
+	// out.
+	Generation int64 `json:"generation,omitempty"`
+
+	// Stage defines the stage of the rollout that is currently updated. This
+	// is either the canary stage or a process class.
+	Stage string `json:"stage,omitempty"`
+
+	// StageCompletedTimestamp defines when all Pods of the current stage were
+	// updated. The next stage will be started once the soak time has passed
+	// since this timestamp.
+	StageCompletedTimestamp *metav1.Time `json:"stageCompletedTimestamp,omitempty"`
+
+	// Paused defines whether the rollout was paused because the cluster was
+	// not healthy after a stage was updated. A paused rollout is only resumed
+	// after the cluster spec was changed and the cluster is healthy again.
+	Paused bool `json:"paused,omitempty"`
+
+	// PausedReason describes why the rollout was paused.
+	PausedReason string `json:"pausedReason,omitempty"`
+}
+
+const (
+	// RolloutStageCanary is the name of the rollout stage that updates the
+	// canary Pods.
+	RolloutStageCanary = "canary"
+
+	// RolloutStageRemaining is the name of the rollout stage that updates the
+	// Pods of all process classes that are not listed in the rollout policy.
+	RolloutStageRemaining = "remaining"
+)
+
 // MaintenanceModeInfo contains information regarding the zone and process groups that are put
 // into maintenance mode by the operator
 type MaintenanceModeInfo struct {
@@ -305,6 +484,96 @@ type MaintenanceModeInfo struct {
 	ProcessGroups []string `json:"processGroups,omitempty"`
 }
 
+// UpgradeStatus provides information about the progress of a version change of the cluster.
+type UpgradeStatus struct {
+	// TargetVersion defines the version the cluster is changed to.
+	TargetVersion string `json:"targetVersion,omitempty"`
+
+	// Phase defines the current phase of the version change.
+	Phase UpgradePhase `json:"phase,omitempty"`
+
+	// IncompatibleClients contains the clients that don't support the target
+	// version, grouped by their log group. Only the first 20 log groups are
+	// listed.
+	// +kubebuilder:validation:MaxItems=20
+	IncompatibleClients []IncompatibleClientGroup `json:"incompatibleClients,omitempty"`
+
+	// PendingRestarts contains the process groups that are ready to be
+	// restarted with the target version. This information is only available
+	// if locks are used.
+	PendingRestarts []ProcessGroupID `json:"pendingRestarts,omitempty"`
+
+	// BlockedReason describes why the version change cannot make progress.
+	BlockedReason string `json:"blockedReason,omitempty"`
+
+	// PreviousVersion defines the version the processes were running before
+	// they were restarted with the target version.
+	PreviousVersion string `json:"previousVersion,omitempty"`
+
+	// RestartTimestamp defines when the processes were restarted with the
+	// target version.
+	RestartTimestamp *metav1.Time `json:"restartTimestamp,omitempty"`
+
+	// Conditions represents the steps of the version change that have been
+	// done.
+	// +kubebuilder:validation:MaxItems=10
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// UpgradePhase represents the phase of a version change of the cluster.
+// +kubebuilder:validation:MaxLength=50
+// +kubebuilder:validation:Enum=Precheck;StagingBinaries;Restarting;Done
+type UpgradePhase string
+
+const (
+	// UpgradePhasePrecheck is the phase in which the operator checks that all
+	// clients support the target version.
+	UpgradePhasePrecheck UpgradePhase = "Precheck"
+
+	// UpgradePhaseStagingBinaries is the phase in which the binaries and the
+	// configuration for the target version are delivered to the Pods.
+	UpgradePhaseStagingBinaries UpgradePhase = "StagingBinaries"
+
+	// UpgradePhaseRestarting is the phase in which the processes are restarted
+	// with the target version.
+	UpgradePhaseRestarting UpgradePhase = "Restarting"
+
+	// UpgradePhaseDone is the phase once all processes run the target version.
+	UpgradePhaseDone UpgradePhase = "Done"
+)
+
+const (
+	// UpgradeConditionProcessesRestarted represents that the processes were
+	// restarted with the target version.
+	UpgradeConditionProcessesRestarted = "ProcessesRestarted"
+
+	// UpgradeConditionCommitted represents that the processes report the
+	// target version and that the database recovered far enough to accept
+	// commits, which means that transactions were committed at the target
+	// version.
+	UpgradeConditionCommitted = "Committed"
+
+	// UpgradeConditionRolledBack represents that the operator rolled back a
+	// failed upgrade to the previous version.
+	UpgradeConditionRolledBack = "RolledBack"
+)
+
+// IncompatibleClientGroup contains the clients of a single log group that
+// don't support a version.
+type IncompatibleClientGroup struct {
+	// LogGroup defines the trace log group the clients have set.
+	LogGroup string `json:"logGroup,omitempty"`
+
+	// Addresses contains the addresses the clients are connecting from. Only
+	// the first 10 addresses are listed.
+	// +kubebuilder:validation:MaxItems=10
+	Addresses []string `json:"addresses,omitempty"`
+
+	// Count defines the number of clients in the log group that don't
+	// support the target version.
+	Count int `json:"count,omitempty"`
+}
+
 // LockSystemStatus provides a summary of the status of the locking system.
 type LockSystemStatus struct {
 	// DenyList contains a list of operator instances that are prevented
@@ -699,6 +968,19 @@ const (
 	SidecarUnreachable ProcessGroupConditionType = "SidecarUnreachable"
 	// PodPending represents a process group where the pod is in a pending state.
 	PodPending ProcessGroupConditionType = "PodPending"
+	// NodeTaintDetected represents a process group whose Pod is running on a node
+	// with a taint that is defined in the automatic replacement options.
+	NodeTaintDetected ProcessGroupConditionType = "NodeTaintDetected"
+	// NodeTaintReplacing represents a process group whose Pod is running on a node
+	// that was tainted for longer than the defined duration and must be replaced.
+	NodeTaintReplacing ProcessGroupConditionType = "NodeTaintReplacing"
+	// StorageHotspot represents a process group with a storage process that
+	// stores significantly more data than the other storage processes or has
+	// a large queue.
+	StorageHotspot ProcessGroupConditionType = "StorageHotspot"
+	// IncorrectStorageEngine represents a process group with a storage
+	// process that uses a different storage engine than the configured one.
+	IncorrectStorageEngine ProcessGroupConditionType = "IncorrectStorageEngine"
 	// ReadyCondition is currently only used in the metrics.
 	ReadyCondition ProcessGroupConditionType = "Ready"
 )
@@ -716,6 +998,10 @@ func AllProcessGroupConditionTypes() []ProcessGroupConditionType {
 		MissingProcesses,
 		SidecarUnreachable,
 		PodPending,
+		NodeTaintDetected,
+		NodeTaintReplacing,
+		StorageHotspot,
+		IncorrectStorageEngine,
 		ReadyCondition,
 	}
 }
@@ -743,6 +1029,14 @@ func GetProcessGroupConditionType(processGroupConditionType string) (ProcessGrou
 		return SidecarUnreachable, nil
 	case "PodPending":
 		return PodPending, nil
+	case "NodeTaintDetected":
+		return NodeTaintDetected, nil
+	case "NodeTaintReplacing":
+		return NodeTaintReplacing, nil
+	case "StorageHotspot":
+		return StorageHotspot, nil
+	case "IncorrectStorageEngine":
+		return IncorrectStorageEngine, nil
 	}
 
 	return "", fmt.Errorf("unknown process group condition type: %s", processGroupConditionType)
@@ -915,16 +1209,277 @@ type FoundationDBClusterAutomationOptions struct {
 	// +kubebuilder:default:=ReplaceTransactionSystem
 	PodUpdateStrategy PodUpdateStrategy `json:"podUpdateStrategy,omitempty"`
 
+	// UpdateImagesInPlace defines if the operator should update the images of the containers in place, if the images
+	// and the version are the only changes to the Pod spec. Otherwise the Pods will be recreated or replaced based on
+	// the PodUpdateStrategy. The default for this is false.
+	UpdateImagesInPlace *bool `json:"updateImagesInPlace,omitempty"`
+
 	// UseManagementAPI defines if the operator should make use of the management API instead of
-	// using fdbcli to interact with the FoundationDB cluster.
+	// using fdbcli to interact with the FoundationDB cluster. The management API will be used for exclusions,
+	// includes, coordinator changes and maintenance zones if the running version supports it and the operator
+	// selected at least the API version 710.
 	UseManagementAPI *bool `json:"useManagementAPI,omitempty"`
 
+	// UseProcessGroupResources defines if the operator should track the process groups in FoundationDBProcessGroup
+	// resources instead of the processGroups list in the cluster status. Existing entries of the cluster status
+	// will be migrated into FoundationDBProcessGroup resources during the next status update.
+	// The default is false.
+	UseProcessGroupResources *bool `json:"useProcessGroupResources,omitempty"`
+
 	// MaintenanceModeOptions contains options for maintenance mode related settings.
 	MaintenanceModeOptions MaintenanceModeOptions `json:"maintenanceModeOptions,omitempty"`
 
 	// IgnoreLogGroupsForUpgrade defines the list of LogGroups that should be ignored during fdb version upgrade.
 	// +kubebuilder:validation:MaxItems=10
 	IgnoreLogGroupsForUpgrade []string `json:"ignoreLogGroupsForUpgrade,omitempty"`
+
+	// UpgradeRollback contains options for automatically rolling back a version incompatible upgrade if the
+	// database doesn't become available after the processes were restarted with the new version.
+	UpgradeRollback UpgradeRollbackOptions `json:"upgradeRollback,omitempty"`
+
+	// RolloutPolicy defines the order in which Pod updates are rolled out to
+	// the process classes.
+	RolloutPolicy RolloutPolicy `json:"rolloutPolicy,omitempty"`
+
+	// ExclusionBudget defines limits for the exclusion of storage processes
+	// to reduce the impact of data movement on the cluster.
+	ExclusionBudget ExclusionBudget `json:"exclusionBudget,omitempty"`
+
+	// HotspotRemediation defines how the operator detects and remediates
+	// overloaded storage processes.
+	HotspotRemediation HotspotRemediationOptions `json:"hotspotRemediation,omitempty"`
+
+	// ResourceRecommendations defines if the operator should recommend
+	// resource requests based on the utilization of the processes.
+	ResourceRecommendations ResourceRecommendationOptions `json:"resourceRecommendations,omitempty"`
+
+	// StorageMigration defines how the storage servers are migrated to a new
+	// storage engine.
+	StorageMigration StorageMigrationOptions `json:"storageMigration,omitempty"`
+}
+
+// StorageMigrationOptions controls how the storage servers are migrated
+// after the storage engine in the database configuration was changed.
+type StorageMigrationOptions struct {
+	// Mode defines how the storage servers are migrated to the new storage
+	// engine.
+	// The default is Background.
+	Mode StorageMigrationMode `json:"mode,omitempty"`
+
+	// MaxConcurrentReplacements defines how many storage process groups can
+	// be replaced at the same time in the Wiggle mode. All storage process
+	// groups that are marked for removal and not yet excluded are counted.
+	// The default is 1.
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentReplacements *int `json:"maxConcurrentReplacements,omitempty"`
+
+	// MaxMovingDataBytes defines the maximum number of bytes that are moved
+	// by data distribution, the sum of moving_data.in_flight_bytes and
+	// moving_data.in_queue_bytes in the machine-readable status, to replace
+	// the next storage process groups in the Wiggle mode.
+	// The default is 0, which means that the operator waits until all data
+	// was moved.
+	// +kubebuilder:validation:Minimum=0
+	MaxMovingDataBytes *int `json:"maxMovingDataBytes,omitempty"`
+}
+
+// StorageMigrationMode defines how the storage servers are migrated to a new
+// storage engine.
+// +kubebuilder:validation:MaxLength=32
+// +kubebuilder:validation:Enum=Background;Wiggle
+type StorageMigrationMode string
+
+const (
+	// StorageMigrationModeBackground changes the storage engine in the
+	// database configuration and lets FDB migrate the storage servers.
+	StorageMigrationModeBackground StorageMigrationMode = "Background"
+
+	// StorageMigrationModeWiggle changes the storage engine in the database
+	// configuration and replaces the storage process groups with the old
+	// storage engine a few at a time. This requires a version that supports
+	// the storage_migration_type configuration.
+	StorageMigrationModeWiggle StorageMigrationMode = "Wiggle"
+)
+
+// ResourceRecommendationOptions controls the recommendation of CPU and memory
+// requests for the main container. The operator reads the CPU and memory
+// usage of the processes from the machine-readable status and tracks the
+// highest usage of a Pod per process class over the observation window.
+type ResourceRecommendationOptions struct {
+	// Enabled defines if the operator should publish resource
+	// recommendations in the cluster status.
+	// The default is false.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// WindowSeconds defines over which duration the usage is observed. The
+	// recommendation is calculated from the highest usage in the window.
+	// The default is 86400 seconds, or 24 hours.
+	// +kubebuilder:validation:Minimum=60
+	WindowSeconds *int `json:"windowSeconds,omitempty"`
+
+	// SampleIntervalSeconds defines how often the operator reads the usage
+	// of the processes from the machine-readable status.
+	// The default is 60 seconds.
+	// +kubebuilder:validation:Minimum=10
+	SampleIntervalSeconds *int `json:"sampleIntervalSeconds,omitempty"`
+
+	// HeadroomPercentage defines how many percent are added to the highest
+	// observed usage for the recommendation.
+	// The default is 20.
+	// +kubebuilder:validation:Minimum=0
+	HeadroomPercentage *int `json:"headroomPercentage,omitempty"`
+
+	// MinimumChangePercentage defines by how many percent a new
+	// recommendation must differ from the current recommendation, or the
+	// configured requests if no recommendation exists, to be published. This
+	// prevents replacements for small changes.
+	// The default is 10.
+	// +kubebuilder:validation:Minimum=0
+	MinimumChangePercentage *int `json:"minimumChangePercentage,omitempty"`
+
+	// Apply defines if the recommendations should be applied to the
+	// requests of the main container. The operator sets the recommended
+	// requests in the process settings of the cluster spec, so changed
+	// requests will be rolled out like any other change to the Pod spec.
+	// The default is false.
+	Apply *bool `json:"apply,omitempty"`
+}
+
+// HotspotRemediationOptions controls the detection of storage processes that
+// store significantly more data than the other storage processes or that have
+// a large queue of data that is not yet durable. Those storage processes are
+// marked with the StorageHotspot condition and can be replaced to force data
+// distribution to move the data to other storage processes.
+type HotspotRemediationOptions struct {
+	// Enabled defines if the operator should detect overloaded storage
+	// processes.
+	// The default is false.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// StoredBytesThresholdPercentage defines how many percent a storage
+	// process must store above the average of all storage processes to be
+	// considered overloaded.
+	// The default is 50.
+	// +kubebuilder:validation:Minimum=1
+	StoredBytesThresholdPercentage *int `json:"storedBytesThresholdPercentage,omitempty"`
+
+	// MaxQueueBytes defines how many bytes can be received by a storage
+	// process but not yet be durable before the storage process is considered
+	// overloaded.
+	// The default is 1073741824 (1 GiB).
+	// +kubebuilder:validation:Minimum=0
+	MaxQueueBytes *int `json:"maxQueueBytes,omitempty"`
+
+	// OverloadedSeconds defines how long a storage process must be overloaded
+	// before the operator remediates it.
+	// The default is 1800 seconds, or 30 minutes.
+	// +kubebuilder:validation:Minimum=0
+	OverloadedSeconds *int `json:"overloadedSeconds,omitempty"`
+
+	// Action defines how the operator remediates overloaded storage
+	// processes.
+	// The default is None.
+	Action HotspotAction `json:"action,omitempty"`
+
+	// MaxConcurrentReplacements defines how many storage process groups can be
+	// replaced at the same time because of a hotspot. All storage process
+	// groups that are marked for removal and not yet excluded are counted.
+	// The default is 1.
+	// +kubebuilder:validation:Minimum=0
+	MaxConcurrentReplacements *int `json:"maxConcurrentReplacements,omitempty"`
+
+	// MinimumSecondsBetweenReplacements defines how long the operator waits
+	// after a hotspot was remediated before the next hotspot is remediated.
+	// The default is 3600 seconds, or 1 hour.
+	// +kubebuilder:validation:Minimum=0
+	MinimumSecondsBetweenReplacements *int `json:"minimumSecondsBetweenReplacements,omitempty"`
+}
+
+// HotspotAction defines how the operator remediates an overloaded storage
+// process.
+// +kubebuilder:validation:MaxLength=32
+// +kubebuilder:validation:Enum=None;Replace
+type HotspotAction string
+
+const (
+	// HotspotActionNone only reports overloaded storage processes with the
+	// StorageHotspot condition.
+	HotspotActionNone HotspotAction = "None"
+
+	// HotspotActionReplace replaces the process groups of overloaded storage
+	// processes. The storage processes are excluded before they are removed,
+	// so their data is moved to other storage processes.
+	HotspotActionReplace HotspotAction = "Replace"
+)
+
+// ExclusionBudget limits how many storage processes are excluded at the same
+// time. Process groups that are marked for removal but exceed the budget will
+// be excluded once the budget allows it.
+type ExclusionBudget struct {
+	// MaxExcludedStorageProcesses defines how many storage processes can be
+	// excluded but not yet removed at the same time.
+	// The default is unlimited.
+	// +kubebuilder:validation:Minimum=1
+	MaxExcludedStorageProcesses *int `json:"maxExcludedStorageProcesses,omitempty"`
+
+	// MaxInFlightBytes defines the maximum number of bytes that are actively
+	// moved by data distribution, reported as moving_data.in_flight_bytes in
+	// the machine-readable status, to start new exclusions of storage
+	// processes.
+	// The default is unlimited.
+	// +kubebuilder:validation:Minimum=0
+	MaxInFlightBytes *int `json:"maxInFlightBytes,omitempty"`
+
+	// MaxInQueueBytes defines the maximum number of bytes that are pending
+	// for data movement, reported as moving_data.in_queue_bytes in the
+	// machine-readable status, to start new exclusions of storage processes.
+	// The default is unlimited.
+	// +kubebuilder:validation:Minimum=0
+	MaxInQueueBytes *int `json:"maxInQueueBytes,omitempty"`
+}
+
+// RolloutPolicy controls the staged rollout of Pod updates. The Pods are
+// updated stage by stage, starting with the canary Pods, followed by the
+// process classes in the defined order and the Pods of all other process
+// classes. The next stage is only started if the cluster is healthy after the
+// soak time.
+type RolloutPolicy struct {
+	// ProcessClasses defines the order in which the Pods of the process
+	// classes are updated. Process classes that are not listed are updated in
+	// the last stage. If no process classes and no canaries are defined, the
+	// Pods of all process classes are updated together.
+	// +kubebuilder:validation:MaxItems=10
+	ProcessClasses []ProcessClass `json:"processClasses,omitempty"`
+
+	// CanaryCount defines how many Pods of the first process class in
+	// ProcessClasses are updated in the canary stage, before the remaining
+	// Pods of that process class are updated.
+	// The default is 0.
+	// +kubebuilder:validation:Minimum=0
+	CanaryCount *int `json:"canaryCount,omitempty"`
+
+	// SoakSeconds defines how long the operator waits after all Pods of a
+	// stage were updated before it checks the health of the cluster and
+	// starts the next stage.
+	// The default is 600.
+	// +kubebuilder:validation:Minimum=0
+	SoakSeconds *int `json:"soakSeconds,omitempty"`
+}
+
+// UpgradeRollbackOptions controls the automatic rollback of version incompatible upgrades.
+type UpgradeRollbackOptions struct {
+	// Enabled defines whether the operator is allowed to roll back a version incompatible upgrade by reverting the
+	// version in the spec to the version the cluster was running before the upgrade. An upgrade is only rolled back
+	// if the database was not available at the new version, which means no transaction was committed at the new
+	// version.
+	// The default is false.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// UnavailableSecondsBeforeRollback defines how long the database must be unavailable after the processes were
+	// restarted with the new version before the operator rolls back the upgrade.
+	// The default is 300.
+	// +kubebuilder:validation:Minimum=1
+	UnavailableSecondsBeforeRollback *int `json:"unavailableSecondsBeforeRollback,omitempty"`
 }
 
 // MaintenanceModeOptions controls options for placing zones in maintenance mode.
@@ -959,8 +1514,54 @@ type AutomaticReplacementOptions struct {
 	// +kubebuilder:default:=1
 	// +kubebuilder:validation:Minimum=0
 	MaxConcurrentReplacements *int `json:"maxConcurrentReplacements,omitempty"`
+
+	// Taints defines how the operator reacts to Pods running on nodes with
+	// a matching taint. A cordoned node is treated like a node with the
+	// node.kubernetes.io/unschedulable taint. The operator only detects the
+	// taints if it runs with the --enable-node-watch flag, which requires read
+	// access to the nodes.
+	// The default is an empty list, which means taints are ignored.
+	// +kubebuilder:validation:MaxItems=32
+	Taints []TaintReplacementOption `json:"taints,omitempty"`
+}
+
+// TaintReplacementOption defines how the operator reacts to a taint on the
+// node a Pod is running on.
+type TaintReplacementOption struct {
+	// Key defines the key of the taint. The wildcard "*" matches all taints
+	// that have no exact match.
+	// +kubebuilder:validation:MaxLength=317
+	Key string `json:"key"`
+
+	// DurationInSeconds defines how long the taint must be present before
+	// the process groups running on the node are replaced. For the
+	// MaintenanceMode action this defines how long the zone of the node
+	// will be in maintenance mode.
+	// +kubebuilder:validation:Minimum=0
+	DurationInSeconds int64 `json:"durationInSeconds"`
+
+	// Action defines how the operator reacts to the taint.
+	// The default is Replace.
+	Action TaintAction `json:"action,omitempty"`
 }
 
+// TaintAction defines how the operator reacts to a taint on a node.
+// +kubebuilder:validation:MaxLength=32
+// +kubebuilder:validation:Enum=Replace;MaintenanceMode
+type TaintAction string
+
+const (
+	// TaintActionReplace replaces the process groups running on the tainted
+	// node once the taint was present for the defined duration.
+	TaintActionReplace TaintAction = "Replace"
+
+	// TaintActionMaintenanceMode puts the zone of the tainted node into
+	// maintenance mode for the defined duration, so that short drains don't
+	// cause data movement. If the taint is still present afterwards the
+	// process groups running on the node are replaced.
+	TaintActionMaintenanceMode TaintAction = "MaintenanceMode"
+)
+
 // ProcessSettings defines process-level settings.
 type ProcessSettings struct {
 	// PodTemplate allows customizing the pod. If a container image with a tag is specified the operator
@@ -1173,7 +1774,8 @@ func (cluster *FoundationDBCluster) MinimumFaultDomains() int {
 // DesiredCoordinatorCount returns the number of coordinators to recruit for
 // a cluster.
 func (cluster *FoundationDBCluster) DesiredCoordinatorCount() int {
-	if cluster.Spec.DatabaseConfiguration.UsableRegions > 1 {
+	// The three_data_hall redundancy mode must be able to survive the loss of a data hall and an additional zone.
+	if cluster.Spec.DatabaseConfiguration.UsableRegions > 1 || cluster.Spec.DatabaseConfiguration.RedundancyMode == RedundancyModeThreeDataHall {
 		return 9
 	}
 
@@ -1233,16 +1835,32 @@ func (cluster *FoundationDBCluster) CheckReconciliation(log logr.Logger) (bool,
 			continue
 		}
 
-		if len(processGroup.ProcessGroupConditions) > 0 {
-			conditions := make([]ProcessGroupConditionType, 0, len(processGroup.ProcessGroupConditions))
-			for _, condition := range processGroup.ProcessGroupConditions {
-				if condition.ProcessGroupConditionType == IncorrectCommandLine && cluster.Status.Generations.NeedsBounce == 0 {
-					logger.Info("Pending restart of fdbserver processes", "state", "NeedsBounce")
-					cluster.Status.Generations.NeedsBounce = cluster.ObjectMeta.Generation
-				}
-				conditions = append(conditions, condition.ProcessGroupConditionType)
+		conditions := make([]ProcessGroupConditionType, 0, len(processGroup.ProcessGroupConditions))
+		for _, condition := range processGroup.ProcessGroupConditions {
+			// A tainted node only requires an action once the taint was present for the defined duration.
+			if condition.ProcessGroupConditionType == NodeTaintDetected {
+				continue
 			}
 
+			// An incorrect storage engine only requires an action if the operator manages the storage migration.
+			if condition.ProcessGroupConditionType == IncorrectStorageEngine && cluster.GetStorageMigrationMode() != StorageMigrationModeWiggle {
+				continue
+			}
+
+			// A storage hotspot only requires an action if the operator replaces hotspots and the process was
+			// overloaded for the defined duration.
+			if condition.ProcessGroupConditionType == StorageHotspot && (cluster.GetHotspotAction() != HotspotActionReplace || time.Since(time.Unix(condition.Timestamp, 0)) < cluster.GetHotspotOverloadedDuration()) {
+				continue
+			}
+
+			if condition.ProcessGroupConditionType == IncorrectCommandLine && cluster.Status.Generations.NeedsBounce == 0 {
+				logger.Info("Pending restart of fdbserver processes", "state", "NeedsBounce")
+				cluster.Status.Generations.NeedsBounce = cluster.ObjectMeta.Generation
+			}
+			conditions = append(conditions, condition.ProcessGroupConditionType)
+		}
+
+		if len(conditions) > 0 {
 			logger.Info("Has unhealthy process group", "processGroupID", processGroup.ProcessGroupID, "state", "HasUnhealthyProcess", "conditions", conditions)
 			cluster.Status.Generations.HasUnhealthyProcess = cluster.ObjectMeta.Generation
 			reconciled = false
@@ -1516,6 +2134,13 @@ type ContainerOverrides struct {
 	// a container.
 	// +kubebuilder:validation:MaxItems=100
 	ImageConfigs []ImageConfig `json:"imageConfigs,omitempty"`
+
+	// APITokenSecretName defines the name of a Secret in the namespace of the
+	// cluster that contains the token to authenticate requests against the
+	// versioned sidecar API. The token must be stored in the key "token".
+	// This setting will be ignored on the main container.
+	// +kubebuilder:validation:MaxLength=253
+	APITokenSecretName *string `json:"apiTokenSecretName,omitempty"`
 }
 
 // DesiredDatabaseConfiguration builds the database configuration for the
@@ -1540,6 +2165,18 @@ func (cluster *FoundationDBCluster) DesiredDatabaseConfiguration() DatabaseConfi
 		configuration.StorageEngine = StorageEngineMemory2
 	}
 
+	// A failover selects the primary data center through the status, so the spec of the user is kept unchanged.
+	specPrimaryDataCenter := configuration.GetPrimaryDataCenter()
+	primaryDataCenter := cluster.Status.PrimaryDataCenterOverride.GetPrimaryDataCenter(specPrimaryDataCenter)
+	if primaryDataCenter != specPrimaryDataCenter {
+		configuration = configuration.FailOverTo(primaryDataCenter)
+	}
+
+	// With the Wiggle mode the operator migrates the storage servers, so FDB must not migrate them on its own.
+	if cluster.GetStorageMigrationMode() == StorageMigrationModeWiggle && version.SupportsStorageMigrationType() {
+		configuration.StorageMigrationType = StorageMigrationTypeDisabled
+	}
+
 	return configuration
 }
 
@@ -1547,7 +2184,8 @@ func (cluster *FoundationDBCluster) DesiredDatabaseConfiguration() DatabaseConfi
 // set in the configuration in the cluster spec.
 //
 // This allows us to compare the spec to the live configuration while ignoring
-// version flags that are unset in the spec.
+// version flags that are unset in the spec. The storage migration type is
+// cleared as well, unless it is defined in the spec or by the Wiggle mode.
 func (cluster *FoundationDBCluster) ClearMissingVersionFlags(configuration *DatabaseConfiguration) {
 	if cluster.Spec.DatabaseConfiguration.LogVersion == 0 {
 		configuration.LogVersion = 0
@@ -1555,6 +2193,9 @@ func (cluster *FoundationDBCluster) ClearMissingVersionFlags(configuration *Data
 	if cluster.Spec.DatabaseConfiguration.LogSpill == 0 {
 		configuration.LogSpill = 0
 	}
+	if cluster.Spec.DatabaseConfiguration.StorageMigrationType == "" && cluster.GetStorageMigrationMode() != StorageMigrationModeWiggle {
+		configuration.StorageMigrationType = ""
+	}
 }
 
 // IsBeingUpgraded determines whether the cluster has a pending upgrade.
@@ -1622,7 +2263,7 @@ func (cluster *FoundationDBCluster) ShouldUseLocks() bool {
 		return !*disabled
 	}
 
-	return cluster.Spec.FaultDomain.ZoneCount > 1 || len(cluster.Spec.DatabaseConfiguration.Regions) > 1
+	return cluster.Spec.FaultDomain.ZoneCount > 1 || len(cluster.Spec.DatabaseConfiguration.Regions) > 1 || cluster.Spec.DatabaseConfiguration.RedundancyMode == RedundancyModeThreeDataHall
 }
 
 // GetLockPrefix gets the prefix for the keys where we store locking
@@ -1915,11 +2556,174 @@ func (cluster *FoundationDBCluster) GetIgnorePendingPodsDuration() time.Duration
 	return cluster.Spec.AutomationOptions.IgnorePendingPodsDuration
 }
 
+// GetDataHallNodeLabel returns the value of DataHallNodeLabel or topology.kubernetes.io/zone if unset.
+func (cluster *FoundationDBCluster) GetDataHallNodeLabel() string {
+	if cluster.Spec.DataHallNodeLabel == "" {
+		return corev1.LabelTopologyZone
+	}
+
+	return cluster.Spec.DataHallNodeLabel
+}
+
 // GetIgnoreMissingProcessesSeconds returns the value of IgnoreMissingProcessesSecond or 30 seconds if unset.
 func (cluster *FoundationDBCluster) GetIgnoreMissingProcessesSeconds() time.Duration {
 	return time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.IgnoreMissingProcessesSeconds, 30)) * time.Second
 }
 
+// GetEnableUpgradeRollback returns the value of UpgradeRollback.Enabled or false if unset.
+func (cluster *FoundationDBCluster) GetEnableUpgradeRollback() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.UpgradeRollback.Enabled, false)
+}
+
+// GetUnavailableDurationBeforeRollback returns the value of UpgradeRollback.UnavailableSecondsBeforeRollback or 5 minutes if unset.
+func (cluster *FoundationDBCluster) GetUnavailableDurationBeforeRollback() time.Duration {
+	return time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.UpgradeRollback.UnavailableSecondsBeforeRollback, 300)) * time.Second
+}
+
+// IsUpgradeRollback returns true if the cluster is being changed back to the version it was running before a failed
+// upgrade.
+func (cluster *FoundationDBCluster) IsUpgradeRollback() bool {
+	upgradeStatus := cluster.Status.Upgrade
+	if upgradeStatus == nil || upgradeStatus.TargetVersion != cluster.Spec.Version {
+		return false
+	}
+
+	return meta.IsStatusConditionTrue(upgradeStatus.Conditions, UpgradeConditionRolledBack)
+}
+
+// GetRolloutStages returns the names of the stages of a staged Pod update rollout. If no rollout policy is defined
+// this will return nil.
+func (cluster *FoundationDBCluster) GetRolloutStages() []string {
+	policy := cluster.Spec.AutomationOptions.RolloutPolicy
+	if len(policy.ProcessClasses) == 0 {
+		return nil
+	}
+
+	stages := make([]string, 0, len(policy.ProcessClasses)+2)
+	if cluster.GetRolloutCanaryCount() > 0 {
+		stages = append(stages, RolloutStageCanary)
+	}
+
+	for _, processClass := range policy.ProcessClasses {
+		stages = append(stages, string(processClass))
+	}
+
+	return append(stages, RolloutStageRemaining)
+}
+
+// GetRolloutCanaryCount returns the value of RolloutPolicy.CanaryCount or 0 if unset.
+func (cluster *FoundationDBCluster) GetRolloutCanaryCount() int {
+	return pointer.IntDeref(cluster.Spec.AutomationOptions.RolloutPolicy.CanaryCount, 0)
+}
+
+// GetRolloutSoakDuration returns the value of RolloutPolicy.SoakSeconds or 10 minutes if unset.
+func (cluster *FoundationDBCluster) GetRolloutSoakDuration() time.Duration {
+	return time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.RolloutPolicy.SoakSeconds, 600)) * time.Second
+}
+
+// GetEnableHotspotRemediation returns the value of HotspotRemediation.Enabled or false if unset.
+func (cluster *FoundationDBCluster) GetEnableHotspotRemediation() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.HotspotRemediation.Enabled, false)
+}
+
+// GetHotspotStoredBytesThresholdPercentage returns the value of HotspotRemediation.StoredBytesThresholdPercentage or 50 if unset.
+func (cluster *FoundationDBCluster) GetHotspotStoredBytesThresholdPercentage() int {
+	return pointer.IntDeref(cluster.Spec.AutomationOptions.HotspotRemediation.StoredBytesThresholdPercentage, 50)
+}
+
+// GetHotspotMaxQueueBytes returns the value of HotspotRemediation.MaxQueueBytes or 1 GiB if unset.
+func (cluster *FoundationDBCluster) GetHotspotMaxQueueBytes() int {
+	return pointer.IntDeref(cluster.Spec.AutomationOptions.HotspotRemediation.MaxQueueBytes, 1073741824)
+}
+
+// GetHotspotOverloadedDuration returns the value of HotspotRemediation.OverloadedSeconds or 30 minutes if unset.
+func (cluster *FoundationDBCluster) GetHotspotOverloadedDuration() time.Duration {
+	return time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.HotspotRemediation.OverloadedSeconds, 1800)) * time.Second
+}
+
+// GetHotspotAction returns the value of HotspotRemediation.Action or None if unset.
+func (cluster *FoundationDBCluster) GetHotspotAction() HotspotAction {
+	if cluster.Spec.AutomationOptions.HotspotRemediation.Action == "" {
+		return HotspotActionNone
+	}
+
+	return cluster.Spec.AutomationOptions.HotspotRemediation.Action
+}
+
+// GetHotspotMaxConcurrentReplacements returns the value of HotspotRemediation.MaxConcurrentReplacements or 1 if unset.
+func (cluster *FoundationDBCluster) GetHotspotMaxConcurrentReplacements() int {
+	return pointer.IntDeref(cluster.Spec.AutomationOptions.HotspotRemediation.MaxConcurrentReplacements, 1)
+}
+
+// GetHotspotMinimumDurationBetweenReplacements returns the value of HotspotRemediation.MinimumSecondsBetweenReplacements
+// or 1 hour if unset.
+func (cluster *FoundationDBCluster) GetHotspotMinimumDurationBetweenReplacements() time.Duration {
+	return time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.HotspotRemediation.MinimumSecondsBetweenReplacements, 3600)) * time.Second
+}
+
+// GetEnableResourceRecommendations returns the value of ResourceRecommendations.Enabled or false if unset.
+func (cluster *FoundationDBCluster) GetEnableResourceRecommendations() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.ResourceRecommendations.Enabled, false)
+}
+
+// GetResourceRecommendationWindow returns the value of ResourceRecommendations.WindowSeconds or 24 hours if unset.
+func (cluster *FoundationDBCluster) GetResourceRecommendationWindow() time.Duration {
+	return time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.ResourceRecommendations.WindowSeconds, 86400)) * time.Second
+}
+
+// GetResourceRecommendationSampleInterval returns the value of ResourceRecommendations.SampleIntervalSeconds or 60 seconds
+// if unset.
+func (cluster *FoundationDBCluster) GetResourceRecommendationSampleInterval() time.Duration {
+	return time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.ResourceRecommendations.SampleIntervalSeconds, 60)) * time.Second
+}
+
+// GetResourceRecommendationHeadroomPercentage returns the value of ResourceRecommendations.HeadroomPercentage or 20 if unset.
+func (cluster *FoundationDBCluster) GetResourceRecommendationHeadroomPercentage() int {
+	return pointer.IntDeref(cluster.Spec.AutomationOptions.ResourceRecommendations.HeadroomPercentage, 20)
+}
+
+// GetResourceRecommendationMinimumChangePercentage returns the value of ResourceRecommendations.MinimumChangePercentage
+// or 10 if unset.
+func (cluster *FoundationDBCluster) GetResourceRecommendationMinimumChangePercentage() int {
+	return pointer.IntDeref(cluster.Spec.AutomationOptions.ResourceRecommendations.MinimumChangePercentage, 10)
+}
+
+// GetApplyResourceRecommendations returns true if the resource recommendations are enabled and should be applied.
+func (cluster *FoundationDBCluster) GetApplyResourceRecommendations() bool {
+	return cluster.GetEnableResourceRecommendations() && pointer.BoolDeref(cluster.Spec.AutomationOptions.ResourceRecommendations.Apply, false)
+}
+
+// GetStorageMigrationMode returns the value of StorageMigration.Mode or Background if unset.
+func (cluster *FoundationDBCluster) GetStorageMigrationMode() StorageMigrationMode {
+	if cluster.Spec.AutomationOptions.StorageMigration.Mode == "" {
+		return StorageMigrationModeBackground
+	}
+
+	return cluster.Spec.AutomationOptions.StorageMigration.Mode
+}
+
+// GetStorageMigrationMaxConcurrentReplacements returns the value of StorageMigration.MaxConcurrentReplacements or 1 if unset.
+func (cluster *FoundationDBCluster) GetStorageMigrationMaxConcurrentReplacements() int {
+	return pointer.IntDeref(cluster.Spec.AutomationOptions.StorageMigration.MaxConcurrentReplacements, 1)
+}
+
+// GetStorageMigrationMaxMovingDataBytes returns the value of StorageMigration.MaxMovingDataBytes or 0 if unset.
+func (cluster *FoundationDBCluster) GetStorageMigrationMaxMovingDataBytes() int {
+	return pointer.IntDeref(cluster.Spec.AutomationOptions.StorageMigration.MaxMovingDataBytes, 0)
+}
+
+// GetResourceRecommendation returns the resource recommendation for the process class or nil if no recommendation
+// exists.
+func (clusterStatus *FoundationDBClusterStatus) GetResourceRecommendation(processClass ProcessClass) *ResourceRecommendation {
+	for idx, recommendation := range clusterStatus.ResourceRecommendations {
+		if recommendation.ProcessClass == processClass {
+			return &clusterStatus.ResourceRecommendations[idx]
+		}
+	}
+
+	return nil
+}
+
 // GetFailedPodDuration returns the value of FailedPodDuration or 5 minutes if unset.
 func (cluster *FoundationDBCluster) GetFailedPodDuration() time.Duration {
 	return time.Duration(pointer.IntDeref(cluster.Spec.AutomationOptions.FailedPodDurationSeconds, 300)) * time.Second
@@ -1941,6 +2745,35 @@ func (cluster *FoundationDBCluster) UseLocalitiesForExclusion() bool {
 	return fdbVersion.IsAtLeast(Versions.NextMajorVersion) && pointer.BoolDeref(cluster.Spec.AutomationOptions.UseLocalitiesForExclusion, false)
 }
 
+// GetUpdateImagesInPlace returns the value of UpdateImagesInPlace or false if unset.
+func (cluster *FoundationDBCluster) GetUpdateImagesInPlace() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.UpdateImagesInPlace, false)
+}
+
+// UseManagementAPI returns the value of UseManagementAPI or false if unset. If the running version doesn't support the
+// management API or the cluster is being upgraded to a version incompatible version false will be returned.
+func (cluster *FoundationDBCluster) UseManagementAPI() bool {
+	if !pointer.BoolDeref(cluster.Spec.AutomationOptions.UseManagementAPI, false) {
+		return false
+	}
+
+	if cluster.IsBeingUpgradedWithVersionIncompatibleVersion() {
+		return false
+	}
+
+	fdbVersion, err := ParseFdbVersion(cluster.GetRunningVersion())
+	if err != nil {
+		return false
+	}
+
+	return fdbVersion.SupportsManagementAPI()
+}
+
+// UseProcessGroupResources returns the value of UseProcessGroupResources or false if unset.
+func (cluster *FoundationDBCluster) UseProcessGroupResources() bool {
+	return pointer.BoolDeref(cluster.Spec.AutomationOptions.UseProcessGroupResources, false)
+}
+
 // GetProcessClassLabel provides the label that this cluster is using for the
 // process class when identifying resources.
 func (cluster *FoundationDBCluster) GetProcessClassLabel() string {
@@ -1966,11 +2799,6 @@ func (cluster *FoundationDBCluster) GetMaxConcurrentReplacements() int {
 	return pointer.IntDeref(cluster.Spec.AutomationOptions.MaxConcurrentReplacements, math.MaxInt64)
 }
 
-// UseManagementAPI returns the value of UseManagementAPI or false if unset.
-func (cluster *FoundationDBCluster) UseManagementAPI() bool {
-	return pointer.BoolDeref(cluster.Spec.AutomationOptions.UseManagementAPI, false)
-}
-
 // PodUpdateMode defines the deletion mode for the cluster
 type PodUpdateMode string
 
@@ -2117,6 +2945,32 @@ func (cluster *FoundationDBCluster) GetEnableAutomaticReplacements() bool {
 	return pointer.BoolDeref(cluster.Spec.AutomationOptions.Replacements.Enabled, true)
 }
 
+// GetTaintReplacementOption returns the taint replacement option for the provided taint key. An exact match is preferred
+// over the wildcard, if no option matches this will return nil.
+func (cluster *FoundationDBCluster) GetTaintReplacementOption(key string) *TaintReplacementOption {
+	var wildcard *TaintReplacementOption
+	for idx, option := range cluster.Spec.AutomationOptions.Replacements.Taints {
+		if option.Key == key {
+			return &cluster.Spec.AutomationOptions.Replacements.Taints[idx]
+		}
+
+		if option.Key == "*" {
+			wildcard = &cluster.Spec.AutomationOptions.Replacements.Taints[idx]
+		}
+	}
+
+	return wildcard
+}
+
+// GetTaintAction returns the action of the taint replacement option or Replace if unset.
+func (option TaintReplacementOption) GetTaintAction() TaintAction {
+	if option.Action == "" {
+		return TaintActionReplace
+	}
+
+	return option.Action
+}
+
 // GetFailureDetectionTimeSeconds returns cluster.Spec.AutomationOptions.Replacements.FailureDetectionTimeSeconds or if unset the default 7200
 func (cluster *FoundationDBCluster) GetFailureDetectionTimeSeconds() int {
 	return pointer.IntDeref(cluster.Spec.AutomationOptions.Replacements.FailureDetectionTimeSeconds, 7200)
@@ -2127,6 +2981,11 @@ func (cluster *FoundationDBCluster) GetSidecarContainerEnableLivenessProbe() boo
 	return pointer.BoolDeref(cluster.Spec.SidecarContainer.EnableLivenessProbe, true)
 }
 
+// GetSidecarAPITokenSecretName returns cluster.Spec.SidecarContainer.APITokenSecretName or if unset an empty string.
+func (cluster *FoundationDBCluster) GetSidecarAPITokenSecretName() string {
+	return pointer.StringDeref(cluster.Spec.SidecarContainer.APITokenSecretName, "")
+}
+
 // GetSidecarContainerEnableReadinessProbe returns cluster.Spec.SidecarContainer.EnableReadinessProbe or if unset the default false
 func (cluster *FoundationDBCluster) GetSidecarContainerEnableReadinessProbe() bool {
 	return pointer.BoolDeref(cluster.Spec.SidecarContainer.EnableReadinessProbe, false)
@@ -2364,6 +3223,27 @@ func (cluster *FoundationDBCluster) Validate() error {
 		validations = append(validations, fmt.Sprintf("storage engine %s is not supported on version %s", cluster.Spec.DatabaseConfiguration.StorageEngine, cluster.Spec.Version))
 	}
 
+	if cluster.GetStorageMigrationMode() == StorageMigrationModeWiggle && !version.SupportsStorageMigrationType() {
+		validations = append(validations, fmt.Sprintf("storage migration mode %s is not supported on version %s", StorageMigrationModeWiggle, cluster.Spec.Version))
+	}
+
+	storageMigrationType := cluster.Spec.DatabaseConfiguration.StorageMigrationType
+	if cluster.GetStorageMigrationMode() == StorageMigrationModeWiggle && storageMigrationType != "" && storageMigrationType != StorageMigrationTypeDisabled {
+		validations = append(validations, fmt.Sprintf("storage migration mode %s requires the storage_migration_type %s, got %s", StorageMigrationModeWiggle, StorageMigrationTypeDisabled, storageMigrationType))
+	}
+
+	redundancyMode := cluster.Spec.DatabaseConfiguration.NormalizeConfiguration().RedundancyMode
+
+	// The three_data_hall redundancy mode requires the data hall locality for all processes.
+	if redundancyMode == RedundancyModeThreeDataHall && cluster.Spec.DataHall == "" {
+		validations = append(validations, fmt.Sprintf("redundancy mode %s requires the dataHall to be set", redundancyMode))
+	}
+
+	// The token for the sidecar API must not be sent in plain text.
+	if cluster.GetSidecarAPITokenSecretName() != "" && !cluster.Spec.SidecarContainer.EnableTLS {
+		validations = append(validations, "sidecarContainer.apiTokenSecretName requires sidecarContainer.enableTls to be true")
+	}
+
 	// Check if all coordinator processes are stateful
 	for _, selection := range cluster.Spec.CoordinatorSelection {
 		if !selection.ProcessClass.IsStateful() {
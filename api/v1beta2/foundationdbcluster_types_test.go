@@ -252,6 +252,11 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 
 			})
 
+			It("should return the correct log count for the three_data_hall redundancy mode", func() {
+				cluster.Spec.DatabaseConfiguration.RedundancyMode = RedundancyModeThreeDataHall
+				Expect(cluster.GetRoleCountsWithDefaults().Logs).To(Equal(4))
+			})
+
 			It("should return the correct counts when all proxies are unconfigured", func() {
 				cluster.Spec.DatabaseConfiguration.RoleCounts = RoleCounts{
 					Proxies:       0,
@@ -644,6 +649,15 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 			Expect(counts.Storage).To(Equal(5))
 			Expect(counts.ClusterController).To(Equal(1))
 		})
+
+		It("should overwrite the process count by name", func() {
+			counts := ProcessCounts{Stateless: -1, Log: 3}
+			counts.SetCount(ProcessClassStateless, 4)
+			Expect(counts.Stateless).To(Equal(4))
+			Expect(counts.Log).To(Equal(3))
+			counts.SetCount(ProcessClassLog, 5)
+			Expect(counts.Log).To(Equal(5))
+		})
 	})
 
 	When("getting desired fault tolerance", func() {
@@ -673,6 +687,12 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 			Expect(cluster.DesiredFaultTolerance()).To(Equal(1))
 			Expect(cluster.MinimumFaultDomains()).To(Equal(2))
 			Expect(cluster.DesiredCoordinatorCount()).To(Equal(9))
+
+			cluster.Spec.DatabaseConfiguration.UsableRegions = 1
+			cluster.Spec.DatabaseConfiguration.RedundancyMode = RedundancyModeThreeDataHall
+			Expect(cluster.DesiredFaultTolerance()).To(Equal(2))
+			Expect(cluster.MinimumFaultDomains()).To(Equal(3))
+			Expect(cluster.DesiredCoordinatorCount()).To(Equal(9))
 		})
 	})
 
@@ -938,6 +958,37 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 					},
 				}))
 			})
+
+			It("should disable the storage migration of FDB with the Wiggle mode", func() {
+				cluster.Spec.AutomationOptions.StorageMigration.Mode = StorageMigrationModeWiggle
+				Expect(cluster.DesiredDatabaseConfiguration().StorageMigrationType).To(Equal(StorageMigrationTypeDisabled))
+			})
+
+			When("a failover selected a different primary data center", func() {
+				BeforeEach(func() {
+					cluster.Spec.DatabaseConfiguration.Regions = []Region{
+						{DataCenters: []DataCenter{{ID: "primary", Priority: 1}}},
+						{DataCenters: []DataCenter{{ID: "remote", Priority: 0}}},
+					}
+					cluster.Status.PrimaryDataCenterOverride = &PrimaryDataCenterOverride{
+						DataCenter:            "remote",
+						SpecPrimaryDataCenter: "primary",
+					}
+				})
+
+				It("should apply the primary data center of the failover", func() {
+					Expect(cluster.DesiredDatabaseConfiguration().Regions).To(Equal([]Region{
+						{DataCenters: []DataCenter{{ID: "primary", Priority: 0}}},
+						{DataCenters: []DataCenter{{ID: "remote", Priority: 1}}},
+					}))
+					Expect(cluster.Spec.DatabaseConfiguration.GetPrimaryDataCenter()).To(Equal("primary"))
+				})
+
+				It("should apply the primary data center of the spec once the spec was changed", func() {
+					cluster.Status.PrimaryDataCenterOverride.SpecPrimaryDataCenter = "remote"
+					Expect(cluster.DesiredDatabaseConfiguration().GetPrimaryDataCenter()).To(Equal("primary"))
+				})
+			})
 		})
 
 		When("the version does not support grv and commit proxies", func() {
@@ -3144,6 +3195,42 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 					NeedsBounce:         2,
 				}))
 
+				cluster = createCluster()
+				cluster.Status.ProcessGroups[0].ProcessGroupConditions = []*ProcessGroupCondition{
+					{ProcessGroupConditionType: StorageHotspot, Timestamp: time.Now().Add(-1 * time.Hour).Unix()},
+				}
+				result, err = cluster.CheckReconciliation(log)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeTrue())
+				Expect(cluster.Status.Generations).To(Equal(ClusterGenerationStatus{
+					Reconciled: 2,
+				}))
+
+				cluster = createCluster()
+				cluster.Spec.AutomationOptions.HotspotRemediation.Action = HotspotActionReplace
+				cluster.Status.ProcessGroups[0].ProcessGroupConditions = []*ProcessGroupCondition{
+					{ProcessGroupConditionType: StorageHotspot, Timestamp: time.Now().Add(-1 * time.Minute).Unix()},
+				}
+				result, err = cluster.CheckReconciliation(log)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeTrue())
+				Expect(cluster.Status.Generations).To(Equal(ClusterGenerationStatus{
+					Reconciled: 2,
+				}))
+
+				cluster = createCluster()
+				cluster.Spec.AutomationOptions.HotspotRemediation.Action = HotspotActionReplace
+				cluster.Status.ProcessGroups[0].ProcessGroupConditions = []*ProcessGroupCondition{
+					{ProcessGroupConditionType: StorageHotspot, Timestamp: time.Now().Add(-1 * time.Hour).Unix()},
+				}
+				result, err = cluster.CheckReconciliation(log)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeFalse())
+				Expect(cluster.Status.Generations).To(Equal(ClusterGenerationStatus{
+					Reconciled:          1,
+					HasUnhealthyProcess: 2,
+				}))
+
 				cluster = createCluster()
 				cluster.Spec.LockOptions.DenyList = append(cluster.Spec.LockOptions.DenyList, LockDenyListEntry{ID: "dc1"})
 				result, err = cluster.CheckReconciliation(log)
@@ -3506,6 +3593,11 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 			}
 			Expect(cluster.ShouldUseLocks()).To(BeTrue())
 
+			cluster.Spec.DatabaseConfiguration.Regions = nil
+			cluster.Spec.DatabaseConfiguration.RedundancyMode = RedundancyModeThreeDataHall
+			Expect(cluster.ShouldUseLocks()).To(BeTrue())
+			cluster.Spec.DatabaseConfiguration.RedundancyMode = ""
+
 			duration := 60
 			cluster.Spec.LockOptions.LockDurationMinutes = &duration
 			Expect(cluster.GetLockDuration()).To(Equal(60 * time.Minute))
@@ -4559,6 +4651,55 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 				},
 				fmt.Errorf("storage engine ssd-redwood-1-experimental is not supported on version 6.3.24"),
 			),
+			Entry("using the wiggle storage migration on an unsupported version",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "6.3.24",
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						AutomationOptions: FoundationDBClusterAutomationOptions{
+							StorageMigration: StorageMigrationOptions{
+								Mode: StorageMigrationModeWiggle,
+							},
+						},
+					},
+				},
+				fmt.Errorf("storage migration mode Wiggle is not supported on version 6.3.24"),
+			),
+			Entry("using the wiggle storage migration on a supported version",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: Versions.SupportsStorageMigration.String(),
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine: StorageEngineSSD2,
+						},
+						AutomationOptions: FoundationDBClusterAutomationOptions{
+							StorageMigration: StorageMigrationOptions{
+								Mode: StorageMigrationModeWiggle,
+							},
+						},
+					},
+				},
+				nil,
+			),
+			Entry("using the wiggle storage migration with a different storage migration type",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: Versions.SupportsStorageMigration.String(),
+						DatabaseConfiguration: DatabaseConfiguration{
+							StorageEngine:        StorageEngineSSD2,
+							StorageMigrationType: StorageMigrationTypeAggressive,
+						},
+						AutomationOptions: FoundationDBClusterAutomationOptions{
+							StorageMigration: StorageMigrationOptions{
+								Mode: StorageMigrationModeWiggle,
+							},
+						},
+					},
+				},
+				fmt.Errorf("storage migration mode Wiggle requires the storage_migration_type disabled, got aggressive"),
+			),
 			Entry("using valid storage engine",
 				&FoundationDBCluster{
 					Spec: FoundationDBClusterSpec{
@@ -4623,6 +4764,29 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 				},
 				fmt.Errorf("stateless is not a valid process class for coordinators"),
 			),
+			Entry("using a sidecar API token without TLS",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: Versions.Default.String(),
+						SidecarContainer: ContainerOverrides{
+							APITokenSecretName: pointer.String("sidecar-token"),
+						},
+					},
+				},
+				fmt.Errorf("sidecarContainer.apiTokenSecretName requires sidecarContainer.enableTls to be true"),
+			),
+			Entry("using a sidecar API token with TLS",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: Versions.Default.String(),
+						SidecarContainer: ContainerOverrides{
+							EnableTLS:          true,
+							APITokenSecretName: pointer.String("sidecar-token"),
+						},
+					},
+				},
+				nil,
+			),
 			Entry("multiple validations",
 				&FoundationDBCluster{
 					Spec: FoundationDBClusterSpec{
@@ -4639,6 +4803,29 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 				},
 				fmt.Errorf("storage engine ssd-rocksdb-v1 is not supported on version 6.1.3, stateless is not a valid process class for coordinators"),
 			),
+			Entry("using three_data_hall without a data hall",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version: "6.3.24",
+						DatabaseConfiguration: DatabaseConfiguration{
+							RedundancyMode: RedundancyModeThreeDataHall,
+						},
+					},
+				},
+				fmt.Errorf("redundancy mode three_data_hall requires the dataHall to be set"),
+			),
+			Entry("using three_data_hall with a data hall",
+				&FoundationDBCluster{
+					Spec: FoundationDBClusterSpec{
+						Version:  "6.3.24",
+						DataHall: "az1",
+						DatabaseConfiguration: DatabaseConfiguration{
+							RedundancyMode: RedundancyModeThreeDataHall,
+						},
+					},
+				},
+				nil,
+			),
 			Entry("using invalid version for sharded rocksdb",
 				&FoundationDBCluster{
 					Spec: FoundationDBClusterSpec{
@@ -4997,4 +5184,133 @@ var _ = Describe("[api] FoundationDBCluster", func() {
 				},
 			}, true, false),
 	)
+
+	DescribeTable("checking if the management API should be used",
+		func(cluster *FoundationDBCluster, expected bool) {
+			Expect(cluster.UseManagementAPI()).To(Equal(expected))
+		},
+		Entry("management API is not enabled",
+			&FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					Version: "7.1.27",
+				},
+			}, false),
+		Entry("management API is enabled",
+			&FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					Version: "7.1.27",
+					AutomationOptions: FoundationDBClusterAutomationOptions{
+						UseManagementAPI: pointer.Bool(true),
+					},
+				},
+			}, true),
+		Entry("management API is enabled but the version is not supported",
+			&FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					Version: "6.3.25",
+					AutomationOptions: FoundationDBClusterAutomationOptions{
+						UseManagementAPI: pointer.Bool(true),
+					},
+				},
+			}, false),
+		Entry("management API is enabled and a version compatible upgrade is ongoing",
+			&FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					Version: "7.1.29",
+					AutomationOptions: FoundationDBClusterAutomationOptions{
+						UseManagementAPI: pointer.Bool(true),
+					},
+				},
+				Status: FoundationDBClusterStatus{
+					RunningVersion: "7.1.27",
+				},
+			}, true),
+		Entry("management API is enabled and a version incompatible upgrade is ongoing",
+			&FoundationDBCluster{
+				Spec: FoundationDBClusterSpec{
+					Version: "7.2.3",
+					AutomationOptions: FoundationDBClusterAutomationOptions{
+						UseManagementAPI: pointer.Bool(true),
+					},
+				},
+				Status: FoundationDBClusterStatus{
+					RunningVersion: "7.1.27",
+				},
+			}, false),
+	)
+
+	DescribeTable("getting the rollout stages", func(policy RolloutPolicy, expected []string) {
+		cluster := &FoundationDBCluster{
+			Spec: FoundationDBClusterSpec{
+				AutomationOptions: FoundationDBClusterAutomationOptions{
+					RolloutPolicy: policy,
+				},
+			},
+		}
+		Expect(cluster.GetRolloutStages()).To(Equal(expected))
+	},
+		Entry("no rollout policy",
+			RolloutPolicy{},
+			nil),
+		Entry("only a canary count",
+			RolloutPolicy{
+				CanaryCount: pointer.Int(1),
+			},
+			nil),
+		Entry("process classes without canaries",
+			RolloutPolicy{
+				ProcessClasses: []ProcessClass{ProcessClassLog, ProcessClassStorage},
+			},
+			[]string{"log", "storage", RolloutStageRemaining}),
+		Entry("process classes with canaries",
+			RolloutPolicy{
+				ProcessClasses: []ProcessClass{ProcessClassStorage},
+				CanaryCount:    pointer.Int(2),
+			},
+			[]string{RolloutStageCanary, "storage", RolloutStageRemaining}),
+	)
+
+	DescribeTable("getting the taint replacement option", func(taints []TaintReplacementOption, key string, expected *TaintReplacementOption) {
+		cluster := &FoundationDBCluster{
+			Spec: FoundationDBClusterSpec{
+				AutomationOptions: FoundationDBClusterAutomationOptions{
+					Replacements: AutomaticReplacementOptions{
+						Taints: taints,
+					},
+				},
+			},
+		}
+		Expect(cluster.GetTaintReplacementOption(key)).To(Equal(expected))
+	},
+		Entry("no taints defined",
+			nil,
+			"example.org/maintenance",
+			nil),
+		Entry("the taint key matches",
+			[]TaintReplacementOption{
+				{Key: "example.org/maintenance", DurationInSeconds: 60, Action: TaintActionMaintenanceMode},
+			},
+			"example.org/maintenance",
+			&TaintReplacementOption{Key: "example.org/maintenance", DurationInSeconds: 60, Action: TaintActionMaintenanceMode}),
+		Entry("the taint key doesn't match",
+			[]TaintReplacementOption{
+				{Key: "example.org/maintenance", DurationInSeconds: 60},
+			},
+			"example.org/other",
+			nil),
+		Entry("the wildcard matches",
+			[]TaintReplacementOption{
+				{Key: "*", DurationInSeconds: 3600},
+				{Key: "example.org/maintenance", DurationInSeconds: 60},
+			},
+			"example.org/other",
+			&TaintReplacementOption{Key: "*", DurationInSeconds: 3600}),
+		Entry("the exact match is preferred over the wildcard",
+			[]TaintReplacementOption{
+				{Key: "*", DurationInSeconds: 3600},
+				{Key: "example.org/maintenance", DurationInSeconds: 60},
+			},
+			"example.org/maintenance",
+			&TaintReplacementOption{Key: "example.org/maintenance", DurationInSeconds: 60}),
+	)
 })
@@ -0,0 +1,340 @@
+/*
+ * foundationdbdisasterrecovery_types.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta2
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=fdbdr
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Generation",type="integer",JSONPath=".metadata.generation",description="Latest generation of the spec",priority=0
+// +kubebuilder:printcolumn:name="Reconciled",type="integer",JSONPath=".status.generations.reconciled",description="Last reconciled generation of the spec",priority=0
+// +kubebuilder:printcolumn:name="Source",type="string",JSONPath=".spec.sourceClusterName",description="Name of the source cluster",priority=0
+// +kubebuilder:printcolumn:name="Destination",type="string",JSONPath=".spec.destinationClusterName",description="Name of the destination cluster",priority=0
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.drDetails.phase",description="Phase of the DR in the destination cluster",priority=0
+// +kubebuilder:printcolumn:name="Lag",type="integer",JSONPath=".status.drDetails.lagSeconds",description="Seconds the destination cluster is behind the source cluster",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:storageversion
+
+// FoundationDBDisasterRecovery is the Schema for the foundationdbdisasterrecoveries API. A
+// FoundationDBDisasterRecovery replicates the data of a source cluster into a destination cluster with the fdbdr
+// tooling.
+type FoundationDBDisasterRecovery struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FoundationDBDisasterRecoverySpec   `json:"spec,omitempty"`
+	Status FoundationDBDisasterRecoveryStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// FoundationDBDisasterRecoveryList contains a list of FoundationDBDisasterRecovery objects
+type FoundationDBDisasterRecoveryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FoundationDBDisasterRecovery `json:"items"`
+}
+
+// FoundationDBDisasterRecoverySpec describes the desired state of the DR between two clusters.
+type FoundationDBDisasterRecoverySpec struct {
+	// The version of FoundationDB that the DR agents should run.
+	Version string `json:"version"`
+
+	// SourceClusterName defines the name of the FoundationDBCluster whose data is replicated. Swapping the source
+	// and the destination cluster of a running DR will switch the DR, which makes the destination cluster the new
+	// source cluster.
+	// +kubebuilder:validation:MaxLength=100
+	SourceClusterName string `json:"sourceClusterName"`
+
+	// DestinationClusterName defines the name of the FoundationDBCluster that receives the data of the source
+	// cluster. The destination cluster will be locked while the DR is running.
+	// +kubebuilder:validation:MaxLength=100
+	DestinationClusterName string `json:"destinationClusterName"`
+
+	// +kubebuilder:validation:Enum=Running;Stopped
+	// The desired state of the DR.
+	// The default is Running.
+	DRState DRState `json:"drState,omitempty"`
+
+	// Tag defines the tag of the DR in the clusters.
+	// The default is "default".
+	// +kubebuilder:validation:MaxLength=100
+	// +kubebuilder:validation:Pattern:=`^[A-Za-z0-9_-]+$`
+	Tag string `json:"tag,omitempty"`
+
+	// AgentCount defines the number of DR agents to run.
+	// The default is run 2 agents.
+	AgentCount *int `json:"agentCount,omitempty"`
+
+	// AgentDeploymentMetadata allows customizing labels and annotations on the
+	// deployment for the DR agents.
+	AgentDeploymentMetadata *metav1.ObjectMeta `json:"agentDeploymentMetadata,omitempty"`
+
+	// PodTemplateSpec allows customizing the pod template for the DR agents.
+	PodTemplateSpec *corev1.PodTemplateSpec `json:"podTemplateSpec,omitempty"`
+
+	// CustomParameters defines additional parameters to pass to the DR agents.
+	CustomParameters FoundationDBCustomParameters `json:"customParameters,omitempty"`
+
+	// MainContainer defines customization for the foundationdb container.
+	MainContainer ContainerOverrides `json:"mainContainer,omitempty"`
+
+	// SidecarContainer defines customization for the
+	// foundationdb-kubernetes-sidecar container.
+	SidecarContainer ContainerOverrides `json:"sidecarContainer,omitempty"`
+}
+
+// FoundationDBDisasterRecoveryStatus describes the current status of the DR between two clusters.
+type FoundationDBDisasterRecoveryStatus struct {
+	// AgentCount provides the number of agents that are up-to-date, ready,
+	// and not terminated.
+	AgentCount int `json:"agentCount,omitempty"`
+
+	// DeploymentConfigured indicates whether the deployment is correctly
+	// configured.
+	DeploymentConfigured bool `json:"deploymentConfigured,omitempty"`
+
+	// DRDetails provides information about the state of the DR in the
+	// destination cluster.
+	DRDetails *FoundationDBDisasterRecoveryStatusDRDetails `json:"drDetails,omitempty"`
+
+	// Generations provides information about the latest generation to be
+	// reconciled, or to reach other stages in reconciliation.
+	Generations DisasterRecoveryGenerationStatus `json:"generations,omitempty"`
+}
+
+// FoundationDBDisasterRecoveryStatusDRDetails provides information about the
+// state of the DR in the destination cluster.
+type FoundationDBDisasterRecoveryStatusDRDetails struct {
+	// Tag provides the tag of the DR.
+	Tag string `json:"tag,omitempty"`
+
+	// SourceClusterName provides the name of the cluster that is currently
+	// the source of the DR.
+	SourceClusterName string `json:"sourceClusterName,omitempty"`
+
+	// DestinationClusterName provides the name of the cluster that is
+	// currently the destination of the DR.
+	DestinationClusterName string `json:"destinationClusterName,omitempty"`
+
+	// Running indicates whether the DR is running.
+	Running bool `json:"running,omitempty"`
+
+	// Phase provides the phase of the DR as reported by fdbdr.
+	Phase DRPhase `json:"phase,omitempty"`
+
+	// LagSeconds provides the number of seconds the destination cluster is
+	// behind the source cluster, rounded down.
+	LagSeconds int64 `json:"lagSeconds,omitempty"`
+}
+
+// DisasterRecoveryGenerationStatus stores information on which generations
+// have reached different stages in reconciliation for the DR.
+type DisasterRecoveryGenerationStatus struct {
+	// Reconciled provides the last generation that was fully reconciled.
+	Reconciled int64 `json:"reconciled,omitempty"`
+
+	// NeedsAgentUpdate provides the last generation that could not complete
+	// reconciliation because the DR agent deployment needs to be updated.
+	NeedsAgentUpdate int64 `json:"needsAgentUpdate,omitempty"`
+
+	// NeedsDRStart provides the last generation that could not complete
+	// reconciliation because we need to start the DR.
+	NeedsDRStart int64 `json:"needsDRStart,omitempty"`
+
+	// NeedsDRStop provides the last generation that could not complete
+	// reconciliation because we need to stop the DR.
+	NeedsDRStop int64 `json:"needsDRStop,omitempty"`
+
+	// NeedsDRSwitch provides the last generation that could not complete
+	// reconciliation because we need to switch the source and the destination
+	// cluster of the DR.
+	NeedsDRSwitch int64 `json:"needsDRSwitch,omitempty"`
+}
+
+// DRState defines the desired state of a DR.
+type DRState string
+
+const (
+	// DRStateRunning defines the running state.
+	DRStateRunning DRState = "Running"
+	// DRStateStopped defines the stopped state.
+	DRStateStopped DRState = "Stopped"
+)
+
+// DRPhase defines the phase of a DR as reported by fdbdr.
+// +kubebuilder:validation:MaxLength=50
+type DRPhase string
+
+const (
+	// DRPhaseSubmitted means that the DR was started, but no data has been
+	// copied yet.
+	DRPhaseSubmitted DRPhase = "Submitted"
+	// DRPhaseRunning means that the DR is copying the initial data and the
+	// destination cluster is not a complete copy of the source cluster.
+	DRPhaseRunning DRPhase = "Running"
+	// DRPhaseRunningDifferentially means that the destination cluster is a
+	// complete copy of the source cluster and the DR is streaming mutations.
+	DRPhaseRunningDifferentially DRPhase = "RunningDifferentially"
+	// DRPhaseCompleted means that the DR was completed.
+	DRPhaseCompleted DRPhase = "Completed"
+	// DRPhaseAborted means that the DR was aborted.
+	DRPhaseAborted DRPhase = "Aborted"
+	// DRPhasePartiallyAborted means that the DR was aborted, but the cleanup
+	// in the source cluster failed.
+	DRPhasePartiallyAborted DRPhase = "PartiallyAborted"
+)
+
+// FoundationDBLiveDRStatus describes the current status of a DR as reported
+// by the fdbdr status command.
+type FoundationDBLiveDRStatus struct {
+	// Tag provides the tag of the DR.
+	Tag string `json:"tag,omitempty"`
+
+	// Phase provides the phase of the DR. If no DR was started, the phase is
+	// empty.
+	Phase DRPhase `json:"phase,omitempty"`
+
+	// SecondsBehind provides the number of seconds the destination cluster is
+	// behind the source cluster.
+	SecondsBehind float64 `json:"secondsBehind,omitempty"`
+}
+
+// IsRunning returns true if the DR is streaming data into the destination
+// cluster.
+func (status *FoundationDBLiveDRStatus) IsRunning() bool {
+	return status.Phase == DRPhaseSubmitted || status.Phase == DRPhaseRunning || status.Phase == DRPhaseRunningDifferentially
+}
+
+// ShouldRun determines whether the DR should be running.
+func (dr *FoundationDBDisasterRecovery) ShouldRun() bool {
+	return dr.Spec.DRState == "" || dr.Spec.DRState == DRStateRunning
+}
+
+// DRTag returns the tag of the DR in the clusters, defaults to "default".
+func (dr *FoundationDBDisasterRecovery) DRTag() string {
+	if dr.Spec.Tag == "" {
+		return "default"
+	}
+
+	return dr.Spec.Tag
+}
+
+// GetDesiredAgentCount determines how many DR agents we should run.
+func (dr *FoundationDBDisasterRecovery) GetDesiredAgentCount() int {
+	return pointer.IntDeref(dr.Spec.AgentCount, 2)
+}
+
+// IsRunning returns true if the status reports a running DR.
+func (dr *FoundationDBDisasterRecovery) IsRunning() bool {
+	return dr.Status.DRDetails != nil && dr.Status.DRDetails.Running
+}
+
+// GetCurrentClusterNames returns the names of the source and the destination
+// cluster of the running DR. If no DR is running, the names from the spec
+// are returned.
+func (dr *FoundationDBDisasterRecovery) GetCurrentClusterNames() (string, string) {
+	if dr.IsRunning() {
+		return dr.Status.DRDetails.SourceClusterName, dr.Status.DRDetails.DestinationClusterName
+	}
+
+	return dr.Spec.SourceClusterName, dr.Spec.DestinationClusterName
+}
+
+// NeedsSwitch returns true if the DR is running and the source and the
+// destination cluster in the spec are swapped compared to the running DR.
+func (dr *FoundationDBDisasterRecovery) NeedsSwitch() bool {
+	if !dr.IsRunning() || !dr.ShouldRun() {
+		return false
+	}
+
+	source, destination := dr.GetCurrentClusterNames()
+	return source == dr.Spec.DestinationClusterName && destination == dr.Spec.SourceClusterName
+}
+
+// Validate checks if the DR spec is valid.
+func (dr *FoundationDBDisasterRecovery) Validate() error {
+	if dr.Spec.SourceClusterName == "" || dr.Spec.DestinationClusterName == "" {
+		return fmt.Errorf("sourceClusterName and destinationClusterName must be defined")
+	}
+
+	if dr.Spec.SourceClusterName == dr.Spec.DestinationClusterName {
+		return fmt.Errorf("the source and the destination cluster must be different, but both are %s", dr.Spec.SourceClusterName)
+	}
+
+	if !dr.IsRunning() || !dr.ShouldRun() || dr.NeedsSwitch() {
+		return nil
+	}
+
+	source, destination := dr.GetCurrentClusterNames()
+	if source != dr.Spec.SourceClusterName || destination != dr.Spec.DestinationClusterName {
+		return fmt.Errorf("the clusters of a running DR can only be swapped, stop the DR from %s to %s first", source, destination)
+	}
+
+	return nil
+}
+
+// CheckReconciliation compares the spec and the status to determine if
+// reconciliation is complete.
+func (dr *FoundationDBDisasterRecovery) CheckReconciliation() (bool, error) {
+	var reconciled = true
+
+	if dr.Status.AgentCount != dr.GetDesiredAgentCount() || !dr.Status.DeploymentConfigured {
+		dr.Status.Generations.NeedsAgentUpdate = dr.ObjectMeta.Generation
+		reconciled = false
+	}
+
+	isRunning := dr.IsRunning()
+
+	if dr.ShouldRun() && !isRunning {
+		dr.Status.Generations.NeedsDRStart = dr.ObjectMeta.Generation
+		reconciled = false
+	}
+
+	if !dr.ShouldRun() && isRunning {
+		dr.Status.Generations.NeedsDRStop = dr.ObjectMeta.Generation
+		reconciled = false
+	}
+
+	if dr.NeedsSwitch() {
+		dr.Status.Generations.NeedsDRSwitch = dr.ObjectMeta.Generation
+		reconciled = false
+	}
+
+	if reconciled {
+		dr.Status.Generations = DisasterRecoveryGenerationStatus{
+			Reconciled: dr.ObjectMeta.Generation,
+		}
+	}
+
+	return reconciled, nil
+}
+
+func init() {
+	SchemeBuilder.Register(&FoundationDBDisasterRecovery{}, &FoundationDBDisasterRecoveryList{})
+}
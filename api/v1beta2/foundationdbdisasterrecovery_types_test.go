@@ -0,0 +1,171 @@
+/*
+ * foundationdbdisasterrecovery_types_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta2
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("[api] FoundationDBDisasterRecovery", func() {
+	var dr *FoundationDBDisasterRecovery
+
+	BeforeEach(func() {
+		dr = &FoundationDBDisasterRecovery{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "test",
+				Namespace:  "default",
+				Generation: 2,
+			},
+			Spec: FoundationDBDisasterRecoverySpec{
+				SourceClusterName:      "source",
+				DestinationClusterName: "destination",
+			},
+		}
+	})
+
+	When("getting the tag", func() {
+		It("should default to default", func() {
+			Expect(dr.DRTag()).To(Equal("default"))
+		})
+
+		When("a tag is defined", func() {
+			BeforeEach(func() {
+				dr.Spec.Tag = "dr"
+			})
+
+			It("should return the defined tag", func() {
+				Expect(dr.DRTag()).To(Equal("dr"))
+			})
+		})
+	})
+
+	When("the DR is running", func() {
+		BeforeEach(func() {
+			dr.Status.DRDetails = &FoundationDBDisasterRecoveryStatusDRDetails{
+				SourceClusterName:      "source",
+				DestinationClusterName: "destination",
+				Running:                true,
+				Phase:                  DRPhaseRunningDifferentially,
+			}
+		})
+
+		It("should return the clusters of the running DR", func() {
+			source, destination := dr.GetCurrentClusterNames()
+			Expect(source).To(Equal("source"))
+			Expect(destination).To(Equal("destination"))
+			Expect(dr.NeedsSwitch()).To(BeFalse())
+		})
+
+		When("the clusters in the spec are swapped", func() {
+			BeforeEach(func() {
+				dr.Spec.SourceClusterName = "destination"
+				dr.Spec.DestinationClusterName = "source"
+			})
+
+			It("should need a switch", func() {
+				Expect(dr.NeedsSwitch()).To(BeTrue())
+				source, _ := dr.GetCurrentClusterNames()
+				Expect(source).To(Equal("source"))
+			})
+
+			It("should not be reconciled", func() {
+				dr.Status.AgentCount = 2
+				dr.Status.DeploymentConfigured = true
+				Expect(dr.CheckReconciliation()).To(BeFalse())
+				Expect(dr.Status.Generations).To(Equal(DisasterRecoveryGenerationStatus{NeedsDRSwitch: 2}))
+			})
+		})
+
+		When("the DR should be stopped", func() {
+			BeforeEach(func() {
+				dr.Spec.DRState = DRStateStopped
+				dr.Status.AgentCount = 2
+				dr.Status.DeploymentConfigured = true
+			})
+
+			It("should need a stop", func() {
+				Expect(dr.CheckReconciliation()).To(BeFalse())
+				Expect(dr.Status.Generations).To(Equal(DisasterRecoveryGenerationStatus{NeedsDRStop: 2}))
+			})
+		})
+	})
+
+	When("checking the reconciliation of a new DR", func() {
+		It("should need an agent update and a start", func() {
+			Expect(dr.CheckReconciliation()).To(BeFalse())
+			Expect(dr.Status.Generations).To(Equal(DisasterRecoveryGenerationStatus{NeedsAgentUpdate: 2, NeedsDRStart: 2}))
+		})
+	})
+
+	DescribeTable("validating the DR", func(modify func(*FoundationDBDisasterRecovery), expected error) {
+		modify(dr)
+		err := dr.Validate()
+		if expected == nil {
+			Expect(err).NotTo(HaveOccurred())
+		} else {
+			Expect(err).To(Equal(expected))
+		}
+	},
+		Entry("valid DR",
+			func(_ *FoundationDBDisasterRecovery) {},
+			nil,
+		),
+		Entry("missing destination cluster",
+			func(dr *FoundationDBDisasterRecovery) {
+				dr.Spec.DestinationClusterName = ""
+			},
+			fmt.Errorf("sourceClusterName and destinationClusterName must be defined"),
+		),
+		Entry("same source and destination cluster",
+			func(dr *FoundationDBDisasterRecovery) {
+				dr.Spec.DestinationClusterName = "source"
+			},
+			fmt.Errorf("the source and the destination cluster must be different, but both are source"),
+		),
+		Entry("changed destination cluster of a running DR",
+			func(dr *FoundationDBDisasterRecovery) {
+				dr.Status.DRDetails = &FoundationDBDisasterRecoveryStatusDRDetails{
+					SourceClusterName:      "source",
+					DestinationClusterName: "destination",
+					Running:                true,
+				}
+				dr.Spec.DestinationClusterName = "other"
+			},
+			fmt.Errorf("the clusters of a running DR can only be swapped, stop the DR from source to destination first"),
+		),
+		Entry("changed destination cluster of a stopped DR",
+			func(dr *FoundationDBDisasterRecovery) {
+				dr.Status.DRDetails = &FoundationDBDisasterRecoveryStatusDRDetails{
+					SourceClusterName:      "source",
+					DestinationClusterName: "destination",
+					Running:                true,
+				}
+				dr.Spec.DestinationClusterName = "other"
+				dr.Spec.DRState = DRStateStopped
+			},
+			nil,
+		),
+	)
+})
@@ -0,0 +1,126 @@
+/*
+ * foundationdbfailover_types.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=fdbfailover
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterName",description="Name of the cluster",priority=0
+// +kubebuilder:printcolumn:name="Target",type="string",JSONPath=".spec.targetDataCenter",description="Data center that should become the primary",priority=0
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state",description="State of the failover",priority=0
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:storageversion
+
+// FoundationDBFailover is the Schema for the foundationdbfailovers API. A FoundationDBFailover describes a single
+// failover of a multi-region database to a different primary data center.
+type FoundationDBFailover struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FoundationDBFailoverSpec   `json:"spec,omitempty"`
+	Status FoundationDBFailoverStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// FoundationDBFailoverList contains a list of FoundationDBFailover objects
+type FoundationDBFailoverList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FoundationDBFailover `json:"items"`
+}
+
+// FoundationDBFailoverSpec describes the desired state of the failover.
+type FoundationDBFailoverSpec struct {
+	// ClusterName defines the name of the FoundationDBCluster that should be failed over. If the cluster is managed
+	// by a FoundationDBMultiRegionCluster, the primary data center of the multi-region cluster will be changed.
+	// +kubebuilder:validation:MaxLength=100
+	ClusterName string `json:"clusterName"`
+
+	// TargetDataCenter defines the main data center that should become the primary data center.
+	// +kubebuilder:validation:MaxLength=100
+	TargetDataCenter string `json:"targetDataCenter"`
+
+	// MaximumDataCenterLagSeconds defines the maximum lag in seconds of the remote data centers before the failover
+	// will be started. The operator will wait until the lag is below this value. Defaults to 5.
+	// +kubebuilder:validation:Minimum=0
+	MaximumDataCenterLagSeconds *int `json:"maximumDataCenterLagSeconds,omitempty"`
+}
+
+// FoundationDBFailoverStatus describes the current state of the failover.
+type FoundationDBFailoverStatus struct {
+	// State defines the current state of the failover.
+	State FailoverState `json:"state,omitempty"`
+
+	// Message provides additional information about the current state, e.g. why the operator is waiting or why the
+	// failover failed.
+	Message string `json:"message,omitempty"`
+
+	// PreviousPrimaryDataCenter defines the primary data center before the failover was started.
+	PreviousPrimaryDataCenter string `json:"previousPrimaryDataCenter,omitempty"`
+
+	// StartTimestamp defines when the priorities of the data centers were changed.
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// CompletionTimestamp defines when the failover was completed or failed.
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
+}
+
+// FailoverState describes the state of a failover.
+// +kubebuilder:validation:MaxLength=50
+type FailoverState string
+
+const (
+	// FailoverStateWaitingForRemote means that the operator waits until the target data center has caught up.
+	FailoverStateWaitingForRemote FailoverState = "WaitingForRemote"
+
+	// FailoverStateFailingOver means that the priorities have been changed and the operator waits until the target
+	// data center is the primary data center.
+	FailoverStateFailingOver FailoverState = "FailingOver"
+
+	// FailoverStateCompleted means that the target data center is the primary data center.
+	FailoverStateCompleted FailoverState = "Completed"
+
+	// FailoverStateFailed means that the failover cannot be performed, the reason is recorded in the message.
+	FailoverStateFailed FailoverState = "Failed"
+)
+
+// GetMaximumDataCenterLagSeconds returns the maximum lag of the remote data centers in seconds, defaults to 5.
+func (failover *FoundationDBFailover) GetMaximumDataCenterLagSeconds() int {
+	if failover.Spec.MaximumDataCenterLagSeconds == nil {
+		return 5
+	}
+
+	return *failover.Spec.MaximumDataCenterLagSeconds
+}
+
+// IsFinished returns true if the failover is either completed or failed.
+func (failover *FoundationDBFailover) IsFinished() bool {
+	return failover.Status.State == FailoverStateCompleted || failover.Status.State == FailoverStateFailed
+}
+
+func init() {
+	SchemeBuilder.Register(&FoundationDBFailover{}, &FoundationDBFailoverList{})
+}
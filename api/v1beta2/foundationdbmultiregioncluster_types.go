@@ -0,0 +1,308 @@
+/*
+ * foundationdbmultiregioncluster_types.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta2
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=fdbmultiregion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Generation",type="integer",JSONPath=".metadata.generation",description="Latest generation of the spec",priority=0
+// +kubebuilder:printcolumn:name="Reconciled",type="integer",JSONPath=".status.generations.reconciled",description="Last reconciled generation of the spec",priority=0
+// +kubebuilder:printcolumn:name="Primary",type="string",JSONPath=".status.primaryDataCenter",description="Current primary data center",priority=0
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:storageversion
+
+// FoundationDBMultiRegionCluster is the Schema for the foundationdbmultiregionclusters API. A
+// FoundationDBMultiRegionCluster manages one FoundationDBCluster per data center of a multi-region database and
+// coordinates the changes that affect all data centers. All FoundationDBClusters are created in the namespace of the
+// FoundationDBMultiRegionCluster, so all data centers must run in the same Kubernetes cluster.
+type FoundationDBMultiRegionCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FoundationDBMultiRegionClusterSpec   `json:"spec,omitempty"`
+	Status FoundationDBMultiRegionClusterStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// FoundationDBMultiRegionClusterList contains a list of FoundationDBMultiRegionCluster objects
+type FoundationDBMultiRegionClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FoundationDBMultiRegionCluster `json:"items"`
+}
+
+// FoundationDBMultiRegionClusterSpec describes the desired state of a multi-region database.
+type FoundationDBMultiRegionClusterSpec struct {
+	// ClusterTemplate defines the spec of the FoundationDBCluster resources for the data centers. The regions in the
+	// database configuration define the data centers, every data center including the satellites will be managed by
+	// its own FoundationDBCluster. The dataCenter, processGroupIDPrefix and seedConnectionString fields and the
+	// priorities of the main data centers are managed by the operator.
+	ClusterTemplate FoundationDBClusterSpec `json:"clusterTemplate"`
+
+	// PrimaryDataCenter defines the main data center that should act as primary. Changing this value will trigger a
+	// failover to the region of the new primary data center once all data centers are reconciled. Defaults to the
+	// main data center with the highest priority in the cluster template. A FoundationDBFailover records the new
+	// primary data center in the status, changing this value afterwards replaces the primary data center of the
+	// failover.
+	// +kubebuilder:validation:MaxLength=100
+	PrimaryDataCenter string `json:"primaryDataCenter,omitempty"`
+}
+
+// FoundationDBMultiRegionClusterStatus describes the current state of a multi-region database.
+type FoundationDBMultiRegionClusterStatus struct {
+	// Generations provides information about the latest generation to be
+	// reconciled.
+	Generations MultiRegionClusterGenerationStatus `json:"generations,omitempty"`
+
+	// SeedDataCenter defines the data center of the FoundationDBCluster that was created first. The connection string
+	// of this cluster is used as seed connection string for the clusters in the other data centers.
+	SeedDataCenter string `json:"seedDataCenter,omitempty"`
+
+	// ConnectionString defines the current connection string of the database. This will only be set once the cluster
+	// in the seed data center is reconciled.
+	ConnectionString string `json:"connectionString,omitempty"`
+
+	// PrimaryDataCenter defines the main data center that is currently configured as primary.
+	PrimaryDataCenter string `json:"primaryDataCenter,omitempty"`
+
+	// UsableRegions defines the number of usable regions that is currently configured in the database configuration
+	// of the data center clusters.
+	UsableRegions int `json:"usableRegions,omitempty"`
+
+	// DataCenters provides the state of the FoundationDBCluster for every data center.
+	DataCenters []MultiRegionDataCenterStatus `json:"dataCenters,omitempty"`
+
+	// PrimaryDataCenterOverride defines the primary data center that was selected by a failover. The override takes
+	// precedence over the primary data center of the spec, as long as the spec is not changed.
+	PrimaryDataCenterOverride *PrimaryDataCenterOverride `json:"primaryDataCenterOverride,omitempty"`
+}
+
+// MultiRegionClusterGenerationStatus stores information on which generations have reached different stages in
+// reconciliation for the multi-region cluster.
+type MultiRegionClusterGenerationStatus struct {
+	// Reconciled provides the last generation that was fully reconciled.
+	Reconciled int64 `json:"reconciled,omitempty"`
+}
+
+// MultiRegionDataCenterStatus describes the state of the FoundationDBCluster of a single data center.
+type MultiRegionDataCenterStatus struct {
+	// ID of the data center.
+	ID string `json:"id"`
+
+	// ClusterName defines the name of the FoundationDBCluster that manages the data center.
+	ClusterName string `json:"clusterName"`
+
+	// Reconciled defines if the FoundationDBCluster of the data center is fully reconciled.
+	Reconciled bool `json:"reconciled,omitempty"`
+}
+
+// GetDataCenterIDs returns the IDs of all data centers in the regions of the cluster template, including the
+// satellites. The IDs are returned in the order they appear in the regions.
+func (cluster *FoundationDBMultiRegionCluster) GetDataCenterIDs() []string {
+	ids := make([]string, 0, len(cluster.Spec.ClusterTemplate.DatabaseConfiguration.Regions))
+	seen := map[string]None{}
+
+	for _, region := range cluster.Spec.ClusterTemplate.DatabaseConfiguration.Regions {
+		for _, dataCenter := range region.DataCenters {
+			if _, ok := seen[dataCenter.ID]; ok {
+				continue
+			}
+
+			seen[dataCenter.ID] = None{}
+			ids = append(ids, dataCenter.ID)
+		}
+	}
+
+	return ids
+}
+
+// GetDataCenterClusterName returns the name of the FoundationDBCluster that manages the provided data center.
+func (cluster *FoundationDBMultiRegionCluster) GetDataCenterClusterName(dataCenterID string) string {
+	return fmt.Sprintf("%s-%s", cluster.Name, dataCenterID)
+}
+
+// GetPrimaryDataCenter returns the main data center that should act as primary. A primary data center that was
+// selected by a failover takes precedence over the primary data center of the spec.
+func (cluster *FoundationDBMultiRegionCluster) GetPrimaryDataCenter() string {
+	return cluster.Status.PrimaryDataCenterOverride.GetPrimaryDataCenter(cluster.GetSpecPrimaryDataCenter())
+}
+
+// GetSpecPrimaryDataCenter returns the main data center that is defined as primary in the spec. If no primary data
+// center is defined the main data center with the highest priority in the cluster template will be returned.
+func (cluster *FoundationDBMultiRegionCluster) GetSpecPrimaryDataCenter() string {
+	if cluster.Spec.PrimaryDataCenter != "" {
+		return cluster.Spec.PrimaryDataCenter
+	}
+
+	primary := ""
+	maxPriority := 0
+	for _, region := range cluster.Spec.ClusterTemplate.DatabaseConfiguration.Regions {
+		for _, dataCenter := range region.DataCenters {
+			if dataCenter.Satellite != 0 {
+				continue
+			}
+
+			if primary == "" || dataCenter.Priority > maxPriority {
+				primary = dataCenter.ID
+				maxPriority = dataCenter.Priority
+			}
+		}
+	}
+
+	return primary
+}
+
+// GetUsableRegions returns the desired number of usable regions. Defaults to 1.
+func (cluster *FoundationDBMultiRegionCluster) GetUsableRegions() int {
+	if cluster.Spec.ClusterTemplate.DatabaseConfiguration.UsableRegions < 1 {
+		return 1
+	}
+
+	return cluster.Spec.ClusterTemplate.DatabaseConfiguration.UsableRegions
+}
+
+// GetSeedDatabaseConfiguration returns the database configuration for the initial cluster in the seed data center.
+// The configuration only contains the region of the seed data center without any satellites, since the other data
+// centers don't have any processes at this point.
+func (cluster *FoundationDBMultiRegionCluster) GetSeedDatabaseConfiguration(seedDataCenter string) DatabaseConfiguration {
+	configuration := cluster.Spec.ClusterTemplate.DatabaseConfiguration.DeepCopy()
+	configuration.UsableRegions = 1
+	configuration.Regions = []Region{
+		{
+			DataCenters: []DataCenter{
+				{
+					ID:       seedDataCenter,
+					Priority: 1,
+				},
+			},
+		},
+	}
+
+	return *configuration
+}
+
+// GetDatabaseConfiguration returns the database configuration for all data centers with the provided primary data
+// center and number of usable regions. The primary data center will get the priority 1 and all other main data centers
+// will get the priority 0, the priorities of the satellites are taken from the cluster template.
+func (cluster *FoundationDBMultiRegionCluster) GetDatabaseConfiguration(primaryDataCenter string, usableRegions int) DatabaseConfiguration {
+	configuration := cluster.Spec.ClusterTemplate.DatabaseConfiguration.DeepCopy()
+	configuration.UsableRegions = usableRegions
+
+	for regionIndex, region := range configuration.Regions {
+		for dataCenterIndex, dataCenter := range region.DataCenters {
+			if dataCenter.Satellite != 0 {
+				continue
+			}
+
+			if dataCenter.ID == primaryDataCenter {
+				configuration.Regions[regionIndex].DataCenters[dataCenterIndex].Priority = 1
+				continue
+			}
+
+			configuration.Regions[regionIndex].DataCenters[dataCenterIndex].Priority = 0
+		}
+	}
+
+	return *configuration
+}
+
+// IsDataCenterReconciled returns true if the FoundationDBCluster of the provided data center is reconciled.
+func (cluster *FoundationDBMultiRegionCluster) IsDataCenterReconciled(dataCenterID string) bool {
+	for _, dataCenter := range cluster.Status.DataCenters {
+		if dataCenter.ID == dataCenterID {
+			return dataCenter.Reconciled
+		}
+	}
+
+	return false
+}
+
+// AllDataCentersReconciled returns true if the FoundationDBClusters of all data centers are reconciled.
+func (cluster *FoundationDBMultiRegionCluster) AllDataCentersReconciled() bool {
+	for _, dataCenterID := range cluster.GetDataCenterIDs() {
+		if !cluster.IsDataCenterReconciled(dataCenterID) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Validate checks if the regions of the cluster template can be managed by the operator.
+func (cluster *FoundationDBMultiRegionCluster) Validate() error {
+	var validations []string
+
+	regions := cluster.Spec.ClusterTemplate.DatabaseConfiguration.Regions
+	if len(regions) == 0 || len(regions) > 2 {
+		validations = append(validations, fmt.Sprintf("the cluster template must define 1 or 2 regions, but defines %d regions", len(regions)))
+	}
+
+	mainDataCenters := map[string]None{}
+	for regionIndex, region := range regions {
+		mainDataCenterCount := 0
+		for _, dataCenter := range region.DataCenters {
+			if dataCenter.ID == "" {
+				validations = append(validations, fmt.Sprintf("region %d contains a data center without an ID", regionIndex))
+			}
+
+			if dataCenter.Satellite != 0 {
+				continue
+			}
+
+			mainDataCenterCount++
+			if _, ok := mainDataCenters[dataCenter.ID]; ok {
+				validations = append(validations, fmt.Sprintf("data center %s is the main data center of multiple regions", dataCenter.ID))
+			}
+			mainDataCenters[dataCenter.ID] = None{}
+		}
+
+		if mainDataCenterCount != 1 {
+			validations = append(validations, fmt.Sprintf("region %d must contain exactly 1 main data center, but contains %d", regionIndex, mainDataCenterCount))
+		}
+	}
+
+	if _, ok := mainDataCenters[cluster.GetSpecPrimaryDataCenter()]; !ok && len(regions) > 0 {
+		validations = append(validations, fmt.Sprintf("primary data center %s is not a main data center of the regions", cluster.GetSpecPrimaryDataCenter()))
+	}
+
+	if cluster.GetUsableRegions() > len(regions) {
+		validations = append(validations, fmt.Sprintf("usable regions %d is greater than the number of regions %d", cluster.GetUsableRegions(), len(regions)))
+	}
+
+	if len(validations) == 0 {
+		return nil
+	}
+
+	return errors.New(strings.Join(validations, ", "))
+}
+
+func init() {
+	SchemeBuilder.Register(&FoundationDBMultiRegionCluster{}, &FoundationDBMultiRegionClusterList{})
+}
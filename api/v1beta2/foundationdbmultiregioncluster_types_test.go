@@ -0,0 +1,229 @@
+/*
+ * foundationdbmultiregioncluster_types_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta2
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("[api] FoundationDBMultiRegionCluster", func() {
+	var cluster *FoundationDBMultiRegionCluster
+
+	BeforeEach(func() {
+		cluster = &FoundationDBMultiRegionCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "default",
+			},
+			Spec: FoundationDBMultiRegionClusterSpec{
+				ClusterTemplate: FoundationDBClusterSpec{
+					Version: "7.1.26",
+					DatabaseConfiguration: DatabaseConfiguration{
+						UsableRegions: 2,
+						Regions: []Region{
+							{
+								DataCenters: []DataCenter{
+									{ID: "primary", Priority: 1},
+									{ID: "primary-sat", Priority: 2, Satellite: 1},
+									{ID: "remote-sat", Priority: 1, Satellite: 1},
+								},
+							},
+							{
+								DataCenters: []DataCenter{
+									{ID: "remote", Priority: 0},
+									{ID: "remote-sat", Priority: 2, Satellite: 1},
+									{ID: "primary-sat", Priority: 1, Satellite: 1},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	When("getting the data center IDs", func() {
+		It("should return every data center once", func() {
+			Expect(cluster.GetDataCenterIDs()).To(Equal([]string{"primary", "primary-sat", "remote-sat", "remote"}))
+		})
+	})
+
+	When("getting the data center cluster name", func() {
+		It("should combine the cluster name and the data center ID", func() {
+			Expect(cluster.GetDataCenterClusterName("remote")).To(Equal("test-remote"))
+		})
+	})
+
+	When("getting the primary data center", func() {
+		It("should default to the main data center with the highest priority", func() {
+			Expect(cluster.GetPrimaryDataCenter()).To(Equal("primary"))
+		})
+
+		When("the primary data center is defined", func() {
+			BeforeEach(func() {
+				cluster.Spec.PrimaryDataCenter = "remote"
+			})
+
+			It("should return the defined data center", func() {
+				Expect(cluster.GetPrimaryDataCenter()).To(Equal("remote"))
+			})
+		})
+
+		When("a failover selected a different primary data center", func() {
+			BeforeEach(func() {
+				cluster.Status.PrimaryDataCenterOverride = &PrimaryDataCenterOverride{
+					DataCenter:            "remote",
+					SpecPrimaryDataCenter: "primary",
+				}
+			})
+
+			It("should return the data center of the failover", func() {
+				Expect(cluster.GetPrimaryDataCenter()).To(Equal("remote"))
+				Expect(cluster.GetSpecPrimaryDataCenter()).To(Equal("primary"))
+			})
+
+			When("the primary data center in the spec was changed afterwards", func() {
+				BeforeEach(func() {
+					cluster.Status.PrimaryDataCenterOverride.SpecPrimaryDataCenter = "remote"
+				})
+
+				It("should return the data center of the spec", func() {
+					Expect(cluster.GetPrimaryDataCenter()).To(Equal("primary"))
+				})
+			})
+		})
+	})
+
+	When("getting the usable regions", func() {
+		It("should return the usable regions of the template", func() {
+			Expect(cluster.GetUsableRegions()).To(Equal(2))
+		})
+
+		When("the usable regions are not defined", func() {
+			BeforeEach(func() {
+				cluster.Spec.ClusterTemplate.DatabaseConfiguration.UsableRegions = 0
+			})
+
+			It("should default to 1", func() {
+				Expect(cluster.GetUsableRegions()).To(Equal(1))
+			})
+		})
+	})
+
+	When("getting the seed database configuration", func() {
+		It("should only contain the seed data center", func() {
+			configuration := cluster.GetSeedDatabaseConfiguration("primary")
+			Expect(configuration.UsableRegions).To(Equal(1))
+			Expect(configuration.Regions).To(Equal([]Region{
+				{
+					DataCenters: []DataCenter{
+						{ID: "primary", Priority: 1},
+					},
+				},
+			}))
+		})
+	})
+
+	When("getting the database configuration", func() {
+		It("should swap the priorities of the main data centers and keep the satellites", func() {
+			configuration := cluster.GetDatabaseConfiguration("remote", 2)
+			Expect(configuration.UsableRegions).To(Equal(2))
+			Expect(configuration.Regions[0].DataCenters).To(Equal([]DataCenter{
+				{ID: "primary", Priority: 0},
+				{ID: "primary-sat", Priority: 2, Satellite: 1},
+				{ID: "remote-sat", Priority: 1, Satellite: 1},
+			}))
+			Expect(configuration.Regions[1].DataCenters).To(Equal([]DataCenter{
+				{ID: "remote", Priority: 1},
+				{ID: "remote-sat", Priority: 2, Satellite: 1},
+				{ID: "primary-sat", Priority: 1, Satellite: 1},
+			}))
+		})
+
+		It("should not modify the cluster template", func() {
+			_ = cluster.GetDatabaseConfiguration("remote", 1)
+			Expect(cluster.Spec.ClusterTemplate.DatabaseConfiguration.Regions[0].DataCenters[0].Priority).To(Equal(1))
+			Expect(cluster.Spec.ClusterTemplate.DatabaseConfiguration.UsableRegions).To(Equal(2))
+		})
+	})
+
+	When("checking if the data centers are reconciled", func() {
+		BeforeEach(func() {
+			cluster.Status.DataCenters = []MultiRegionDataCenterStatus{
+				{ID: "primary", Reconciled: true},
+				{ID: "primary-sat", Reconciled: true},
+				{ID: "remote-sat", Reconciled: true},
+				{ID: "remote", Reconciled: false},
+			}
+		})
+
+		It("should return the state of the data center", func() {
+			Expect(cluster.IsDataCenterReconciled("primary")).To(BeTrue())
+			Expect(cluster.IsDataCenterReconciled("remote")).To(BeFalse())
+			Expect(cluster.IsDataCenterReconciled("unknown")).To(BeFalse())
+			Expect(cluster.AllDataCentersReconciled()).To(BeFalse())
+		})
+	})
+
+	DescribeTable("validating the multi-region cluster", func(modify func(*FoundationDBMultiRegionCluster), expected error) {
+		modify(cluster)
+		err := cluster.Validate()
+		if expected == nil {
+			Expect(err).NotTo(HaveOccurred())
+		} else {
+			Expect(err).To(Equal(expected))
+		}
+	},
+		Entry("valid multi-region cluster",
+			func(_ *FoundationDBMultiRegionCluster) {},
+			nil,
+		),
+		Entry("no regions",
+			func(cluster *FoundationDBMultiRegionCluster) {
+				cluster.Spec.ClusterTemplate.DatabaseConfiguration.Regions = nil
+				cluster.Spec.ClusterTemplate.DatabaseConfiguration.UsableRegions = 0
+			},
+			fmt.Errorf("the cluster template must define 1 or 2 regions, but defines 0 regions, usable regions 1 is greater than the number of regions 0"),
+		),
+		Entry("primary data center is a satellite",
+			func(cluster *FoundationDBMultiRegionCluster) {
+				cluster.Spec.PrimaryDataCenter = "primary-sat"
+			},
+			fmt.Errorf("primary data center primary-sat is not a main data center of the regions"),
+		),
+		Entry("region without a main data center",
+			func(cluster *FoundationDBMultiRegionCluster) {
+				cluster.Spec.ClusterTemplate.DatabaseConfiguration.Regions[1].DataCenters[0].Satellite = 1
+			},
+			fmt.Errorf("region 1 must contain exactly 1 main data center, but contains 0"),
+		),
+		Entry("too many usable regions",
+			func(cluster *FoundationDBMultiRegionCluster) {
+				cluster.Spec.ClusterTemplate.DatabaseConfiguration.Regions = cluster.Spec.ClusterTemplate.DatabaseConfiguration.Regions[:1]
+			},
+			fmt.Errorf("usable regions 2 is greater than the number of regions 1"),
+		),
+	)
+})
@@ -0,0 +1,125 @@
+/*
+ * foundationdbprocessgroup_types.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta2
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=fdbprocessgroup
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterName"
+// +kubebuilder:printcolumn:name="Class",type="string",JSONPath=".spec.processClass"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:storageversion
+
+// FoundationDBProcessGroup is the Schema for the foundationdbprocessgroups API. A FoundationDBProcessGroup
+// represents a single process group of a FoundationDBCluster and replaces the entry in the process group
+// list of the cluster status.
+type FoundationDBProcessGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FoundationDBProcessGroupSpec   `json:"spec,omitempty"`
+	Status FoundationDBProcessGroupStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// FoundationDBProcessGroupList contains a list of FoundationDBProcessGroup objects
+type FoundationDBProcessGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FoundationDBProcessGroup `json:"items"`
+}
+
+// FoundationDBProcessGroupSpec describes the identity of a process group.
+type FoundationDBProcessGroupSpec struct {
+	// ClusterName is the name of the FoundationDBCluster that this process group belongs to.
+	ClusterName string `json:"clusterName"`
+
+	// ProcessGroupID represents the ID of the process group.
+	ProcessGroupID ProcessGroupID `json:"processGroupID"`
+
+	// ProcessClass represents the class the process group has.
+	ProcessClass ProcessClass `json:"processClass"`
+}
+
+// FoundationDBProcessGroupStatus describes the current state of a process group.
+type FoundationDBProcessGroupStatus struct {
+	// Addresses represents the list of addresses the process group has been known to have.
+	Addresses []string `json:"addresses,omitempty"`
+
+	// RemovalTimestamp if not empty defines when the process group was marked for removal.
+	RemovalTimestamp *metav1.Time `json:"removalTimestamp,omitempty"`
+
+	// ExclusionTimestamp defines when the process group has been fully excluded.
+	// This is only used within the reconciliation process, and should not be considered authoritative.
+	ExclusionTimestamp *metav1.Time `json:"exclusionTimestamp,omitempty"`
+
+	// ExclusionSkipped determines if exclusion has been skipped for a process, which will allow the process group to be removed without exclusion.
+	ExclusionSkipped bool `json:"exclusionSkipped,omitempty"`
+
+	// ProcessGroupConditions represents a list of degraded conditions that the process group is in.
+	ProcessGroupConditions []*ProcessGroupCondition `json:"processGroupConditions,omitempty"`
+}
+
+// GetProcessGroupResourceName returns the name of the FoundationDBProcessGroup resource for the provided process group ID.
+func GetProcessGroupResourceName(clusterName string, processGroupID ProcessGroupID) string {
+	return fmt.Sprintf("%s-%s", clusterName, processGroupID)
+}
+
+// GetProcessGroupStatus returns the ProcessGroupStatus that is represented by this FoundationDBProcessGroup.
+func (processGroup *FoundationDBProcessGroup) GetProcessGroupStatus() *ProcessGroupStatus {
+	status := processGroup.Status.DeepCopy()
+
+	return &ProcessGroupStatus{
+		ProcessGroupID:         processGroup.Spec.ProcessGroupID,
+		ProcessClass:           processGroup.Spec.ProcessClass,
+		Addresses:              status.Addresses,
+		RemovalTimestamp:       status.RemovalTimestamp,
+		ExclusionTimestamp:     status.ExclusionTimestamp,
+		ExclusionSkipped:       status.ExclusionSkipped,
+		ProcessGroupConditions: status.ProcessGroupConditions,
+	}
+}
+
+// SetProcessGroupStatus updates the spec and status of this FoundationDBProcessGroup to match the provided ProcessGroupStatus.
+func (processGroup *FoundationDBProcessGroup) SetProcessGroupStatus(processGroupStatus *ProcessGroupStatus) {
+	status := processGroupStatus.DeepCopy()
+
+	processGroup.Spec.ProcessGroupID = status.ProcessGroupID
+	processGroup.Spec.ProcessClass = status.ProcessClass
+	processGroup.Status = FoundationDBProcessGroupStatus{
+		Addresses:              status.Addresses,
+		RemovalTimestamp:       status.RemovalTimestamp,
+		ExclusionTimestamp:     status.ExclusionTimestamp,
+		ExclusionSkipped:       status.ExclusionSkipped,
+		ProcessGroupConditions: status.ProcessGroupConditions,
+	}
+}
+
+func init() {
+	SchemeBuilder.Register(&FoundationDBProcessGroup{}, &FoundationDBProcessGroupList{})
+}
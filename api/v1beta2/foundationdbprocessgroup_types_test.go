@@ -0,0 +1,69 @@
+/*
+ * foundationdbprocessgroup_types_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package v1beta2
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("[api] FoundationDBProcessGroup", func() {
+	When("converting a process group status", func() {
+		var processGroupStatus *ProcessGroupStatus
+
+		BeforeEach(func() {
+			timestamp := metav1.Now()
+			processGroupStatus = &ProcessGroupStatus{
+				ProcessGroupID:     "storage-1",
+				ProcessClass:       ProcessClassStorage,
+				Addresses:          []string{"1.1.1.1"},
+				RemovalTimestamp:   &timestamp,
+				ExclusionTimestamp: &timestamp,
+				ExclusionSkipped:   true,
+				ProcessGroupConditions: []*ProcessGroupCondition{
+					NewProcessGroupCondition(MissingProcesses),
+				},
+			}
+		})
+
+		It("should return the same process group status", func() {
+			processGroup := &FoundationDBProcessGroup{}
+			processGroup.SetProcessGroupStatus(processGroupStatus)
+			Expect(processGroup.Spec.ProcessGroupID).To(Equal(ProcessGroupID("storage-1")))
+			Expect(processGroup.Spec.ProcessClass).To(Equal(ProcessClassStorage))
+			Expect(processGroup.GetProcessGroupStatus()).To(Equal(processGroupStatus))
+		})
+
+		It("should not share the conditions with the process group status", func() {
+			processGroup := &FoundationDBProcessGroup{}
+			processGroup.SetProcessGroupStatus(processGroupStatus)
+			processGroupStatus.ProcessGroupConditions[0].ProcessGroupConditionType = PodFailing
+			Expect(processGroup.Status.ProcessGroupConditions[0].ProcessGroupConditionType).To(Equal(MissingProcesses))
+		})
+	})
+
+	When("getting the resource name", func() {
+		It("should combine the cluster name and the process group ID", func() {
+			Expect(GetProcessGroupResourceName("test", "storage-1")).To(Equal("test-storage-1"))
+		})
+	})
+})
@@ -17,12 +17,16 @@ limitations under the License.
 package v1beta2
 
 import (
+	"fmt"
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:shortName=fdbrestore
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:storageversion
 
@@ -56,15 +60,154 @@ type FoundationDBRestoreSpec struct {
 	// This is the configuration of the target blobstore for this backup.
 	BlobStoreConfiguration *BlobStoreConfiguration `json:"blobStoreConfiguration,omitempty"`
 
+	// FileSystemConfiguration defines a file system source for this
+	// restore, e.g. a shared persistent volume. The backup name must point
+	// to the backup-<timestamp> directory created by the backup. This field
+	// is mutually exclusive with BlobStoreConfiguration. File system sources
+	// are currently rejected, as the operator can't access the persistent
+	// volume.
+	FileSystemConfiguration *FileSystemConfiguration `json:"fileSystemConfiguration,omitempty"`
+
 	// CustomParameters defines additional parameters to pass to the backup
 	// agents.
 	CustomParameters FoundationDBCustomParameters `json:"customParameters,omitempty"`
+
+	// TargetVersion defines the version the backup should be restored to.
+	// If neither TargetVersion nor TargetTimestamp is defined, the backup
+	// will be restored to the latest restorable version.
+	// +kubebuilder:validation:Minimum=0
+	TargetVersion *int64 `json:"targetVersion,omitempty"`
+
+	// TargetTimestamp defines the point in time the backup should be
+	// restored to, in the format YYYY/MM/DD.HH:MI:SS+hhmm, e.g.
+	// 2023/01/02.15:04:05+0000. This field is mutually exclusive with
+	// TargetVersion and requires SourceClusterName.
+	// +kubebuilder:validation:Pattern:=`^[0-9]{4}/[0-9]{2}/[0-9]{2}\.[0-9]{2}:[0-9]{2}:[0-9]{2}[+-][0-9]{4}$`
+	TargetTimestamp *string `json:"targetTimestamp,omitempty"`
+
+	// SourceClusterName defines the name of the FoundationDBCluster the
+	// backup was taken from. The source cluster is used to convert the
+	// TargetTimestamp into a version, if the source cluster is not
+	// available anymore the restore must define a TargetVersion instead.
+	// +kubebuilder:validation:MaxLength=100
+	SourceClusterName string `json:"sourceClusterName,omitempty"`
 }
 
 // FoundationDBRestoreStatus describes the current status of the restore for a cluster.
 type FoundationDBRestoreStatus struct {
 	// Running describes whether the restore is currently running.
 	Running bool `json:"running,omitempty"`
+
+	// Phase provides the current phase of the restore.
+	Phase RestorePhase `json:"phase,omitempty"`
+
+	// Progress provides information about the progress of the restore, as
+	// reported by the restore status command.
+	Progress *FoundationDBRestoreProgress `json:"progress,omitempty"`
+
+	// TargetVersion provides the version the backup is restored to, as
+	// reported by the restore status command.
+	TargetVersion int64 `json:"targetVersion,omitempty"`
+
+	// Errors provides the errors that occurred while validating or running
+	// the restore.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// RestorePhase describes the phase of a restore.
+// +kubebuilder:validation:MaxLength=100
+type RestorePhase string
+
+const (
+	// RestorePhaseRunning indicates that the restore was started and is
+	// still running.
+	RestorePhaseRunning RestorePhase = "Running"
+
+	// RestorePhaseCompleted indicates that the restore is completed.
+	RestorePhaseCompleted RestorePhase = "Completed"
+
+	// RestorePhaseFailed indicates that the restore could not be started or
+	// that it was aborted.
+	RestorePhaseFailed RestorePhase = "Failed"
+)
+
+// FoundationDBRestoreProgress provides information about the progress of a
+// restore.
+type FoundationDBRestoreProgress struct {
+	// BlocksCompleted provides the number of blocks that are restored.
+	BlocksCompleted int64 `json:"blocksCompleted,omitempty"`
+
+	// BlocksTotal provides the total number of blocks to restore.
+	BlocksTotal int64 `json:"blocksTotal,omitempty"`
+
+	// BlocksInProgress provides the number of blocks that are currently
+	// restored.
+	BlocksInProgress int64 `json:"blocksInProgress,omitempty"`
+
+	// BytesWritten provides the number of bytes written to the cluster.
+	BytesWritten int64 `json:"bytesWritten,omitempty"`
+
+	// ApplyVersionLag provides the lag of the mutation log that is applied
+	// to the cluster.
+	ApplyVersionLag int64 `json:"applyVersionLag,omitempty"`
+}
+
+// FoundationDBLiveRestoreStatus describes the current status of a restore as
+// reported by the restore status command.
+type FoundationDBLiveRestoreStatus struct {
+	// Tag provides the tag of the restore.
+	Tag string `json:"tag,omitempty"`
+
+	// UID provides the unique ID of the restore.
+	UID string `json:"uid,omitempty"`
+
+	// State provides the state of the restore, e.g. running or completed.
+	// If no restore was started, the state is empty.
+	State string `json:"state,omitempty"`
+
+	// BlocksCompleted provides the number of blocks that are restored.
+	BlocksCompleted int64 `json:"blocksCompleted,omitempty"`
+
+	// BlocksTotal provides the total number of blocks to restore.
+	BlocksTotal int64 `json:"blocksTotal,omitempty"`
+
+	// BlocksInProgress provides the number of blocks that are currently
+	// restored.
+	BlocksInProgress int64 `json:"blocksInProgress,omitempty"`
+
+	// Files provides the number of files of the backup.
+	Files int64 `json:"files,omitempty"`
+
+	// BytesWritten provides the number of bytes written to the cluster.
+	BytesWritten int64 `json:"bytesWritten,omitempty"`
+
+	// ApplyVersionLag provides the lag of the mutation log that is applied
+	// to the cluster.
+	ApplyVersionLag int64 `json:"applyVersionLag,omitempty"`
+
+	// LastError provides the last error of the restore.
+	LastError string `json:"lastError,omitempty"`
+
+	// URL provides the URL of the backup that is restored.
+	URL string `json:"url,omitempty"`
+
+	// TargetVersion provides the version the backup is restored to.
+	TargetVersion int64 `json:"targetVersion,omitempty"`
+}
+
+// GetRestorePhase returns the phase of the restore based on the state
+// reported by the restore status command.
+func (status *FoundationDBLiveRestoreStatus) GetRestorePhase() RestorePhase {
+	switch status.State {
+	case "":
+		return ""
+	case "completed":
+		return RestorePhaseCompleted
+	case "aborted":
+		return RestorePhaseFailed
+	default:
+		return RestorePhaseRunning
+	}
 }
 
 // FoundationDBKeyRange describes a range of keys for a command.
@@ -85,6 +228,14 @@ type FoundationDBKeyRange struct {
 // BackupName gets the name of the backup for the source backup.
 // This will fill in a default value if the backup name in the spec is empty.
 func (restore *FoundationDBRestore) BackupName() string {
+	if restore.Spec.FileSystemConfiguration != nil {
+		if restore.Spec.FileSystemConfiguration.BackupName == "" {
+			return restore.ObjectMeta.Name
+		}
+
+		return restore.Spec.FileSystemConfiguration.BackupName
+	}
+
 	if restore.Spec.BlobStoreConfiguration == nil || restore.Spec.BlobStoreConfiguration.BackupName == "" {
 		return restore.ObjectMeta.Name
 	}
@@ -94,9 +245,82 @@ func (restore *FoundationDBRestore) BackupName() string {
 
 // BackupURL gets the destination url of the backup.
 func (restore *FoundationDBRestore) BackupURL() string {
+	if restore.Spec.FileSystemConfiguration != nil {
+		return restore.Spec.FileSystemConfiguration.getURL(restore.BackupName())
+	}
+
+	if restore.Spec.BlobStoreConfiguration == nil {
+		return ""
+	}
+
 	return restore.Spec.BlobStoreConfiguration.getURL(restore.BackupName(), restore.Spec.BlobStoreConfiguration.BucketName())
 }
 
+// ValidateDestination checks that exactly one supported source is defined
+// for the restore.
+func (restore *FoundationDBRestore) ValidateDestination() error {
+	return validateDestination(restore.Spec.BlobStoreConfiguration, restore.Spec.FileSystemConfiguration)
+}
+
+// restoreTimestampLayout defines the layout of the timestamps used by the
+// backup and restore commands.
+const restoreTimestampLayout = "2006/01/02.15:04:05-0700"
+
+// ValidateTarget checks that the target version or target timestamp of the
+// restore is part of the restorable range of the provided backup
+// description.
+func (restore *FoundationDBRestore) ValidateTarget(description *FoundationDBBackupDescription) error {
+	if restore.Spec.TargetVersion == nil && restore.Spec.TargetTimestamp == nil {
+		return nil
+	}
+
+	if restore.Spec.TargetVersion != nil && restore.Spec.TargetTimestamp != nil {
+		return fmt.Errorf("only one of targetVersion and targetTimestamp can be defined")
+	}
+
+	if description.MinRestorableVersion == nil || description.MaxRestorableVersion == nil {
+		return fmt.Errorf("backup %s has no restorable versions", restore.BackupURL())
+	}
+
+	minVersion := description.MinRestorableVersion
+	maxVersion := description.MaxRestorableVersion
+
+	if restore.Spec.TargetVersion != nil {
+		targetVersion := *restore.Spec.TargetVersion
+		if targetVersion < minVersion.Version || targetVersion > maxVersion.Version {
+			return fmt.Errorf("target version %d is not in the restorable range from %d to %d", targetVersion, minVersion.Version, maxVersion.Version)
+		}
+
+		return nil
+	}
+
+	targetTimestamp, err := time.Parse(restoreTimestampLayout, *restore.Spec.TargetTimestamp)
+	if err != nil {
+		return fmt.Errorf("could not parse target timestamp %s: %w", *restore.Spec.TargetTimestamp, err)
+	}
+
+	minTimestamp, err := time.Parse(restoreTimestampLayout, minVersion.Timestamp)
+	if err != nil {
+		return fmt.Errorf("could not parse minimum restorable timestamp %s: %w", minVersion.Timestamp, err)
+	}
+
+	maxTimestamp, err := time.Parse(restoreTimestampLayout, maxVersion.Timestamp)
+	if err != nil {
+		return fmt.Errorf("could not parse maximum restorable timestamp %s: %w", maxVersion.Timestamp, err)
+	}
+
+	if targetTimestamp.Before(minTimestamp) || targetTimestamp.After(maxTimestamp) {
+		return fmt.Errorf("target timestamp %s is not in the restorable range from %s to %s", *restore.Spec.TargetTimestamp, minVersion.Timestamp, maxVersion.Timestamp)
+	}
+
+	// fdbrestore converts the timestamp into a version with the data of the cluster the backup was taken from.
+	if restore.Spec.SourceClusterName == "" {
+		return fmt.Errorf("sourceClusterName must be defined to restore to a target timestamp")
+	}
+
+	return nil
+}
+
 func init() {
 	SchemeBuilder.Register(&FoundationDBRestore{}, &FoundationDBRestoreList{})
 }
@@ -24,6 +24,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
 var _ = Describe("[api] FoundationDBRestore", func() {
@@ -118,4 +119,97 @@ var _ = Describe("[api] FoundationDBRestore", func() {
 				"blobstore://account@account/mybackup?bucket=fdb-backups&secure_connection=0"),
 		)
 	})
+
+	When("using a file system source", func() {
+		It("should generate a file URL below the mount path", func() {
+			restore := &FoundationDBRestore{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mybackup",
+				},
+				Spec: FoundationDBRestoreSpec{
+					FileSystemConfiguration: &FileSystemConfiguration{
+						BackupName:                "mybackup/backup-2023-01-02-15-04-05.123456",
+						PersistentVolumeClaimName: "backup-pvc",
+					},
+				},
+			}
+
+			Expect(restore.BackupURL()).To(Equal("file:///var/fdb/backups/mybackup/backup-2023-01-02-15-04-05.123456"))
+			Expect(restore.ValidateDestination()).To(MatchError("fileSystemConfiguration is not supported, as the operator can't access the persistent volume"))
+		})
+	})
+
+	When("validating the restore target", func() {
+		var restore *FoundationDBRestore
+		var description *FoundationDBBackupDescription
+
+		BeforeEach(func() {
+			restore = &FoundationDBRestore{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mybackup",
+				},
+				Spec: FoundationDBRestoreSpec{
+					BlobStoreConfiguration: &BlobStoreConfiguration{
+						AccountName: "account@account",
+					},
+				},
+			}
+			description = &FoundationDBBackupDescription{
+				MinRestorableVersion: &FoundationDBBackupDescriptionVersion{Version: 100, Timestamp: "2023/01/01.00:00:00+0000"},
+				MaxRestorableVersion: &FoundationDBBackupDescriptionVersion{Version: 500, Timestamp: "2023/01/05.00:00:00+0000"},
+			}
+		})
+
+		It("should accept a restore without a target", func() {
+			Expect(restore.ValidateTarget(description)).To(Succeed())
+		})
+
+		It("should accept a target version in the restorable range", func() {
+			restore.Spec.TargetVersion = pointer.Int64(500)
+			Expect(restore.ValidateTarget(description)).To(Succeed())
+		})
+
+		It("should reject a target version outside of the restorable range", func() {
+			restore.Spec.TargetVersion = pointer.Int64(99)
+			Expect(restore.ValidateTarget(description)).To(MatchError("target version 99 is not in the restorable range from 100 to 500"))
+		})
+
+		It("should accept a target timestamp in the restorable range with a different time zone", func() {
+			restore.Spec.TargetTimestamp = pointer.String("2023/01/04.20:00:00-0200")
+			restore.Spec.SourceClusterName = "source"
+			Expect(restore.ValidateTarget(description)).To(Succeed())
+		})
+
+		It("should reject a target timestamp without a source cluster", func() {
+			restore.Spec.TargetTimestamp = pointer.String("2023/01/04.20:00:00-0200")
+			Expect(restore.ValidateTarget(description)).To(MatchError("sourceClusterName must be defined to restore to a target timestamp"))
+		})
+
+		It("should reject a target timestamp outside of the restorable range", func() {
+			restore.Spec.TargetTimestamp = pointer.String("2023/01/05.00:00:01+0000")
+			Expect(restore.ValidateTarget(description)).To(MatchError("target timestamp 2023/01/05.00:00:01+0000 is not in the restorable range from 2023/01/01.00:00:00+0000 to 2023/01/05.00:00:00+0000"))
+		})
+
+		It("should reject a restore with a target version and a target timestamp", func() {
+			restore.Spec.TargetVersion = pointer.Int64(200)
+			restore.Spec.TargetTimestamp = pointer.String("2023/01/03.00:00:00+0000")
+			Expect(restore.ValidateTarget(description)).To(MatchError("only one of targetVersion and targetTimestamp can be defined"))
+		})
+
+		It("should reject a target if the backup is not restorable", func() {
+			restore.Spec.TargetVersion = pointer.Int64(200)
+			Expect(restore.ValidateTarget(&FoundationDBBackupDescription{})).To(MatchError("backup blobstore://account@account/mybackup?bucket=fdb-backups has no restorable versions"))
+		})
+	})
+
+	DescribeTable("getting the restore phase", func(state string, expected RestorePhase) {
+		status := &FoundationDBLiveRestoreStatus{State: state}
+		Expect(status.GetRestorePhase()).To(Equal(expected))
+	},
+		Entry("no restore", "", RestorePhase("")),
+		Entry("queued restore", "queued", RestorePhaseRunning),
+		Entry("running restore", "running", RestorePhaseRunning),
+		Entry("completed restore", "completed", RestorePhaseCompleted),
+		Entry("aborted restore", "aborted", RestorePhaseFailed),
+	)
 })
@@ -16,7 +16,10 @@ limitations under the License.
 
 package v1beta2
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // ImageConfig provides a policy for customizing an image.
 //
@@ -74,3 +77,57 @@ func (config ImageConfig) Image() string {
 	}
 	return fmt.Sprintf("%s:%s", config.BaseImage, config.Tag)
 }
+
+// ValidateImageTag checks if the image contains a tag or a digest. Images with a tag or a digest are only allowed if
+// allowTagOverride is true, otherwise the tag must be defined in the image configs.
+func ValidateImageTag(image string, allowTagOverride bool) error {
+	if allowTagOverride {
+		return nil
+	}
+
+	digest := GetImageDigest(image)
+	if digest != "" {
+		return fmt.Errorf("image should not contain a digest but contains the digest \"%s\", please remove the digest", digest)
+	}
+
+	tag := GetImageTag(image)
+	if tag != "" {
+		return fmt.Errorf("image should not contain a tag but contains the tag \"%s\", please remove the tag", tag)
+	}
+
+	return nil
+}
+
+// GetImageTag returns the tag of the image or an empty string if the image contains no tag. Only the last path
+// component of the image is checked, as the registry host of the image can contain a port. A digest of the image is
+// not part of the tag.
+func GetImageTag(image string) string {
+	name := getImageName(image)
+	idx := strings.Index(name, ":")
+	if idx < 0 {
+		return ""
+	}
+
+	return name[idx+1:]
+}
+
+// GetImageDigest returns the digest of the image, e.g. "sha256:abc", or an empty string if the image contains no
+// digest.
+func GetImageDigest(image string) string {
+	idx := strings.Index(image, "@")
+	if idx < 0 {
+		return ""
+	}
+
+	return image[idx+1:]
+}
+
+// getImageName returns the last path component of the image without the digest.
+func getImageName(image string) string {
+	idx := strings.Index(image, "@")
+	if idx >= 0 {
+		image = image[:idx]
+	}
+
+	return image[strings.LastIndex(image, "/")+1:]
+}
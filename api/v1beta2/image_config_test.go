@@ -78,4 +78,45 @@ var _ = Describe("[api] ImageConfig", func() {
 			Expect(image).To(Equal("foundationdb/foundationdb-kubernetes-sidecar:abcdef"))
 		})
 	})
+
+	DescribeTable("getting the image tag",
+		func(image string, expected string) {
+			Expect(GetImageTag(image)).To(Equal(expected))
+		},
+		Entry("image without a tag", "foundationdb/foundationdb", ""),
+		Entry("image with a tag", "foundationdb/foundationdb:7.1.26", "7.1.26"),
+		Entry("image from a registry with a port", "registry:5000/foundationdb/foundationdb", ""),
+		Entry("image with a tag from a registry with a port", "registry:5000/foundationdb/foundationdb:7.1.26", "7.1.26"),
+		Entry("image with a digest", "foundationdb/foundationdb@sha256:abc", ""),
+		Entry("image with a tag and a digest", "foundationdb/foundationdb:7.1.26@sha256:abc", "7.1.26"),
+		Entry("image with a digest from a registry with a port", "registry:5000/foundationdb/foundationdb@sha256:abc", ""),
+	)
+
+	DescribeTable("getting the image digest",
+		func(image string, expected string) {
+			Expect(GetImageDigest(image)).To(Equal(expected))
+		},
+		Entry("image without a digest", "foundationdb/foundationdb:7.1.26", ""),
+		Entry("image with a digest", "foundationdb/foundationdb@sha256:abc", "sha256:abc"),
+		Entry("image with a tag and a digest", "foundationdb/foundationdb:7.1.26@sha256:abc", "sha256:abc"),
+		Entry("image with a digest from a registry with a port", "registry:5000/foundationdb/foundationdb@sha256:abc", "sha256:abc"),
+	)
+
+	DescribeTable("validating the image tag",
+		func(image string, allowTagOverride bool, expected string) {
+			err := ValidateImageTag(image, allowTagOverride)
+			if expected == "" {
+				Expect(err).NotTo(HaveOccurred())
+				return
+			}
+
+			Expect(err).To(MatchError(expected))
+		},
+		Entry("image without a tag", "foundationdb/foundationdb", false, ""),
+		Entry("image from a registry with a port", "registry:5000/foundationdb/foundationdb", false, ""),
+		Entry("image with a tag", "foundationdb/foundationdb:7.1.26", false, "image should not contain a tag but contains the tag \"7.1.26\", please remove the tag"),
+		Entry("image with a digest", "foundationdb/foundationdb@sha256:abc", false, "image should not contain a digest but contains the digest \"sha256:abc\", please remove the digest"),
+		Entry("image with a tag and allowTagOverride", "foundationdb/foundationdb:7.1.26", true, ""),
+		Entry("image with a digest and allowTagOverride", "foundationdb/foundationdb@sha256:abc", true, ""),
+	)
 })
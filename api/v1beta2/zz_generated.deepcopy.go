@@ -46,6 +46,11 @@ func (in *AutomaticReplacementOptions) DeepCopyInto(out *AutomaticReplacementOpt
 		*out = new(int)
 		**out = **in
 	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]TaintReplacementOption, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutomaticReplacementOptions.
@@ -58,6 +63,41 @@ func (in *AutomaticReplacementOptions) DeepCopy() *AutomaticReplacementOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalerSignals) DeepCopyInto(out *AutoscalerSignals) {
+	*out = *in
+	if in.MaxCommitLatencyMilliseconds != nil {
+		in, out := &in.MaxCommitLatencyMilliseconds, &out.MaxCommitLatencyMilliseconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxLogQueueBytes != nil {
+		in, out := &in.MaxLogQueueBytes, &out.MaxLogQueueBytes
+		*out = new(int)
+		**out = **in
+	}
+	if in.LimitingReasons != nil {
+		in, out := &in.LimitingReasons, &out.LimitingReasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScaleDownThresholdPercentage != nil {
+		in, out := &in.ScaleDownThresholdPercentage, &out.ScaleDownThresholdPercentage
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalerSignals.
+func (in *AutoscalerSignals) DeepCopy() *AutoscalerSignals {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalerSignals)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupGenerationStatus) DeepCopyInto(out *BackupGenerationStatus) {
 	*out = *in
@@ -73,6 +113,80 @@ func (in *BackupGenerationStatus) DeepCopy() *BackupGenerationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRestorablePoint) DeepCopyInto(out *BackupRestorablePoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRestorablePoint.
+func (in *BackupRestorablePoint) DeepCopy() *BackupRestorablePoint {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRestorablePoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRetentionPolicy) DeepCopyInto(out *BackupRetentionPolicy) {
+	*out = *in
+	if in.RestorablePoints != nil {
+		in, out := &in.RestorablePoints, &out.RestorablePoints
+		*out = new(int)
+		**out = **in
+	}
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.DeleteWhenStopped != nil {
+		in, out := &in.DeleteWhenStopped, &out.DeleteWhenStopped
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRetentionPolicy.
+func (in *BackupRetentionPolicy) DeepCopy() *BackupRetentionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRetentionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupRetentionStatus) DeepCopyInto(out *BackupRetentionStatus) {
+	*out = *in
+	if in.RestorablePoints != nil {
+		in, out := &in.RestorablePoints, &out.RestorablePoints
+		*out = make([]BackupRestorablePoint, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxRestorableVersion != nil {
+		in, out := &in.MaxRestorableVersion, &out.MaxRestorableVersion
+		*out = new(BackupRestorablePoint)
+		**out = **in
+	}
+	if in.LastCheckTimestamp != nil {
+		in, out := &in.LastCheckTimestamp, &out.LastCheckTimestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRetentionStatus.
+func (in *BackupRetentionStatus) DeepCopy() *BackupRetentionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupRetentionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BlobStoreConfiguration) DeepCopyInto(out *BlobStoreConfiguration) {
 	*out = *in
@@ -198,6 +312,11 @@ func (in *ContainerOverrides) DeepCopyInto(out *ContainerOverrides) {
 		*out = make([]ImageConfig, len(*in))
 		copy(*out, *in)
 	}
+	if in.APITokenSecretName != nil {
+		in, out := &in.APITokenSecretName, &out.APITokenSecretName
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerOverrides.
@@ -289,6 +408,21 @@ func (in *DatabaseConfiguration) DeepCopy() *DatabaseConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisasterRecoveryGenerationStatus) DeepCopyInto(out *DisasterRecoveryGenerationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisasterRecoveryGenerationStatus.
+func (in *DisasterRecoveryGenerationStatus) DeepCopy() *DisasterRecoveryGenerationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DisasterRecoveryGenerationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExcludedServers) DeepCopyInto(out *ExcludedServers) {
 	*out = *in
@@ -304,6 +438,56 @@ func (in *ExcludedServers) DeepCopy() *ExcludedServers {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExclusionBacklog) DeepCopyInto(out *ExclusionBacklog) {
+	*out = *in
+	if in.ProcessGroups != nil {
+		in, out := &in.ProcessGroups, &out.ProcessGroups
+		*out = make([]ProcessGroupID, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExclusionBacklog.
+func (in *ExclusionBacklog) DeepCopy() *ExclusionBacklog {
+	if in == nil {
+		return nil
+	}
+	out := new(ExclusionBacklog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExclusionBudget) DeepCopyInto(out *ExclusionBudget) {
+	*out = *in
+	if in.MaxExcludedStorageProcesses != nil {
+		in, out := &in.MaxExcludedStorageProcesses, &out.MaxExcludedStorageProcesses
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxInFlightBytes != nil {
+		in, out := &in.MaxInFlightBytes, &out.MaxInFlightBytes
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxInQueueBytes != nil {
+		in, out := &in.MaxInQueueBytes, &out.MaxInQueueBytes
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExclusionBudget.
+func (in *ExclusionBudget) DeepCopy() *ExclusionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(ExclusionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FaultTolerance) DeepCopyInto(out *FaultTolerance) {
 	*out = *in
@@ -320,7 +504,22 @@ func (in *FaultTolerance) DeepCopy() *FaultTolerance {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FoundationDBBackup) DeepCopyInto(out *FoundationDBBackup) {
+func (in *FileSystemConfiguration) DeepCopyInto(out *FileSystemConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileSystemConfiguration.
+func (in *FileSystemConfiguration) DeepCopy() *FileSystemConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(FileSystemConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBAutoscaler) DeepCopyInto(out *FoundationDBAutoscaler) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -328,18 +527,18 @@ func (in *FoundationDBBackup) DeepCopyInto(out *FoundationDBBackup) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackup.
-func (in *FoundationDBBackup) DeepCopy() *FoundationDBBackup {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBAutoscaler.
+func (in *FoundationDBAutoscaler) DeepCopy() *FoundationDBAutoscaler {
 	if in == nil {
 		return nil
 	}
-	out := new(FoundationDBBackup)
+	out := new(FoundationDBAutoscaler)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *FoundationDBBackup) DeepCopyObject() runtime.Object {
+func (in *FoundationDBAutoscaler) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -347,31 +546,31 @@ func (in *FoundationDBBackup) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FoundationDBBackupList) DeepCopyInto(out *FoundationDBBackupList) {
+func (in *FoundationDBAutoscalerList) DeepCopyInto(out *FoundationDBAutoscalerList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]FoundationDBBackup, len(*in))
+		*out = make([]FoundationDBAutoscaler, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackupList.
-func (in *FoundationDBBackupList) DeepCopy() *FoundationDBBackupList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBAutoscalerList.
+func (in *FoundationDBAutoscalerList) DeepCopy() *FoundationDBAutoscalerList {
 	if in == nil {
 		return nil
 	}
-	out := new(FoundationDBBackupList)
+	out := new(FoundationDBAutoscalerList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *FoundationDBBackupList) DeepCopyObject() runtime.Object {
+func (in *FoundationDBAutoscalerList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -379,95 +578,71 @@ func (in *FoundationDBBackupList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FoundationDBBackupSpec) DeepCopyInto(out *FoundationDBBackupSpec) {
+func (in *FoundationDBAutoscalerSpec) DeepCopyInto(out *FoundationDBAutoscalerSpec) {
 	*out = *in
-	if in.AgentCount != nil {
-		in, out := &in.AgentCount, &out.AgentCount
-		*out = new(int)
-		**out = **in
-	}
-	if in.SnapshotPeriodSeconds != nil {
-		in, out := &in.SnapshotPeriodSeconds, &out.SnapshotPeriodSeconds
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
 		*out = new(int)
 		**out = **in
 	}
-	if in.BackupDeploymentMetadata != nil {
-		in, out := &in.BackupDeploymentMetadata, &out.BackupDeploymentMetadata
-		*out = new(v1.ObjectMeta)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.PodTemplateSpec != nil {
-		in, out := &in.PodTemplateSpec, &out.PodTemplateSpec
-		*out = new(corev1.PodTemplateSpec)
+	if in.Signals != nil {
+		in, out := &in.Signals, &out.Signals
+		*out = new(AutoscalerSignals)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.CustomParameters != nil {
-		in, out := &in.CustomParameters, &out.CustomParameters
-		*out = make(FoundationDBCustomParameters, len(*in))
-		copy(*out, *in)
+	if in.StepSize != nil {
+		in, out := &in.StepSize, &out.StepSize
+		*out = new(int)
+		**out = **in
 	}
-	if in.AllowTagOverride != nil {
-		in, out := &in.AllowTagOverride, &out.AllowTagOverride
-		*out = new(bool)
+	if in.ScaleUpCooldownSeconds != nil {
+		in, out := &in.ScaleUpCooldownSeconds, &out.ScaleUpCooldownSeconds
+		*out = new(int)
 		**out = **in
 	}
-	if in.BlobStoreConfiguration != nil {
-		in, out := &in.BlobStoreConfiguration, &out.BlobStoreConfiguration
-		*out = new(BlobStoreConfiguration)
-		(*in).DeepCopyInto(*out)
+	if in.ScaleDownCooldownSeconds != nil {
+		in, out := &in.ScaleDownCooldownSeconds, &out.ScaleDownCooldownSeconds
+		*out = new(int)
+		**out = **in
 	}
-	in.MainContainer.DeepCopyInto(&out.MainContainer)
-	in.SidecarContainer.DeepCopyInto(&out.SidecarContainer)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackupSpec.
-func (in *FoundationDBBackupSpec) DeepCopy() *FoundationDBBackupSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBAutoscalerSpec.
+func (in *FoundationDBAutoscalerSpec) DeepCopy() *FoundationDBAutoscalerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FoundationDBBackupSpec)
+	out := new(FoundationDBAutoscalerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FoundationDBBackupStatus) DeepCopyInto(out *FoundationDBBackupStatus) {
+func (in *FoundationDBAutoscalerStatus) DeepCopyInto(out *FoundationDBAutoscalerStatus) {
 	*out = *in
-	if in.BackupDetails != nil {
-		in, out := &in.BackupDetails, &out.BackupDetails
-		*out = new(FoundationDBBackupStatusBackupDetails)
+	if in.RecommendedReplicas != nil {
+		in, out := &in.RecommendedReplicas, &out.RecommendedReplicas
+		*out = new(int)
 		**out = **in
 	}
-	out.Generations = in.Generations
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackupStatus.
-func (in *FoundationDBBackupStatus) DeepCopy() *FoundationDBBackupStatus {
-	if in == nil {
-		return nil
+	if in.LastScaleTime != nil {
+		in, out := &in.LastScaleTime, &out.LastScaleTime
+		*out = (*in).DeepCopy()
 	}
-	out := new(FoundationDBBackupStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FoundationDBBackupStatusBackupDetails) DeepCopyInto(out *FoundationDBBackupStatusBackupDetails) {
-	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackupStatusBackupDetails.
-func (in *FoundationDBBackupStatusBackupDetails) DeepCopy() *FoundationDBBackupStatusBackupDetails {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBAutoscalerStatus.
+func (in *FoundationDBAutoscalerStatus) DeepCopy() *FoundationDBAutoscalerStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(FoundationDBBackupStatusBackupDetails)
+	out := new(FoundationDBAutoscalerStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FoundationDBCluster) DeepCopyInto(out *FoundationDBCluster) {
+func (in *FoundationDBBackup) DeepCopyInto(out *FoundationDBBackup) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -475,18 +650,18 @@ func (in *FoundationDBCluster) DeepCopyInto(out *FoundationDBCluster) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBCluster.
-func (in *FoundationDBCluster) DeepCopy() *FoundationDBCluster {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackup.
+func (in *FoundationDBBackup) DeepCopy() *FoundationDBBackup {
 	if in == nil {
 		return nil
 	}
-	out := new(FoundationDBCluster)
+	out := new(FoundationDBBackup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *FoundationDBCluster) DeepCopyObject() runtime.Object {
+func (in *FoundationDBBackup) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -494,38 +669,262 @@ func (in *FoundationDBCluster) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FoundationDBClusterAutomationOptions) DeepCopyInto(out *FoundationDBClusterAutomationOptions) {
+func (in *FoundationDBBackupDescription) DeepCopyInto(out *FoundationDBBackupDescription) {
 	*out = *in
-	if in.ConfigureDatabase != nil {
-		in, out := &in.ConfigureDatabase, &out.ConfigureDatabase
-		*out = new(bool)
-		**out = **in
-	}
-	if in.KillProcesses != nil {
-		in, out := &in.KillProcesses, &out.KillProcesses
-		*out = new(bool)
-		**out = **in
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = make([]FoundationDBBackupDescriptionSnapshot, len(*in))
+		copy(*out, *in)
 	}
-	in.Replacements.DeepCopyInto(&out.Replacements)
-	if in.UseNonBlockingExcludes != nil {
-		in, out := &in.UseNonBlockingExcludes, &out.UseNonBlockingExcludes
-		*out = new(bool)
+	if in.MinRestorableVersion != nil {
+		in, out := &in.MinRestorableVersion, &out.MinRestorableVersion
+		*out = new(FoundationDBBackupDescriptionVersion)
 		**out = **in
 	}
-	if in.UseLocalitiesForExclusion != nil {
-		in, out := &in.UseLocalitiesForExclusion, &out.UseLocalitiesForExclusion
-		*out = new(bool)
+	if in.MaxRestorableVersion != nil {
+		in, out := &in.MaxRestorableVersion, &out.MaxRestorableVersion
+		*out = new(FoundationDBBackupDescriptionVersion)
 		**out = **in
 	}
-	if in.IgnoreTerminatingPodsSeconds != nil {
-		in, out := &in.IgnoreTerminatingPodsSeconds, &out.IgnoreTerminatingPodsSeconds
-		*out = new(int)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackupDescription.
+func (in *FoundationDBBackupDescription) DeepCopy() *FoundationDBBackupDescription {
+	if in == nil {
+		return nil
 	}
-	if in.IgnoreMissingProcessesSeconds != nil {
-		in, out := &in.IgnoreMissingProcessesSeconds, &out.IgnoreMissingProcessesSeconds
-		*out = new(int)
-		**out = **in
+	out := new(FoundationDBBackupDescription)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBBackupDescriptionSnapshot) DeepCopyInto(out *FoundationDBBackupDescriptionSnapshot) {
+	*out = *in
+	out.Start = in.Start
+	out.End = in.End
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackupDescriptionSnapshot.
+func (in *FoundationDBBackupDescriptionSnapshot) DeepCopy() *FoundationDBBackupDescriptionSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBBackupDescriptionSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBBackupDescriptionVersion) DeepCopyInto(out *FoundationDBBackupDescriptionVersion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackupDescriptionVersion.
+func (in *FoundationDBBackupDescriptionVersion) DeepCopy() *FoundationDBBackupDescriptionVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBBackupDescriptionVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBBackupList) DeepCopyInto(out *FoundationDBBackupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FoundationDBBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackupList.
+func (in *FoundationDBBackupList) DeepCopy() *FoundationDBBackupList {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FoundationDBBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBBackupSpec) DeepCopyInto(out *FoundationDBBackupSpec) {
+	*out = *in
+	if in.AgentCount != nil {
+		in, out := &in.AgentCount, &out.AgentCount
+		*out = new(int)
+		**out = **in
+	}
+	if in.SnapshotPeriodSeconds != nil {
+		in, out := &in.SnapshotPeriodSeconds, &out.SnapshotPeriodSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.BackupDeploymentMetadata != nil {
+		in, out := &in.BackupDeploymentMetadata, &out.BackupDeploymentMetadata
+		*out = new(v1.ObjectMeta)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodTemplateSpec != nil {
+		in, out := &in.PodTemplateSpec, &out.PodTemplateSpec
+		*out = new(corev1.PodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CustomParameters != nil {
+		in, out := &in.CustomParameters, &out.CustomParameters
+		*out = make(FoundationDBCustomParameters, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowTagOverride != nil {
+		in, out := &in.AllowTagOverride, &out.AllowTagOverride
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BlobStoreConfiguration != nil {
+		in, out := &in.BlobStoreConfiguration, &out.BlobStoreConfiguration
+		*out = new(BlobStoreConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FileSystemConfiguration != nil {
+		in, out := &in.FileSystemConfiguration, &out.FileSystemConfiguration
+		*out = new(FileSystemConfiguration)
+		**out = **in
+	}
+	in.MainContainer.DeepCopyInto(&out.MainContainer)
+	in.SidecarContainer.DeepCopyInto(&out.SidecarContainer)
+	if in.RetentionPolicy != nil {
+		in, out := &in.RetentionPolicy, &out.RetentionPolicy
+		*out = new(BackupRetentionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackupSpec.
+func (in *FoundationDBBackupSpec) DeepCopy() *FoundationDBBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBBackupStatus) DeepCopyInto(out *FoundationDBBackupStatus) {
+	*out = *in
+	if in.BackupDetails != nil {
+		in, out := &in.BackupDetails, &out.BackupDetails
+		*out = new(FoundationDBBackupStatusBackupDetails)
+		**out = **in
+	}
+	out.Generations = in.Generations
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(BackupRetentionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackupStatus.
+func (in *FoundationDBBackupStatus) DeepCopy() *FoundationDBBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBBackupStatusBackupDetails) DeepCopyInto(out *FoundationDBBackupStatusBackupDetails) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBBackupStatusBackupDetails.
+func (in *FoundationDBBackupStatusBackupDetails) DeepCopy() *FoundationDBBackupStatusBackupDetails {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBBackupStatusBackupDetails)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBCluster) DeepCopyInto(out *FoundationDBCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBCluster.
+func (in *FoundationDBCluster) DeepCopy() *FoundationDBCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FoundationDBCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBClusterAutomationOptions) DeepCopyInto(out *FoundationDBClusterAutomationOptions) {
+	*out = *in
+	if in.ConfigureDatabase != nil {
+		in, out := &in.ConfigureDatabase, &out.ConfigureDatabase
+		*out = new(bool)
+		**out = **in
+	}
+	if in.KillProcesses != nil {
+		in, out := &in.KillProcesses, &out.KillProcesses
+		*out = new(bool)
+		**out = **in
+	}
+	in.Replacements.DeepCopyInto(&out.Replacements)
+	if in.UseNonBlockingExcludes != nil {
+		in, out := &in.UseNonBlockingExcludes, &out.UseNonBlockingExcludes
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UseLocalitiesForExclusion != nil {
+		in, out := &in.UseLocalitiesForExclusion, &out.UseLocalitiesForExclusion
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IgnoreTerminatingPodsSeconds != nil {
+		in, out := &in.IgnoreTerminatingPodsSeconds, &out.IgnoreTerminatingPodsSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.IgnoreMissingProcessesSeconds != nil {
+		in, out := &in.IgnoreMissingProcessesSeconds, &out.IgnoreMissingProcessesSeconds
+		*out = new(int)
+		**out = **in
 	}
 	if in.FailedPodDurationSeconds != nil {
 		in, out := &in.FailedPodDurationSeconds, &out.FailedPodDurationSeconds
@@ -542,17 +941,33 @@ func (in *FoundationDBClusterAutomationOptions) DeepCopyInto(out *FoundationDBCl
 		*out = new(int)
 		**out = **in
 	}
+	if in.UpdateImagesInPlace != nil {
+		in, out := &in.UpdateImagesInPlace, &out.UpdateImagesInPlace
+		*out = new(bool)
+		**out = **in
+	}
 	if in.UseManagementAPI != nil {
 		in, out := &in.UseManagementAPI, &out.UseManagementAPI
 		*out = new(bool)
 		**out = **in
 	}
+	if in.UseProcessGroupResources != nil {
+		in, out := &in.UseProcessGroupResources, &out.UseProcessGroupResources
+		*out = new(bool)
+		**out = **in
+	}
 	in.MaintenanceModeOptions.DeepCopyInto(&out.MaintenanceModeOptions)
 	if in.IgnoreLogGroupsForUpgrade != nil {
 		in, out := &in.IgnoreLogGroupsForUpgrade, &out.IgnoreLogGroupsForUpgrade
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.UpgradeRollback.DeepCopyInto(&out.UpgradeRollback)
+	in.RolloutPolicy.DeepCopyInto(&out.RolloutPolicy)
+	in.ExclusionBudget.DeepCopyInto(&out.ExclusionBudget)
+	in.HotspotRemediation.DeepCopyInto(&out.HotspotRemediation)
+	in.ResourceRecommendations.DeepCopyInto(&out.ResourceRecommendations)
+	in.StorageMigration.DeepCopyInto(&out.StorageMigration)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBClusterAutomationOptions.
@@ -720,6 +1135,47 @@ func (in *FoundationDBClusterStatus) DeepCopyInto(out *FoundationDBClusterStatus
 	}
 	in.Locks.DeepCopyInto(&out.Locks)
 	in.MaintenanceModeInfo.DeepCopyInto(&out.MaintenanceModeInfo)
+	if in.TaintedNodeMaintenanceZones != nil {
+		in, out := &in.TaintedNodeMaintenanceZones, &out.TaintedNodeMaintenanceZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(UpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExclusionBacklog != nil {
+		in, out := &in.ExclusionBacklog, &out.ExclusionBacklog
+		*out = new(ExclusionBacklog)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastHotspotReplacement != nil {
+		in, out := &in.LastHotspotReplacement, &out.LastHotspotReplacement
+		*out = (*in).DeepCopy()
+	}
+	if in.ResourceRecommendations != nil {
+		in, out := &in.ResourceRecommendations, &out.ResourceRecommendations
+		*out = make([]ResourceRecommendation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StorageEngineMigration != nil {
+		in, out := &in.StorageEngineMigration, &out.StorageEngineMigration
+		*out = new(StorageEngineMigrationStatus)
+		**out = **in
+	}
+	if in.PrimaryDataCenterOverride != nil {
+		in, out := &in.PrimaryDataCenterOverride, &out.PrimaryDataCenterOverride
+		*out = new(PrimaryDataCenterOverride)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBClusterStatus.
@@ -733,66 +1189,544 @@ func (in *FoundationDBClusterStatus) DeepCopy() *FoundationDBClusterStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in FoundationDBCustomParameters) DeepCopyInto(out *FoundationDBCustomParameters) {
-	{
-		in := &in
-		*out = make(FoundationDBCustomParameters, len(*in))
-		copy(*out, *in)
-	}
+func (in FoundationDBCustomParameters) DeepCopyInto(out *FoundationDBCustomParameters) {
+	{
+		in := &in
+		*out = make(FoundationDBCustomParameters, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBCustomParameters.
+func (in FoundationDBCustomParameters) DeepCopy() FoundationDBCustomParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBCustomParameters)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBDisasterRecovery) DeepCopyInto(out *FoundationDBDisasterRecovery) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBDisasterRecovery.
+func (in *FoundationDBDisasterRecovery) DeepCopy() *FoundationDBDisasterRecovery {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBDisasterRecovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FoundationDBDisasterRecovery) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBDisasterRecoveryList) DeepCopyInto(out *FoundationDBDisasterRecoveryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FoundationDBDisasterRecovery, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBDisasterRecoveryList.
+func (in *FoundationDBDisasterRecoveryList) DeepCopy() *FoundationDBDisasterRecoveryList {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBDisasterRecoveryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FoundationDBDisasterRecoveryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBDisasterRecoverySpec) DeepCopyInto(out *FoundationDBDisasterRecoverySpec) {
+	*out = *in
+	if in.AgentCount != nil {
+		in, out := &in.AgentCount, &out.AgentCount
+		*out = new(int)
+		**out = **in
+	}
+	if in.AgentDeploymentMetadata != nil {
+		in, out := &in.AgentDeploymentMetadata, &out.AgentDeploymentMetadata
+		*out = new(v1.ObjectMeta)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodTemplateSpec != nil {
+		in, out := &in.PodTemplateSpec, &out.PodTemplateSpec
+		*out = new(corev1.PodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CustomParameters != nil {
+		in, out := &in.CustomParameters, &out.CustomParameters
+		*out = make(FoundationDBCustomParameters, len(*in))
+		copy(*out, *in)
+	}
+	in.MainContainer.DeepCopyInto(&out.MainContainer)
+	in.SidecarContainer.DeepCopyInto(&out.SidecarContainer)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBDisasterRecoverySpec.
+func (in *FoundationDBDisasterRecoverySpec) DeepCopy() *FoundationDBDisasterRecoverySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBDisasterRecoverySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBDisasterRecoveryStatus) DeepCopyInto(out *FoundationDBDisasterRecoveryStatus) {
+	*out = *in
+	if in.DRDetails != nil {
+		in, out := &in.DRDetails, &out.DRDetails
+		*out = new(FoundationDBDisasterRecoveryStatusDRDetails)
+		**out = **in
+	}
+	out.Generations = in.Generations
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBDisasterRecoveryStatus.
+func (in *FoundationDBDisasterRecoveryStatus) DeepCopy() *FoundationDBDisasterRecoveryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBDisasterRecoveryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBDisasterRecoveryStatusDRDetails) DeepCopyInto(out *FoundationDBDisasterRecoveryStatusDRDetails) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBDisasterRecoveryStatusDRDetails.
+func (in *FoundationDBDisasterRecoveryStatusDRDetails) DeepCopy() *FoundationDBDisasterRecoveryStatusDRDetails {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBDisasterRecoveryStatusDRDetails)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBFailover) DeepCopyInto(out *FoundationDBFailover) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBFailover.
+func (in *FoundationDBFailover) DeepCopy() *FoundationDBFailover {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBFailover)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FoundationDBFailover) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBFailoverList) DeepCopyInto(out *FoundationDBFailoverList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FoundationDBFailover, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBFailoverList.
+func (in *FoundationDBFailoverList) DeepCopy() *FoundationDBFailoverList {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBFailoverList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FoundationDBFailoverList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBFailoverSpec) DeepCopyInto(out *FoundationDBFailoverSpec) {
+	*out = *in
+	if in.MaximumDataCenterLagSeconds != nil {
+		in, out := &in.MaximumDataCenterLagSeconds, &out.MaximumDataCenterLagSeconds
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBFailoverSpec.
+func (in *FoundationDBFailoverSpec) DeepCopy() *FoundationDBFailoverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBFailoverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBFailoverStatus) DeepCopyInto(out *FoundationDBFailoverStatus) {
+	*out = *in
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTimestamp != nil {
+		in, out := &in.CompletionTimestamp, &out.CompletionTimestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBFailoverStatus.
+func (in *FoundationDBFailoverStatus) DeepCopy() *FoundationDBFailoverStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBFailoverStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBKeyRange) DeepCopyInto(out *FoundationDBKeyRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBKeyRange.
+func (in *FoundationDBKeyRange) DeepCopy() *FoundationDBKeyRange {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBKeyRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBLiveBackupStatus) DeepCopyInto(out *FoundationDBLiveBackupStatus) {
+	*out = *in
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBLiveBackupStatus.
+func (in *FoundationDBLiveBackupStatus) DeepCopy() *FoundationDBLiveBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBLiveBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBLiveBackupStatusState) DeepCopyInto(out *FoundationDBLiveBackupStatusState) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBLiveBackupStatusState.
+func (in *FoundationDBLiveBackupStatusState) DeepCopy() *FoundationDBLiveBackupStatusState {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBLiveBackupStatusState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBLiveDRStatus) DeepCopyInto(out *FoundationDBLiveDRStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBLiveDRStatus.
+func (in *FoundationDBLiveDRStatus) DeepCopy() *FoundationDBLiveDRStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBLiveDRStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBLiveRestoreStatus) DeepCopyInto(out *FoundationDBLiveRestoreStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBLiveRestoreStatus.
+func (in *FoundationDBLiveRestoreStatus) DeepCopy() *FoundationDBLiveRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBLiveRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBMultiRegionCluster) DeepCopyInto(out *FoundationDBMultiRegionCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBMultiRegionCluster.
+func (in *FoundationDBMultiRegionCluster) DeepCopy() *FoundationDBMultiRegionCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBMultiRegionCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FoundationDBMultiRegionCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBMultiRegionClusterList) DeepCopyInto(out *FoundationDBMultiRegionClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FoundationDBMultiRegionCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBMultiRegionClusterList.
+func (in *FoundationDBMultiRegionClusterList) DeepCopy() *FoundationDBMultiRegionClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBMultiRegionClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FoundationDBMultiRegionClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBMultiRegionClusterSpec) DeepCopyInto(out *FoundationDBMultiRegionClusterSpec) {
+	*out = *in
+	in.ClusterTemplate.DeepCopyInto(&out.ClusterTemplate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBMultiRegionClusterSpec.
+func (in *FoundationDBMultiRegionClusterSpec) DeepCopy() *FoundationDBMultiRegionClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBMultiRegionClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBMultiRegionClusterStatus) DeepCopyInto(out *FoundationDBMultiRegionClusterStatus) {
+	*out = *in
+	out.Generations = in.Generations
+	if in.DataCenters != nil {
+		in, out := &in.DataCenters, &out.DataCenters
+		*out = make([]MultiRegionDataCenterStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrimaryDataCenterOverride != nil {
+		in, out := &in.PrimaryDataCenterOverride, &out.PrimaryDataCenterOverride
+		*out = new(PrimaryDataCenterOverride)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBMultiRegionClusterStatus.
+func (in *FoundationDBMultiRegionClusterStatus) DeepCopy() *FoundationDBMultiRegionClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBMultiRegionClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBProcessGroup) DeepCopyInto(out *FoundationDBProcessGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBCustomParameters.
-func (in FoundationDBCustomParameters) DeepCopy() FoundationDBCustomParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBProcessGroup.
+func (in *FoundationDBProcessGroup) DeepCopy() *FoundationDBProcessGroup {
 	if in == nil {
 		return nil
 	}
-	out := new(FoundationDBCustomParameters)
+	out := new(FoundationDBProcessGroup)
 	in.DeepCopyInto(out)
-	return *out
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FoundationDBProcessGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FoundationDBKeyRange) DeepCopyInto(out *FoundationDBKeyRange) {
+func (in *FoundationDBProcessGroupList) DeepCopyInto(out *FoundationDBProcessGroupList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FoundationDBProcessGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBKeyRange.
-func (in *FoundationDBKeyRange) DeepCopy() *FoundationDBKeyRange {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBProcessGroupList.
+func (in *FoundationDBProcessGroupList) DeepCopy() *FoundationDBProcessGroupList {
 	if in == nil {
 		return nil
 	}
-	out := new(FoundationDBKeyRange)
+	out := new(FoundationDBProcessGroupList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FoundationDBProcessGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FoundationDBLiveBackupStatus) DeepCopyInto(out *FoundationDBLiveBackupStatus) {
+func (in *FoundationDBProcessGroupSpec) DeepCopyInto(out *FoundationDBProcessGroupSpec) {
 	*out = *in
-	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBLiveBackupStatus.
-func (in *FoundationDBLiveBackupStatus) DeepCopy() *FoundationDBLiveBackupStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBProcessGroupSpec.
+func (in *FoundationDBProcessGroupSpec) DeepCopy() *FoundationDBProcessGroupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FoundationDBLiveBackupStatus)
+	out := new(FoundationDBProcessGroupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FoundationDBLiveBackupStatusState) DeepCopyInto(out *FoundationDBLiveBackupStatusState) {
+func (in *FoundationDBProcessGroupStatus) DeepCopyInto(out *FoundationDBProcessGroupStatus) {
 	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemovalTimestamp != nil {
+		in, out := &in.RemovalTimestamp, &out.RemovalTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.ExclusionTimestamp != nil {
+		in, out := &in.ExclusionTimestamp, &out.ExclusionTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.ProcessGroupConditions != nil {
+		in, out := &in.ProcessGroupConditions, &out.ProcessGroupConditions
+		*out = make([]*ProcessGroupCondition, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ProcessGroupCondition)
+				**out = **in
+			}
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBLiveBackupStatusState.
-func (in *FoundationDBLiveBackupStatusState) DeepCopy() *FoundationDBLiveBackupStatusState {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBProcessGroupStatus.
+func (in *FoundationDBProcessGroupStatus) DeepCopy() *FoundationDBProcessGroupStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(FoundationDBLiveBackupStatusState)
+	out := new(FoundationDBProcessGroupStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -803,7 +1737,7 @@ func (in *FoundationDBRestore) DeepCopyInto(out *FoundationDBRestore) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBRestore.
@@ -856,6 +1790,21 @@ func (in *FoundationDBRestoreList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBRestoreProgress) DeepCopyInto(out *FoundationDBRestoreProgress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBRestoreProgress.
+func (in *FoundationDBRestoreProgress) DeepCopy() *FoundationDBRestoreProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBRestoreProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBRestoreSpec) DeepCopyInto(out *FoundationDBRestoreSpec) {
 	*out = *in
@@ -869,11 +1818,26 @@ func (in *FoundationDBRestoreSpec) DeepCopyInto(out *FoundationDBRestoreSpec) {
 		*out = new(BlobStoreConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FileSystemConfiguration != nil {
+		in, out := &in.FileSystemConfiguration, &out.FileSystemConfiguration
+		*out = new(FileSystemConfiguration)
+		**out = **in
+	}
 	if in.CustomParameters != nil {
 		in, out := &in.CustomParameters, &out.CustomParameters
 		*out = make(FoundationDBCustomParameters, len(*in))
 		copy(*out, *in)
 	}
+	if in.TargetVersion != nil {
+		in, out := &in.TargetVersion, &out.TargetVersion
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TargetTimestamp != nil {
+		in, out := &in.TargetTimestamp, &out.TargetTimestamp
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBRestoreSpec.
@@ -889,6 +1853,16 @@ func (in *FoundationDBRestoreSpec) DeepCopy() *FoundationDBRestoreSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBRestoreStatus) DeepCopyInto(out *FoundationDBRestoreStatus) {
 	*out = *in
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(FoundationDBRestoreProgress)
+		**out = **in
+	}
+	if in.Errors != nil {
+		in, out := &in.Errors, &out.Errors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBRestoreStatus.
@@ -1013,6 +1987,9 @@ func (in *FoundationDBStatusClusterInfo) DeepCopyInto(out *FoundationDBStatusClu
 		copy(*out, *in)
 	}
 	out.RecoveryState = in.RecoveryState
+	out.DatacenterLag = in.DatacenterLag
+	out.LatencyProbe = in.LatencyProbe
+	out.Qos = in.Qos
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusClusterInfo.
@@ -1078,6 +2055,36 @@ func (in *FoundationDBStatusCoordinatorInfo) DeepCopy() *FoundationDBStatusCoord
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBStatusCounter) DeepCopyInto(out *FoundationDBStatusCounter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusCounter.
+func (in *FoundationDBStatusCounter) DeepCopy() *FoundationDBStatusCounter {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBStatusCounter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBStatusDataCenterLag) DeepCopyInto(out *FoundationDBStatusDataCenterLag) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusDataCenterLag.
+func (in *FoundationDBStatusDataCenterLag) DeepCopy() *FoundationDBStatusDataCenterLag {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBStatusDataCenterLag)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBStatusDataState) DeepCopyInto(out *FoundationDBStatusDataState) {
 	*out = *in
@@ -1110,6 +2117,21 @@ func (in *FoundationDBStatusDataStatistics) DeepCopy() *FoundationDBStatusDataSt
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBStatusLatencyProbe) DeepCopyInto(out *FoundationDBStatusLatencyProbe) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusLatencyProbe.
+func (in *FoundationDBStatusLatencyProbe) DeepCopy() *FoundationDBStatusLatencyProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBStatusLatencyProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBStatusLayerInfo) DeepCopyInto(out *FoundationDBStatusLayerInfo) {
 	*out = *in
@@ -1158,6 +2180,36 @@ func (in *FoundationDBStatusMovingData) DeepCopy() *FoundationDBStatusMovingData
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBStatusPerformanceLimitedBy) DeepCopyInto(out *FoundationDBStatusPerformanceLimitedBy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusPerformanceLimitedBy.
+func (in *FoundationDBStatusPerformanceLimitedBy) DeepCopy() *FoundationDBStatusPerformanceLimitedBy {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBStatusPerformanceLimitedBy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBStatusProcessCPUStatistics) DeepCopyInto(out *FoundationDBStatusProcessCPUStatistics) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusProcessCPUStatistics.
+func (in *FoundationDBStatusProcessCPUStatistics) DeepCopy() *FoundationDBStatusProcessCPUStatistics {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBStatusProcessCPUStatistics)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBStatusProcessInfo) DeepCopyInto(out *FoundationDBStatusProcessInfo) {
 	*out = *in
@@ -1179,6 +2231,8 @@ func (in *FoundationDBStatusProcessInfo) DeepCopyInto(out *FoundationDBStatusPro
 		*out = make([]FoundationDBStatusProcessMessage, len(*in))
 		copy(*out, *in)
 	}
+	out.CPU = in.CPU
+	out.Memory = in.Memory
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusProcessInfo.
@@ -1191,72 +2245,186 @@ func (in *FoundationDBStatusProcessInfo) DeepCopy() *FoundationDBStatusProcessIn
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBStatusProcessMemoryStatistics) DeepCopyInto(out *FoundationDBStatusProcessMemoryStatistics) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusProcessMemoryStatistics.
+func (in *FoundationDBStatusProcessMemoryStatistics) DeepCopy() *FoundationDBStatusProcessMemoryStatistics {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBStatusProcessMemoryStatistics)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FoundationDBStatusProcessMessage) DeepCopyInto(out *FoundationDBStatusProcessMessage) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusProcessMessage.
-func (in *FoundationDBStatusProcessMessage) DeepCopy() *FoundationDBStatusProcessMessage {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusProcessMessage.
+func (in *FoundationDBStatusProcessMessage) DeepCopy() *FoundationDBStatusProcessMessage {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBStatusProcessMessage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBStatusProcessRoleInfo) DeepCopyInto(out *FoundationDBStatusProcessRoleInfo) {
+	*out = *in
+	out.InputBytes = in.InputBytes
+	out.DurableBytes = in.DurableBytes
+	out.StorageMetadata = in.StorageMetadata
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusProcessRoleInfo.
+func (in *FoundationDBStatusProcessRoleInfo) DeepCopy() *FoundationDBStatusProcessRoleInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBStatusProcessRoleInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBStatusQosInfo) DeepCopyInto(out *FoundationDBStatusQosInfo) {
+	*out = *in
+	out.PerformanceLimitedBy = in.PerformanceLimitedBy
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusQosInfo.
+func (in *FoundationDBStatusQosInfo) DeepCopy() *FoundationDBStatusQosInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBStatusQosInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBStatusStorageMetadata) DeepCopyInto(out *FoundationDBStatusStorageMetadata) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusStorageMetadata.
+func (in *FoundationDBStatusStorageMetadata) DeepCopy() *FoundationDBStatusStorageMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBStatusStorageMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FoundationDBStatusSupportedVersion) DeepCopyInto(out *FoundationDBStatusSupportedVersion) {
+	*out = *in
+	if in.ConnectedClients != nil {
+		in, out := &in.ConnectedClients, &out.ConnectedClients
+		*out = make([]FoundationDBStatusConnectedClient, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxProtocolClients != nil {
+		in, out := &in.MaxProtocolClients, &out.MaxProtocolClients
+		*out = make([]FoundationDBStatusConnectedClient, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusSupportedVersion.
+func (in *FoundationDBStatusSupportedVersion) DeepCopy() *FoundationDBStatusSupportedVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(FoundationDBStatusSupportedVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HotspotRemediationOptions) DeepCopyInto(out *HotspotRemediationOptions) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StoredBytesThresholdPercentage != nil {
+		in, out := &in.StoredBytesThresholdPercentage, &out.StoredBytesThresholdPercentage
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxQueueBytes != nil {
+		in, out := &in.MaxQueueBytes, &out.MaxQueueBytes
+		*out = new(int)
+		**out = **in
+	}
+	if in.OverloadedSeconds != nil {
+		in, out := &in.OverloadedSeconds, &out.OverloadedSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxConcurrentReplacements != nil {
+		in, out := &in.MaxConcurrentReplacements, &out.MaxConcurrentReplacements
+		*out = new(int)
+		**out = **in
+	}
+	if in.MinimumSecondsBetweenReplacements != nil {
+		in, out := &in.MinimumSecondsBetweenReplacements, &out.MinimumSecondsBetweenReplacements
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HotspotRemediationOptions.
+func (in *HotspotRemediationOptions) DeepCopy() *HotspotRemediationOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(FoundationDBStatusProcessMessage)
+	out := new(HotspotRemediationOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FoundationDBStatusProcessRoleInfo) DeepCopyInto(out *FoundationDBStatusProcessRoleInfo) {
+func (in *ImageConfig) DeepCopyInto(out *ImageConfig) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusProcessRoleInfo.
-func (in *FoundationDBStatusProcessRoleInfo) DeepCopy() *FoundationDBStatusProcessRoleInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageConfig.
+func (in *ImageConfig) DeepCopy() *ImageConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(FoundationDBStatusProcessRoleInfo)
+	out := new(ImageConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FoundationDBStatusSupportedVersion) DeepCopyInto(out *FoundationDBStatusSupportedVersion) {
+func (in *IncompatibleClientGroup) DeepCopyInto(out *IncompatibleClientGroup) {
 	*out = *in
-	if in.ConnectedClients != nil {
-		in, out := &in.ConnectedClients, &out.ConnectedClients
-		*out = make([]FoundationDBStatusConnectedClient, len(*in))
-		copy(*out, *in)
-	}
-	if in.MaxProtocolClients != nil {
-		in, out := &in.MaxProtocolClients, &out.MaxProtocolClients
-		*out = make([]FoundationDBStatusConnectedClient, len(*in))
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FoundationDBStatusSupportedVersion.
-func (in *FoundationDBStatusSupportedVersion) DeepCopy() *FoundationDBStatusSupportedVersion {
-	if in == nil {
-		return nil
-	}
-	out := new(FoundationDBStatusSupportedVersion)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageConfig) DeepCopyInto(out *ImageConfig) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageConfig.
-func (in *ImageConfig) DeepCopy() *ImageConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IncompatibleClientGroup.
+func (in *IncompatibleClientGroup) DeepCopy() *IncompatibleClientGroup {
 	if in == nil {
 		return nil
 	}
-	out := new(ImageConfig)
+	out := new(IncompatibleClientGroup)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1419,6 +2587,36 @@ func (in *MaintenanceModeOptions) DeepCopy() *MaintenanceModeOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiRegionClusterGenerationStatus) DeepCopyInto(out *MultiRegionClusterGenerationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiRegionClusterGenerationStatus.
+func (in *MultiRegionClusterGenerationStatus) DeepCopy() *MultiRegionClusterGenerationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiRegionClusterGenerationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiRegionDataCenterStatus) DeepCopyInto(out *MultiRegionDataCenterStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiRegionDataCenterStatus.
+func (in *MultiRegionDataCenterStatus) DeepCopy() *MultiRegionDataCenterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiRegionDataCenterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *None) DeepCopyInto(out *None) {
 	*out = *in
@@ -1434,6 +2632,21 @@ func (in *None) DeepCopy() *None {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrimaryDataCenterOverride) DeepCopyInto(out *PrimaryDataCenterOverride) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrimaryDataCenterOverride.
+func (in *PrimaryDataCenterOverride) DeepCopy() *PrimaryDataCenterOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(PrimaryDataCenterOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProcessAddress) DeepCopyInto(out *ProcessAddress) {
 	*out = *in
@@ -1610,6 +2823,102 @@ func (in *RequiredAddressSet) DeepCopy() *RequiredAddressSet {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRecommendation) DeepCopyInto(out *ResourceRecommendation) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	in.ObservedSince.DeepCopyInto(&out.ObservedSince)
+	if in.Samples != nil {
+		in, out := &in.Samples, &out.Samples
+		*out = make([]ResourceUsageSample, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRecommendation.
+func (in *ResourceRecommendation) DeepCopy() *ResourceRecommendation {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRecommendation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRecommendationOptions) DeepCopyInto(out *ResourceRecommendationOptions) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.WindowSeconds != nil {
+		in, out := &in.WindowSeconds, &out.WindowSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.SampleIntervalSeconds != nil {
+		in, out := &in.SampleIntervalSeconds, &out.SampleIntervalSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.HeadroomPercentage != nil {
+		in, out := &in.HeadroomPercentage, &out.HeadroomPercentage
+		*out = new(int)
+		**out = **in
+	}
+	if in.MinimumChangePercentage != nil {
+		in, out := &in.MinimumChangePercentage, &out.MinimumChangePercentage
+		*out = new(int)
+		**out = **in
+	}
+	if in.Apply != nil {
+		in, out := &in.Apply, &out.Apply
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRecommendationOptions.
+func (in *ResourceRecommendationOptions) DeepCopy() *ResourceRecommendationOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRecommendationOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceUsageSample) DeepCopyInto(out *ResourceUsageSample) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+	out.CPU = in.CPU.DeepCopy()
+	out.Memory = in.Memory.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUsageSample.
+func (in *ResourceUsageSample) DeepCopy() *ResourceUsageSample {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceUsageSample)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoleCounts) DeepCopyInto(out *RoleCounts) {
 	*out = *in
@@ -1625,6 +2934,55 @@ func (in *RoleCounts) DeepCopy() *RoleCounts {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutPolicy) DeepCopyInto(out *RolloutPolicy) {
+	*out = *in
+	if in.ProcessClasses != nil {
+		in, out := &in.ProcessClasses, &out.ProcessClasses
+		*out = make([]ProcessClass, len(*in))
+		copy(*out, *in)
+	}
+	if in.CanaryCount != nil {
+		in, out := &in.CanaryCount, &out.CanaryCount
+		*out = new(int)
+		**out = **in
+	}
+	if in.SoakSeconds != nil {
+		in, out := &in.SoakSeconds, &out.SoakSeconds
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutPolicy.
+func (in *RolloutPolicy) DeepCopy() *RolloutPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
+	*out = *in
+	if in.StageCompletedTimestamp != nil {
+		in, out := &in.StageCompletedTimestamp, &out.StageCompletedTimestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
+func (in *RolloutStatus) DeepCopy() *RolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoutingConfig) DeepCopyInto(out *RoutingConfig) {
 	*out = *in
@@ -1665,6 +3023,124 @@ func (in *RoutingConfig) DeepCopy() *RoutingConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageEngineMigrationStatus) DeepCopyInto(out *StorageEngineMigrationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageEngineMigrationStatus.
+func (in *StorageEngineMigrationStatus) DeepCopy() *StorageEngineMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageEngineMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageMigrationOptions) DeepCopyInto(out *StorageMigrationOptions) {
+	*out = *in
+	if in.MaxConcurrentReplacements != nil {
+		in, out := &in.MaxConcurrentReplacements, &out.MaxConcurrentReplacements
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxMovingDataBytes != nil {
+		in, out := &in.MaxMovingDataBytes, &out.MaxMovingDataBytes
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageMigrationOptions.
+func (in *StorageMigrationOptions) DeepCopy() *StorageMigrationOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageMigrationOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaintReplacementOption) DeepCopyInto(out *TaintReplacementOption) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaintReplacementOption.
+func (in *TaintReplacementOption) DeepCopy() *TaintReplacementOption {
+	if in == nil {
+		return nil
+	}
+	out := new(TaintReplacementOption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeRollbackOptions) DeepCopyInto(out *UpgradeRollbackOptions) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UnavailableSecondsBeforeRollback != nil {
+		in, out := &in.UnavailableSecondsBeforeRollback, &out.UnavailableSecondsBeforeRollback
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeRollbackOptions.
+func (in *UpgradeRollbackOptions) DeepCopy() *UpgradeRollbackOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeRollbackOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStatus) DeepCopyInto(out *UpgradeStatus) {
+	*out = *in
+	if in.IncompatibleClients != nil {
+		in, out := &in.IncompatibleClients, &out.IncompatibleClients
+		*out = make([]IncompatibleClientGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PendingRestarts != nil {
+		in, out := &in.PendingRestarts, &out.PendingRestarts
+		*out = make([]ProcessGroupID, len(*in))
+		copy(*out, *in)
+	}
+	if in.RestartTimestamp != nil {
+		in, out := &in.RestartTimestamp, &out.RestartTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStatus.
+func (in *UpgradeStatus) DeepCopy() *UpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Version) DeepCopyInto(out *Version) {
 	*out = *in
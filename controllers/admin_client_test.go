@@ -227,7 +227,7 @@ var _ = Describe("admin_client_test", func() {
 
 		Context("with a backup running", func() {
 			BeforeEach(func() {
-				err = mockAdminClient.StartBackup("blobstore://test@test-service/test-backup", 10)
+				err = mockAdminClient.StartBackup("default", "blobstore://test@test-service/test-backup", 10)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -268,7 +268,7 @@ var _ = Describe("admin_client_test", func() {
 
 			Context("with a stopped backup", func() {
 				BeforeEach(func() {
-					err = mockAdminClient.StopBackup("blobstore://test@test-service/test-backup")
+					err = mockAdminClient.StopBackup("default")
 					Expect(err).NotTo(HaveOccurred())
 				})
 
@@ -282,13 +282,37 @@ var _ = Describe("admin_client_test", func() {
 					}))
 				})
 			})
+
+			Context("with a second backup tag", func() {
+				BeforeEach(func() {
+					err = mockAdminClient.StartBackup("offsite", "blobstore://test@test-service/offsite-backup", 10)
+					Expect(err).NotTo(HaveOccurred())
+					err = mockAdminClient.StopBackup("default")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should report both tags", func() {
+					Expect(status.Cluster.Layers.Backup.Tags).To(Equal(map[string]fdbv1beta2.FoundationDBStatusBackupTag{
+						"default": {
+							CurrentContainer: "blobstore://test@test-service/test-backup",
+							RunningBackup:    false,
+							Restorable:       true,
+						},
+						"offsite": {
+							CurrentContainer: "blobstore://test@test-service/offsite-backup",
+							RunningBackup:    true,
+							Restorable:       true,
+						},
+					}))
+				})
+			})
 		})
 	})
 
 	Describe("backup status", func() {
 		var status *fdbv1beta2.FoundationDBLiveBackupStatus
 		JustBeforeEach(func() {
-			status, err = mockAdminClient.GetBackupStatus()
+			status, err = mockAdminClient.GetBackupStatus("default")
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -302,7 +326,7 @@ var _ = Describe("admin_client_test", func() {
 
 		Context("with a backup running", func() {
 			BeforeEach(func() {
-				err = mockAdminClient.StartBackup("blobstore://test@test-service/test-backup", 10)
+				err = mockAdminClient.StartBackup("default", "blobstore://test@test-service/test-backup", 10)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -339,7 +363,7 @@ var _ = Describe("admin_client_test", func() {
 
 			Context("with a stopped backup", func() {
 				BeforeEach(func() {
-					err = mockAdminClient.StopBackup("blobstore://test@test-service/test-backup")
+					err = mockAdminClient.StopBackup("default")
 					Expect(err).NotTo(HaveOccurred())
 				})
 
@@ -350,7 +374,7 @@ var _ = Describe("admin_client_test", func() {
 
 			Context("with a modification to the snapshot time", func() {
 				BeforeEach(func() {
-					err = mockAdminClient.ModifyBackup(20)
+					err = mockAdminClient.ModifyBackup("default", 20)
 					Expect(err).NotTo(HaveOccurred())
 				})
 
@@ -362,7 +386,7 @@ var _ = Describe("admin_client_test", func() {
 	})
 
 	Describe("restore status", func() {
-		var status string
+		var status *fdbv1beta2.FoundationDBLiveRestoreStatus
 
 		Context("with no restore running", func() {
 			BeforeEach(func() {
@@ -371,13 +395,13 @@ var _ = Describe("admin_client_test", func() {
 			})
 
 			It("should be empty", func() {
-				Expect(status).To(Equal("\n"))
+				Expect(status.State).To(BeEmpty())
 			})
 		})
 
 		Context("with a restore running", func() {
 			BeforeEach(func() {
-				err = mockAdminClient.StartRestore("blobstore://test@test-service/test-backup", nil)
+				err = mockAdminClient.StartRestore("blobstore://test@test-service/test-backup", nil, nil, nil, nil)
 				Expect(err).NotTo(HaveOccurred())
 
 				status, err = mockAdminClient.GetRestoreStatus()
@@ -385,7 +409,8 @@ var _ = Describe("admin_client_test", func() {
 			})
 
 			It("should contain the backup URL", func() {
-				Expect(status).To(Equal("blobstore://test@test-service/test-backup\n"))
+				Expect(status.URL).To(Equal("blobstore://test@test-service/test-backup"))
+				Expect(status.State).To(Equal("running"))
 			})
 		})
 	})
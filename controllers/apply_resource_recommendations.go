@@ -0,0 +1,98 @@
+/*
+ * apply_resource_recommendations.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2026 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// applyResourceRecommendations provides a reconciliation step for applying the resource recommendations to the
+// requests of the main container in the cluster spec.
+type applyResourceRecommendations struct{}
+
+// reconcile runs the reconciler's work.
+func (applyResourceRecommendations) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster) *requeue {
+	if !cluster.GetApplyResourceRecommendations() {
+		return nil
+	}
+
+	logger := log.WithValues("namespace", cluster.Namespace, "cluster", cluster.Name, "reconciler", "applyResourceRecommendations")
+
+	// The spec of a cluster that is managed by a FoundationDBMultiRegionCluster is overwritten by the multi-region
+	// cluster reconciler, so the recommendations are only reported in the status.
+	if multiRegionClusterName, ok := cluster.Labels[fdbv1beta2.MultiRegionClusterLabel]; ok {
+		logger.Info("Skipping resource recommendations for cluster managed by a multi-region cluster", "multiRegionCluster", multiRegionClusterName)
+		return nil
+	}
+
+	requests := map[fdbv1beta2.ProcessClass]corev1.ResourceList{}
+	for _, recommendation := range cluster.Status.ResourceRecommendations {
+		recommendedRequests := internal.GetRecommendedRequests(cluster, recommendation.ProcessClass)
+		if recommendedRequests == nil {
+			continue
+		}
+
+		requests[recommendation.ProcessClass] = recommendedRequests
+	}
+
+	if len(requests) == 0 {
+		return nil
+	}
+
+	// Only the requests are changed in the spec, the cluster object in the reconciliation loop contains the normalized
+	// spec that should not be persisted.
+	latestCluster := &fdbv1beta2.FoundationDBCluster{}
+	err := r.Get(ctx, client.ObjectKeyFromObject(cluster), latestCluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	changes := make([]string, 0, len(requests))
+	for _, recommendation := range cluster.Status.ResourceRecommendations {
+		recommendedRequests, ok := requests[recommendation.ProcessClass]
+		if !ok {
+			continue
+		}
+
+		internal.SetMainContainerRequests(latestCluster, recommendation.ProcessClass, recommendedRequests)
+		changes = append(changes, fmt.Sprintf("%s (cpu: %s, memory: %s)", recommendation.ProcessClass, recommendedRequests.Cpu().String(), recommendedRequests.Memory().String()))
+	}
+
+	message := fmt.Sprintf("Applying the recommended requests for the process classes %s", strings.Join(changes, ", "))
+	logger.Info("Applying resource recommendations", "message", message)
+
+	err = r.Update(ctx, latestCluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "ApplyResourceRecommendations", message)
+
+	// The Pods will be updated once the new generation of the cluster is reconciled.
+	return &requeue{message: "Applied resource recommendations to the cluster spec"}
+}
@@ -0,0 +1,140 @@
+/*
+ * apply_resource_recommendations_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2026 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("apply_resource_recommendations", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var req *requeue
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		cluster.Spec.AutomationOptions.ResourceRecommendations.Enabled = pointer.Bool(true)
+		Expect(k8sClient.Create(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+		result, err := reconcileCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Requeue).To(BeFalse())
+
+		_, err = reloadCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+
+		cluster.Status.ResourceRecommendations = []fdbv1beta2.ResourceRecommendation{
+			{
+				ProcessClass: fdbv1beta2.ProcessClassStorage,
+				CPU:          resource.NewMilliQuantity(500, resource.DecimalSI),
+				Memory:       resource.NewQuantity(2*1024*1024*1024, resource.BinarySI),
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(k8sClient.Status().Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+		normalizedCluster := cluster.DeepCopy()
+		Expect(internal.NormalizeClusterSpec(normalizedCluster, internal.DeprecationOptions{})).NotTo(HaveOccurred())
+		req = applyResourceRecommendations{}.reconcile(context.TODO(), clusterReconciler, normalizedCluster)
+
+		_, err := reloadCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	When("the recommendations should not be applied", func() {
+		It("should not change the cluster spec", func() {
+			Expect(req).To(BeNil())
+			Expect(cluster.Spec.Processes).NotTo(HaveKey(fdbv1beta2.ProcessClassStorage))
+		})
+	})
+
+	When("the recommendations should be applied", func() {
+		BeforeEach(func() {
+			cluster.Spec.AutomationOptions.ResourceRecommendations.Apply = pointer.Bool(true)
+			Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+		})
+
+		It("should set the recommended requests capped at the limits in the cluster spec", func() {
+			Expect(req).NotTo(BeNil())
+			Expect(req.curError).NotTo(HaveOccurred())
+
+			processSettings, ok := cluster.Spec.Processes[fdbv1beta2.ProcessClassStorage]
+			Expect(ok).To(BeTrue())
+			Expect(processSettings.PodTemplate).NotTo(BeNil())
+			var mainContainer corev1.Container
+			for _, container := range processSettings.PodTemplate.Spec.Containers {
+				if container.Name == fdbv1beta2.MainContainerName {
+					mainContainer = container
+				}
+			}
+			Expect(mainContainer.Resources.Requests.Cpu().String()).To(Equal("500m"))
+			Expect(mainContainer.Resources.Requests.Memory().String()).To(Equal("1Gi"))
+		})
+
+		It("should emit an event", func() {
+			events := &corev1.EventList{}
+			Expect(k8sClient.List(context.TODO(), events)).NotTo(HaveOccurred())
+
+			var matchingEvents []corev1.Event
+			for _, event := range events.Items {
+				if event.InvolvedObject.UID == cluster.ObjectMeta.UID && event.Reason == "ApplyResourceRecommendations" {
+					matchingEvents = append(matchingEvents, event)
+				}
+			}
+			Expect(matchingEvents).To(HaveLen(1))
+		})
+
+		When("the cluster is managed by a multi-region cluster", func() {
+			BeforeEach(func() {
+				cluster.Labels = map[string]string{
+					fdbv1beta2.MultiRegionClusterLabel: "multi-region",
+				}
+				Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+			})
+
+			It("should not change the cluster spec", func() {
+				Expect(req).To(BeNil())
+				Expect(cluster.Spec.Processes).NotTo(HaveKey(fdbv1beta2.ProcessClassStorage))
+			})
+		})
+
+		When("the requests already match the recommendations", func() {
+			JustBeforeEach(func() {
+				normalizedCluster := cluster.DeepCopy()
+				Expect(internal.NormalizeClusterSpec(normalizedCluster, internal.DeprecationOptions{})).NotTo(HaveOccurred())
+				req = applyResourceRecommendations{}.reconcile(context.TODO(), clusterReconciler, normalizedCluster)
+			})
+
+			It("should not change the cluster spec again", func() {
+				Expect(req).To(BeNil())
+			})
+		})
+	})
+})
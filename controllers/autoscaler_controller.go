@@ -0,0 +1,458 @@
+/*
+ * autoscaler_controller.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// autoscalerRequeueDelay determines how often the signals of the database are evaluated by an autoscaler.
+const autoscalerRequeueDelay = 1 * time.Minute
+
+// FoundationDBAutoscalerReconciler reconciles a FoundationDBAutoscaler object
+type FoundationDBAutoscalerReconciler struct {
+	client.Client
+	Recorder               record.EventRecorder
+	Log                    logr.Logger
+	DatabaseClientProvider fdbadminclient.DatabaseClientProvider
+	ServerSideApply        bool
+}
+
+// +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbautoscalers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbautoscalers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbautoscalers/scale,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbclusters,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile runs the reconciliation logic.
+func (r *FoundationDBAutoscalerReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	autoscaler := &fdbv1beta2.FoundationDBAutoscaler{}
+	err := r.Get(ctx, request.NamespacedName, autoscaler)
+
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			// Object not found, return.  Created objects are automatically garbage collected.
+			// For additional cleanup logic use finalizers.
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	autoscalerLog := log.WithValues("namespace", autoscaler.Namespace, "autoscaler", autoscaler.Name)
+
+	err = autoscaler.Validate()
+	if err != nil {
+		r.Recorder.Event(autoscaler, corev1.EventTypeWarning, "AutoscalerSpec not valid", err.Error())
+		return ctrl.Result{}, fmt.Errorf("AutoscalerSpec is not valid: %w", err)
+	}
+
+	cluster := &fdbv1beta2.FoundationDBCluster{}
+	err = r.Get(ctx, client.ObjectKey{Namespace: autoscaler.Namespace, Name: autoscaler.Spec.ClusterName}, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// The spec of a cluster that is managed by a FoundationDBMultiRegionCluster is overwritten by the multi-region
+	// cluster reconciler, so a change of the process counts would be reverted during the next reconciliation.
+	if multiRegionClusterName, ok := cluster.Labels[fdbv1beta2.MultiRegionClusterLabel]; ok {
+		err = fmt.Errorf("cluster %s is managed by the FoundationDBMultiRegionCluster %s, the process counts must be changed in the FoundationDBMultiRegionCluster", cluster.Name, multiRegionClusterName)
+		r.Recorder.Event(autoscaler, corev1.EventTypeWarning, "ClusterManagedByMultiRegionCluster", err.Error())
+		return ctrl.Result{}, err
+	}
+
+	err = internal.LoadProcessGroups(ctx, r, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	processClass := autoscaler.Spec.ProcessClass
+	desiredCounts, err := cluster.GetProcessCountsWithDefaults()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	desiredReplicas := desiredCounts.Map()[processClass]
+
+	requiredReplicas, err := getRequiredReplicas(cluster, processClass)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	message := ""
+	if autoscaler.Spec.Signals != nil {
+		message, err = r.evaluateSignals(autoscalerLog, autoscaler, cluster, desiredReplicas, requiredReplicas)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	desired := autoscaler.GetDesiredReplicas()
+	if desired != nil {
+		err = r.scaleCluster(ctx, autoscalerLog, autoscaler, client.ObjectKeyFromObject(cluster), *desired)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	currentReplicas := 0
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.ProcessClass != processClass || processGroup.IsMarkedForRemoval() {
+			continue
+		}
+
+		currentReplicas++
+	}
+
+	autoscaler.Status.Replicas = currentReplicas
+	autoscaler.Status.Selector = labels.SelectorFromSet(internal.GetPodMatchLabels(cluster, processClass, "")).String()
+	if message != "" {
+		autoscaler.Status.Message = message
+	}
+
+	err = r.updateOrApply(ctx, autoscaler)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	autoscalerLog.Info("Reconciliation complete", "replicas", currentReplicas)
+
+	if autoscaler.Spec.Signals != nil {
+		// Requeue the autoscaler to evaluate the signals again.
+		return ctrl.Result{RequeueAfter: autoscalerRequeueDelay}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// evaluateSignals checks the signals of the database and updates the recommended replicas in the status of the
+// autoscaler if the process class should be scaled. The spec of the autoscaler is never changed, as the replicas in
+// the spec are owned by the scale subresource. The returned message describes the scaling decision.
+func (r *FoundationDBAutoscalerReconciler) evaluateSignals(logger logr.Logger, autoscaler *fdbv1beta2.FoundationDBAutoscaler, cluster *fdbv1beta2.FoundationDBCluster, desiredReplicas int, requiredReplicas int) (string, error) {
+	// Only make a new scaling decision once the cluster has applied the previous decision, otherwise the signals
+	// might be based on an outdated process count.
+	if cluster.Status.Generations.Reconciled < cluster.Generation {
+		return "waiting for the cluster to be reconciled", nil
+	}
+
+	status, err := r.getStatus(cluster)
+	if err != nil {
+		return "", err
+	}
+
+	direction, reason := getScalingDirection(autoscaler, status)
+	if direction == 0 {
+		return reason, nil
+	}
+
+	cooldown := autoscaler.GetScaleUpCooldown()
+	if direction < 0 {
+		cooldown = autoscaler.GetScaleDownCooldown()
+	}
+
+	if autoscaler.Status.LastScaleTime != nil {
+		remaining := cooldown - time.Since(autoscaler.Status.LastScaleTime.Time)
+		if remaining > 0 {
+			return fmt.Sprintf("%s, waiting %s for the cooldown", reason, remaining.Round(time.Second)), nil
+		}
+	}
+
+	replicas := autoscaler.BoundReplicas(desiredReplicas+direction*autoscaler.GetStepSize(), requiredReplicas)
+	if replicas == desiredReplicas {
+		return fmt.Sprintf("%s, but the process count is already at the limit of %d", reason, replicas), nil
+	}
+
+	logger.Info("Updating recommended replicas based on the signals", "reason", reason, "current", desiredReplicas, "recommended", replicas)
+	autoscaler.Status.RecommendedReplicas = &replicas
+
+	return reason, nil
+}
+
+// scaleCluster changes the process count and the role counts of the cluster to the desired replicas. The cluster is
+// read again before the change, so that the counts are changed based on the latest spec of the cluster.
+func (r *FoundationDBAutoscalerReconciler) scaleCluster(ctx context.Context, logger logr.Logger, autoscaler *fdbv1beta2.FoundationDBAutoscaler, key client.ObjectKey, desired int) error {
+	cluster := &fdbv1beta2.FoundationDBCluster{}
+	err := r.Get(ctx, key, cluster)
+	if err != nil {
+		return err
+	}
+
+	processClass := autoscaler.Spec.ProcessClass
+	desiredCounts, err := cluster.GetProcessCountsWithDefaults()
+	if err != nil {
+		return err
+	}
+	desiredReplicas := desiredCounts.Map()[processClass]
+
+	requiredReplicas, err := getRequiredReplicas(cluster, processClass)
+	if err != nil {
+		return err
+	}
+
+	replicas := autoscaler.BoundReplicas(desired, requiredReplicas)
+
+	var changes []string
+	if replicas != desiredReplicas {
+		changes = append(changes, fmt.Sprintf("the %s process count from %d to %d", processClass, desiredReplicas, replicas))
+	}
+
+	roleCountChange := setRoleCount(cluster, processClass, replicas)
+	if roleCountChange != "" {
+		changes = append(changes, roleCountChange)
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	logger.Info("Changing process count", "processClass", processClass, "current", desiredReplicas, "desired", replicas, "roleCounts", cluster.Spec.DatabaseConfiguration.RoleCounts)
+	cluster.Spec.ProcessCounts.SetCount(processClass, replicas)
+	err = r.Update(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	r.Recorder.Event(autoscaler, corev1.EventTypeNormal, "ScalingProcessClass", fmt.Sprintf("Changing %s of cluster %s", strings.Join(changes, " and "), cluster.Name))
+	autoscaler.Status.LastScaleTime = &metav1.Time{Time: time.Now()}
+
+	return nil
+}
+
+// getRequiredReplicas returns the minimum number of processes of the process class that is needed to recruit the
+// roles of the process class. For the log process class this is the number of logs that is needed for the
+// redundancy mode together with the desired fault tolerance, for the stateless process class this is the number of
+// processes that is needed for the roles that are not handled by a dedicated process class.
+func getRequiredReplicas(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass) (int, error) {
+	switch processClass {
+	case fdbv1beta2.ProcessClassLog:
+		return cluster.MinimumFaultDomains() + cluster.DesiredFaultTolerance(), nil
+	case fdbv1beta2.ProcessClassStateless:
+		defaultCluster := cluster.DeepCopy()
+		defaultCluster.Spec.ProcessCounts.Stateless = 0
+		counts, err := defaultCluster.GetProcessCountsWithDefaults()
+		if err != nil {
+			return 0, err
+		}
+
+		// The default process count contains the desired fault tolerance as spare processes, those are not needed
+		// to recruit the roles.
+		required := counts.Stateless - cluster.DesiredFaultTolerance()
+		if required < 0 {
+			return 0, nil
+		}
+
+		return required, nil
+	}
+
+	return 1, nil
+}
+
+// setRoleCount changes the role count of the database configuration that is served by the process class, so that
+// the roles are scaled together with the processes. For the log process class the desired fault tolerance is kept
+// as spare processes. Returns a description of the change or an empty string if the role count was not changed.
+func setRoleCount(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass, replicas int) string {
+	var roleCount *int
+	var role string
+	switch processClass {
+	case fdbv1beta2.ProcessClassLog:
+		roleCount = &cluster.Spec.DatabaseConfiguration.Logs
+		role = "logs"
+		replicas -= cluster.DesiredFaultTolerance()
+	case fdbv1beta2.ProcessClassProxy:
+		roleCount = &cluster.Spec.DatabaseConfiguration.Proxies
+		role = "proxies"
+	case fdbv1beta2.ProcessClassCommitProxy:
+		roleCount = &cluster.Spec.DatabaseConfiguration.CommitProxies
+		role = "commit_proxies"
+	case fdbv1beta2.ProcessClassGrvProxy:
+		roleCount = &cluster.Spec.DatabaseConfiguration.GrvProxies
+		role = "grv_proxies"
+	default:
+		return ""
+	}
+
+	if *roleCount == replicas {
+		return ""
+	}
+
+	change := fmt.Sprintf("the %s count from %d to %d", role, *roleCount, replicas)
+	*roleCount = replicas
+	return change
+}
+
+// getScalingDirection returns 1 if the process class should be scaled up, -1 if it should be scaled down and 0 if
+// the process count should not be changed, together with the reason for the decision.
+func getScalingDirection(autoscaler *fdbv1beta2.FoundationDBAutoscaler, status *fdbv1beta2.FoundationDBStatus) (int, string) {
+	signals := autoscaler.Spec.Signals
+	limitedBy := status.Cluster.Qos.PerformanceLimitedBy.Name
+	for _, reason := range signals.LimitingReasons {
+		if reason == limitedBy {
+			return 1, fmt.Sprintf("ratekeeper is limited by %s", limitedBy)
+		}
+	}
+
+	commitLatencyMilliseconds := status.Cluster.LatencyProbe.CommitSeconds * 1000
+	if signals.MaxCommitLatencyMilliseconds != nil && commitLatencyMilliseconds > float64(*signals.MaxCommitLatencyMilliseconds) {
+		return 1, fmt.Sprintf("commit latency of %.2fms exceeds %dms", commitLatencyMilliseconds, *signals.MaxCommitLatencyMilliseconds)
+	}
+
+	logQueueBytes := status.Cluster.Qos.WorstQueueBytesLogServer
+	if signals.MaxLogQueueBytes != nil && logQueueBytes > *signals.MaxLogQueueBytes {
+		return 1, fmt.Sprintf("log queue of %d bytes exceeds %d bytes", logQueueBytes, *signals.MaxLogQueueBytes)
+	}
+
+	// Without any thresholds there is no signal that indicates that the process class is underutilized.
+	if signals.MaxCommitLatencyMilliseconds == nil && signals.MaxLogQueueBytes == nil {
+		return 0, "signals are within the thresholds"
+	}
+
+	scaleDownFactor := float64(autoscaler.GetScaleDownThresholdPercentage()) / 100
+	if signals.MaxCommitLatencyMilliseconds != nil && commitLatencyMilliseconds >= float64(*signals.MaxCommitLatencyMilliseconds)*scaleDownFactor {
+		return 0, "signals are within the thresholds"
+	}
+
+	if signals.MaxLogQueueBytes != nil && float64(logQueueBytes) >= float64(*signals.MaxLogQueueBytes)*scaleDownFactor {
+		return 0, "signals are within the thresholds"
+	}
+
+	return -1, "signals are below the scale down thresholds"
+}
+
+// getDatabaseClientProvider gets the client provider for a reconciler.
+func (r *FoundationDBAutoscalerReconciler) getDatabaseClientProvider() fdbadminclient.DatabaseClientProvider {
+	if r.DatabaseClientProvider != nil {
+		return r.DatabaseClientProvider
+	}
+	panic("Autoscaler reconciler does not have a DatabaseClientProvider defined")
+}
+
+// getStatus fetches the machine-readable status of the cluster that should be scaled.
+func (r *FoundationDBAutoscalerReconciler) getStatus(cluster *fdbv1beta2.FoundationDBCluster) (*fdbv1beta2.FoundationDBStatus, error) {
+	adminClient, err := r.getDatabaseClientProvider().GetAdminClient(cluster, r)
+	if err != nil {
+		return nil, err
+	}
+	defer adminClient.Close()
+
+	return adminClient.GetStatus()
+}
+
+// SetupWithManager prepares a reconciler for use.
+func (r *FoundationDBAutoscalerReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentReconciles int, selector metav1.LabelSelector) error {
+	labelSelectorPredicate, err := predicate.LabelSelectorPredicate(selector)
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrentReconciles},
+		).
+		// Only react on generation changes or annotation changes and only watch
+		// resources with the provided label selector.
+		For(&fdbv1beta2.FoundationDBAutoscaler{}, builder.WithPredicates(
+			predicate.Or(
+				predicate.GenerationChangedPredicate{},
+				predicate.AnnotationChangedPredicate{},
+			),
+		)).
+		// The scaling decisions depend on the spec and the reconciliation state
+		// of the cluster, so the autoscalers of a cluster are reconciled once
+		// the cluster was changed or reconciled.
+		Watches(
+			&source.Kind{Type: &fdbv1beta2.FoundationDBCluster{}},
+			handler.EnqueueRequestsFromMapFunc(r.findFoundationDBAutoscalersForCluster),
+			builder.WithPredicates(
+				predicate.Or(
+					predicate.GenerationChangedPredicate{},
+					reconciledGenerationChangedPredicate(),
+				),
+			),
+		).
+		WithEventFilter(labelSelectorPredicate).
+		Complete(r)
+}
+
+// reconciledGenerationChangedPredicate returns a predicate that only accepts updates of clusters where the reconciled
+// generation in the status has changed.
+func reconciledGenerationChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldCluster, ok := e.ObjectOld.(*fdbv1beta2.FoundationDBCluster)
+			if !ok {
+				return false
+			}
+
+			newCluster, ok := e.ObjectNew.(*fdbv1beta2.FoundationDBCluster)
+			if !ok {
+				return false
+			}
+
+			return oldCluster.Status.Generations.Reconciled != newCluster.Status.Generations.Reconciled
+		},
+	}
+}
+
+// findFoundationDBAutoscalersForCluster returns the reconcile requests for all autoscalers that scale the cluster.
+func (r *FoundationDBAutoscalerReconciler) findFoundationDBAutoscalersForCluster(object client.Object) []reconcile.Request {
+	autoscalers := &fdbv1beta2.FoundationDBAutoscalerList{}
+	err := r.List(context.Background(), autoscalers, client.InNamespace(object.GetNamespace()))
+	if err != nil {
+		log.Error(err, "could not list autoscalers for cluster", "namespace", object.GetNamespace(), "cluster", object.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, autoscaler := range autoscalers.Items {
+		if autoscaler.Spec.ClusterName != object.GetName() {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&autoscaler)})
+	}
+
+	return requests
+}
+
+// updateOrApply updates the status either with server-side apply or if disabled with the normal update call.
+func (r *FoundationDBAutoscalerReconciler) updateOrApply(ctx context.Context, autoscaler *fdbv1beta2.FoundationDBAutoscaler) error {
+	return updateOrApplyStatus(ctx, r, r.ServerSideApply, autoscaler)
+}
@@ -0,0 +1,439 @@
+/*
+ * autoscaler_controller_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func reloadAutoscaler(autoscaler *fdbv1beta2.FoundationDBAutoscaler) error {
+	return k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(autoscaler), autoscaler)
+}
+
+var _ = Describe("autoscaler_controller", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var autoscaler *fdbv1beta2.FoundationDBAutoscaler
+	var adminClient *mock.AdminClient
+	var initialCount int
+	var expectError bool
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+
+		expectError = false
+		var err error
+		adminClient, err = mock.NewMockAdminClientUncast(cluster, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+
+		counts, err := cluster.GetProcessCountsWithDefaults()
+		Expect(err).NotTo(HaveOccurred())
+		initialCount = counts.Stateless
+
+		autoscaler = &fdbv1beta2.FoundationDBAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "autoscaler",
+				Namespace: cluster.Namespace,
+			},
+			Spec: fdbv1beta2.FoundationDBAutoscalerSpec{
+				ClusterName:  cluster.Name,
+				ProcessClass: fdbv1beta2.ProcessClassStateless,
+				MinReplicas:  2,
+				MaxReplicas:  initialCount + 2,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(k8sClient.Create(context.TODO(), autoscaler)).NotTo(HaveOccurred())
+		_, err := reconcileAutoscaler(autoscaler)
+		if expectError {
+			Expect(err).To(HaveOccurred())
+		} else {
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(reloadAutoscaler(autoscaler)).NotTo(HaveOccurred())
+		Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(cluster), cluster)).NotTo(HaveOccurred())
+	})
+
+	When("no replicas and no signals are defined", func() {
+		It("should only update the status", func() {
+			Expect(cluster.Spec.ProcessCounts.Stateless).To(Equal(0))
+			Expect(autoscaler.Spec.Replicas).To(BeNil())
+			Expect(autoscaler.Status.Replicas).To(Equal(initialCount))
+			Expect(autoscaler.Status.Selector).To(Equal("foundationdb.org/fdb-cluster-name=operator-test-1,foundationdb.org/fdb-process-class=stateless"))
+			Expect(autoscaler.Status.LastScaleTime).To(BeNil())
+		})
+	})
+
+	When("the replicas are set by a HorizontalPodAutoscaler", func() {
+		BeforeEach(func() {
+			autoscaler.Spec.Replicas = pointer.Int(initialCount + 1)
+		})
+
+		It("should change the process count of the cluster", func() {
+			Expect(cluster.Spec.ProcessCounts.Stateless).To(Equal(initialCount + 1))
+			Expect(autoscaler.Status.LastScaleTime).NotTo(BeNil())
+		})
+
+		When("the cluster is reconciled", func() {
+			JustBeforeEach(func() {
+				_, err := reconcileCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = reconcileAutoscaler(autoscaler)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reloadAutoscaler(autoscaler)).NotTo(HaveOccurred())
+			})
+
+			It("should report the new replicas", func() {
+				Expect(autoscaler.Status.Replicas).To(Equal(initialCount + 1))
+			})
+		})
+
+		When("the replicas exceed the maximum", func() {
+			BeforeEach(func() {
+				autoscaler.Spec.Replicas = pointer.Int(initialCount + 10)
+			})
+
+			It("should use the maximum", func() {
+				Expect(cluster.Spec.ProcessCounts.Stateless).To(Equal(initialCount + 2))
+			})
+		})
+	})
+
+	When("the process groups are stored in process group resources", func() {
+		BeforeEach(func() {
+			cluster.Spec.AutomationOptions.UseProcessGroupResources = pointer.Bool(true)
+			Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+			_, err := reconcileCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should count the replicas from the process group resources", func() {
+			Expect(cluster.Status.ProcessGroups).To(BeEmpty())
+			Expect(autoscaler.Status.Replicas).To(Equal(initialCount))
+		})
+	})
+
+	When("the log process class is scaled", func() {
+		BeforeEach(func() {
+			autoscaler.Spec.ProcessClass = fdbv1beta2.ProcessClassLog
+			autoscaler.Spec.MinReplicas = 1
+			autoscaler.Spec.MaxReplicas = 10
+			autoscaler.Spec.Replicas = pointer.Int(6)
+		})
+
+		It("should change the log count together with the process count", func() {
+			Expect(cluster.Spec.ProcessCounts.Log).To(Equal(6))
+			Expect(cluster.Spec.DatabaseConfiguration.Logs).To(Equal(5))
+			Expect(getAutoscalerEventMessages(autoscaler)).To(ContainElement(fmt.Sprintf("Changing the log process count from 4 to 6 and the logs count from 0 to 5 of cluster %s", cluster.Name)))
+		})
+
+		When("only the log count must be changed", func() {
+			BeforeEach(func() {
+				autoscaler.Spec.Replicas = pointer.Int(4)
+			})
+
+			It("should only report the change of the log count", func() {
+				Expect(cluster.Spec.ProcessCounts.Log).To(Equal(4))
+				Expect(cluster.Spec.DatabaseConfiguration.Logs).To(Equal(3))
+				Expect(getAutoscalerEventMessages(autoscaler)).To(ContainElement(fmt.Sprintf("Changing the logs count from 0 to 3 of cluster %s", cluster.Name)))
+			})
+		})
+
+		When("the replicas are below the processes that are required for the logs", func() {
+			BeforeEach(func() {
+				autoscaler.Spec.Replicas = pointer.Int(1)
+			})
+
+			It("should use the required process count", func() {
+				Expect(cluster.Spec.ProcessCounts.Log).To(Equal(3))
+				Expect(cluster.Spec.DatabaseConfiguration.Logs).To(Equal(2))
+			})
+		})
+	})
+
+	When("the proxy process class is scaled", func() {
+		BeforeEach(func() {
+			autoscaler.Spec.ProcessClass = fdbv1beta2.ProcessClassProxy
+			autoscaler.Spec.MinReplicas = 1
+			autoscaler.Spec.MaxReplicas = 10
+			autoscaler.Spec.Replicas = pointer.Int(4)
+		})
+
+		It("should change the proxy count together with the process count", func() {
+			Expect(cluster.Spec.ProcessCounts.Proxy).To(Equal(4))
+			Expect(cluster.Spec.DatabaseConfiguration.Proxies).To(Equal(4))
+		})
+	})
+
+	When("the replicas are below the processes that are required for the stateless roles", func() {
+		BeforeEach(func() {
+			autoscaler.Spec.MinReplicas = 1
+			autoscaler.Spec.Replicas = pointer.Int(1)
+		})
+
+		It("should use the required process count", func() {
+			Expect(cluster.Spec.ProcessCounts.Stateless).To(Equal(initialCount - 1))
+		})
+	})
+
+	When("signals are defined", func() {
+		BeforeEach(func() {
+			autoscaler.Spec.Signals = &fdbv1beta2.AutoscalerSignals{
+				MaxCommitLatencyMilliseconds: pointer.Int(10),
+				MaxLogQueueBytes:             pointer.Int(1000000),
+				LimitingReasons:              []string{"log_server_write_queue"},
+			}
+		})
+
+		When("the signals are within the thresholds", func() {
+			BeforeEach(func() {
+				adminClient.MockLatencyProbe(0.008)
+			})
+
+			It("should not change the process count", func() {
+				Expect(autoscaler.Status.RecommendedReplicas).To(BeNil())
+				Expect(cluster.Spec.ProcessCounts.Stateless).To(Equal(0))
+				Expect(autoscaler.Status.Message).To(Equal("signals are within the thresholds"))
+			})
+		})
+
+		When("the commit latency exceeds the threshold", func() {
+			BeforeEach(func() {
+				adminClient.MockLatencyProbe(0.02)
+			})
+
+			It("should scale up the process class", func() {
+				Expect(autoscaler.Spec.Replicas).To(BeNil())
+				Expect(autoscaler.Status.RecommendedReplicas).To(HaveValue(Equal(initialCount + 1)))
+				Expect(cluster.Spec.ProcessCounts.Stateless).To(Equal(initialCount + 1))
+				Expect(autoscaler.Status.Message).To(Equal("commit latency of 20.00ms exceeds 10ms"))
+				Expect(autoscaler.Status.LastScaleTime).NotTo(BeNil())
+			})
+
+			When("the autoscaler is reconciled again within the cooldown", func() {
+				JustBeforeEach(func() {
+					markClusterReconciled(cluster, adminClient)
+					_, err := reconcileAutoscaler(autoscaler)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(reloadAutoscaler(autoscaler)).NotTo(HaveOccurred())
+					Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(cluster), cluster)).NotTo(HaveOccurred())
+				})
+
+				It("should not scale up the process class again", func() {
+					Expect(autoscaler.Status.RecommendedReplicas).To(HaveValue(Equal(initialCount + 1)))
+					Expect(cluster.Spec.ProcessCounts.Stateless).To(Equal(initialCount + 1))
+					Expect(autoscaler.Status.Message).To(HavePrefix("commit latency of 20.00ms exceeds 10ms, waiting"))
+				})
+			})
+		})
+
+		When("the ratekeeper is limited by the log servers", func() {
+			BeforeEach(func() {
+				adminClient.MockQos("log_server_write_queue", 0)
+			})
+
+			It("should scale up the process class", func() {
+				Expect(cluster.Spec.ProcessCounts.Stateless).To(Equal(initialCount + 1))
+				Expect(autoscaler.Status.Message).To(Equal("ratekeeper is limited by log_server_write_queue"))
+			})
+		})
+
+		When("the log queue exceeds the threshold", func() {
+			BeforeEach(func() {
+				adminClient.MockQos("workload", 2000000)
+			})
+
+			It("should scale up the process class", func() {
+				Expect(cluster.Spec.ProcessCounts.Stateless).To(Equal(initialCount + 1))
+				Expect(autoscaler.Status.Message).To(Equal("log queue of 2000000 bytes exceeds 1000000 bytes"))
+			})
+		})
+
+		When("all signals are below the scale down thresholds", func() {
+			BeforeEach(func() {
+				adminClient.MockLatencyProbe(0.001)
+			})
+
+			It("should scale down the process class", func() {
+				Expect(autoscaler.Spec.Replicas).To(BeNil())
+				Expect(autoscaler.Status.RecommendedReplicas).To(HaveValue(Equal(initialCount - 1)))
+				Expect(cluster.Spec.ProcessCounts.Stateless).To(Equal(initialCount - 1))
+				Expect(autoscaler.Status.Message).To(Equal("signals are below the scale down thresholds"))
+			})
+
+			When("the process count is at the minimum", func() {
+				BeforeEach(func() {
+					autoscaler.Spec.MinReplicas = initialCount
+				})
+
+				It("should not change the process count", func() {
+					Expect(autoscaler.Status.RecommendedReplicas).To(BeNil())
+					Expect(cluster.Spec.ProcessCounts.Stateless).To(Equal(0))
+					Expect(autoscaler.Status.Message).To(Equal("signals are below the scale down thresholds, but the process count is already at the limit of " + fmt.Sprint(initialCount)))
+				})
+			})
+		})
+
+		When("the cluster is not reconciled", func() {
+			BeforeEach(func() {
+				adminClient.MockLatencyProbe(0.02)
+				cluster.Spec.ProcessCounts.Log = 5
+				Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+			})
+
+			It("should wait for the cluster to be reconciled", func() {
+				Expect(autoscaler.Status.RecommendedReplicas).To(BeNil())
+				Expect(autoscaler.Status.Message).To(Equal("waiting for the cluster to be reconciled"))
+			})
+		})
+
+		When("the replicas are set by a HorizontalPodAutoscaler", func() {
+			BeforeEach(func() {
+				adminClient.MockLatencyProbe(0.02)
+				autoscaler.Spec.Replicas = pointer.Int(initialCount - 1)
+			})
+
+			It("should only recommend the replicas and use the replicas from the spec", func() {
+				Expect(autoscaler.Spec.Replicas).To(HaveValue(Equal(initialCount - 1)))
+				Expect(autoscaler.Status.RecommendedReplicas).To(HaveValue(Equal(initialCount + 1)))
+				Expect(cluster.Spec.ProcessCounts.Stateless).To(Equal(initialCount - 1))
+			})
+		})
+	})
+
+	When("the cluster is managed by a multi-region cluster", func() {
+		BeforeEach(func() {
+			cluster.Labels = map[string]string{
+				fdbv1beta2.MultiRegionClusterLabel: "multi-region",
+			}
+			Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+			autoscaler.Spec.Replicas = pointer.Int(initialCount + 1)
+			expectError = true
+		})
+
+		It("should not change the process count", func() {
+			Expect(cluster.Spec.ProcessCounts.Stateless).To(Equal(0))
+			Expect(autoscaler.Status.LastScaleTime).To(BeNil())
+			Expect(getAutoscalerEventMessages(autoscaler)).To(ConsistOf("cluster operator-test-1 is managed by the FoundationDBMultiRegionCluster multi-region, the process counts must be changed in the FoundationDBMultiRegionCluster"))
+		})
+	})
+
+	When("the minimum replicas are greater than the maximum replicas", func() {
+		BeforeEach(func() {
+			autoscaler.Spec.MinReplicas = initialCount + 3
+			autoscaler.Spec.Replicas = pointer.Int(initialCount + 3)
+			expectError = true
+		})
+
+		It("should not change the process count", func() {
+			Expect(cluster.Spec.ProcessCounts.Stateless).To(Equal(0))
+		})
+	})
+
+	When("the cluster was changed after the autoscaler read it", func() {
+		var staleCluster *fdbv1beta2.FoundationDBCluster
+
+		JustBeforeEach(func() {
+			staleCluster = cluster.DeepCopy()
+			cluster.Spec.ProcessCounts.Log = 5
+			Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+			Expect(autoscalerReconciler.scaleCluster(context.TODO(), log, autoscaler, client.ObjectKeyFromObject(staleCluster), initialCount+1)).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(cluster), cluster)).NotTo(HaveOccurred())
+		})
+
+		It("should change the process count based on the latest cluster", func() {
+			Expect(cluster.Spec.ProcessCounts.Stateless).To(Equal(initialCount + 1))
+			Expect(cluster.Spec.ProcessCounts.Log).To(Equal(5))
+		})
+	})
+
+	When("another autoscaler scales a different cluster", func() {
+		JustBeforeEach(func() {
+			otherAutoscaler := autoscaler.DeepCopy()
+			otherAutoscaler.ObjectMeta = metav1.ObjectMeta{
+				Name:      "other-autoscaler",
+				Namespace: autoscaler.Namespace,
+			}
+			otherAutoscaler.Spec.ClusterName = "other-cluster"
+			Expect(k8sClient.Create(context.TODO(), otherAutoscaler)).NotTo(HaveOccurred())
+		})
+
+		It("should only map the cluster to its own autoscaler", func() {
+			requests := autoscalerReconciler.findFoundationDBAutoscalersForCluster(cluster)
+			Expect(requests).To(HaveLen(1))
+			Expect(requests[0].NamespacedName).To(Equal(client.ObjectKeyFromObject(autoscaler)))
+		})
+	})
+})
+
+var _ = Describe("reconciledGenerationChangedPredicate", func() {
+	var oldCluster, newCluster *fdbv1beta2.FoundationDBCluster
+
+	BeforeEach(func() {
+		oldCluster = internal.CreateDefaultCluster()
+		oldCluster.Status.Generations.Reconciled = 1
+		newCluster = oldCluster.DeepCopy()
+	})
+
+	When("the reconciled generation is unchanged", func() {
+		It("should ignore the update", func() {
+			newCluster.Status.RequiredAddresses.TLS = true
+			Expect(reconciledGenerationChangedPredicate().Update(event.UpdateEvent{ObjectOld: oldCluster, ObjectNew: newCluster})).To(BeFalse())
+		})
+	})
+
+	When("the reconciled generation has changed", func() {
+		It("should accept the update", func() {
+			newCluster.Status.Generations.Reconciled = 2
+			Expect(reconciledGenerationChangedPredicate().Update(event.UpdateEvent{ObjectOld: oldCluster, ObjectNew: newCluster})).To(BeTrue())
+		})
+	})
+})
+
+// getAutoscalerEventMessages returns the messages of all events that were recorded for the autoscaler.
+func getAutoscalerEventMessages(autoscaler *fdbv1beta2.FoundationDBAutoscaler) []string {
+	events := &corev1.EventList{}
+	Expect(k8sClient.List(context.TODO(), events)).NotTo(HaveOccurred())
+
+	var messages []string
+	for _, event := range events.Items {
+		if event.InvolvedObject.UID == autoscaler.UID {
+			messages = append(messages, event.Message)
+		}
+	}
+
+	return messages
+}
@@ -22,6 +22,7 @@ package controllers
 
 import (
 	"context"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -48,6 +49,9 @@ type FoundationDBBackupReconciler struct {
 	InSimulation           bool
 	DatabaseClientProvider fdbadminclient.DatabaseClientProvider
 	ServerSideApply        bool
+	// retentionTasks tracks the commands that describe, expire or delete the
+	// backup data and run in the background.
+	retentionTasks backupRetentionTasks
 }
 
 // +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbbackups,verbs=get;list;watch;create;update;patch;delete
@@ -83,6 +87,7 @@ func (r *FoundationDBBackupReconciler) Reconcile(ctx context.Context, request ct
 		toggleBackupPaused{},
 		modifyBackup{},
 		updateBackupStatus{},
+		updateBackupRetention{},
 	}
 
 	for _, subReconciler := range subReconcilers {
@@ -101,6 +106,11 @@ func (r *FoundationDBBackupReconciler) Reconcile(ctx context.Context, request ct
 
 	backupLog.Info("Reconciliation complete")
 
+	if backup.Spec.RetentionPolicy != nil {
+		// Requeue the backup to check the restorable points again after the interval.
+		return ctrl.Result{RequeueAfter: time.Duration(backup.GetRetentionIntervalSeconds()) * time.Second}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -181,24 +191,5 @@ type backupSubReconciler interface {
 
 // updateOrApply updates the status either with server-side apply or if disabled with the normal update call.
 func (r *FoundationDBBackupReconciler) updateOrApply(ctx context.Context, backup *fdbv1beta2.FoundationDBBackup) error {
-	if r.ServerSideApply {
-		// TODO(johscheuer): We have to set the TypeMeta otherwise the Patch command will fail. This is the rudimentary
-		// support for server side apply which should be enough for the status use case. The controller runtime will
-		// add some additional support in the future: https://github.com/kubernetes-sigs/controller-runtime/issues/347.
-		patch := &fdbv1beta2.FoundationDBBackup{
-			TypeMeta: metav1.TypeMeta{
-				Kind:       backup.Kind,
-				APIVersion: backup.APIVersion,
-			},
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      backup.Name,
-				Namespace: backup.Namespace,
-			},
-			Status: backup.Status,
-		}
-
-		return r.Status().Patch(ctx, patch, client.Apply, client.FieldOwner("fdb-operator"), client.ForceOwnership)
-	}
-
-	return r.Status().Update(ctx, backup)
+	return updateOrApplyStatus(ctx, r, r.ServerSideApply, backup)
 }
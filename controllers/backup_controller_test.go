@@ -34,6 +34,7 @@ import (
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -135,6 +136,7 @@ var _ = Describe("backup_controller", func() {
 					AgentCount:           3,
 					DeploymentConfigured: true,
 					BackupDetails: &fdbv1beta2.FoundationDBBackupStatusBackupDetails{
+						Tag:                   "default",
 						URL:                   "blobstore://test@test-service/test-backup?bucket=fdb-backups",
 						Running:               true,
 						SnapshotPeriodSeconds: 864000,
@@ -146,7 +148,7 @@ var _ = Describe("backup_controller", func() {
 			})
 
 			It("should start a backup", func() {
-				status, err := adminClient.GetBackupStatus()
+				status, err := adminClient.GetBackupStatus(backup.BackupTag())
 				Expect(err).NotTo(HaveOccurred())
 				Expect(status.DestinationURL).To(Equal("blobstore://test@test-service/test-backup?bucket=fdb-backups"))
 				Expect(status.Status.Running).To(BeTrue())
@@ -194,12 +196,255 @@ var _ = Describe("backup_controller", func() {
 			})
 
 			It("should stop the backup", func() {
-				status, err := adminClient.GetBackupStatus()
+				status, err := adminClient.GetBackupStatus(backup.BackupTag())
 				Expect(err).NotTo(HaveOccurred())
 				Expect(status.Status.Running).To(BeFalse())
 			})
 		})
 
+		When("the destination of a running backup is changed and the backup is stopped", func() {
+			BeforeEach(func() {
+				backup.Spec.BlobStoreConfiguration.BackupName = "new-backup"
+				backup.Spec.BackupState = fdbv1beta2.BackupStateStopped
+				err = k8sClient.Update(context.TODO(), backup)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should stop the backup", func() {
+				status, err := adminClient.GetBackupStatus(backup.BackupTag())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.Status.Running).To(BeFalse())
+				Expect(status.DestinationURL).To(Equal("blobstore://test@test-service/test-backup?bucket=fdb-backups"))
+			})
+		})
+
+		When("a retention policy is defined", func() {
+			BeforeEach(func() {
+				restorablePoints := 2
+				backup.Spec.RetentionPolicy = &fdbv1beta2.BackupRetentionPolicy{
+					RestorablePoints: &restorablePoints,
+				}
+				err = k8sClient.Update(context.TODO(), backup)
+				Expect(err).NotTo(HaveOccurred())
+
+				adminClient.MockBackupDescription(&fdbv1beta2.FoundationDBBackupDescription{
+					URL:        backup.BackupURL(),
+					Restorable: true,
+					Snapshots: []fdbv1beta2.FoundationDBBackupDescriptionSnapshot{
+						{Start: fdbv1beta2.FoundationDBBackupDescriptionVersion{Version: 100}, End: fdbv1beta2.FoundationDBBackupDescriptionVersion{Version: 200}, Restorable: true},
+						{Start: fdbv1beta2.FoundationDBBackupDescriptionVersion{Version: 300}, End: fdbv1beta2.FoundationDBBackupDescriptionVersion{Version: 400}, Restorable: true},
+						{Start: fdbv1beta2.FoundationDBBackupDescriptionVersion{Version: 500}, End: fdbv1beta2.FoundationDBBackupDescriptionVersion{Version: 600}, Restorable: true},
+					},
+					MaxRestorableVersion: &fdbv1beta2.FoundationDBBackupDescriptionVersion{Version: 650},
+				})
+			})
+
+			It("should expire the old backup data and report the restorable points", func() {
+				description, err := adminClient.DescribeBackup(backup.BackupURL())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(description.Snapshots).To(HaveLen(2))
+
+				Expect(backup.Status.Retention).NotTo(BeNil())
+				Expect(backup.Status.Retention.ExpiredBeforeVersion).To(BeNumerically("==", 300))
+				Expect(backup.Status.Retention.RestorablePoints).To(Equal([]fdbv1beta2.BackupRestorablePoint{
+					{Version: 400},
+					{Version: 600},
+				}))
+				Expect(backup.Status.Retention.MaxRestorableVersion).To(Equal(&fdbv1beta2.BackupRestorablePoint{Version: 650}))
+				Expect(backup.Status.Retention.LastCheckTimestamp).NotTo(BeNil())
+				Expect(backup.Status.Retention.DataDeleted).To(BeFalse())
+			})
+
+			When("the backup is stopped and the data should be deleted", func() {
+				BeforeEach(func() {
+					result, err := reconcileBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.Requeue).To(BeFalse())
+					_, err = reloadBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+
+					deleteWhenStopped := true
+					backup.Spec.RetentionPolicy.DeleteWhenStopped = &deleteWhenStopped
+					backup.Spec.BackupState = fdbv1beta2.BackupStateStopped
+					err = k8sClient.Update(context.TODO(), backup)
+					Expect(err).NotTo(HaveOccurred())
+					generationGap = 2
+				})
+
+				It("should delete the backup data", func() {
+					description, err := adminClient.DescribeBackup(backup.BackupURL())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(description.Snapshots).To(BeEmpty())
+
+					Expect(backup.Status.Retention).NotTo(BeNil())
+					Expect(backup.Status.Retention.DataDeleted).To(BeTrue())
+					Expect(backup.Status.Retention.RestorablePoints).To(BeEmpty())
+				})
+			})
+		})
+
+		When("a second backup with a different tag is created for the same cluster", func() {
+			var secondBackup *fdbv1beta2.FoundationDBBackup
+
+			BeforeEach(func() {
+				generationGap = 0
+				secondBackup = internal.CreateDefaultBackup(cluster)
+				secondBackup.Name = "offsite"
+				secondBackup.Spec.BackupTag = "offsite"
+				secondBackup.Spec.BlobStoreConfiguration.BackupName = "offsite-backup"
+				Expect(k8sClient.Create(context.TODO(), secondBackup)).NotTo(HaveOccurred())
+
+				result, err := reconcileBackup(secondBackup)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Requeue).To(BeFalse())
+				_, err = reloadBackup(secondBackup)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should run both backups", func() {
+				status, err := adminClient.GetBackupStatus("default")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.DestinationURL).To(Equal("blobstore://test@test-service/test-backup?bucket=fdb-backups"))
+				Expect(status.Status.Running).To(BeTrue())
+
+				status, err = adminClient.GetBackupStatus("offsite")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.DestinationURL).To(Equal("blobstore://test@test-service/offsite-backup?bucket=fdb-backups"))
+				Expect(status.Status.Running).To(BeTrue())
+
+				Expect(backup.Status.BackupDetails.Tag).To(Equal("default"))
+				Expect(secondBackup.Status.BackupDetails.Tag).To(Equal("offsite"))
+				Expect(secondBackup.Status.BackupDetails.URL).To(Equal("blobstore://test@test-service/offsite-backup?bucket=fdb-backups"))
+			})
+
+			When("only the first backup is paused", func() {
+				It("should not pause the backup agents", func() {
+					backup.Spec.BackupState = fdbv1beta2.BackupStatePaused
+					Expect(k8sClient.Update(context.TODO(), backup)).NotTo(HaveOccurred())
+
+					result, err := reconcileBackup(backup)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.Requeue).To(BeTrue())
+
+					status, err := adminClient.GetBackupStatus("offsite")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(status.BackupAgentsPaused).To(BeFalse())
+				})
+			})
+
+			When("both backups are paused", func() {
+				BeforeEach(func() {
+					backup.Spec.BackupState = fdbv1beta2.BackupStatePaused
+					Expect(k8sClient.Update(context.TODO(), backup)).NotTo(HaveOccurred())
+					secondBackup.Spec.BackupState = fdbv1beta2.BackupStatePaused
+					Expect(k8sClient.Update(context.TODO(), secondBackup)).NotTo(HaveOccurred())
+					generationGap = 1
+				})
+
+				It("should pause the backup agents", func() {
+					status, err := adminClient.GetBackupStatus("offsite")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(status.BackupAgentsPaused).To(BeTrue())
+				})
+
+				When("the second backup is resumed", func() {
+					It("should not resume the backup agents", func() {
+						secondBackup.Spec.BackupState = fdbv1beta2.BackupStateRunning
+						Expect(k8sClient.Update(context.TODO(), secondBackup)).NotTo(HaveOccurred())
+
+						result, err := reconcileBackup(secondBackup)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(result.Requeue).To(BeTrue())
+
+						status, err := adminClient.GetBackupStatus("offsite")
+						Expect(err).NotTo(HaveOccurred())
+						Expect(status.BackupAgentsPaused).To(BeTrue())
+					})
+				})
+			})
+		})
+
+		When("a second backup with the same tag is created for the same cluster", func() {
+			var secondBackup *fdbv1beta2.FoundationDBBackup
+
+			BeforeEach(func() {
+				generationGap = 0
+				secondBackup = internal.CreateDefaultBackup(cluster)
+				secondBackup.Name = "offsite"
+				secondBackup.Spec.BlobStoreConfiguration.BackupName = "offsite-backup"
+				Expect(k8sClient.Create(context.TODO(), secondBackup)).NotTo(HaveOccurred())
+			})
+
+			It("should not start the second backup", func() {
+				result, err := reconcileBackup(secondBackup)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Requeue).To(BeTrue())
+
+				status, err := adminClient.GetBackupStatus("default")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.DestinationURL).To(Equal("blobstore://test@test-service/test-backup?bucket=fdb-backups"))
+			})
+
+			When("the second backup is stopped", func() {
+				BeforeEach(func() {
+					secondBackup.Spec.BackupState = fdbv1beta2.BackupStateStopped
+					Expect(k8sClient.Update(context.TODO(), secondBackup)).NotTo(HaveOccurred())
+				})
+
+				It("should not stop the first backup", func() {
+					_, err := reconcileBackup(secondBackup)
+					Expect(err).NotTo(HaveOccurred())
+
+					status, err := adminClient.GetBackupStatus("default")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(status.Status.Running).To(BeTrue())
+					Expect(status.DestinationURL).To(Equal("blobstore://test@test-service/test-backup?bucket=fdb-backups"))
+
+					_, err = reloadBackup(secondBackup)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(secondBackup.OwnsBackupTag()).To(BeFalse())
+				})
+			})
+		})
+
+		When("a backup with a file system destination is created", func() {
+			var fileBackup *fdbv1beta2.FoundationDBBackup
+
+			BeforeEach(func() {
+				generationGap = 0
+				fileBackup = internal.CreateDefaultBackup(cluster)
+				fileBackup.Name = "local"
+				fileBackup.Spec.BackupTag = "local"
+				fileBackup.Spec.BlobStoreConfiguration = nil
+				fileBackup.Spec.FileSystemConfiguration = &fdbv1beta2.FileSystemConfiguration{
+					PersistentVolumeClaimName: "backup-pvc",
+				}
+				Expect(k8sClient.Create(context.TODO(), fileBackup)).NotTo(HaveOccurred())
+
+				_, err := reconcileBackup(fileBackup)
+				Expect(err).To(MatchError("fileSystemConfiguration is not supported, as the operator can't access the persistent volume"))
+				_, err = reloadBackup(fileBackup)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should not start the backup", func() {
+				status, err := adminClient.GetBackupStatus("local")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.Status.Running).To(BeFalse())
+			})
+
+			It("should mount the persistent volume claim into the backup agents", func() {
+				deployment := &appsv1.Deployment{}
+				Expect(k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: fileBackup.Namespace, Name: "local-backup-agents"}, deployment)).NotTo(HaveOccurred())
+				Expect(deployment.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+					Name: "backup-data",
+					VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: "backup-pvc",
+					}},
+				}))
+			})
+		})
+
 		Context("when pausing a backup", func() {
 			BeforeEach(func() {
 				backup.Spec.BackupState = fdbv1beta2.BackupStatePaused
@@ -208,7 +453,7 @@ var _ = Describe("backup_controller", func() {
 			})
 
 			It("should pause the backup", func() {
-				status, err := adminClient.GetBackupStatus()
+				status, err := adminClient.GetBackupStatus(backup.BackupTag())
 				Expect(err).NotTo(HaveOccurred())
 				Expect(status.BackupAgentsPaused).To(BeTrue())
 			})
@@ -225,7 +470,7 @@ var _ = Describe("backup_controller", func() {
 			})
 
 			It("should resume the backup", func() {
-				status, err := adminClient.GetBackupStatus()
+				status, err := adminClient.GetBackupStatus(backup.BackupTag())
 				Expect(err).NotTo(HaveOccurred())
 				Expect(status.BackupAgentsPaused).To(BeFalse())
 			})
@@ -240,7 +485,7 @@ var _ = Describe("backup_controller", func() {
 			})
 
 			It("should modify the backup", func() {
-				status, err := adminClient.GetBackupStatus()
+				status, err := adminClient.GetBackupStatus(backup.BackupTag())
 				Expect(err).NotTo(HaveOccurred())
 				Expect(status.SnapshotIntervalSeconds).To(Equal(100000))
 			})
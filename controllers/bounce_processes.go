@@ -30,9 +30,13 @@ import (
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/podclient"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // bounceProcesses provides a reconciliation step for bouncing fdbserver
@@ -62,18 +66,27 @@ func (bounceProcesses) reconcile(ctx context.Context, r *FoundationDBClusterReco
 		return &requeue{curError: err}
 	}
 
-	addresses, req := getProcessesReadyForRestart(logger, cluster, addressMap)
+	addresses, missingProcessGroups, req := getProcessesReadyForRestart(logger, cluster, addressMap)
 	if req != nil {
 		return req
 	}
 
-	if len(addresses) == 0 {
+	upgrading := cluster.IsBeingUpgradedWithVersionIncompatibleVersion()
+	// Processes that are missing in the machine-readable status can't be restarted with the kill command, those
+	// processes will be restarted through the sidecar if supported, except during an upgrade.
+	if upgrading {
+		missingProcessGroups = nil
+	}
+
+	if len(addresses) == 0 && len(missingProcessGroups) == 0 {
 		return nil
 	}
 
-	logger.V(1).Info("processes that can be restarted", "addresses", addresses)
+	logger.V(1).Info("processes that can be restarted", "addresses", addresses, "missingProcessGroups", missingProcessGroups)
 
-	if minimumUptime < float64(cluster.GetMinimumUptimeSecondsForBounce()) {
+	// A rollback of a failed upgrade must not wait for the minimum uptime, the database is unavailable anyway. The
+	// processes that are restarted through the sidecar are checked against the minimum uptime individually.
+	if len(addresses) > 0 && minimumUptime < float64(cluster.GetMinimumUptimeSecondsForBounce()) && !cluster.IsUpgradeRollback() {
 		r.Recorder.Event(cluster, corev1.EventTypeNormal, "NeedsBounce",
 			fmt.Sprintf("Spec require a bounce of some processes, but the cluster has only been up for %f seconds", minimumUptime))
 		cluster.Status.Generations.NeedsBounce = cluster.ObjectMeta.Generation
@@ -102,8 +115,6 @@ func (bounceProcesses) reconcile(ctx context.Context, r *FoundationDBClusterReco
 		return &requeue{curError: err}
 	}
 
-	upgrading := cluster.IsBeingUpgradedWithVersionIncompatibleVersion()
-
 	if useLocks && upgrading {
 		processGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(cluster.Status.ProcessGroups))
 		for _, processGroup := range cluster.Status.ProcessGroups {
@@ -138,17 +149,34 @@ func (bounceProcesses) reconcile(ctx context.Context, r *FoundationDBClusterReco
 		addresses = filteredAddresses
 	}
 
+	if len(missingProcessGroups) > 0 {
+		err = restartProcessesThroughSidecar(ctx, logger, r, cluster, filterIgnoredMissingProcessGroups(cluster, missingProcessGroups))
+		if err != nil {
+			return &requeue{curError: err}
+		}
+	}
+
 	if len(filteredAddresses) == 0 {
 		return nil
 	}
 
 	logger.Info("Bouncing processes", "addresses", addresses, "upgrading", upgrading)
 	r.Recorder.Event(cluster, corev1.EventTypeNormal, "BouncingProcesses", fmt.Sprintf("Bouncing processes: %v", addresses))
+	previousVersion := cluster.Status.RunningVersion
 	err = adminClient.KillProcesses(addresses)
 	if err != nil {
 		return &requeue{curError: err}
 	}
 
+	// The restart is only recorded once the kill command succeeded, otherwise a failed kill could be mistaken for a
+	// database that doesn't become available with the target version.
+	if upgrading {
+		err = recordUpgradeRestart(ctx, r, cluster, previousVersion)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+	}
+
 	// If the cluster was upgraded we will requeue and let the update_status command set the correct version.
 	// Updating the version in this method has the drawback that we upgrade the version independent of the success
 	// of the kill command. The kill command is not reliable, which means that some kill request might not be
@@ -160,12 +188,61 @@ func (bounceProcesses) reconcile(ctx context.Context, r *FoundationDBClusterReco
 	return nil
 }
 
-// getProcessesReadyForRestart returns a slice of process addresses that can be restarted. If addresses are missing or not all processes
-// have the latest configuration this method will return a requeue struct with more details.
-func getProcessesReadyForRestart(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, addressMap map[fdbv1beta2.ProcessGroupID][]fdbv1beta2.ProcessAddress) ([]fdbv1beta2.ProcessAddress, *requeue) {
+// recordUpgradeRestart records in the upgrade status that the processes were restarted with the target version. This
+// information is used to decide if a failed upgrade can be rolled back.
+func recordUpgradeRestart(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, previousVersion string) error {
+	// The status of the cluster could have been changed while the processes were restarted, so the upgrade status is
+	// recorded on the latest version of the cluster.
+	latestCluster := &fdbv1beta2.FoundationDBCluster{}
+	err := r.Get(ctx, client.ObjectKeyFromObject(cluster), latestCluster)
+	if err != nil {
+		return err
+	}
+
+	err = internal.LoadProcessGroups(ctx, r, latestCluster)
+	if err != nil {
+		return err
+	}
+
+	upgradeStatus := latestCluster.Status.Upgrade
+	if upgradeStatus == nil || upgradeStatus.TargetVersion != cluster.Spec.Version {
+		upgradeStatus = &fdbv1beta2.UpgradeStatus{
+			TargetVersion: cluster.Spec.Version,
+		}
+	}
+
+	upgradeStatus.Phase = fdbv1beta2.UpgradePhaseRestarting
+	upgradeStatus.PreviousVersion = previousVersion
+	upgradeStatus.RestartTimestamp = &metav1.Time{Time: time.Now()}
+	meta.SetStatusCondition(&upgradeStatus.Conditions, metav1.Condition{
+		Type:               fdbv1beta2.UpgradeConditionProcessesRestarted,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cluster.Generation,
+		Reason:             "ProcessesBounced",
+		Message:            fmt.Sprintf("Restarted processes with version %s", cluster.Spec.Version),
+	})
+	latestCluster.Status.Upgrade = upgradeStatus
+
+	err = r.updateOrApply(ctx, latestCluster)
+	if err != nil {
+		return err
+	}
+
+	cluster.ObjectMeta = latestCluster.ObjectMeta
+	cluster.Status.Upgrade = upgradeStatus
+
+	return nil
+}
+
+// getProcessesReadyForRestart returns a slice of process addresses that can be restarted and the process groups that
+// should be restarted but are missing in the machine-readable status for longer than the ignore missing processes
+// duration. If addresses are missing or not all processes have the latest configuration this method will return a
+// requeue struct with more details.
+func getProcessesReadyForRestart(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, addressMap map[fdbv1beta2.ProcessGroupID][]fdbv1beta2.ProcessAddress) ([]fdbv1beta2.ProcessAddress, []fdbv1beta2.ProcessGroupID, *requeue) {
 	addresses := make([]fdbv1beta2.ProcessAddress, 0, len(cluster.Status.ProcessGroups))
 	allSynced := true
 	var missingAddress []fdbv1beta2.ProcessGroupID
+	var missingProcessGroups []fdbv1beta2.ProcessGroupID
 
 	filterConditions := restarts.GetFilterConditions(cluster)
 	var missingProcesses int
@@ -181,6 +258,9 @@ func getProcessesReadyForRestart(logger logr.Logger, cluster *fdbv1beta2.Foundat
 			if time.Unix(*missingTime, 0).Add(cluster.GetIgnoreMissingProcessesSeconds()).Before(time.Now()) {
 				logger.Info("ignore process group with missing process", "processGroupID", processGroup.ProcessGroupID)
 				missingProcesses++
+				if addressMap[processGroup.ProcessGroupID] == nil && processGroup.MatchesConditions(filterConditions) {
+					missingProcessGroups = append(missingProcessGroups, processGroup.ProcessGroupID)
+				}
 				continue
 			}
 		}
@@ -204,16 +284,16 @@ func getProcessesReadyForRestart(logger logr.Logger, cluster *fdbv1beta2.Foundat
 	}
 
 	if len(missingAddress) > 0 {
-		return nil, &requeue{message: fmt.Sprintf("could not find address for processes: %s", missingAddress), delayedRequeue: true}
+		return nil, nil, &requeue{message: fmt.Sprintf("could not find address for processes: %s", missingAddress), delayedRequeue: true}
 	}
 
 	if !allSynced {
-		return nil, &requeue{message: "Waiting for config map to sync to all pods", delayedRequeue: true}
+		return nil, nil, &requeue{message: "Waiting for config map to sync to all pods", delayedRequeue: true}
 	}
 
 	counts, err := cluster.GetProcessCountsWithDefaults()
 	if err != nil {
-		return nil, &requeue{
+		return nil, nil, &requeue{
 			curError:       err,
 			delayedRequeue: true,
 		}
@@ -232,13 +312,107 @@ func getProcessesReadyForRestart(logger logr.Logger, cluster *fdbv1beta2.Foundat
 	}
 
 	if cluster.IsBeingUpgradedWithVersionIncompatibleVersion() && expectedProcesses != len(addresses) {
-		return nil, &requeue{
+		return nil, nil, &requeue{
 			message:        fmt.Sprintf("expected %d processes, got %d processes ready to restart", counts.Total(), len(addresses)),
 			delayedRequeue: true,
 		}
 	}
 
-	return addresses, nil
+	return addresses, missingProcessGroups, nil
+}
+
+// restartProcessesThroughSidecar restarts the fdbserver processes of the provided process groups through the sidecar.
+// This allows to restart processes that never joined the cluster, e.g. because of an incorrect command line. Processes
+// that were started less than the minimum uptime for a bounce ago will not be restarted again and process groups with
+// a sidecar that doesn't support restarts or doesn't report the process status will be skipped, as the uptime of
+// their processes is unknown.
+func restartProcessesThroughSidecar(ctx context.Context, logger logr.Logger, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, processGroupIDs []fdbv1beta2.ProcessGroupID) error {
+	minimumStartTime := time.Now().Add(-time.Duration(cluster.GetMinimumUptimeSecondsForBounce()) * time.Second).Unix()
+	restarted := make([]fdbv1beta2.ProcessGroupID, 0, len(processGroupIDs))
+
+	for _, processGroupID := range processGroupIDs {
+		pods, err := r.PodLifecycleManager.GetPods(ctx, r, cluster, internal.GetSinglePodListOptions(cluster, processGroupID)...)
+		if err != nil {
+			return err
+		}
+
+		if len(pods) == 0 {
+			logger.Info("Could not find Pod for process group", "processGroupID", processGroupID)
+			continue
+		}
+
+		podClient, message := r.getPodClient(cluster, pods[0])
+		if podClient == nil {
+			logger.Info("Could not create client for Pod", "processGroupID", processGroupID, "message", message)
+			continue
+		}
+
+		supported, err := podclient.HasCapability(podClient, podclient.CapabilityRestartProcess)
+		if err != nil {
+			logger.Info("Could not fetch the capabilities of the sidecar", "processGroupID", processGroupID, "error", err.Error())
+			continue
+		}
+
+		if !supported {
+			logger.Info("Sidecar doesn't support restarting processes", "processGroupID", processGroupID)
+			continue
+		}
+
+		supported, err = podclient.HasCapability(podClient, podclient.CapabilityProcessStatus)
+		if err != nil {
+			logger.Info("Could not fetch the capabilities of the sidecar", "processGroupID", processGroupID, "error", err.Error())
+			continue
+		}
+
+		if !supported {
+			logger.Info("Sidecar doesn't report the process status", "processGroupID", processGroupID)
+			continue
+		}
+
+		processNumbers, err := getProcessNumbersForRestart(podClient, minimumStartTime)
+		if err != nil {
+			return err
+		}
+
+		for _, processNumber := range processNumbers {
+			logger.Info("Restarting process through the sidecar", "processGroupID", processGroupID, "processNumber", processNumber)
+			err = podClient.RestartProcess(processNumber)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(processNumbers) > 0 {
+			restarted = append(restarted, processGroupID)
+		}
+	}
+
+	if len(restarted) > 0 {
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "RestartingProcesses", fmt.Sprintf("Restarting processes through the sidecar: %v", restarted))
+	}
+
+	return nil
+}
+
+// getProcessNumbersForRestart returns the process numbers of the processes in the Pod that should be restarted, based
+// on the process status reported by the sidecar. Processes that were started after the minimum start time will be
+// skipped.
+func getProcessNumbersForRestart(podClient podclient.FdbPodClient, minimumStartTime int64) ([]int, error) {
+	status, err := podClient.GetProcessStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	processNumbers := make([]int, 0, len(status.Processes))
+	for _, process := range status.Processes {
+		if process.Running && process.StartTimestamp > minimumStartTime {
+			continue
+		}
+
+		processNumbers = append(processNumbers, process.ProcessNumber)
+	}
+
+	return processNumbers, nil
 }
 
 // getAddressesForUpgrade checks that all processes in a cluster are ready to be
@@ -253,7 +427,7 @@ func getAddressesForUpgrade(logger logr.Logger, r *FoundationDBClusterReconciler
 	// processes where restarted before the operator issued the cluster wide restart. For version incompatible upgrades
 	// that would mean that the processes restarted earlier are not part of the cluster anymore leading to a fault tolerance
 	// drop.
-	if !databaseStatus.Client.DatabaseStatus.Available {
+	if !databaseStatus.Client.DatabaseStatus.Available && !cluster.IsUpgradeRollback() {
 		r.Recorder.Event(cluster, corev1.EventTypeNormal, "UpgradeRequeued", "Database is unavailable")
 		return nil, &requeue{message: "Deferring upgrade until database is available"}
 	}
@@ -286,6 +460,30 @@ func getAddressesForUpgrade(logger logr.Logger, r *FoundationDBClusterReconciler
 	return addresses, nil
 }
 
+// filterIgnoredMissingProcessGroups removes all process groups that are defined in the IgnoreDuringRestart list from
+// the provided process groups.
+func filterIgnoredMissingProcessGroups(cluster *fdbv1beta2.FoundationDBCluster, processGroupIDs []fdbv1beta2.ProcessGroupID) []fdbv1beta2.ProcessGroupID {
+	if len(cluster.Spec.Buggify.IgnoreDuringRestart) == 0 {
+		return processGroupIDs
+	}
+
+	ignoredIDs := make(map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None, len(cluster.Spec.Buggify.IgnoreDuringRestart))
+	for _, id := range cluster.Spec.Buggify.IgnoreDuringRestart {
+		ignoredIDs[id] = fdbv1beta2.None{}
+	}
+
+	filteredProcessGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(processGroupIDs))
+	for _, processGroupID := range processGroupIDs {
+		if _, ok := ignoredIDs[processGroupID]; ok {
+			continue
+		}
+
+		filteredProcessGroupIDs = append(filteredProcessGroupIDs, processGroupID)
+	}
+
+	return filteredProcessGroupIDs
+}
+
 // filterIgnoredProcessGroups removes all addresses from the addresses slice that are associated with a process group that should be ignored
 // during a restart.
 func filterIgnoredProcessGroups(cluster *fdbv1beta2.FoundationDBCluster, addresses []fdbv1beta2.ProcessAddress) ([]fdbv1beta2.ProcessAddress, bool) {
@@ -310,7 +508,7 @@ func filterIgnoredProcessGroups(cluster *fdbv1beta2.FoundationDBCluster, address
 		}
 	}
 
-	filteredAddresses := make([]fdbv1beta2.ProcessAddress, 0, len(addresses)-len(ignoredAddresses))
+	filteredAddresses := make([]fdbv1beta2.ProcessAddress, 0, len(addresses))
 	removedAddresses := false
 	for _, address := range addresses {
 		if _, ok := ignoredAddresses[address.MachineAddress()]; ok {
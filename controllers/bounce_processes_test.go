@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	mockpodclient "github.com/FoundationDB/fdb-kubernetes-operator/pkg/podclient/mock"
 	"k8s.io/utils/pointer"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
@@ -477,6 +478,59 @@ var _ = Describe("bounceProcesses", func() {
 		})
 	})
 
+	When("a process group with an incorrect command line is missing in the status", func() {
+		var missingProcessGroup *fdbv1beta2.ProcessGroupStatus
+
+		BeforeEach(func() {
+			missingProcessGroup = fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-1")
+			missingProcessGroup.UpdateCondition(fdbv1beta2.IncorrectCommandLine, true, nil, "")
+			missingProcessGroup.ProcessGroupConditions = append(missingProcessGroup.ProcessGroupConditions, &fdbv1beta2.ProcessGroupCondition{
+				ProcessGroupConditionType: fdbv1beta2.MissingProcesses,
+				Timestamp:                 time.Now().Add(-2 * time.Minute).Unix(),
+			})
+			adminClient.MockMissingProcessGroup(missingProcessGroup.ProcessGroupID, true)
+		})
+
+		It("should not requeue", func() {
+			Expect(requeue).To(BeNil())
+		})
+
+		It("should restart the process through the sidecar", func() {
+			Expect(adminClient.KilledAddresses).To(BeEmpty())
+
+			pods, err := clusterReconciler.PodLifecycleManager.GetPods(context.TODO(), k8sClient, cluster, internal.GetSinglePodListOptions(cluster, missingProcessGroup.ProcessGroupID)...)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pods).To(HaveLen(1))
+			Expect(mockpodclient.GetRestartedProcesses(pods[0])).To(ConsistOf(1))
+		})
+
+		When("the process group doesn't need a restart", func() {
+			BeforeEach(func() {
+				missingProcessGroup.UpdateCondition(fdbv1beta2.IncorrectCommandLine, false, nil, "")
+			})
+
+			It("should not restart the process through the sidecar", func() {
+				pods, err := clusterReconciler.PodLifecycleManager.GetPods(context.TODO(), k8sClient, cluster, internal.GetSinglePodListOptions(cluster, missingProcessGroup.ProcessGroupID)...)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pods).To(HaveLen(1))
+				Expect(mockpodclient.GetRestartedProcesses(pods[0])).To(BeEmpty())
+			})
+		})
+
+		When("the process group is ignored during restarts", func() {
+			BeforeEach(func() {
+				cluster.Spec.Buggify.IgnoreDuringRestart = []fdbv1beta2.ProcessGroupID{missingProcessGroup.ProcessGroupID}
+			})
+
+			It("should not restart the process through the sidecar", func() {
+				pods, err := clusterReconciler.PodLifecycleManager.GetPods(context.TODO(), k8sClient, cluster, internal.GetSinglePodListOptions(cluster, missingProcessGroup.ProcessGroupID)...)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pods).To(HaveLen(1))
+				Expect(mockpodclient.GetRestartedProcesses(pods[0])).To(BeEmpty())
+			})
+		})
+	})
+
 	When("the buggify option ignoreDuringRestart is set", func() {
 		var ignoredProcessGroup *fdbv1beta2.ProcessGroupStatus
 
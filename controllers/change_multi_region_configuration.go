@@ -0,0 +1,115 @@
+/*
+ * change_multi_region_configuration.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// maximumDataCenterLagSeconds defines the maximum lag of the remote data centers in seconds before the primary data
+// center of a multi-region cluster will be changed, this matches the default of a FoundationDBFailover.
+const maximumDataCenterLagSeconds = 5
+
+// changeMultiRegionConfiguration provides a reconciliation step for changing the number of usable regions and the
+// primary data center of a multi-region cluster. Only one change will be made at a time and only if the clusters in
+// all data centers are reconciled. An increase of the usable regions is made before the primary data center is
+// changed and a reduction of the usable regions only after the database is running in the primary data center.
+type changeMultiRegionConfiguration struct{}
+
+// reconcile runs the reconciler's work.
+func (c changeMultiRegionConfiguration) reconcile(ctx context.Context, r *FoundationDBMultiRegionClusterReconciler, multiRegionCluster *fdbv1beta2.FoundationDBMultiRegionCluster) *requeue {
+	usableRegions := multiRegionCluster.GetUsableRegions()
+	primaryDataCenter := multiRegionCluster.GetPrimaryDataCenter()
+	if multiRegionCluster.Status.UsableRegions == usableRegions && multiRegionCluster.Status.PrimaryDataCenter == primaryDataCenter {
+		return nil
+	}
+
+	if !multiRegionCluster.AllDataCentersReconciled() {
+		return &requeue{message: "Waiting for the clusters in all data centers to be reconciled", delay: multiRegionClusterRequeueDelay}
+	}
+
+	logger := log.WithValues("namespace", multiRegionCluster.Namespace, "multiRegionCluster", multiRegionCluster.Name, "reconciler", "changeMultiRegionConfiguration")
+
+	// Increasing the number of usable regions first makes sure that the remote region is usable before we fail over.
+	if multiRegionCluster.Status.UsableRegions < usableRegions {
+		return c.changeUsableRegions(ctx, logger, r, multiRegionCluster, usableRegions)
+	}
+
+	if multiRegionCluster.Status.PrimaryDataCenter != primaryDataCenter {
+		status, err := r.getStatus(ctx, multiRegionCluster, multiRegionCluster.Status.PrimaryDataCenter)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		err = checkRemoteDataCentersCaughtUp(status, maximumDataCenterLagSeconds)
+		if err != nil {
+			logger.Info("Waiting for remote data centers to catch up", "reason", err.Error())
+			return &requeue{message: err.Error(), delay: multiRegionClusterRequeueDelay}
+		}
+
+		logger.Info("Changing primary data center", "current", multiRegionCluster.Status.PrimaryDataCenter, "desired", primaryDataCenter)
+		r.Recorder.Event(multiRegionCluster, corev1.EventTypeNormal, "ChangingPrimaryDataCenter", fmt.Sprintf("Changing primary data center from %s to %s", multiRegionCluster.Status.PrimaryDataCenter, primaryDataCenter))
+		multiRegionCluster.Status.PrimaryDataCenter = primaryDataCenter
+		err = r.updateOrApply(ctx, multiRegionCluster)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		return &requeue{message: "Changed primary data center"}
+	}
+
+	// Reducing the number of usable regions drops the data of the remote region, so we have to wait until the database
+	// is running in the primary data center and the data distribution is healthy. The region priorities of the
+	// database configuration are not sufficient, as they change before the database has recovered in the new primary.
+	status, err := r.getStatus(ctx, multiRegionCluster, primaryDataCenter)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	err = checkPrimaryDataCenterRunning(status, primaryDataCenter)
+	if err != nil {
+		return &requeue{message: fmt.Sprintf("Waiting for data center %s to become the primary data center before reducing the usable regions: %s", primaryDataCenter, err.Error()), delay: multiRegionClusterRequeueDelay}
+	}
+
+	if !status.Cluster.Data.State.Healthy {
+		return &requeue{message: fmt.Sprintf("Waiting for the data distribution to be healthy before reducing the usable regions, current state: %s", status.Cluster.Data.State.Name), delay: multiRegionClusterRequeueDelay}
+	}
+
+	return c.changeUsableRegions(ctx, logger, r, multiRegionCluster, usableRegions)
+}
+
+// changeUsableRegions updates the usable regions in the status of the multi-region cluster, which will be applied to
+// the clusters of all data centers.
+func (c changeMultiRegionConfiguration) changeUsableRegions(ctx context.Context, logger logr.Logger, r *FoundationDBMultiRegionClusterReconciler, multiRegionCluster *fdbv1beta2.FoundationDBMultiRegionCluster, usableRegions int) *requeue {
+	logger.Info("Changing usable regions", "current", multiRegionCluster.Status.UsableRegions, "desired", usableRegions)
+	multiRegionCluster.Status.UsableRegions = usableRegions
+	err := r.updateOrApply(ctx, multiRegionCluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return &requeue{message: "Changed usable regions"}
+}
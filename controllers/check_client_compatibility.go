@@ -23,20 +23,32 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 )
 
+const (
+	// maxIncompatibleClientGroups defines how many log groups with incompatible clients are reported in the upgrade
+	// status.
+	maxIncompatibleClientGroups = 20
+
+	// maxIncompatibleClientAddresses defines how many addresses of incompatible clients are reported per log group
+	// in the upgrade status.
+	maxIncompatibleClientAddresses = 10
+)
+
 // checkClientCompatibility confirms that all clients are compatible with the
 // version of FoundationDB configured on the cluster.
 type checkClientCompatibility struct{}
 
 // reconcile runs the reconciler's work.
-func (c checkClientCompatibility) reconcile(_ context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster) *requeue {
+func (c checkClientCompatibility) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster) *requeue {
 	logger := log.WithValues("namespace", cluster.Namespace, "cluster", cluster.Name, "reconciler", "checkClientCompatibility")
 	if !cluster.Status.Configured && !cluster.IsBeingUpgraded() {
 		return nil
@@ -52,6 +64,11 @@ func (c checkClientCompatibility) reconcile(_ context.Context, r *FoundationDBCl
 		return &requeue{curError: err}
 	}
 
+	// A rollback of a failed upgrade must be possible even if the clients were already upgraded.
+	if cluster.IsUpgradeRollback() {
+		return nil
+	}
+
 	if !version.IsAtLeast(runningVersion) && !version.IsProtocolCompatible(runningVersion) {
 		return &requeue{message: fmt.Sprintf("cluster downgrade operation is only supported for protocol compatible versions, running version %s and desired version %s are not compatible", runningVersion, version)}
 	}
@@ -79,12 +96,14 @@ func (c checkClientCompatibility) reconcile(_ context.Context, r *FoundationDBCl
 		return &requeue{curError: err}
 	}
 
-	ignoredLogGroups := make(map[string]fdbv1beta2.None)
-	for _, logGroup := range cluster.Spec.AutomationOptions.IgnoreLogGroupsForUpgrade {
-		ignoredLogGroups[logGroup] = fdbv1beta2.None{}
-	}
+	ignoredLogGroups := getIgnoredLogGroups(cluster)
 	unsupportedClients := getUnsupportedClients(status.Cluster.Clients.SupportedVersions, protocolVersion, ignoredLogGroups)
 
+	err = updateIncompatibleClients(ctx, r, cluster, getIncompatibleClientGroups(status.Cluster.Clients.SupportedVersions, protocolVersion, ignoredLogGroups), len(unsupportedClients))
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
 	if len(unsupportedClients) > 0 {
 		message := fmt.Sprintf(
 			"%d clients do not support version %s: %s", len(unsupportedClients),
@@ -98,6 +117,46 @@ func (c checkClientCompatibility) reconcile(_ context.Context, r *FoundationDBCl
 	return nil
 }
 
+// updateIncompatibleClients reports the clients that don't support the target version in the upgrade status of the
+// cluster. The status is only updated if the reported clients have changed.
+func updateIncompatibleClients(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, groups []fdbv1beta2.IncompatibleClientGroup, incompatibleClients int) error {
+	upgradeStatus := cluster.Status.Upgrade
+	if upgradeStatus == nil || upgradeStatus.TargetVersion != cluster.Spec.Version {
+		return nil
+	}
+
+	var blockedReason string
+	if len(groups) > 0 {
+		blockedReason = getIncompatibleClientsBlockedReason(incompatibleClients, cluster.Spec.Version)
+	} else if len(upgradeStatus.IncompatibleClients) == 0 {
+		return nil
+	}
+
+	if equality.Semantic.DeepEqual(upgradeStatus.IncompatibleClients, groups) && upgradeStatus.BlockedReason == blockedReason {
+		return nil
+	}
+
+	upgradeStatus.IncompatibleClients = groups
+	upgradeStatus.BlockedReason = blockedReason
+
+	return r.updateOrApply(ctx, cluster)
+}
+
+// getIncompatibleClientsBlockedReason returns the reason why the version change is blocked by incompatible clients.
+func getIncompatibleClientsBlockedReason(incompatibleClients int, version string) string {
+	return fmt.Sprintf("%d clients do not support version %s", incompatibleClients, version)
+}
+
+// getIgnoredLogGroups returns the log groups that should be ignored when checking the client compatibility.
+func getIgnoredLogGroups(cluster *fdbv1beta2.FoundationDBCluster) map[string]fdbv1beta2.None {
+	ignoredLogGroups := make(map[string]fdbv1beta2.None)
+	for _, logGroup := range cluster.Spec.AutomationOptions.IgnoreLogGroupsForUpgrade {
+		ignoredLogGroups[logGroup] = fdbv1beta2.None{}
+	}
+
+	return ignoredLogGroups
+}
+
 func getUnsupportedClients(supportedVersions []fdbv1beta2.FoundationDBStatusSupportedVersion, protocolVersion string, ignoredLogGroups map[string]fdbv1beta2.None) []string {
 	var unsupportedClients []string
 	for _, versionInfo := range supportedVersions {
@@ -116,3 +175,52 @@ func getUnsupportedClients(supportedVersions []fdbv1beta2.FoundationDBStatusSupp
 	}
 	return unsupportedClients
 }
+
+// getIncompatibleClientGroups returns the clients that don't support the provided protocol version grouped by their
+// log group. The groups and the addresses are sorted to provide a stable result. To limit the size of the cluster
+// status only the first maxIncompatibleClientGroups groups and the first maxIncompatibleClientAddresses addresses of
+// every group are returned, the number of clients in a group is always reported.
+func getIncompatibleClientGroups(supportedVersions []fdbv1beta2.FoundationDBStatusSupportedVersion, protocolVersion string, ignoredLogGroups map[string]fdbv1beta2.None) []fdbv1beta2.IncompatibleClientGroup {
+	addressesByLogGroup := make(map[string][]string)
+	for _, versionInfo := range supportedVersions {
+		if versionInfo.ProtocolVersion == "Unknown" || versionInfo.ProtocolVersion == protocolVersion {
+			continue
+		}
+
+		for _, client := range versionInfo.MaxProtocolClients {
+			if _, ok := ignoredLogGroups[client.LogGroup]; ok {
+				continue
+			}
+			addressesByLogGroup[client.LogGroup] = append(addressesByLogGroup[client.LogGroup], client.Address)
+		}
+	}
+
+	if len(addressesByLogGroup) == 0 {
+		return nil
+	}
+
+	groups := make([]fdbv1beta2.IncompatibleClientGroup, 0, len(addressesByLogGroup))
+	for logGroup, addresses := range addressesByLogGroup {
+		sort.Strings(addresses)
+		count := len(addresses)
+		if count > maxIncompatibleClientAddresses {
+			addresses = addresses[:maxIncompatibleClientAddresses]
+		}
+
+		groups = append(groups, fdbv1beta2.IncompatibleClientGroup{
+			LogGroup:  logGroup,
+			Addresses: addresses,
+			Count:     count,
+		})
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].LogGroup < groups[j].LogGroup
+	})
+
+	if len(groups) > maxIncompatibleClientGroups {
+		groups = groups[:maxIncompatibleClientGroups]
+	}
+
+	return groups
+}
@@ -21,6 +21,8 @@
 package controllers
 
 import (
+	"fmt"
+
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -244,4 +246,45 @@ var _ = Describe("check client compatibility", func() {
 				}),
 		)
 	})
+
+	When("getting the incompatible clients grouped by their log group", func() {
+		It("should return the sorted groups", func() {
+			groups := getIncompatibleClientGroups(supportedVersion, "fdb00b063010001", map[string]fdbv1beta2.None{"sample-cluster-client": {}})
+			Expect(groups).To(Equal([]fdbv1beta2.IncompatibleClientGroup{
+				{
+					LogGroup:  "fdb-kubernetes-operator",
+					Addresses: []string{"10.1.18.249:34874", "10.1.18.249:35022"},
+					Count:     2,
+				},
+			}))
+		})
+
+		It("should limit the number of reported groups and addresses", func() {
+			clients := make([]fdbv1beta2.FoundationDBStatusConnectedClient, 0, 30*15)
+			for group := 0; group < 30; group++ {
+				for client := 0; client < 15; client++ {
+					clients = append(clients, fdbv1beta2.FoundationDBStatusConnectedClient{
+						Address:  fmt.Sprintf("10.1.%d.%d:4500", group, client),
+						LogGroup: fmt.Sprintf("group-%02d", group),
+					})
+				}
+			}
+
+			groups := getIncompatibleClientGroups([]fdbv1beta2.FoundationDBStatusSupportedVersion{
+				{
+					ClientVersion:      "6.2.15",
+					MaxProtocolClients: clients,
+					ProtocolVersion:    "fdb00b062010001",
+				},
+			}, "fdb00b063010001", nil)
+			Expect(groups).To(HaveLen(maxIncompatibleClientGroups))
+			Expect(groups[0].LogGroup).To(Equal("group-00"))
+			Expect(groups[0].Addresses).To(HaveLen(maxIncompatibleClientAddresses))
+			Expect(groups[0].Count).To(Equal(15))
+		})
+
+		It("should return nil if all clients support the protocol version", func() {
+			Expect(getIncompatibleClientGroups(supportedVersion, "fdb00b062010002", nil)).To(BeNil())
+		})
+	})
 })
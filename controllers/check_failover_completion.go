@@ -0,0 +1,68 @@
+/*
+ * check_failover_completion.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+)
+
+// checkFailoverCompletion provides a reconciliation step for marking a failover as completed once the database is
+// fully recovered in the target data center.
+type checkFailoverCompletion struct{}
+
+// reconcile runs the reconciler's work.
+func (c checkFailoverCompletion) reconcile(ctx context.Context, r *FoundationDBFailoverReconciler, failover *fdbv1beta2.FoundationDBFailover) *requeue {
+	if failover.Status.State != fdbv1beta2.FailoverStateFailingOver {
+		return nil
+	}
+
+	cluster, err := r.getCluster(ctx, failover)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	if cluster.Status.Generations.Reconciled < cluster.Generation {
+		return &requeue{message: fmt.Sprintf("Waiting for cluster %s to be reconciled", cluster.Name), delay: failoverRequeueDelay}
+	}
+
+	status, err := r.getStatus(cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	// The region priorities of the database configuration change as soon as the configuration is applied, so the
+	// failover is only completed once the database has recovered in the target data center.
+	err = checkPrimaryDataCenterRunning(status, failover.Spec.TargetDataCenter)
+	if err != nil {
+		return &requeue{message: fmt.Sprintf("Waiting for data center %s to become the primary data center: %s", failover.Spec.TargetDataCenter, err.Error()), delay: failoverRequeueDelay}
+	}
+
+	log.Info("Failover completed", "namespace", failover.Namespace, "failover", failover.Name, "primary", failover.Spec.TargetDataCenter)
+	err = r.finishFailover(ctx, failover, fdbv1beta2.FailoverStateCompleted, fmt.Sprintf("data center %s is the primary data center", failover.Spec.TargetDataCenter))
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
@@ -25,6 +25,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"sync"
 	"time"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
@@ -34,13 +35,20 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/podclient"
 	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -55,18 +63,79 @@ type FoundationDBClusterReconciler struct {
 	EnableRestartIncompatibleProcesses bool
 	ServerSideApply                    bool
 	EnableRecoveryState                bool
+	EnableNodeWatch                    bool
 	PodLifecycleManager                podmanager.PodLifecycleManager
 	PodClientProvider                  func(*fdbv1beta2.FoundationDBCluster, *corev1.Pod) (podclient.FdbPodClient, error)
 	DatabaseClientProvider             fdbadminclient.DatabaseClientProvider
 	DeprecationOptions                 internal.DeprecationOptions
 	GetTimeout                         time.Duration
 	PostTimeout                        time.Duration
+	// sidecarAPITokens caches the tokens for the sidecar API per cluster. If
+	// unset, the token is read from the Secret for every pod client.
+	sidecarAPITokens *sidecarAPITokenCache
+	// sidecarCapabilities caches the capabilities of the sidecar API per
+	// cluster. If unset, the capabilities are fetched by every pod client.
+	sidecarCapabilities *internal.SidecarCapabilitiesCache
+}
+
+// sidecarAPITokenCacheDuration defines how long a token for the sidecar API is
+// cached before the Secret is read again.
+const sidecarAPITokenCacheDuration = 5 * time.Minute
+
+// sidecarAPITokenCache caches the tokens for the sidecar API, so the Secret
+// doesn't have to be read for every pod client.
+type sidecarAPITokenCache struct {
+	lock   sync.Mutex
+	tokens map[types.NamespacedName]sidecarAPIToken
+}
+
+// sidecarAPIToken represents a cached token for the sidecar API.
+type sidecarAPIToken struct {
+	// secretName defines the name of the Secret the token was read from.
+	secretName string
+	// token defines the token from the Secret.
+	token string
+	// expiration defines when the token must be read again from the Secret.
+	expiration time.Time
+}
+
+// newSidecarAPITokenCache creates an empty sidecarAPITokenCache.
+func newSidecarAPITokenCache() *sidecarAPITokenCache {
+	return &sidecarAPITokenCache{tokens: map[types.NamespacedName]sidecarAPIToken{}}
+}
+
+// get returns the cached token for the cluster if the token was read from the
+// provided Secret and is not expired.
+func (cache *sidecarAPITokenCache) get(key types.NamespacedName, secretName string) (string, bool) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	entry, ok := cache.tokens[key]
+	if !ok || entry.secretName != secretName || time.Now().After(entry.expiration) {
+		return "", false
+	}
+
+	return entry.token, true
+}
+
+// set caches the token for the cluster.
+func (cache *sidecarAPITokenCache) set(key types.NamespacedName, secretName string, token string) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	cache.tokens[key] = sidecarAPIToken{
+		secretName: secretName,
+		token:      token,
+		expiration: time.Now().Add(sidecarAPITokenCacheDuration),
+	}
 }
 
 // NewFoundationDBClusterReconciler creates a new FoundationDBClusterReconciler with defaults.
 func NewFoundationDBClusterReconciler(podLifecycleManager podmanager.PodLifecycleManager) *FoundationDBClusterReconciler {
 	r := &FoundationDBClusterReconciler{
 		PodLifecycleManager: podLifecycleManager,
+		sidecarAPITokens:    newSidecarAPITokenCache(),
+		sidecarCapabilities: internal.NewSidecarCapabilitiesCache(),
 	}
 	r.PodClientProvider = r.newFdbPodClient
 
@@ -75,7 +144,10 @@ func NewFoundationDBClusterReconciler(podLifecycleManager podmanager.PodLifecycl
 
 // +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbclusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbclusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbprocessgroups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbprocessgroups/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=pods;configmaps;persistentvolumeclaims;events;secrets;services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="coordination.k8s.io",resources=leases,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile runs the reconciliation logic.
@@ -104,6 +176,11 @@ func (r *FoundationDBClusterReconciler) Reconcile(ctx context.Context, request c
 		return ctrl.Result{}, err
 	}
 
+	err = r.loadProcessGroups(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	adminClient, err := r.getDatabaseClientProvider().GetAdminClient(cluster, r)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -127,11 +204,16 @@ func (r *FoundationDBClusterReconciler) Reconcile(ctx context.Context, request c
 	subReconcilers := []clusterSubReconciler{
 		updateStatus{},
 		updateLockConfiguration{},
+		rollbackUpgrade{},
 		updateConfigMap{},
 		checkClientCompatibility{},
 		deletePodsForBuggification{},
+		applyResourceRecommendations{},
 		replaceMisconfiguredProcessGroups{},
 		replaceFailedProcessGroups{},
+		replaceHotspotProcessGroups{},
+		migrateStorageEngine{},
+		taintedNodeMaintenance{},
 		addProcessGroups{},
 		addServices{},
 		addPVCs{},
@@ -189,7 +271,21 @@ func (r *FoundationDBClusterReconciler) Reconcile(ctx context.Context, request c
 	clusterLog.Info("Reconciliation complete", "generation", cluster.Status.Generations.Reconciled)
 	r.Recorder.Event(cluster, corev1.EventTypeNormal, "ReconciliationComplete", fmt.Sprintf("Reconciled generation %d", cluster.Status.Generations.Reconciled))
 
-	return ctrl.Result{}, nil
+	// The process metrics change without any change to the cluster resource, so the cluster must be reconciled
+	// periodically to detect hotspots and to sample the resource usage.
+	var requeueAfter time.Duration
+	if cluster.GetEnableHotspotRemediation() {
+		requeueAfter = hotspotDetectionInterval
+	}
+
+	if cluster.GetEnableResourceRecommendations() {
+		sampleInterval := cluster.GetResourceRecommendationSampleInterval()
+		if requeueAfter == 0 || sampleInterval < requeueAfter {
+			requeueAfter = sampleInterval
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 // SetupWithManager prepares a reconciler for use.
@@ -219,6 +315,30 @@ func (r *FoundationDBClusterReconciler) SetupWithManager(mgr ctrl.Manager, maxCo
 		return err
 	}
 
+	// Only react on generation changes, annotation changes or changes of the
+	// primary data center by a failover and only watch resources with the
+	// provided label selector.
+	eventFilter := predicate.And(
+		labelSelectorPredicate,
+		predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+			primaryDataCenterOverrideChangedPredicate(),
+		),
+	)
+
+	if r.EnableNodeWatch {
+		err = mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Pod{}, "spec.nodeName", func(o client.Object) []string {
+			return []string{o.(*corev1.Pod).Spec.NodeName}
+		})
+		if err != nil {
+			return err
+		}
+
+		// Nodes don't carry the labels of the cluster, so the taint changes are handled separately.
+		eventFilter = predicate.Or(nodeTaintChangedPredicate(), eventFilter)
+	}
+
 	builder := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: maxConcurrentReconciles},
@@ -228,16 +348,18 @@ func (r *FoundationDBClusterReconciler) SetupWithManager(mgr ctrl.Manager, maxCo
 		Owns(&corev1.PersistentVolumeClaim{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&corev1.Service{}).
-		// Only react on generation changes or annotation changes and only watch
-		// resources with the provided label selector.
-		WithEventFilter(
-			predicate.And(
-				labelSelectorPredicate,
-				predicate.Or(
-					predicate.GenerationChangedPredicate{},
-					predicate.AnnotationChangedPredicate{},
-				),
-			))
+		WithEventFilter(eventFilter)
+
+	if r.EnableNodeWatch {
+		builder.Watches(&source.Kind{Type: &corev1.Node{}}, handler.EnqueueRequestsFromMapFunc(r.findFoundationDBClustersForNode))
+	}
+
+	// The Pods are owned by the StatefulSets, which are owned by the cluster, so the events of the Pods must be mapped
+	// to the owner of the StatefulSet.
+	if _, ok := r.PodLifecycleManager.(podmanager.StatefulSetPodLifecycleManager); ok {
+		builder.Owns(&appsv1.StatefulSet{})
+		builder.Watches(&source.Kind{Type: &corev1.Pod{}}, handler.EnqueueRequestsFromMapFunc(r.findFoundationDBClusterForStatefulSetPod))
+	}
 
 	for _, object := range watchedObjects {
 		builder.Owns(object)
@@ -245,6 +367,131 @@ func (r *FoundationDBClusterReconciler) SetupWithManager(mgr ctrl.Manager, maxCo
 	return builder.Complete(r)
 }
 
+// nodeTaintChangedPredicate returns a predicate that only accepts updates of nodes where the taints or the
+// unschedulable flag have changed. All other objects are rejected.
+func nodeTaintChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(_ event.CreateEvent) bool {
+			return false
+		},
+		DeleteFunc: func(_ event.DeleteEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, ok := e.ObjectOld.(*corev1.Node)
+			if !ok {
+				return false
+			}
+
+			newNode, ok := e.ObjectNew.(*corev1.Node)
+			if !ok {
+				return false
+			}
+
+			return oldNode.Spec.Unschedulable != newNode.Spec.Unschedulable || !equality.Semantic.DeepEqual(oldNode.Spec.Taints, newNode.Spec.Taints)
+		},
+		GenericFunc: func(_ event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// primaryDataCenterOverrideChangedPredicate returns a predicate that only accepts updates of clusters and multi-region
+// clusters where the primary data center override in the status has changed. A failover only changes the status, so
+// the generation of the resource is not changed.
+func primaryDataCenterOverrideChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(_ event.CreateEvent) bool {
+			return false
+		},
+		DeleteFunc: func(_ event.DeleteEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !equality.Semantic.DeepEqual(getPrimaryDataCenterOverride(e.ObjectOld), getPrimaryDataCenterOverride(e.ObjectNew))
+		},
+		GenericFunc: func(_ event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// getPrimaryDataCenterOverride returns the primary data center override of a cluster or a multi-region cluster.
+func getPrimaryDataCenterOverride(object client.Object) *fdbv1beta2.PrimaryDataCenterOverride {
+	switch typedObject := object.(type) {
+	case *fdbv1beta2.FoundationDBCluster:
+		return typedObject.Status.PrimaryDataCenterOverride
+	case *fdbv1beta2.FoundationDBMultiRegionCluster:
+		return typedObject.Status.PrimaryDataCenterOverride
+	default:
+		return nil
+	}
+}
+
+// findFoundationDBClustersForNode returns the reconcile requests for all clusters that have Pods running on the node.
+func (r *FoundationDBClusterReconciler) findFoundationDBClustersForNode(object client.Object) []reconcile.Request {
+	pods := &corev1.PodList{}
+	err := r.List(context.Background(), pods, client.MatchingFields{"spec.nodeName": object.GetName()})
+	if err != nil {
+		log.Error(err, "could not list Pods for node", "node", object.GetName())
+		return nil
+	}
+
+	clusters := make(map[types.NamespacedName]fdbv1beta2.None)
+	for _, pod := range pods.Items {
+		owner := metav1.GetControllerOf(&pod)
+		if owner == nil {
+			continue
+		}
+
+		// Pods that are managed by a StatefulSet are mapped to the cluster that owns the StatefulSet.
+		if owner.Kind == "StatefulSet" {
+			for _, request := range r.findFoundationDBClusterForStatefulSetPod(&pod) {
+				clusters[request.NamespacedName] = fdbv1beta2.None{}
+			}
+			continue
+		}
+
+		if owner.Kind != "FoundationDBCluster" {
+			continue
+		}
+
+		clusters[types.NamespacedName{Namespace: pod.Namespace, Name: owner.Name}] = fdbv1beta2.None{}
+	}
+
+	requests := make([]reconcile.Request, 0, len(clusters))
+	for cluster := range clusters {
+		requests = append(requests, reconcile.Request{NamespacedName: cluster})
+	}
+
+	return requests
+}
+
+// findFoundationDBClusterForStatefulSetPod returns the reconcile request for the cluster that owns the StatefulSet of
+// the Pod.
+func (r *FoundationDBClusterReconciler) findFoundationDBClusterForStatefulSetPod(object client.Object) []reconcile.Request {
+	owner := metav1.GetControllerOf(object)
+	if owner == nil || owner.Kind != "StatefulSet" {
+		return nil
+	}
+
+	statefulSet := &appsv1.StatefulSet{}
+	err := r.Get(context.Background(), client.ObjectKey{Namespace: object.GetNamespace(), Name: owner.Name}, statefulSet)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			log.Error(err, "could not get StatefulSet for Pod", "namespace", object.GetNamespace(), "pod", object.GetName())
+		}
+		return nil
+	}
+
+	clusterOwner := metav1.GetControllerOf(statefulSet)
+	if clusterOwner == nil || clusterOwner.Kind != "FoundationDBCluster" {
+		return nil
+	}
+
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: object.GetNamespace(), Name: clusterOwner.Name}}}
+}
+
 func (r *FoundationDBClusterReconciler) updatePodDynamicConf(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, pod *corev1.Pod) (bool, error) {
 	if cluster.ProcessGroupIsBeingRemoved(podmanager.GetProcessGroupID(cluster, pod)) {
 		return true, nil
@@ -373,7 +620,46 @@ type clusterSubReconciler interface {
 
 // newFdbPodClient builds a client for working with an FDB Pod
 func (r *FoundationDBClusterReconciler) newFdbPodClient(cluster *fdbv1beta2.FoundationDBCluster, pod *corev1.Pod) (podclient.FdbPodClient, error) {
-	return internal.NewFdbPodClient(cluster, pod, log.WithValues("namespace", cluster.Namespace, "cluster", cluster.Name, "pod", pod.Name), r.GetTimeout, r.PostTimeout)
+	apiToken, err := r.getSidecarAPIToken(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return internal.NewFdbPodClient(cluster, pod, log.WithValues("namespace", cluster.Namespace, "cluster", cluster.Name, "pod", pod.Name), r.GetTimeout, r.PostTimeout, apiToken, r.sidecarCapabilities)
+}
+
+// getSidecarAPIToken returns the token to authenticate requests against the sidecar API or an empty string if no
+// token is defined for this cluster. The token is cached for sidecarAPITokenCacheDuration.
+func (r *FoundationDBClusterReconciler) getSidecarAPIToken(cluster *fdbv1beta2.FoundationDBCluster) (string, error) {
+	secretName := cluster.GetSidecarAPITokenSecretName()
+	if secretName == "" {
+		return "", nil
+	}
+
+	key := client.ObjectKeyFromObject(cluster)
+	if r.sidecarAPITokens != nil {
+		token, ok := r.sidecarAPITokens.get(key, secretName)
+		if ok {
+			return token, nil
+		}
+	}
+
+	secret := &corev1.Secret{}
+	err := r.Get(context.TODO(), client.ObjectKey{Namespace: cluster.Namespace, Name: secretName}, secret)
+	if err != nil {
+		return "", err
+	}
+
+	token, ok := secret.Data[fdbv1beta2.SidecarAPITokenSecretKey]
+	if !ok {
+		return "", fmt.Errorf("secret %s/%s has no key %s", cluster.Namespace, secretName, fdbv1beta2.SidecarAPITokenSecretKey)
+	}
+
+	if r.sidecarAPITokens != nil {
+		r.sidecarAPITokens.set(key, secretName, string(token))
+	}
+
+	return string(token), nil
 }
 
 func (r *FoundationDBClusterReconciler) getCoordinatorSet(cluster *fdbv1beta2.FoundationDBCluster) (map[string]fdbv1beta2.None, error) {
@@ -386,26 +672,56 @@ func (r *FoundationDBClusterReconciler) getCoordinatorSet(cluster *fdbv1beta2.Fo
 	return adminClient.GetCoordinatorSet()
 }
 
-// updateOrApply updates the status either with server-side apply or if disabled with the normal update call.
+// updateOrApply updates the status either with server-side apply or if disabled with the normal update call. If the
+// cluster makes use of FoundationDBProcessGroup resources, the process groups will be written to those resources
+// and not to the cluster status. Readers of the cluster must use internal.LoadProcessGroups to get the process groups.
+// If the FoundationDBProcessGroup resources were disabled, the resources will be deleted once the process groups
+// are written to the cluster status.
 func (r *FoundationDBClusterReconciler) updateOrApply(ctx context.Context, cluster *fdbv1beta2.FoundationDBCluster) error {
-	if r.ServerSideApply {
-		// TODO(johscheuer): We have to set the TypeMeta otherwise the Patch command will fail. This is the rudimentary
-		// support for server side apply which should be enough for the status use case. The controller runtime will
-		// add some additional support in the future: https://github.com/kubernetes-sigs/controller-runtime/issues/347.
-		patch := &fdbv1beta2.FoundationDBCluster{
-			TypeMeta: metav1.TypeMeta{
-				Kind:       cluster.Kind,
-				APIVersion: cluster.APIVersion,
-			},
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      cluster.Name,
-				Namespace: cluster.Namespace,
-			},
-			Status: cluster.Status,
+	if cluster.UseProcessGroupResources() {
+		err := r.updateProcessGroupResources(ctx, cluster)
+		if err != nil {
+			return err
+		}
+
+		cluster.Status.HasProcessGroupResources = true
+		clusterWithoutProcessGroups := cluster.DeepCopy()
+		clusterWithoutProcessGroups.Status.ProcessGroups = nil
+
+		err = r.updateOrApplyClusterStatus(ctx, clusterWithoutProcessGroups)
+		if err != nil {
+			return err
 		}
 
-		return r.Status().Patch(ctx, patch, client.Apply, client.FieldOwner("fdb-operator"), client.ForceOwnership)
+		cluster.ObjectMeta.ResourceVersion = clusterWithoutProcessGroups.ObjectMeta.ResourceVersion
+
+		return nil
 	}
 
-	return r.Status().Update(ctx, cluster)
+	err := r.updateOrApplyClusterStatus(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	// The process groups might have been loaded from the FoundationDBProcessGroup resources, so they can only be
+	// removed once the process groups are stored in the cluster status. The resources are only deleted once, on the
+	// transition out of the FoundationDBProcessGroup resources, to not list the resources on every status update.
+	if !cluster.Status.HasProcessGroupResources || len(cluster.Status.ProcessGroups) == 0 {
+		return nil
+	}
+
+	err = r.deleteProcessGroupResources(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	cluster.Status.HasProcessGroupResources = false
+
+	return r.updateOrApplyClusterStatus(ctx, cluster)
+}
+
+// updateOrApplyClusterStatus updates the status of the cluster resource either with server-side apply or if disabled
+// with the normal update call.
+func (r *FoundationDBClusterReconciler) updateOrApplyClusterStatus(ctx context.Context, cluster *fdbv1beta2.FoundationDBCluster) error {
+	return updateOrApplyStatus(ctx, r, r.ServerSideApply, cluster)
 }
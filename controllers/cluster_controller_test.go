@@ -44,12 +44,15 @@ import (
 	. "github.com/onsi/gomega"
 	gomegatypes "github.com/onsi/gomega/types"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 )
@@ -2372,6 +2375,15 @@ var _ = Describe("cluster_controller", func() {
 				It("should update the running version", func() {
 					Expect(cluster.Status.RunningVersion).To(Equal(cluster.Spec.Version))
 				})
+
+				It("should report the finished upgrade in the status", func() {
+					Expect(cluster.Status.Upgrade).NotTo(BeNil())
+					Expect(cluster.Status.Upgrade.TargetVersion).To(Equal(fdbv1beta2.Versions.NextMajorVersion.String()))
+					Expect(cluster.Status.Upgrade.Phase).To(Equal(fdbv1beta2.UpgradePhaseDone))
+					Expect(cluster.Status.Upgrade.PreviousVersion).To(Equal(fdbv1beta2.Versions.Default.String()))
+					Expect(meta.IsStatusConditionTrue(cluster.Status.Upgrade.Conditions, fdbv1beta2.UpgradeConditionProcessesRestarted)).To(BeTrue())
+					Expect(meta.IsStatusConditionTrue(cluster.Status.Upgrade.Conditions, fdbv1beta2.UpgradeConditionCommitted)).To(BeTrue())
+				})
 			})
 
 			Context("with a non-upgradable client", func() {
@@ -2402,6 +2414,23 @@ var _ = Describe("cluster_controller", func() {
 							fmt.Sprintf("1 clients do not support version %s: 127.0.0.3:85891 (%s)", fdbv1beta2.Versions.NextMajorVersion, cluster.Name),
 						))
 					})
+
+					It("should report the blocked upgrade in the status", func() {
+						_, err := reloadCluster(cluster)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(cluster.Status.Upgrade).To(Equal(&fdbv1beta2.UpgradeStatus{
+							TargetVersion: fdbv1beta2.Versions.NextMajorVersion.String(),
+							Phase:         fdbv1beta2.UpgradePhasePrecheck,
+							IncompatibleClients: []fdbv1beta2.IncompatibleClientGroup{
+								{
+									LogGroup:  cluster.Name,
+									Addresses: []string{"127.0.0.3:85891"},
+									Count:     1,
+								},
+							},
+							BlockedReason: fmt.Sprintf("1 clients do not support version %s", fdbv1beta2.Versions.NextMajorVersion),
+						}))
+					})
 				})
 
 				Context("with the check disabled", func() {
@@ -2740,6 +2769,22 @@ var _ = Describe("cluster_controller", func() {
 			})
 		})
 
+		When("changing the storage engine with the Wiggle storage migration mode", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.StorageMigration.Mode = fdbv1beta2.StorageMigrationModeWiggle
+				cluster.Spec.DatabaseConfiguration.StorageEngine = fdbv1beta2.StorageEngineRocksDbV1
+				cluster.Spec.Version = "7.1.0"
+				Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+			})
+
+			It("should disable the storage migration of FDB with the new storage engine", func() {
+				adminClient, err := mock.NewMockAdminClientUncast(cluster, k8sClient)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(adminClient.DatabaseConfiguration.StorageEngine).To(Equal(fdbv1beta2.StorageEngineRocksDbV1))
+				Expect(adminClient.DatabaseConfiguration.StorageMigrationType).To(Equal(fdbv1beta2.StorageMigrationTypeDisabled))
+			})
+		})
+
 		When("creating a cluster with RocksDB as storage engine", func() {
 			When("using rocksdb-v1 engine", func() {
 				When("using 6.3.26", func() {
@@ -3705,6 +3750,230 @@ var _ = Describe("cluster_controller", func() {
 			})
 		})
 	})
+
+	When("getting the token for the sidecar API", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var token string
+		var err error
+
+		BeforeEach(func() {
+			cluster = internal.CreateDefaultCluster()
+		})
+
+		JustBeforeEach(func() {
+			token, err = clusterReconciler.getSidecarAPIToken(cluster)
+		})
+
+		When("no secret is defined", func() {
+			It("should return an empty token", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(token).To(BeEmpty())
+			})
+		})
+
+		When("a secret is defined", func() {
+			BeforeEach(func() {
+				cluster.Spec.SidecarContainer.APITokenSecretName = pointer.String("sidecar-token")
+			})
+
+			When("the secret exists", func() {
+				BeforeEach(func() {
+					Expect(k8sClient.Create(context.TODO(), &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "sidecar-token",
+							Namespace: cluster.Namespace,
+						},
+						Data: map[string][]byte{
+							fdbv1beta2.SidecarAPITokenSecretKey: []byte("secret-token"),
+						},
+					})).NotTo(HaveOccurred())
+				})
+
+				It("should return the token from the secret", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(token).To(Equal("secret-token"))
+				})
+
+				When("the token is cached", func() {
+					BeforeEach(func() {
+						clusterReconciler.sidecarAPITokens = newSidecarAPITokenCache()
+					})
+
+					AfterEach(func() {
+						clusterReconciler.sidecarAPITokens = nil
+					})
+
+					It("should return the cached token until it expires", func() {
+						Expect(err).NotTo(HaveOccurred())
+						Expect(token).To(Equal("secret-token"))
+
+						secret := &corev1.Secret{}
+						Expect(k8sClient.Get(context.TODO(), client.ObjectKey{Namespace: cluster.Namespace, Name: "sidecar-token"}, secret)).NotTo(HaveOccurred())
+						secret.Data[fdbv1beta2.SidecarAPITokenSecretKey] = []byte("rotated-token")
+						Expect(k8sClient.Update(context.TODO(), secret)).NotTo(HaveOccurred())
+
+						token, err = clusterReconciler.getSidecarAPIToken(cluster)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(token).To(Equal("secret-token"))
+
+						key := client.ObjectKeyFromObject(cluster)
+						clusterReconciler.sidecarAPITokens.tokens[key] = sidecarAPIToken{
+							secretName: "sidecar-token",
+							token:      "secret-token",
+							expiration: time.Now().Add(-time.Second),
+						}
+
+						token, err = clusterReconciler.getSidecarAPIToken(cluster)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(token).To(Equal("rotated-token"))
+					})
+				})
+			})
+
+			When("the secret is missing", func() {
+				It("should return an error", func() {
+					Expect(err).To(HaveOccurred())
+				})
+			})
+		})
+	})
+})
+
+var _ = Describe("findFoundationDBClusterForStatefulSetPod", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var pod *corev1.Pod
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		cluster.TypeMeta = metav1.TypeMeta{Kind: "FoundationDBCluster", APIVersion: fdbv1beta2.GroupVersion.String()}
+		cluster.UID = "cluster-uid"
+
+		statefulSet := &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "operator-test-1-storage-1",
+				Namespace:       cluster.Namespace,
+				OwnerReferences: internal.BuildOwnerReference(cluster.TypeMeta, cluster.ObjectMeta),
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), statefulSet)).NotTo(HaveOccurred())
+
+		pod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "operator-test-1-storage-1-0",
+				Namespace: cluster.Namespace,
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: "apps/v1",
+						Kind:       "StatefulSet",
+						Name:       statefulSet.Name,
+						Controller: pointer.Bool(true),
+					},
+				},
+			},
+		}
+	})
+
+	It("should return the cluster that owns the StatefulSet of the Pod", func() {
+		Expect(clusterReconciler.findFoundationDBClusterForStatefulSetPod(pod)).To(ConsistOf(reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name},
+		}))
+	})
+
+	When("the Pod is not owned by a StatefulSet", func() {
+		BeforeEach(func() {
+			pod.OwnerReferences = internal.BuildOwnerReference(cluster.TypeMeta, cluster.ObjectMeta)
+		})
+
+		It("should not return a request", func() {
+			Expect(clusterReconciler.findFoundationDBClusterForStatefulSetPod(pod)).To(BeEmpty())
+		})
+	})
+
+	When("the StatefulSet is missing", func() {
+		BeforeEach(func() {
+			pod.OwnerReferences[0].Name = "missing"
+		})
+
+		It("should not return a request", func() {
+			Expect(clusterReconciler.findFoundationDBClusterForStatefulSetPod(pod)).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("findFoundationDBClustersForNode", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var node *corev1.Node
+	var pod *corev1.Pod
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		cluster.TypeMeta = metav1.TypeMeta{Kind: "FoundationDBCluster", APIVersion: fdbv1beta2.GroupVersion.String()}
+		cluster.UID = "cluster-uid"
+
+		node = &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node-1",
+			},
+		}
+
+		pod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "operator-test-1-storage-1",
+				Namespace:       cluster.Namespace,
+				OwnerReferences: internal.BuildOwnerReference(cluster.TypeMeta, cluster.ObjectMeta),
+			},
+			Spec: corev1.PodSpec{
+				NodeName: node.Name,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(k8sClient.Create(context.TODO(), pod)).NotTo(HaveOccurred())
+	})
+
+	It("should return the cluster that owns the Pod", func() {
+		Expect(clusterReconciler.findFoundationDBClustersForNode(node)).To(ConsistOf(reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name},
+		}))
+	})
+
+	When("the node watch is enabled with the StatefulSet pod lifecycle manager", func() {
+		BeforeEach(func() {
+			clusterReconciler.EnableNodeWatch = true
+			clusterReconciler.PodLifecycleManager = podmanager.StatefulSetPodLifecycleManager{}
+
+			statefulSet := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "operator-test-1-storage-1",
+					Namespace:       cluster.Namespace,
+					OwnerReferences: internal.BuildOwnerReference(cluster.TypeMeta, cluster.ObjectMeta),
+				},
+			}
+			Expect(k8sClient.Create(context.TODO(), statefulSet)).NotTo(HaveOccurred())
+
+			pod.Name = "operator-test-1-storage-1-0"
+			pod.OwnerReferences = []metav1.OwnerReference{
+				{
+					APIVersion: "apps/v1",
+					Kind:       "StatefulSet",
+					Name:       statefulSet.Name,
+					Controller: pointer.Bool(true),
+				},
+			}
+		})
+
+		AfterEach(func() {
+			clusterReconciler.EnableNodeWatch = false
+			clusterReconciler.PodLifecycleManager = podmanager.StandardPodLifecycleManager{}
+		})
+
+		It("should return the cluster that owns the StatefulSet of the Pod", func() {
+			Expect(clusterReconciler.findFoundationDBClustersForNode(node)).To(ConsistOf(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name},
+			}))
+		})
+	})
 })
 
 func getProcessClassMap(cluster *fdbv1beta2.FoundationDBCluster, pods []corev1.Pod) map[fdbv1beta2.ProcessClass]int {
@@ -21,6 +21,7 @@
 package controllers
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -28,9 +29,11 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -40,6 +43,11 @@ const (
 	// podSchedulingDelayDuration determines how long we should delay a requeue
 	// of reconciliation when a pod is not ready.
 	podSchedulingDelayDuration = 15 * time.Second
+
+	// hotspotDetectionInterval determines how often the operator reads the
+	// storage metrics to detect hotspots when the hotspot remediation is
+	// enabled.
+	hotspotDetectionInterval = 5 * time.Minute
 )
 
 // metadataMatches determines if the current metadata on an object matches the
@@ -118,3 +126,29 @@ func processRequeue(requeue *requeue, subReconciler interface{}, object runtime.
 
 	return ctrl.Result{Requeue: true, RequeueAfter: requeue.delay}, nil
 }
+
+// updateOrApplyStatus updates the status of the object either with server-side apply or if disabled with the normal
+// update call.
+func updateOrApplyStatus(ctx context.Context, statusClient client.StatusClient, serverSideApply bool, object client.Object) error {
+	if !serverSideApply {
+		return statusClient.Status().Update(ctx, object)
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return err
+	}
+
+	// TODO(johscheuer): We have to set the TypeMeta otherwise the Patch command will fail. This is the rudimentary
+	// support for server side apply which should be enough for the status use case. The controller runtime will
+	// add some additional support in the future: https://github.com/kubernetes-sigs/controller-runtime/issues/347.
+	patch := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	patch.SetGroupVersionKind(object.GetObjectKind().GroupVersionKind())
+	patch.SetName(object.GetName())
+	patch.SetNamespace(object.GetNamespace())
+	if status, ok := content["status"]; ok {
+		patch.Object["status"] = status
+	}
+
+	return statusClient.Status().Patch(ctx, patch, client.Apply, client.FieldOwner("fdb-operator"), client.ForceOwnership)
+}
@@ -0,0 +1,202 @@
+/*
+ * disaster_recovery_controller.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// drStatusRequeueDelay determines how long we should wait before we update
+// the lag of a running DR in the status.
+const drStatusRequeueDelay = 1 * time.Minute
+
+// FoundationDBDisasterRecoveryReconciler reconciles a FoundationDBDisasterRecovery object
+type FoundationDBDisasterRecoveryReconciler struct {
+	client.Client
+	Recorder               record.EventRecorder
+	Log                    logr.Logger
+	InSimulation           bool
+	DatabaseClientProvider fdbadminclient.DatabaseClientProvider
+	ServerSideApply        bool
+}
+
+// +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbdisasterrecoveries,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbdisasterrecoveries/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbclusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile runs the reconciliation logic.
+func (r *FoundationDBDisasterRecoveryReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	dr := &fdbv1beta2.FoundationDBDisasterRecovery{}
+	err := r.Get(ctx, request.NamespacedName, dr)
+
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			// Object not found, return.  Created objects are automatically garbage collected.
+			// For additional cleanup logic use finalizers.
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	drLog := log.WithValues("namespace", dr.Namespace, "disasterRecovery", dr.Name)
+
+	err = dr.Validate()
+	if err != nil {
+		r.Recorder.Event(dr, corev1.EventTypeWarning, "DisasterRecoverySpec not valid", err.Error())
+		return ctrl.Result{}, fmt.Errorf("DisasterRecoverySpec is not valid: %w", err)
+	}
+
+	originalGeneration := dr.ObjectMeta.Generation
+
+	subReconcilers := []drSubReconciler{
+		updateDRStatus{},
+		switchDR{},
+		updateDRAgents{},
+		startDR{},
+		stopDR{},
+		updateDRStatus{},
+	}
+
+	for _, subReconciler := range subReconcilers {
+		requeue := subReconciler.reconcile(ctx, r, dr)
+		if requeue == nil {
+			continue
+		}
+
+		return processRequeue(requeue, subReconciler, dr, r.Recorder, drLog)
+	}
+
+	if dr.Status.Generations.Reconciled < originalGeneration {
+		drLog.Info("DR was not fully reconciled by reconciliation process")
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	drLog.Info("Reconciliation complete")
+
+	if dr.IsRunning() {
+		// Requeue the DR to update the lag in the status.
+		return ctrl.Result{RequeueAfter: drStatusRequeueDelay}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// getDatabaseClientProvider gets the client provider for a reconciler.
+func (r *FoundationDBDisasterRecoveryReconciler) getDatabaseClientProvider() fdbadminclient.DatabaseClientProvider {
+	if r.DatabaseClientProvider != nil {
+		return r.DatabaseClientProvider
+	}
+	panic("DisasterRecovery reconciler does not have a DatabaseClientProvider defined")
+}
+
+// getClusters fetches the source and the destination cluster with the provided names.
+func (r *FoundationDBDisasterRecoveryReconciler) getClusters(ctx context.Context, dr *fdbv1beta2.FoundationDBDisasterRecovery, sourceClusterName string, destinationClusterName string) (*fdbv1beta2.FoundationDBCluster, *fdbv1beta2.FoundationDBCluster, error) {
+	source := &fdbv1beta2.FoundationDBCluster{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: dr.Namespace, Name: sourceClusterName}, source)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	destination := &fdbv1beta2.FoundationDBCluster{}
+	err = r.Get(ctx, client.ObjectKey{Namespace: dr.Namespace, Name: destinationClusterName}, destination)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return source, destination, nil
+}
+
+// adminClientForDR provides an admin client for the destination cluster of a DR.
+func (r *FoundationDBDisasterRecoveryReconciler) adminClientForDR(dr *fdbv1beta2.FoundationDBDisasterRecovery, destination *fdbv1beta2.FoundationDBCluster) (fdbadminclient.AdminClient, error) {
+	adminClient, err := r.getDatabaseClientProvider().GetAdminClient(destination, r)
+	if err != nil {
+		return nil, err
+	}
+
+	adminClient.SetKnobs(dr.Spec.CustomParameters.GetKnobsForCLI())
+
+	return adminClient, nil
+}
+
+// SetupWithManager prepares a reconciler for use.
+func (r *FoundationDBDisasterRecoveryReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentReconciles int, selector metav1.LabelSelector) error {
+	labelSelectorPredicate, err := predicate.LabelSelectorPredicate(selector)
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrentReconciles},
+		).
+		For(&fdbv1beta2.FoundationDBDisasterRecovery{}).
+		Owns(&appsv1.Deployment{}).
+		// Only react on generation changes or annotation changes and only watch
+		// resources with the provided label selector.
+		WithEventFilter(
+			predicate.And(
+				labelSelectorPredicate,
+				predicate.Or(
+					predicate.GenerationChangedPredicate{},
+					predicate.AnnotationChangedPredicate{},
+				),
+			)).
+		Complete(r)
+}
+
+// drSubReconciler describes a class that does part of the work of
+// reconciliation for a DR.
+type drSubReconciler interface {
+	/**
+	reconcile runs the reconciler's work.
+
+	If reconciliation can continue, this should return nil.
+
+	If reconciliation encounters an error, this should return a requeue object
+	with an `Error` field.
+
+	If reconciliation cannot proceed, this should return a requeue object with a
+	`Message` field.
+	*/
+	reconcile(ctx context.Context, r *FoundationDBDisasterRecoveryReconciler, dr *fdbv1beta2.FoundationDBDisasterRecovery) *requeue
+}
+
+// updateOrApply updates the status either with server-side apply or if disabled with the normal update call.
+func (r *FoundationDBDisasterRecoveryReconciler) updateOrApply(ctx context.Context, dr *fdbv1beta2.FoundationDBDisasterRecovery) error {
+	return updateOrApplyStatus(ctx, r, r.ServerSideApply, dr)
+}
@@ -0,0 +1,219 @@
+/*
+ * disaster_recovery_controller_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func reloadDisasterRecovery(dr *fdbv1beta2.FoundationDBDisasterRecovery) error {
+	return k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(dr), dr)
+}
+
+var _ = Describe("disaster_recovery_controller", func() {
+	var source, destination *fdbv1beta2.FoundationDBCluster
+	var dr *fdbv1beta2.FoundationDBDisasterRecovery
+	var sourceAdminClient, destinationAdminClient *mock.AdminClient
+
+	BeforeEach(func() {
+		source = internal.CreateDefaultCluster()
+		source.Name = "source"
+		destination = internal.CreateDefaultCluster()
+		destination.Name = "destination"
+		Expect(k8sClient.Create(context.TODO(), source)).NotTo(HaveOccurred())
+		Expect(k8sClient.Create(context.TODO(), destination)).NotTo(HaveOccurred())
+
+		var err error
+		sourceAdminClient, err = mock.NewMockAdminClientUncast(source, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+		destinationAdminClient, err = mock.NewMockAdminClientUncast(destination, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+
+		dr = &fdbv1beta2.FoundationDBDisasterRecovery{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "operator-test",
+				Namespace: source.Namespace,
+			},
+			Spec: fdbv1beta2.FoundationDBDisasterRecoverySpec{
+				Version:                fdbv1beta2.Versions.Default.String(),
+				SourceClusterName:      source.Name,
+				DestinationClusterName: destination.Name,
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), dr)).NotTo(HaveOccurred())
+
+		result, err := reconcileDisasterRecovery(dr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Requeue).To(BeFalse())
+		Expect(reloadDisasterRecovery(dr)).NotTo(HaveOccurred())
+	})
+
+	When("reconciling a new DR", func() {
+		It("should start the DR into the destination cluster", func() {
+			Expect(dr.Status.Generations.Reconciled).To(Equal(dr.Generation))
+			Expect(dr.Status.DRDetails).To(Equal(&fdbv1beta2.FoundationDBDisasterRecoveryStatusDRDetails{
+				Tag:                    "default",
+				SourceClusterName:      "source",
+				DestinationClusterName: "destination",
+				Running:                true,
+				Phase:                  fdbv1beta2.DRPhaseRunningDifferentially,
+			}))
+
+			status, err := destinationAdminClient.GetDRStatus(source, "default")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.IsRunning()).To(BeTrue())
+		})
+
+		It("should create the DR agents with both cluster files", func() {
+			deployment := &appsv1.Deployment{}
+			Expect(k8sClient.Get(context.TODO(), client.ObjectKey{Namespace: dr.Namespace, Name: "operator-test-dr-agents"}, deployment)).NotTo(HaveOccurred())
+			Expect(*deployment.Spec.Replicas).To(BeNumerically("==", 2))
+			Expect(deployment.Labels).To(HaveKeyWithValue(fdbv1beta2.DRDeploymentLabel, string(dr.UID)))
+			Expect(deployment.Spec.Template.Spec.Containers[0].Args).To(ContainElements("-d", "/var/dynamic-conf/fdb.cluster", "-s", "/var/dynamic-conf/source.cluster"))
+			Expect(dr.Status.AgentCount).To(Equal(2))
+			Expect(dr.Status.DeploymentConfigured).To(BeTrue())
+		})
+	})
+
+	When("the lag of the DR changes", func() {
+		BeforeEach(func() {
+			destinationAdminClient.MockDRStatus("default", fdbv1beta2.DRPhaseRunningDifferentially, 12.7)
+			_, err := reconcileDisasterRecovery(dr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reloadDisasterRecovery(dr)).NotTo(HaveOccurred())
+		})
+
+		It("should report the lag in the status", func() {
+			Expect(dr.Status.DRDetails.LagSeconds).To(BeNumerically("==", 12))
+		})
+	})
+
+	When("the DR is stopped", func() {
+		BeforeEach(func() {
+			dr.Spec.DRState = fdbv1beta2.DRStateStopped
+			Expect(k8sClient.Update(context.TODO(), dr)).NotTo(HaveOccurred())
+			result, err := reconcileDisasterRecovery(dr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Requeue).To(BeFalse())
+			Expect(reloadDisasterRecovery(dr)).NotTo(HaveOccurred())
+		})
+
+		It("should abort the DR", func() {
+			Expect(dr.Status.Generations.Reconciled).To(Equal(dr.Generation))
+			Expect(dr.Status.DRDetails.Running).To(BeFalse())
+			Expect(dr.Status.DRDetails.Phase).To(Equal(fdbv1beta2.DRPhaseAborted))
+		})
+	})
+
+	When("the source and the destination cluster are swapped", func() {
+		BeforeEach(func() {
+			dr.Spec.SourceClusterName = destination.Name
+			dr.Spec.DestinationClusterName = source.Name
+			Expect(k8sClient.Update(context.TODO(), dr)).NotTo(HaveOccurred())
+		})
+
+		When("the DR is a complete copy", func() {
+			BeforeEach(func() {
+				result, err := reconcileDisasterRecovery(dr)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Requeue).To(BeFalse())
+				Expect(reloadDisasterRecovery(dr)).NotTo(HaveOccurred())
+			})
+
+			It("should switch the DR", func() {
+				Expect(dr.Status.Generations.Reconciled).To(Equal(dr.Generation))
+				Expect(dr.Status.DRDetails.SourceClusterName).To(Equal("destination"))
+				Expect(dr.Status.DRDetails.DestinationClusterName).To(Equal("source"))
+				Expect(dr.Status.DRDetails.Running).To(BeTrue())
+
+				status, err := sourceAdminClient.GetDRStatus(destination, "default")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.IsRunning()).To(BeTrue())
+
+				status, err = destinationAdminClient.GetDRStatus(source, "default")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.IsRunning()).To(BeFalse())
+			})
+
+			It("should update the DR agents to the new direction", func() {
+				deployment := &appsv1.Deployment{}
+				Expect(k8sClient.Get(context.TODO(), client.ObjectKey{Namespace: dr.Namespace, Name: "operator-test-dr-agents"}, deployment)).NotTo(HaveOccurred())
+				Expect(deployment.Spec.Template.Spec.Volumes[2].Projected.Sources[0].ConfigMap.Name).To(Equal("source-config"))
+				Expect(deployment.Spec.Template.Spec.Volumes[2].Projected.Sources[1].ConfigMap.Name).To(Equal("destination-config"))
+			})
+		})
+
+		When("the DR is not a complete copy", func() {
+			BeforeEach(func() {
+				destinationAdminClient.MockDRStatus("default", fdbv1beta2.DRPhaseRunning, 30)
+				result, err := reconcileDisasterRecovery(dr)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Requeue).To(BeTrue())
+				Expect(reloadDisasterRecovery(dr)).NotTo(HaveOccurred())
+			})
+
+			It("should wait with the switch", func() {
+				Expect(dr.Status.Generations.Reconciled).To(BeNumerically("<", dr.Generation))
+				Expect(dr.Status.Generations.NeedsDRSwitch).To(Equal(dr.Generation))
+				Expect(dr.Status.DRDetails.SourceClusterName).To(Equal("source"))
+			})
+		})
+	})
+
+	When("the agent count is changed", func() {
+		BeforeEach(func() {
+			dr.Spec.AgentCount = pointer.Int(5)
+			Expect(k8sClient.Update(context.TODO(), dr)).NotTo(HaveOccurred())
+			_, err := reconcileDisasterRecovery(dr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reloadDisasterRecovery(dr)).NotTo(HaveOccurred())
+		})
+
+		It("should update the deployment", func() {
+			deployment := &appsv1.Deployment{}
+			Expect(k8sClient.Get(context.TODO(), client.ObjectKey{Namespace: dr.Namespace, Name: "operator-test-dr-agents"}, deployment)).NotTo(HaveOccurred())
+			Expect(*deployment.Spec.Replicas).To(BeNumerically("==", 5))
+			Expect(dr.Status.AgentCount).To(Equal(5))
+		})
+	})
+
+	When("the destination cluster of a running DR is changed", func() {
+		BeforeEach(func() {
+			dr.Spec.DestinationClusterName = "other"
+			Expect(k8sClient.Update(context.TODO(), dr)).NotTo(HaveOccurred())
+		})
+
+		It("should return an error", func() {
+			_, err := reconcileDisasterRecovery(dr)
+			Expect(err).To(MatchError("DisasterRecoverySpec is not valid: the clusters of a running DR can only be swapped, stop the DR from source to destination first"))
+		})
+	})
+})
@@ -26,7 +26,9 @@ import (
 	"math"
 	"net"
 
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 )
@@ -40,7 +42,7 @@ var missingProcessThreshold = 0.8
 type excludeProcesses struct{}
 
 // reconcile runs the reconciler's work.
-func (e excludeProcesses) reconcile(_ context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster) *requeue {
+func (e excludeProcesses) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster) *requeue {
 	logger := log.WithValues("namespace", cluster.Namespace, "cluster", cluster.Name, "reconciler", "excludeProcesses")
 	adminClient, err := r.getDatabaseClientProvider().GetAdminClient(cluster, r)
 	if err != nil {
@@ -57,13 +59,31 @@ func (e excludeProcesses) reconcile(_ context.Context, r *FoundationDBClusterRec
 
 	var fdbProcessesToExclude []fdbv1beta2.ProcessAddress
 	var processClassesToExclude map[fdbv1beta2.ProcessClass]fdbv1beta2.None
+	var backlog []fdbv1beta2.ProcessGroupID
+	var backlogReason string
 	if removalCount > 0 {
 		exclusions, err := adminClient.GetExclusions()
 		if err != nil {
 			return &requeue{curError: err, delayedRequeue: true}
 		}
 		logger.Info("current exclusions", "ex", exclusions)
-		fdbProcessesToExclude, processClassesToExclude = getProcessesToExclude(exclusions, cluster, removalCount)
+
+		backlog, backlogReason, err = getExclusionBacklog(adminClient, cluster, exclusions)
+		if err != nil {
+			return &requeue{curError: err, delayedRequeue: true}
+		}
+
+		delayedProcessGroups := make(map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None, len(backlog))
+		for _, processGroupID := range backlog {
+			delayedProcessGroups[processGroupID] = fdbv1beta2.None{}
+		}
+
+		fdbProcessesToExclude, processClassesToExclude = getProcessesToExclude(exclusions, cluster, removalCount, delayedProcessGroups)
+	}
+
+	err = updateExclusionBacklog(ctx, r, cluster, backlog, backlogReason)
+	if err != nil {
+		return &requeue{curError: err, delayedRequeue: true}
 	}
 
 	if len(fdbProcessesToExclude) > 0 {
@@ -87,10 +107,118 @@ func (e excludeProcesses) reconcile(_ context.Context, r *FoundationDBClusterRec
 		}
 	}
 
+	if len(backlog) > 0 {
+		logger.Info("Delaying exclusions", "processGroupIDs", backlog, "reason", backlogReason)
+		return &requeue{
+			message:        fmt.Sprintf("Delaying exclusion of %d process groups: %s", len(backlog), backlogReason),
+			delayedRequeue: true,
+		}
+	}
+
 	return nil
 }
 
-func getProcessesToExclude(exclusions []fdbv1beta2.ProcessAddress, cluster *fdbv1beta2.FoundationDBCluster, removalCount int) ([]fdbv1beta2.ProcessAddress, map[fdbv1beta2.ProcessClass]fdbv1beta2.None) {
+// getExclusionBacklog returns the storage process groups that are marked for removal but must not be excluded yet,
+// because the exclusion budget of the cluster is exhausted, together with the reason.
+func getExclusionBacklog(adminClient fdbadminclient.AdminClient, cluster *fdbv1beta2.FoundationDBCluster, exclusions []fdbv1beta2.ProcessAddress) ([]fdbv1beta2.ProcessGroupID, string, error) {
+	budget := cluster.Spec.AutomationOptions.ExclusionBudget
+	if budget.MaxExcludedStorageProcesses == nil && budget.MaxInFlightBytes == nil && budget.MaxInQueueBytes == nil {
+		return nil, "", nil
+	}
+
+	currentExclusionMap := make(map[string]fdbv1beta2.None, len(exclusions))
+	for _, exclusion := range exclusions {
+		currentExclusionMap[exclusion.String()] = fdbv1beta2.None{}
+	}
+
+	var excludedCount int
+	var pendingProcessGroups []fdbv1beta2.ProcessGroupID
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.ProcessClass != fdbv1beta2.ProcessClassStorage || !processGroup.IsMarkedForRemoval() {
+			continue
+		}
+
+		if processGroup.IsExcluded() || isProcessGroupInExclusions(processGroup, currentExclusionMap) {
+			excludedCount++
+			continue
+		}
+
+		pendingProcessGroups = append(pendingProcessGroups, processGroup.ProcessGroupID)
+	}
+
+	if len(pendingProcessGroups) == 0 {
+		return nil, "", nil
+	}
+
+	if budget.MaxInFlightBytes != nil || budget.MaxInQueueBytes != nil {
+		status, err := adminClient.GetStatus()
+		if err != nil {
+			return nil, "", err
+		}
+
+		movingData := status.Cluster.Data.MovingData
+		if budget.MaxInFlightBytes != nil && movingData.InFlightBytes > *budget.MaxInFlightBytes {
+			return pendingProcessGroups, fmt.Sprintf("%d bytes in flight exceed the budget of %d bytes", movingData.InFlightBytes, *budget.MaxInFlightBytes), nil
+		}
+
+		if budget.MaxInQueueBytes != nil && movingData.InQueueBytes > *budget.MaxInQueueBytes {
+			return pendingProcessGroups, fmt.Sprintf("%d bytes in queue exceed the budget of %d bytes", movingData.InQueueBytes, *budget.MaxInQueueBytes), nil
+		}
+	}
+
+	if budget.MaxExcludedStorageProcesses != nil {
+		available := *budget.MaxExcludedStorageProcesses - excludedCount
+		if available < 0 {
+			available = 0
+		}
+
+		if available < len(pendingProcessGroups) {
+			return pendingProcessGroups[available:], fmt.Sprintf("%d storage processes are excluded, the budget allows %d", excludedCount, *budget.MaxExcludedStorageProcesses), nil
+		}
+	}
+
+	return nil, "", nil
+}
+
+// isProcessGroupInExclusions checks if the process group is already excluded either by locality or by one of its
+// addresses.
+func isProcessGroupInExclusions(processGroup *fdbv1beta2.ProcessGroupStatus, currentExclusionMap map[string]fdbv1beta2.None) bool {
+	if _, ok := currentExclusionMap[processGroup.GetExclusionString()]; ok {
+		return true
+	}
+
+	for _, address := range processGroup.Addresses {
+		if _, ok := currentExclusionMap[address]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// updateExclusionBacklog updates the exclusion backlog in the cluster status if it has changed.
+func updateExclusionBacklog(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, backlog []fdbv1beta2.ProcessGroupID, reason string) error {
+	var exclusionBacklog *fdbv1beta2.ExclusionBacklog
+	if len(backlog) > 0 {
+		exclusionBacklog = &fdbv1beta2.ExclusionBacklog{
+			ProcessGroups: backlog,
+			Reason:        reason,
+		}
+	}
+
+	if equality.Semantic.DeepEqual(cluster.Status.ExclusionBacklog, exclusionBacklog) {
+		return nil
+	}
+
+	if exclusionBacklog != nil {
+		r.Recorder.Event(cluster, corev1.EventTypeNormal, "ExclusionsDelayed", fmt.Sprintf("Delaying exclusion of %v: %s", backlog, reason))
+	}
+
+	cluster.Status.ExclusionBacklog = exclusionBacklog
+	return r.updateOrApply(ctx, cluster)
+}
+
+func getProcessesToExclude(exclusions []fdbv1beta2.ProcessAddress, cluster *fdbv1beta2.FoundationDBCluster, removalCount int, delayedProcessGroups map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None) ([]fdbv1beta2.ProcessAddress, map[fdbv1beta2.ProcessClass]fdbv1beta2.None) {
 	processClassesToExclude := make(map[fdbv1beta2.ProcessClass]fdbv1beta2.None)
 	fdbProcessesToExclude := make([]fdbv1beta2.ProcessAddress, 0, removalCount)
 
@@ -100,6 +228,11 @@ func getProcessesToExclude(exclusions []fdbv1beta2.ProcessAddress, cluster *fdbv
 	}
 
 	for _, processGroup := range cluster.Status.ProcessGroups {
+		// The exclusion of this process group is delayed by the exclusion budget.
+		if _, ok := delayedProcessGroups[processGroup.ProcessGroupID]; ok {
+			continue
+		}
+
 		// Process already excluded using locality, so we don't have to exclude it again
 		if _, ok := currentExclusionMap[processGroup.GetExclusionString()]; ok {
 			continue
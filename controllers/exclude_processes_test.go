@@ -28,6 +28,7 @@ import (
 	"k8s.io/utils/pointer"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	. "github.com/onsi/ginkgo/v2"
@@ -180,7 +181,7 @@ var _ = Describe("exclude_processes", func() {
 
 			When("there are no exclusions", func() {
 				It("should not exclude anything", func() {
-					fdbProcessesToExclude, processClassesToExclude := getProcessesToExclude(exclusions, cluster, 0)
+					fdbProcessesToExclude, processClassesToExclude := getProcessesToExclude(exclusions, cluster, 0, nil)
 					Expect(len(processClassesToExclude)).To(Equal(0))
 					Expect(len(fdbProcessesToExclude)).To(Equal(0))
 				})
@@ -195,7 +196,7 @@ var _ = Describe("exclude_processes", func() {
 				})
 
 				It("should report the excluded process", func() {
-					fdbProcessesToExclude, processClassesToExclude := getProcessesToExclude(exclusions, cluster, 0)
+					fdbProcessesToExclude, processClassesToExclude := getProcessesToExclude(exclusions, cluster, 0, nil)
 					Expect(len(processClassesToExclude)).To(Equal(1))
 					Expect(processClassesToExclude).To(Equal(map[fdbv1beta2.ProcessClass]fdbv1beta2.None{fdbv1beta2.ProcessClassStorage: {}}))
 					Expect(len(fdbProcessesToExclude)).To(Equal(1))
@@ -217,7 +218,7 @@ var _ = Describe("exclude_processes", func() {
 				})
 
 				It("should report the excluded process", func() {
-					fdbProcessesToExclude, processClassesToExclude := getProcessesToExclude(exclusions, cluster, 0)
+					fdbProcessesToExclude, processClassesToExclude := getProcessesToExclude(exclusions, cluster, 0, nil)
 					Expect(len(processClassesToExclude)).To(Equal(1))
 					Expect(processClassesToExclude).To(Equal(map[fdbv1beta2.ProcessClass]fdbv1beta2.None{fdbv1beta2.ProcessClassStorage: {}}))
 					Expect(len(fdbProcessesToExclude)).To(Equal(2))
@@ -241,7 +242,7 @@ var _ = Describe("exclude_processes", func() {
 				})
 
 				It("should report the excluded process", func() {
-					fdbProcessesToExclude, processClassesToExclude := getProcessesToExclude(exclusions, cluster, 1)
+					fdbProcessesToExclude, processClassesToExclude := getProcessesToExclude(exclusions, cluster, 1, nil)
 					Expect(len(processClassesToExclude)).To(Equal(1))
 					Expect(processClassesToExclude).To(Equal(map[fdbv1beta2.ProcessClass]fdbv1beta2.None{fdbv1beta2.ProcessClassStorage: {}}))
 					Expect(len(fdbProcessesToExclude)).To(Equal(1))
@@ -257,7 +258,7 @@ var _ = Describe("exclude_processes", func() {
 
 			When("there are no exclusions", func() {
 				It("should not exclude anything", func() {
-					fdbProcessesToExclude, processClassesToExclude := getProcessesToExclude(exclusions, cluster, 0)
+					fdbProcessesToExclude, processClassesToExclude := getProcessesToExclude(exclusions, cluster, 0, nil)
 					Expect(len(processClassesToExclude)).To(Equal(0))
 					Expect(len(fdbProcessesToExclude)).To(Equal(0))
 				})
@@ -272,7 +273,7 @@ var _ = Describe("exclude_processes", func() {
 				})
 
 				It("should report the excluded process", func() {
-					fdbProcessesToExclude, processClassesToExclude := getProcessesToExclude(exclusions, cluster, 0)
+					fdbProcessesToExclude, processClassesToExclude := getProcessesToExclude(exclusions, cluster, 0, nil)
 					Expect(len(processClassesToExclude)).To(Equal(1))
 					Expect(processClassesToExclude).To(Equal(map[fdbv1beta2.ProcessClass]fdbv1beta2.None{fdbv1beta2.ProcessClassStorage: {}}))
 					Expect(len(fdbProcessesToExclude)).To(Equal(1))
@@ -294,7 +295,7 @@ var _ = Describe("exclude_processes", func() {
 				})
 
 				It("should report the excluded process", func() {
-					fdbProcessesToExclude, processClassesToExclude := getProcessesToExclude(exclusions, cluster, 0)
+					fdbProcessesToExclude, processClassesToExclude := getProcessesToExclude(exclusions, cluster, 0, nil)
 					Expect(len(processClassesToExclude)).To(Equal(1))
 					Expect(processClassesToExclude).To(Equal(map[fdbv1beta2.ProcessClass]fdbv1beta2.None{fdbv1beta2.ProcessClassStorage: {}}))
 					Expect(len(fdbProcessesToExclude)).To(Equal(2))
@@ -318,7 +319,7 @@ var _ = Describe("exclude_processes", func() {
 				})
 
 				It("should report the excluded process", func() {
-					fdbProcessesToExclude, processClassesToExclude := getProcessesToExclude(exclusions, cluster, 1)
+					fdbProcessesToExclude, processClassesToExclude := getProcessesToExclude(exclusions, cluster, 1, nil)
 					Expect(len(processClassesToExclude)).To(Equal(1))
 					Expect(processClassesToExclude).To(Equal(map[fdbv1beta2.ProcessClass]fdbv1beta2.None{fdbv1beta2.ProcessClassStorage: {}}))
 					Expect(len(fdbProcessesToExclude)).To(Equal(2))
@@ -341,7 +342,7 @@ var _ = Describe("exclude_processes", func() {
 					exclusions = append(exclusions, fdbv1beta2.ProcessAddress{StringAddress: processGroup2.GetExclusionString()})
 				})
 				It("should report the excluded process", func() {
-					fdbProcessesToExclude, processClassesToExclude := getProcessesToExclude(exclusions, cluster, 1)
+					fdbProcessesToExclude, processClassesToExclude := getProcessesToExclude(exclusions, cluster, 1, nil)
 					Expect(len(processClassesToExclude)).To(Equal(1))
 					Expect(processClassesToExclude).To(Equal(map[fdbv1beta2.ProcessClass]fdbv1beta2.None{fdbv1beta2.ProcessClassStorage: {}}))
 					Expect(len(fdbProcessesToExclude)).To(Equal(1))
@@ -350,6 +351,94 @@ var _ = Describe("exclude_processes", func() {
 			})
 		})
 	})
+
+	When("an exclusion budget is defined", func() {
+		var adminClient *mock.AdminClient
+		var req *requeue
+
+		BeforeEach(func() {
+			cluster = internal.CreateDefaultCluster()
+			Expect(k8sClient.Create(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+			result, err := reconcileCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Requeue).To(BeFalse())
+
+			_, err = reloadCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+
+			adminClient, err = mock.NewMockAdminClientUncast(cluster, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, processGroup := range cluster.Status.ProcessGroups {
+				if processGroup.ProcessClass == fdbv1beta2.ProcessClassStorage {
+					processGroup.MarkForRemoval()
+				}
+			}
+
+			// Add the replacements for the storage processes, otherwise the exclusion is blocked.
+			for idx := 10; idx < 14; idx++ {
+				processGroup := fdbv1beta2.NewProcessGroupStatus(fdbv1beta2.ProcessGroupID(fmt.Sprintf("storage-%d", idx)), fdbv1beta2.ProcessClassStorage, nil)
+				processGroup.ProcessGroupConditions = nil
+				cluster.Status.ProcessGroups = append(cluster.Status.ProcessGroups, processGroup)
+			}
+		})
+
+		JustBeforeEach(func() {
+			req = excludeProcesses{}.reconcile(context.TODO(), clusterReconciler, cluster)
+		})
+
+		When("the number of excluded storage processes is limited", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.ExclusionBudget.MaxExcludedStorageProcesses = pointer.Int(2)
+			})
+
+			It("should only exclude the processes within the budget", func() {
+				Expect(req).NotTo(BeNil())
+				Expect(req.delayedRequeue).To(BeTrue())
+				Expect(adminClient.ExcludedAddresses).To(HaveLen(2))
+				Expect(cluster.Status.ExclusionBacklog).NotTo(BeNil())
+				Expect(cluster.Status.ExclusionBacklog.ProcessGroups).To(HaveLen(2))
+				Expect(cluster.Status.ExclusionBacklog.Reason).To(Equal("0 storage processes are excluded, the budget allows 2"))
+			})
+
+			When("the exclusions are done", func() {
+				It("should not exclude more processes until the excluded processes are removed", func() {
+					req = excludeProcesses{}.reconcile(context.TODO(), clusterReconciler, cluster)
+					Expect(req).NotTo(BeNil())
+					Expect(adminClient.ExcludedAddresses).To(HaveLen(2))
+					Expect(cluster.Status.ExclusionBacklog.Reason).To(Equal("2 storage processes are excluded, the budget allows 2"))
+				})
+			})
+		})
+
+		When("the in-flight bytes exceed the budget", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.ExclusionBudget.MaxInFlightBytes = pointer.Int(1024)
+				adminClient.MockMovingData(2048, 0)
+			})
+
+			It("should not exclude any storage processes", func() {
+				Expect(req).NotTo(BeNil())
+				Expect(adminClient.ExcludedAddresses).To(BeEmpty())
+				Expect(cluster.Status.ExclusionBacklog.ProcessGroups).To(HaveLen(4))
+				Expect(cluster.Status.ExclusionBacklog.Reason).To(Equal("2048 bytes in flight exceed the budget of 1024 bytes"))
+			})
+		})
+
+		When("the budget is not exceeded", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.ExclusionBudget.MaxInQueueBytes = pointer.Int(1024)
+				adminClient.MockMovingData(0, 512)
+			})
+
+			It("should exclude all storage processes", func() {
+				Expect(req).To(BeNil())
+				Expect(adminClient.ExcludedAddresses).To(HaveLen(4))
+				Expect(cluster.Status.ExclusionBacklog).To(BeNil())
+			})
+		})
+	})
 })
 
 func createMissingProcesses(cluster *fdbv1beta2.FoundationDBCluster, count int, processClass fdbv1beta2.ProcessClass) {
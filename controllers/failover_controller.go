@@ -0,0 +1,187 @@
+/*
+ * failover_controller.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// failoverRequeueDelay determines how long we should delay a requeue of reconciliation when we are waiting for the
+// remote data centers to catch up or for the failover to complete.
+const failoverRequeueDelay = 15 * time.Second
+
+// FoundationDBFailoverReconciler reconciles a FoundationDBFailover object
+type FoundationDBFailoverReconciler struct {
+	client.Client
+	Recorder               record.EventRecorder
+	Log                    logr.Logger
+	DatabaseClientProvider fdbadminclient.DatabaseClientProvider
+	ServerSideApply        bool
+}
+
+// +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbfailovers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbfailovers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbclusters,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbmultiregionclusters,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile runs the reconciliation logic.
+func (r *FoundationDBFailoverReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	failover := &fdbv1beta2.FoundationDBFailover{}
+	err := r.Get(ctx, request.NamespacedName, failover)
+
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			// Object not found, return.  Created objects are automatically garbage collected.
+			// For additional cleanup logic use finalizers.
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	failoverLog := log.WithValues("namespace", failover.Namespace, "failover", failover.Name)
+
+	// A failover is only performed once, completed or failed failovers will not be reconciled again.
+	if failover.IsFinished() {
+		failoverLog.Info("Failover is already finished", "state", failover.Status.State)
+		return ctrl.Result{}, nil
+	}
+
+	subReconcilers := []failoverSubReconciler{
+		startFailover{},
+		checkFailoverCompletion{},
+	}
+
+	for _, subReconciler := range subReconcilers {
+		requeue := subReconciler.reconcile(ctx, r, failover)
+		if requeue == nil {
+			continue
+		}
+
+		return processRequeue(requeue, subReconciler, failover, r.Recorder, failoverLog)
+	}
+
+	failoverLog.Info("Reconciliation complete", "state", failover.Status.State)
+
+	return ctrl.Result{}, nil
+}
+
+// getDatabaseClientProvider gets the client provider for a reconciler.
+func (r *FoundationDBFailoverReconciler) getDatabaseClientProvider() fdbadminclient.DatabaseClientProvider {
+	if r.DatabaseClientProvider != nil {
+		return r.DatabaseClientProvider
+	}
+	panic("Failover reconciler does not have a DatabaseClientProvider defined")
+}
+
+// getCluster fetches the cluster that should be failed over.
+func (r *FoundationDBFailoverReconciler) getCluster(ctx context.Context, failover *fdbv1beta2.FoundationDBFailover) (*fdbv1beta2.FoundationDBCluster, error) {
+	cluster := &fdbv1beta2.FoundationDBCluster{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: failover.Namespace, Name: failover.Spec.ClusterName}, cluster)
+
+	return cluster, err
+}
+
+// getStatus fetches the machine-readable status of the cluster that should be failed over.
+func (r *FoundationDBFailoverReconciler) getStatus(cluster *fdbv1beta2.FoundationDBCluster) (*fdbv1beta2.FoundationDBStatus, error) {
+	adminClient, err := r.getDatabaseClientProvider().GetAdminClient(cluster, r)
+	if err != nil {
+		return nil, err
+	}
+	defer adminClient.Close()
+
+	return adminClient.GetStatus()
+}
+
+// finishFailover sets the final state of the failover and records an event.
+func (r *FoundationDBFailoverReconciler) finishFailover(ctx context.Context, failover *fdbv1beta2.FoundationDBFailover, state fdbv1beta2.FailoverState, message string) error {
+	eventType := corev1.EventTypeNormal
+	if state == fdbv1beta2.FailoverStateFailed {
+		eventType = corev1.EventTypeWarning
+	}
+
+	r.Recorder.Event(failover, eventType, "Failover"+string(state), message)
+	failover.Status.State = state
+	failover.Status.Message = message
+	failover.Status.CompletionTimestamp = &metav1.Time{Time: time.Now()}
+
+	return r.updateOrApply(ctx, failover)
+}
+
+// SetupWithManager prepares a reconciler for use.
+func (r *FoundationDBFailoverReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentReconciles int, selector metav1.LabelSelector) error {
+	labelSelectorPredicate, err := predicate.LabelSelectorPredicate(selector)
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrentReconciles},
+		).
+		For(&fdbv1beta2.FoundationDBFailover{}).
+		// Only react on generation changes or annotation changes and only watch
+		// resources with the provided label selector.
+		WithEventFilter(
+			predicate.And(
+				labelSelectorPredicate,
+				predicate.Or(
+					predicate.GenerationChangedPredicate{},
+					predicate.AnnotationChangedPredicate{},
+				),
+			)).
+		Complete(r)
+}
+
+// failoverSubReconciler describes a class that does part of the work of
+// reconciliation for a failover.
+type failoverSubReconciler interface {
+	/**
+	reconcile runs the reconciler's work.
+
+	If reconciliation can continue, this should return nil.
+
+	If reconciliation encounters an error, this should return a `requeue` object
+	with an `Error` field.
+
+	If reconciliation cannot proceed, this should return a `requeue` object with
+	a `Message` field.
+	*/
+	reconcile(ctx context.Context, r *FoundationDBFailoverReconciler, failover *fdbv1beta2.FoundationDBFailover) *requeue
+}
+
+// updateOrApply updates the status either with server-side apply or if disabled with the normal update call.
+func (r *FoundationDBFailoverReconciler) updateOrApply(ctx context.Context, failover *fdbv1beta2.FoundationDBFailover) error {
+	return updateOrApplyStatus(ctx, r, r.ServerSideApply, failover)
+}
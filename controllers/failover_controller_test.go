@@ -0,0 +1,315 @@
+/*
+ * failover_controller_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func reloadFailover(failover *fdbv1beta2.FoundationDBFailover) error {
+	return k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(failover), failover)
+}
+
+// markClusterReconciled simulates the cluster controller by marking the cluster as reconciled and applying the
+// desired database configuration.
+func markClusterReconciled(cluster *fdbv1beta2.FoundationDBCluster, adminClient *mock.AdminClient) {
+	Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(cluster), cluster)).NotTo(HaveOccurred())
+	cluster.Status.Generations.Reconciled = cluster.Generation
+	Expect(k8sClient.Status().Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+	Expect(adminClient.ConfigureDatabase(cluster.DesiredDatabaseConfiguration(), false, cluster.Spec.Version)).NotTo(HaveOccurred())
+}
+
+var _ = Describe("failover_controller", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var failover *fdbv1beta2.FoundationDBFailover
+	var adminClient *mock.AdminClient
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		cluster.Spec.DatabaseConfiguration = fdbv1beta2.DatabaseConfiguration{
+			RedundancyMode: fdbv1beta2.RedundancyModeDouble,
+			UsableRegions:  2,
+			Regions: []fdbv1beta2.Region{
+				{
+					DataCenters: []fdbv1beta2.DataCenter{
+						{ID: "primary", Priority: 1},
+						{ID: "primary-sat", Priority: 1, Satellite: 1},
+					},
+				},
+				{
+					DataCenters: []fdbv1beta2.DataCenter{
+						{ID: "remote", Priority: 0},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+		var err error
+		adminClient, err = mock.NewMockAdminClientUncast(cluster, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+		markClusterReconciled(cluster, adminClient)
+
+		failover = &fdbv1beta2.FoundationDBFailover{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "failover",
+				Namespace: cluster.Namespace,
+			},
+			Spec: fdbv1beta2.FoundationDBFailoverSpec{
+				ClusterName:      cluster.Name,
+				TargetDataCenter: "remote",
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(k8sClient.Create(context.TODO(), failover)).NotTo(HaveOccurred())
+		_, err := reconcileFailover(failover)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reloadFailover(failover)).NotTo(HaveOccurred())
+		Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(cluster), cluster)).NotTo(HaveOccurred())
+	})
+
+	When("the remote data center has caught up", func() {
+		It("should swap the priorities of the data centers through the status", func() {
+			Expect(failover.Status.State).To(Equal(fdbv1beta2.FailoverStateFailingOver))
+			Expect(failover.Status.PreviousPrimaryDataCenter).To(Equal("primary"))
+			Expect(failover.Status.StartTimestamp).NotTo(BeNil())
+			Expect(failover.Status.CompletionTimestamp).To(BeNil())
+			Expect(cluster.Status.PrimaryDataCenterOverride).To(Equal(&fdbv1beta2.PrimaryDataCenterOverride{
+				DataCenter:            "remote",
+				SpecPrimaryDataCenter: "primary",
+			}))
+			Expect(cluster.Spec.DatabaseConfiguration.GetPrimaryDataCenter()).To(Equal("primary"))
+			Expect(cluster.DesiredDatabaseConfiguration().Regions).To(Equal([]fdbv1beta2.Region{
+				{
+					DataCenters: []fdbv1beta2.DataCenter{
+						{ID: "primary", Priority: 0},
+						{ID: "primary-sat", Priority: 1, Satellite: 1},
+					},
+				},
+				{
+					DataCenters: []fdbv1beta2.DataCenter{
+						{ID: "remote", Priority: 1},
+					},
+				},
+			}))
+		})
+
+		When("the cluster is reconciled with the new configuration", func() {
+			JustBeforeEach(func() {
+				markClusterReconciled(cluster, adminClient)
+				_, err := reconcileFailover(failover)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reloadFailover(failover)).NotTo(HaveOccurred())
+			})
+
+			It("should wait for the database to recover in the target data center", func() {
+				Expect(failover.Status.State).To(Equal(fdbv1beta2.FailoverStateFailingOver))
+				Expect(failover.Status.CompletionTimestamp).To(BeNil())
+			})
+
+			When("the database is running in the target data center", func() {
+				BeforeEach(func() {
+					adminClient.MockRunningPrimaryDataCenter("remote")
+				})
+
+				It("should mark the failover as completed", func() {
+					Expect(failover.Status.State).To(Equal(fdbv1beta2.FailoverStateCompleted))
+					Expect(failover.Status.Message).To(Equal("data center remote is the primary data center"))
+					Expect(failover.Status.CompletionTimestamp).NotTo(BeNil())
+				})
+			})
+
+			When("the database is running in the target data center but is not fully recovered", func() {
+				BeforeEach(func() {
+					adminClient.MockRunningPrimaryDataCenter("remote")
+					adminClient.MockDatabaseUnavailable(true)
+				})
+
+				It("should wait for the recovery", func() {
+					Expect(failover.Status.State).To(Equal(fdbv1beta2.FailoverStateFailingOver))
+					Expect(failover.Status.CompletionTimestamp).To(BeNil())
+				})
+			})
+		})
+	})
+
+	When("the remote data center is lagging behind", func() {
+		BeforeEach(func() {
+			adminClient.MockDataCenterLag(10)
+		})
+
+		It("should wait for the remote data center", func() {
+			Expect(failover.Status.State).To(Equal(fdbv1beta2.FailoverStateWaitingForRemote))
+			Expect(failover.Status.Message).To(Equal("remote data centers are 10.00 seconds behind, the maximum allowed lag is 5 seconds"))
+			Expect(cluster.DesiredDatabaseConfiguration().GetPrimaryDataCenter()).To(Equal("primary"))
+		})
+
+		When("the remote data center has caught up", func() {
+			JustBeforeEach(func() {
+				adminClient.MockDataCenterLag(0)
+				_, err := reconcileFailover(failover)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reloadFailover(failover)).NotTo(HaveOccurred())
+				Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(cluster), cluster)).NotTo(HaveOccurred())
+			})
+
+			It("should start the failover", func() {
+				Expect(failover.Status.State).To(Equal(fdbv1beta2.FailoverStateFailingOver))
+				Expect(failover.Status.Message).To(BeEmpty())
+				Expect(cluster.DesiredDatabaseConfiguration().GetPrimaryDataCenter()).To(Equal("remote"))
+			})
+		})
+
+		When("the maximum lag is increased", func() {
+			BeforeEach(func() {
+				failover.Spec.MaximumDataCenterLagSeconds = pointer.Int(20)
+			})
+
+			It("should start the failover", func() {
+				Expect(failover.Status.State).To(Equal(fdbv1beta2.FailoverStateFailingOver))
+				Expect(cluster.DesiredDatabaseConfiguration().GetPrimaryDataCenter()).To(Equal("remote"))
+			})
+		})
+	})
+
+	When("the data distribution is not healthy", func() {
+		BeforeEach(func() {
+			Expect(adminClient.FreezeStatus()).NotTo(HaveOccurred())
+			adminClient.FrozenStatus.Cluster.Data.State.Healthy = false
+			adminClient.FrozenStatus.Cluster.Data.State.Name = "healing"
+		})
+
+		It("should wait for the data distribution", func() {
+			Expect(failover.Status.State).To(Equal(fdbv1beta2.FailoverStateWaitingForRemote))
+			Expect(failover.Status.Message).To(Equal("data distribution is not healthy, current state: healing"))
+			Expect(cluster.DesiredDatabaseConfiguration().GetPrimaryDataCenter()).To(Equal("primary"))
+		})
+	})
+
+	When("the target data center is a satellite", func() {
+		BeforeEach(func() {
+			failover.Spec.TargetDataCenter = "primary-sat"
+		})
+
+		It("should mark the failover as failed", func() {
+			Expect(failover.Status.State).To(Equal(fdbv1beta2.FailoverStateFailed))
+			Expect(failover.Status.Message).To(Equal("data center primary-sat is not a main data center of the regions of cluster operator-test-1"))
+			Expect(failover.Status.CompletionTimestamp).NotTo(BeNil())
+			Expect(cluster.DesiredDatabaseConfiguration().GetPrimaryDataCenter()).To(Equal("primary"))
+		})
+	})
+
+	When("the target data center is already the primary", func() {
+		BeforeEach(func() {
+			failover.Spec.TargetDataCenter = "primary"
+			adminClient.MockRunningPrimaryDataCenter("primary")
+		})
+
+		It("should mark the failover as completed", func() {
+			Expect(failover.Status.State).To(Equal(fdbv1beta2.FailoverStateCompleted))
+			Expect(failover.Status.PreviousPrimaryDataCenter).To(Equal("primary"))
+			Expect(cluster.Status.PrimaryDataCenterOverride).To(BeNil())
+		})
+	})
+
+	When("other clusters manage the same database", func() {
+		var remoteCluster *fdbv1beta2.FoundationDBCluster
+		var otherDatabaseCluster *fdbv1beta2.FoundationDBCluster
+
+		BeforeEach(func() {
+			cluster.Status.ConnectionString = "test:abcd@127.0.0.1:4501"
+			Expect(k8sClient.Status().Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+			remoteCluster = internal.CreateDefaultCluster()
+			remoteCluster.Name = "remote-cluster"
+			remoteCluster.Spec.DataCenter = "remote"
+			remoteCluster.Spec.DatabaseConfiguration = *cluster.Spec.DatabaseConfiguration.DeepCopy()
+			Expect(k8sClient.Create(context.TODO(), remoteCluster)).NotTo(HaveOccurred())
+			remoteCluster.Status.ConnectionString = cluster.Status.ConnectionString
+			Expect(k8sClient.Status().Update(context.TODO(), remoteCluster)).NotTo(HaveOccurred())
+
+			otherDatabaseCluster = internal.CreateDefaultCluster()
+			otherDatabaseCluster.Name = "other-database"
+			otherDatabaseCluster.Spec.DatabaseConfiguration = *cluster.Spec.DatabaseConfiguration.DeepCopy()
+			Expect(k8sClient.Create(context.TODO(), otherDatabaseCluster)).NotTo(HaveOccurred())
+			otherDatabaseCluster.Status.ConnectionString = "other:abcd@127.0.0.1:4501"
+			Expect(k8sClient.Status().Update(context.TODO(), otherDatabaseCluster)).NotTo(HaveOccurred())
+		})
+
+		It("should change the primary data center in all clusters of the database", func() {
+			Expect(failover.Status.State).To(Equal(fdbv1beta2.FailoverStateFailingOver))
+			Expect(cluster.DesiredDatabaseConfiguration().GetPrimaryDataCenter()).To(Equal("remote"))
+
+			Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(remoteCluster), remoteCluster)).NotTo(HaveOccurred())
+			Expect(remoteCluster.DesiredDatabaseConfiguration().GetPrimaryDataCenter()).To(Equal("remote"))
+			Expect(remoteCluster.Spec.DatabaseConfiguration.GetPrimaryDataCenter()).To(Equal("primary"))
+
+			Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(otherDatabaseCluster), otherDatabaseCluster)).NotTo(HaveOccurred())
+			Expect(otherDatabaseCluster.DesiredDatabaseConfiguration().GetPrimaryDataCenter()).To(Equal("primary"))
+			Expect(otherDatabaseCluster.Status.PrimaryDataCenterOverride).To(BeNil())
+		})
+	})
+
+	When("the cluster is managed by a multi-region cluster", func() {
+		var multiRegionCluster *fdbv1beta2.FoundationDBMultiRegionCluster
+
+		BeforeEach(func() {
+			multiRegionCluster = &fdbv1beta2.FoundationDBMultiRegionCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "multi-region",
+					Namespace: cluster.Namespace,
+				},
+				Spec: fdbv1beta2.FoundationDBMultiRegionClusterSpec{
+					ClusterTemplate: fdbv1beta2.FoundationDBClusterSpec{
+						DatabaseConfiguration: cluster.Spec.DatabaseConfiguration,
+					},
+				},
+			}
+			Expect(k8sClient.Create(context.TODO(), multiRegionCluster)).NotTo(HaveOccurred())
+
+			cluster.Labels = map[string]string{fdbv1beta2.MultiRegionClusterLabel: multiRegionCluster.Name}
+			Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+		})
+
+		It("should change the primary data center of the multi-region cluster", func() {
+			Expect(failover.Status.State).To(Equal(fdbv1beta2.FailoverStateFailingOver))
+			Expect(reloadMultiRegionCluster(multiRegionCluster)).NotTo(HaveOccurred())
+			Expect(multiRegionCluster.Spec.PrimaryDataCenter).To(BeEmpty())
+			Expect(multiRegionCluster.Status.PrimaryDataCenterOverride).To(Equal(&fdbv1beta2.PrimaryDataCenterOverride{
+				DataCenter:            "remote",
+				SpecPrimaryDataCenter: "primary",
+			}))
+			Expect(multiRegionCluster.GetPrimaryDataCenter()).To(Equal("remote"))
+			Expect(cluster.DesiredDatabaseConfiguration().GetPrimaryDataCenter()).To(Equal("primary"))
+		})
+	})
+})
@@ -35,7 +35,8 @@ type maintenanceModeChecker struct{}
 func (maintenanceModeChecker) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster) *requeue {
 	logger := log.WithValues("namespace", cluster.Namespace, "cluster", cluster.Name, "reconciler", "maintenanceModeChecker")
 
-	if !cluster.UseMaintenaceMode() {
+	// The maintenance mode can also be set for tainted nodes without the maintenance mode checker being enabled.
+	if !cluster.UseMaintenaceMode() && cluster.Status.MaintenanceModeInfo.ZoneID == "" {
 		return nil
 	}
 
@@ -24,7 +24,9 @@ import (
 	"context"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
@@ -100,6 +102,13 @@ var (
 		append(descClusterDefaultLabels, "process_class"),
 		nil,
 	)
+
+	descResourceRecommendation = prometheus.NewDesc(
+		"fdb_operator_resource_recommendation",
+		"the recommended requests for the main container, in cores for cpu and bytes for memory.",
+		append(descClusterDefaultLabels, "process_class", "resource"),
+		nil,
+	)
 )
 
 type fdbClusterCollector struct {
@@ -124,6 +133,12 @@ func (c *fdbClusterCollector) Collect(ch chan<- prometheus.Metric) {
 		return
 	}
 	for _, cluster := range clusters.Items {
+		err = internal.LoadProcessGroups(context.Background(), c.reconciler, &cluster)
+		if err != nil {
+			log.Error(err, "could not load process groups", "namespace", cluster.Namespace, "cluster", cluster.Name)
+			continue
+		}
+
 		collectMetrics(ch, &cluster)
 	}
 }
@@ -159,6 +174,16 @@ func collectMetrics(ch chan<- prometheus.Metric, cluster *fdbv1beta2.FoundationD
 		addGauge(descProcessGroupMarkedExcluded, float64(exclusions[pclass]), string(pclass))
 	}
 
+	for _, recommendation := range cluster.Status.ResourceRecommendations {
+		if recommendation.CPU != nil {
+			addGauge(descResourceRecommendation, recommendation.CPU.AsApproximateFloat64(), string(recommendation.ProcessClass), string(corev1.ResourceCPU))
+		}
+
+		if recommendation.Memory != nil {
+			addGauge(descResourceRecommendation, recommendation.Memory.AsApproximateFloat64(), string(recommendation.ProcessClass), string(corev1.ResourceMemory))
+		}
+	}
+
 	counts, err := cluster.GetProcessCountsWithDefaults()
 	if err != nil {
 		return
@@ -26,6 +26,9 @@ import (
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -75,4 +78,45 @@ var _ = Describe("metrics", func() {
 			Expect(exclusions[fdbv1beta2.ProcessClassStateless]).To(BeNumerically("==", 1))
 		})
 	})
+
+	Context("Collecting the resource recommendation metrics", func() {
+		BeforeEach(func() {
+			cluster.Status.ResourceRecommendations = []fdbv1beta2.ResourceRecommendation{
+				{
+					ProcessClass: fdbv1beta2.ProcessClassStorage,
+					CPU:          resource.NewMilliQuantity(1500, resource.DecimalSI),
+					Memory:       resource.NewQuantity(1024, resource.BinarySI),
+				},
+				{
+					ProcessClass: fdbv1beta2.ProcessClassLog,
+				},
+			}
+		})
+
+		It("generates the recommendation metrics", func() {
+			ch := make(chan prometheus.Metric, 1000)
+			collectMetrics(ch, cluster)
+			close(ch)
+
+			recommendations := map[string]float64{}
+			for metric := range ch {
+				if metric.Desc() != descResourceRecommendation {
+					continue
+				}
+
+				result := &dto.Metric{}
+				Expect(metric.Write(result)).NotTo(HaveOccurred())
+				labels := map[string]string{}
+				for _, label := range result.GetLabel() {
+					labels[label.GetName()] = label.GetValue()
+				}
+				recommendations[labels["process_class"]+"/"+labels["resource"]] = result.GetGauge().GetValue()
+			}
+
+			Expect(recommendations).To(Equal(map[string]float64{
+				"storage/cpu":    1.5,
+				"storage/memory": 1024,
+			}))
+		})
+	})
 })
@@ -0,0 +1,128 @@
+/*
+ * migrate_storage_engine.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// migrateStorageEngine replaces the process groups of storage processes that use a different storage engine than the
+// configured one, if the storage migration is configured with the Wiggle mode.
+type migrateStorageEngine struct{}
+
+// reconcile runs the reconciler's work.
+func (migrateStorageEngine) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster) *requeue {
+	logger := log.WithValues("namespace", cluster.Namespace, "cluster", cluster.Name, "reconciler", "migrateStorageEngine")
+
+	if cluster.GetStorageMigrationMode() != fdbv1beta2.StorageMigrationModeWiggle {
+		return nil
+	}
+
+	maxReplacements := cluster.GetStorageMigrationMaxConcurrentReplacements()
+	var processGroups []*fdbv1beta2.ProcessGroupStatus
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.ProcessClass != fdbv1beta2.ProcessClassStorage {
+			continue
+		}
+
+		if processGroup.IsMarkedForRemoval() {
+			// Count all storage removals that are in-flight.
+			if !processGroup.IsExcluded() {
+				maxReplacements--
+			}
+			continue
+		}
+
+		if processGroup.GetConditionTime(fdbv1beta2.IncorrectStorageEngine) == nil {
+			continue
+		}
+
+		processGroups = append(processGroups, processGroup)
+	}
+
+	if len(processGroups) == 0 {
+		return nil
+	}
+
+	if maxReplacements <= 0 {
+		return &requeue{message: "Waiting for ongoing storage replacements before the storage engine migration continues", delay: podSchedulingDelayDuration, delayedRequeue: true}
+	}
+
+	adminClient, err := r.getDatabaseClientProvider().GetAdminClient(cluster, r)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+	defer adminClient.Close()
+
+	status, err := adminClient.GetStatus()
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	if !internal.HasDesiredFaultToleranceFromStatus(logger, status, cluster) {
+		return &requeue{message: "Waiting for the desired fault tolerance before the storage engine migration continues", delay: podSchedulingDelayDuration, delayedRequeue: true}
+	}
+
+	movingData := status.Cluster.Data.MovingData
+	movingDataBytes := movingData.InFlightBytes + movingData.InQueueBytes
+	if movingDataBytes > cluster.GetStorageMigrationMaxMovingDataBytes() {
+		return &requeue{message: fmt.Sprintf("Waiting for data movement of %d bytes before the storage engine migration continues", movingDataBytes), delay: podSchedulingDelayDuration, delayedRequeue: true}
+	}
+
+	// Replace the process groups in a stable order, starting with the process groups that were detected first.
+	sort.SliceStable(processGroups, func(i, j int) bool {
+		iTime := *processGroups[i].GetConditionTime(fdbv1beta2.IncorrectStorageEngine)
+		jTime := *processGroups[j].GetConditionTime(fdbv1beta2.IncorrectStorageEngine)
+		if iTime != jTime {
+			return iTime < jTime
+		}
+
+		return processGroups[i].ProcessGroupID < processGroups[j].ProcessGroupID
+	})
+
+	if len(processGroups) > maxReplacements {
+		processGroups = processGroups[:maxReplacements]
+	}
+
+	processGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(processGroups))
+	for _, processGroup := range processGroups {
+		logger.Info("Replace process group",
+			"processGroupID", processGroup.ProcessGroupID,
+			"reason", fmt.Sprintf("storage engine migration to %s", cluster.Spec.DatabaseConfiguration.StorageEngine))
+		processGroup.MarkForRemoval()
+		processGroupIDs = append(processGroupIDs, processGroup.ProcessGroupID)
+	}
+
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "MigratingStorageEngine", fmt.Sprintf("Replacing process groups of storage processes with an incorrect storage engine: %v", processGroupIDs))
+
+	err = r.updateOrApply(ctx, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return &requeue{message: "Removals have been updated in the cluster status"}
+}
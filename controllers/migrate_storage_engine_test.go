@@ -0,0 +1,171 @@
+/*
+ * migrate_storage_engine_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("migrate_storage_engine", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var req *requeue
+
+	// setIncorrectStorageEngine marks the process group with an incorrect storage engine that was detected at the
+	// provided time.
+	setIncorrectStorageEngine := func(processGroupID fdbv1beta2.ProcessGroupID, detectedTime time.Time) {
+		for _, processGroup := range cluster.Status.ProcessGroups {
+			if processGroup.ProcessGroupID != processGroupID {
+				continue
+			}
+
+			processGroup.ProcessGroupConditions = append(processGroup.ProcessGroupConditions, &fdbv1beta2.ProcessGroupCondition{
+				ProcessGroupConditionType: fdbv1beta2.IncorrectStorageEngine,
+				Timestamp:                 detectedTime.Unix(),
+			})
+		}
+	}
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(k8sClient.Create(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+		result, err := reconcileCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Requeue).To(BeFalse())
+
+		_, err = reloadCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+
+		cluster.Spec.AutomationOptions.StorageMigration.Mode = fdbv1beta2.StorageMigrationModeWiggle
+	})
+
+	JustBeforeEach(func() {
+		req = migrateStorageEngine{}.reconcile(context.TODO(), clusterReconciler, cluster)
+	})
+
+	When("all storage processes use the configured storage engine", func() {
+		It("should not replace any process group", func() {
+			Expect(req).To(BeNil())
+			Expect(getRemovedProcessGroupIDs(cluster)).To(BeEmpty())
+		})
+	})
+
+	When("multiple storage processes use a different storage engine", func() {
+		BeforeEach(func() {
+			setIncorrectStorageEngine("storage-1", time.Now().Add(-1*time.Minute))
+			setIncorrectStorageEngine("storage-2", time.Now().Add(-1*time.Minute))
+		})
+
+		It("should replace one process group", func() {
+			Expect(req).NotTo(BeNil())
+			Expect(req.message).To(Equal("Removals have been updated in the cluster status"))
+			Expect(getRemovedProcessGroupIDs(cluster)).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-1")))
+		})
+
+		When("the storage migration mode is Background", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.StorageMigration.Mode = fdbv1beta2.StorageMigrationModeBackground
+			})
+
+			It("should not replace any process group", func() {
+				Expect(req).To(BeNil())
+				Expect(getRemovedProcessGroupIDs(cluster)).To(BeEmpty())
+			})
+		})
+
+		When("another storage process group is being removed", func() {
+			BeforeEach(func() {
+				for _, processGroup := range cluster.Status.ProcessGroups {
+					if processGroup.ProcessGroupID == "storage-3" {
+						processGroup.MarkForRemoval()
+					}
+				}
+			})
+
+			It("should wait for the ongoing replacement", func() {
+				Expect(req).NotTo(BeNil())
+				Expect(req.delayedRequeue).To(BeTrue())
+				Expect(getRemovedProcessGroupIDs(cluster)).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-3")))
+			})
+
+			When("the concurrent replacements are increased", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.StorageMigration.MaxConcurrentReplacements = pointer.Int(3)
+				})
+
+				It("should replace both process groups", func() {
+					Expect(req).NotTo(BeNil())
+					Expect(req.delayedRequeue).To(BeFalse())
+					Expect(getRemovedProcessGroupIDs(cluster)).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-1"), fdbv1beta2.ProcessGroupID("storage-2"), fdbv1beta2.ProcessGroupID("storage-3")))
+				})
+			})
+		})
+
+		When("data is moved by data distribution", func() {
+			BeforeEach(func() {
+				adminClient, err := mock.NewMockAdminClientUncast(cluster, k8sClient)
+				Expect(err).NotTo(HaveOccurred())
+				adminClient.MockMovingData(1000, 500)
+			})
+
+			It("should wait for the data movement", func() {
+				Expect(req).NotTo(BeNil())
+				Expect(req.delayedRequeue).To(BeTrue())
+				Expect(req.message).To(Equal("Waiting for data movement of 1500 bytes before the storage engine migration continues"))
+				Expect(getRemovedProcessGroupIDs(cluster)).To(BeEmpty())
+			})
+
+			When("the data movement is below the limit", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.StorageMigration.MaxMovingDataBytes = pointer.Int(2000)
+				})
+
+				It("should replace one process group", func() {
+					Expect(req).NotTo(BeNil())
+					Expect(getRemovedProcessGroupIDs(cluster)).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-1")))
+				})
+			})
+		})
+
+		When("the cluster doesn't have the desired fault tolerance", func() {
+			BeforeEach(func() {
+				adminClient, err := mock.NewMockAdminClientUncast(cluster, k8sClient)
+				Expect(err).NotTo(HaveOccurred())
+				adminClient.MaxZoneFailuresWithoutLosingData = pointer.Int(0)
+			})
+
+			It("should wait for the desired fault tolerance", func() {
+				Expect(req).NotTo(BeNil())
+				Expect(req.delayedRequeue).To(BeTrue())
+				Expect(req.message).To(Equal("Waiting for the desired fault tolerance before the storage engine migration continues"))
+				Expect(getRemovedProcessGroupIDs(cluster)).To(BeEmpty())
+			})
+		})
+	})
+})
@@ -33,7 +33,7 @@ type modifyBackup struct {
 
 // reconcile runs the reconciler's work.
 func (s modifyBackup) reconcile(ctx context.Context, r *FoundationDBBackupReconciler, backup *fdbv1beta2.FoundationDBBackup) *requeue {
-	if backup.Status.BackupDetails == nil || !backup.ShouldRun() {
+	if backup.Status.BackupDetails == nil || !backup.ShouldRun() || !backup.OwnsBackupTag() {
 		return nil
 	}
 
@@ -45,7 +45,7 @@ func (s modifyBackup) reconcile(ctx context.Context, r *FoundationDBBackupReconc
 		}
 		defer adminClient.Close()
 
-		err = adminClient.ModifyBackup(snapshotPeriod)
+		err = adminClient.ModifyBackup(backup.BackupTag(), snapshotPeriod)
 		if err != nil {
 			return &requeue{curError: err}
 		}
@@ -0,0 +1,189 @@
+/*
+ * multi_region_cluster_controller.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// multiRegionClusterRequeueDelay determines how long we should delay a requeue
+// of reconciliation when we are waiting for the clusters of the data centers.
+const multiRegionClusterRequeueDelay = 15 * time.Second
+
+// FoundationDBMultiRegionClusterReconciler reconciles a FoundationDBMultiRegionCluster object
+type FoundationDBMultiRegionClusterReconciler struct {
+	client.Client
+	Recorder               record.EventRecorder
+	Log                    logr.Logger
+	DatabaseClientProvider fdbadminclient.DatabaseClientProvider
+	ServerSideApply        bool
+	DeprecationOptions     internal.DeprecationOptions
+}
+
+// +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbmultiregionclusters,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbmultiregionclusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.foundationdb.org,resources=foundationdbclusters,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile runs the reconciliation logic.
+func (r *FoundationDBMultiRegionClusterReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	multiRegionCluster := &fdbv1beta2.FoundationDBMultiRegionCluster{}
+	err := r.Get(ctx, request.NamespacedName, multiRegionCluster)
+
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			// Object not found, return.  Created objects are automatically garbage collected.
+			// For additional cleanup logic use finalizers.
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	multiRegionClusterLog := log.WithValues("namespace", multiRegionCluster.Namespace, "multiRegionCluster", multiRegionCluster.Name)
+
+	err = multiRegionCluster.Validate()
+	if err != nil {
+		r.Recorder.Event(multiRegionCluster, corev1.EventTypeWarning, "MultiRegionClusterSpec not valid", err.Error())
+		return ctrl.Result{}, fmt.Errorf("MultiRegionClusterSpec is not valid: %w", err)
+	}
+
+	subReconcilers := []multiRegionClusterSubReconciler{
+		updateMultiRegionStatus{},
+		updateDataCenterClusters{},
+		changeMultiRegionConfiguration{},
+	}
+
+	for _, subReconciler := range subReconcilers {
+		requeue := subReconciler.reconcile(ctx, r, multiRegionCluster)
+		if requeue == nil {
+			continue
+		}
+
+		return processRequeue(requeue, subReconciler, multiRegionCluster, r.Recorder, multiRegionClusterLog)
+	}
+
+	if !multiRegionCluster.AllDataCentersReconciled() {
+		multiRegionClusterLog.Info("Multi-region cluster was not fully reconciled by reconciliation process")
+		return ctrl.Result{Requeue: true, RequeueAfter: multiRegionClusterRequeueDelay}, nil
+	}
+
+	if multiRegionCluster.Status.Generations.Reconciled < multiRegionCluster.ObjectMeta.Generation {
+		multiRegionCluster.Status.Generations.Reconciled = multiRegionCluster.ObjectMeta.Generation
+		err = r.updateOrApply(ctx, multiRegionCluster)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	multiRegionClusterLog.Info("Reconciliation complete")
+
+	return ctrl.Result{}, nil
+}
+
+// getDatabaseClientProvider gets the client provider for a reconciler.
+func (r *FoundationDBMultiRegionClusterReconciler) getDatabaseClientProvider() fdbadminclient.DatabaseClientProvider {
+	if r.DatabaseClientProvider != nil {
+		return r.DatabaseClientProvider
+	}
+	panic("Multi-region cluster reconciler does not have a DatabaseClientProvider defined")
+}
+
+// getStatus fetches the machine-readable status of the database through the cluster of the provided data center.
+func (r *FoundationDBMultiRegionClusterReconciler) getStatus(ctx context.Context, multiRegionCluster *fdbv1beta2.FoundationDBMultiRegionCluster, dataCenterID string) (*fdbv1beta2.FoundationDBStatus, error) {
+	cluster := &fdbv1beta2.FoundationDBCluster{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: multiRegionCluster.Namespace, Name: multiRegionCluster.GetDataCenterClusterName(dataCenterID)}, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	adminClient, err := r.getDatabaseClientProvider().GetAdminClient(cluster, r)
+	if err != nil {
+		return nil, err
+	}
+	defer adminClient.Close()
+
+	return adminClient.GetStatus()
+}
+
+// SetupWithManager prepares a reconciler for use.
+func (r *FoundationDBMultiRegionClusterReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrentReconciles int, selector metav1.LabelSelector) error {
+	labelSelectorPredicate, err := predicate.LabelSelectorPredicate(selector)
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrentReconciles},
+		).
+		For(&fdbv1beta2.FoundationDBMultiRegionCluster{}).
+		Owns(&fdbv1beta2.FoundationDBCluster{}).
+		// Only react on generation changes, annotation changes or changes of the
+		// primary data center by a failover and only watch resources with the
+		// provided label selector.
+		WithEventFilter(
+			predicate.And(
+				labelSelectorPredicate,
+				predicate.Or(
+					predicate.GenerationChangedPredicate{},
+					predicate.AnnotationChangedPredicate{},
+					primaryDataCenterOverrideChangedPredicate(),
+				),
+			)).
+		Complete(r)
+}
+
+// multiRegionClusterSubReconciler describes a class that does part of the work of
+// reconciliation for a multi-region cluster.
+type multiRegionClusterSubReconciler interface {
+	/**
+	reconcile runs the reconciler's work.
+
+	If reconciliation can continue, this should return nil.
+
+	If reconciliation encounters an error, this should return a `requeue` object
+	with an `Error` field.
+
+	If reconciliation cannot proceed, this should return a `requeue` object with
+	a `Message` field.
+	*/
+	reconcile(ctx context.Context, r *FoundationDBMultiRegionClusterReconciler, multiRegionCluster *fdbv1beta2.FoundationDBMultiRegionCluster) *requeue
+}
+
+// updateOrApply updates the status either with server-side apply or if disabled with the normal update call.
+func (r *FoundationDBMultiRegionClusterReconciler) updateOrApply(ctx context.Context, multiRegionCluster *fdbv1beta2.FoundationDBMultiRegionCluster) error {
+	return updateOrApplyStatus(ctx, r, r.ServerSideApply, multiRegionCluster)
+}
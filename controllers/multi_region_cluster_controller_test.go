@@ -0,0 +1,381 @@
+/*
+ * multi_region_cluster_controller_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func reloadMultiRegionCluster(multiRegionCluster *fdbv1beta2.FoundationDBMultiRegionCluster) error {
+	return k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(multiRegionCluster), multiRegionCluster)
+}
+
+// markDataCenterClustersReconciled simulates the cluster controller by marking all clusters of the multi-region
+// cluster as reconciled and by applying their database configuration.
+func markDataCenterClustersReconciled(multiRegionCluster *fdbv1beta2.FoundationDBMultiRegionCluster) {
+	clusters := &fdbv1beta2.FoundationDBClusterList{}
+	Expect(k8sClient.List(context.TODO(), clusters, client.InNamespace(multiRegionCluster.Namespace), client.MatchingLabels{fdbv1beta2.MultiRegionClusterLabel: multiRegionCluster.Name})).NotTo(HaveOccurred())
+
+	for _, cluster := range clusters.Items {
+		cluster.Status.ConnectionString = "test:abcd@127.0.0.1:4501"
+		cluster.Status.Generations.Reconciled = cluster.Generation
+		Expect(k8sClient.Status().Update(context.TODO(), &cluster)).NotTo(HaveOccurred())
+
+		adminClient, err := mock.NewMockAdminClientUncast(&cluster, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+		adminClient.DatabaseConfiguration = cluster.Spec.DatabaseConfiguration.DeepCopy()
+	}
+}
+
+func getDataCenterCluster(multiRegionCluster *fdbv1beta2.FoundationDBMultiRegionCluster, dataCenterID string) (*fdbv1beta2.FoundationDBCluster, error) {
+	cluster := &fdbv1beta2.FoundationDBCluster{}
+	err := k8sClient.Get(context.TODO(), client.ObjectKey{Namespace: multiRegionCluster.Namespace, Name: multiRegionCluster.GetDataCenterClusterName(dataCenterID)}, cluster)
+
+	return cluster, err
+}
+
+var _ = Describe("multi_region_cluster_controller", func() {
+	var multiRegionCluster *fdbv1beta2.FoundationDBMultiRegionCluster
+
+	BeforeEach(func() {
+		multiRegionCluster = &fdbv1beta2.FoundationDBMultiRegionCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "operator-test",
+				Namespace: "my-ns",
+			},
+			Spec: fdbv1beta2.FoundationDBMultiRegionClusterSpec{
+				ClusterTemplate: fdbv1beta2.FoundationDBClusterSpec{
+					Version: fdbv1beta2.Versions.Default.String(),
+					DatabaseConfiguration: fdbv1beta2.DatabaseConfiguration{
+						UsableRegions: 2,
+						Regions: []fdbv1beta2.Region{
+							{
+								DataCenters: []fdbv1beta2.DataCenter{
+									{ID: "primary", Priority: 1},
+								},
+							},
+							{
+								DataCenters: []fdbv1beta2.DataCenter{
+									{ID: "remote", Priority: 0},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		Expect(k8sClient.Create(context.TODO(), multiRegionCluster)).NotTo(HaveOccurred())
+		_, err := reconcileMultiRegionCluster(multiRegionCluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reloadMultiRegionCluster(multiRegionCluster)).NotTo(HaveOccurred())
+	})
+
+	When("the seed cluster is not reconciled", func() {
+		It("should only create the seed cluster", func() {
+			Expect(multiRegionCluster.Status.SeedDataCenter).To(Equal("primary"))
+			Expect(multiRegionCluster.Status.PrimaryDataCenter).To(Equal("primary"))
+			Expect(multiRegionCluster.Status.UsableRegions).To(Equal(1))
+			Expect(multiRegionCluster.Status.ConnectionString).To(BeEmpty())
+			Expect(multiRegionCluster.Status.Generations.Reconciled).To(BeZero())
+
+			seedCluster, err := getDataCenterCluster(multiRegionCluster, "primary")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(seedCluster.Spec.DataCenter).To(Equal("primary"))
+			Expect(seedCluster.Spec.ProcessGroupIDPrefix).To(Equal("primary"))
+			Expect(seedCluster.Spec.SeedConnectionString).To(BeEmpty())
+			Expect(seedCluster.Spec.DatabaseConfiguration.UsableRegions).To(Equal(1))
+			Expect(seedCluster.Spec.DatabaseConfiguration.Regions).To(Equal([]fdbv1beta2.Region{
+				{
+					DataCenters: []fdbv1beta2.DataCenter{
+						{ID: "primary", Priority: 1},
+					},
+				},
+			}))
+			Expect(seedCluster.Labels).To(HaveKeyWithValue(fdbv1beta2.MultiRegionClusterLabel, multiRegionCluster.Name))
+
+			_, err = getDataCenterCluster(multiRegionCluster, "remote")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("the seed cluster is reconciled", func() {
+		BeforeEach(func() {
+			markDataCenterClustersReconciled(multiRegionCluster)
+			_, err := reconcileMultiRegionCluster(multiRegionCluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reloadMultiRegionCluster(multiRegionCluster)).NotTo(HaveOccurred())
+		})
+
+		It("should create the remote cluster with the seed connection string", func() {
+			Expect(multiRegionCluster.Status.ConnectionString).To(Equal("test:abcd@127.0.0.1:4501"))
+
+			remoteCluster, err := getDataCenterCluster(multiRegionCluster, "remote")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(remoteCluster.Spec.DataCenter).To(Equal("remote"))
+			Expect(remoteCluster.Spec.SeedConnectionString).To(Equal("test:abcd@127.0.0.1:4501"))
+			Expect(remoteCluster.Spec.DatabaseConfiguration.Regions).To(HaveLen(2))
+		})
+
+		It("should add all regions to the seed cluster but keep a single usable region", func() {
+			seedCluster, err := getDataCenterCluster(multiRegionCluster, "primary")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(seedCluster.Spec.SeedConnectionString).To(BeEmpty())
+			Expect(seedCluster.Spec.DatabaseConfiguration.UsableRegions).To(Equal(1))
+			Expect(seedCluster.Spec.DatabaseConfiguration.Regions).To(HaveLen(2))
+			Expect(multiRegionCluster.Status.UsableRegions).To(Equal(1))
+		})
+
+		When("all data center clusters are reconciled", func() {
+			BeforeEach(func() {
+				markDataCenterClustersReconciled(multiRegionCluster)
+				_, err := reconcileMultiRegionCluster(multiRegionCluster)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reloadMultiRegionCluster(multiRegionCluster)).NotTo(HaveOccurred())
+			})
+
+			It("should change the usable regions", func() {
+				Expect(multiRegionCluster.Status.UsableRegions).To(Equal(2))
+
+				for _, dataCenterID := range []string{"primary", "remote"} {
+					cluster, err := getDataCenterCluster(multiRegionCluster, dataCenterID)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(cluster.Spec.DatabaseConfiguration.UsableRegions).To(Equal(2))
+				}
+			})
+
+			When("the clusters are reconciled with the new usable regions", func() {
+				BeforeEach(func() {
+					markDataCenterClustersReconciled(multiRegionCluster)
+					result, err := reconcileMultiRegionCluster(multiRegionCluster)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.Requeue).To(BeFalse())
+					Expect(reloadMultiRegionCluster(multiRegionCluster)).NotTo(HaveOccurred())
+				})
+
+				It("should mark the multi-region cluster as reconciled", func() {
+					Expect(multiRegionCluster.Status.Generations.Reconciled).To(Equal(multiRegionCluster.Generation))
+					Expect(multiRegionCluster.Status.DataCenters).To(ConsistOf(
+						fdbv1beta2.MultiRegionDataCenterStatus{ID: "primary", ClusterName: "operator-test-primary", Reconciled: true},
+						fdbv1beta2.MultiRegionDataCenterStatus{ID: "remote", ClusterName: "operator-test-remote", Reconciled: true},
+					))
+				})
+
+				When("the primary data center is changed", func() {
+					BeforeEach(func() {
+						multiRegionCluster.Spec.PrimaryDataCenter = "remote"
+						Expect(k8sClient.Update(context.TODO(), multiRegionCluster)).NotTo(HaveOccurred())
+						_, err := reconcileMultiRegionCluster(multiRegionCluster)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(reloadMultiRegionCluster(multiRegionCluster)).NotTo(HaveOccurred())
+					})
+
+					It("should swap the priorities in all data center clusters", func() {
+						Expect(multiRegionCluster.Status.PrimaryDataCenter).To(Equal("remote"))
+
+						for _, dataCenterID := range []string{"primary", "remote"} {
+							cluster, err := getDataCenterCluster(multiRegionCluster, dataCenterID)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(cluster.Spec.DatabaseConfiguration.Regions).To(ConsistOf(
+								fdbv1beta2.Region{DataCenters: []fdbv1beta2.DataCenter{{ID: "primary", Priority: 0}}},
+								fdbv1beta2.Region{DataCenters: []fdbv1beta2.DataCenter{{ID: "remote", Priority: 1}}},
+							))
+						}
+					})
+
+					It("should not change the seed connection string of the remote cluster", func() {
+						remoteCluster, err := getDataCenterCluster(multiRegionCluster, "remote")
+						Expect(err).NotTo(HaveOccurred())
+						Expect(remoteCluster.Spec.SeedConnectionString).To(Equal("test:abcd@127.0.0.1:4501"))
+					})
+				})
+
+				When("the primary data center is changed while the remote data center is lagging", func() {
+					BeforeEach(func() {
+						primaryCluster, err := getDataCenterCluster(multiRegionCluster, "primary")
+						Expect(err).NotTo(HaveOccurred())
+						adminClient, err := mock.NewMockAdminClientUncast(primaryCluster, k8sClient)
+						Expect(err).NotTo(HaveOccurred())
+						adminClient.MockDataCenterLag(10)
+
+						multiRegionCluster.Spec.PrimaryDataCenter = "remote"
+						Expect(k8sClient.Update(context.TODO(), multiRegionCluster)).NotTo(HaveOccurred())
+						result, err := reconcileMultiRegionCluster(multiRegionCluster)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(result.RequeueAfter).To(Equal(multiRegionClusterRequeueDelay))
+						Expect(reloadMultiRegionCluster(multiRegionCluster)).NotTo(HaveOccurred())
+					})
+
+					It("should wait for the remote data center to catch up", func() {
+						Expect(multiRegionCluster.Status.PrimaryDataCenter).To(Equal("primary"))
+
+						remoteCluster, err := getDataCenterCluster(multiRegionCluster, "remote")
+						Expect(err).NotTo(HaveOccurred())
+						Expect(remoteCluster.Spec.DatabaseConfiguration.GetPrimaryDataCenter()).To(Equal("primary"))
+					})
+				})
+
+				When("the primary data center is changed and the usable regions are reduced", func() {
+					BeforeEach(func() {
+						multiRegionCluster.Spec.PrimaryDataCenter = "remote"
+						multiRegionCluster.Spec.ClusterTemplate.DatabaseConfiguration.UsableRegions = 1
+						Expect(k8sClient.Update(context.TODO(), multiRegionCluster)).NotTo(HaveOccurred())
+						_, err := reconcileMultiRegionCluster(multiRegionCluster)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(reloadMultiRegionCluster(multiRegionCluster)).NotTo(HaveOccurred())
+					})
+
+					It("should change the primary data center before reducing the usable regions", func() {
+						Expect(multiRegionCluster.Status.PrimaryDataCenter).To(Equal("remote"))
+						Expect(multiRegionCluster.Status.UsableRegions).To(Equal(2))
+					})
+
+					When("the clusters are reconciled but the database has not failed over yet", func() {
+						BeforeEach(func() {
+							markDataCenterClustersReconciled(multiRegionCluster)
+
+							remoteCluster, err := getDataCenterCluster(multiRegionCluster, "remote")
+							Expect(err).NotTo(HaveOccurred())
+							adminClient, err := mock.NewMockAdminClientUncast(remoteCluster, k8sClient)
+							Expect(err).NotTo(HaveOccurred())
+							configuration := multiRegionCluster.GetDatabaseConfiguration("primary", 2)
+							adminClient.DatabaseConfiguration = &configuration
+
+							result, err := reconcileMultiRegionCluster(multiRegionCluster)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(result.RequeueAfter).To(Equal(multiRegionClusterRequeueDelay))
+							Expect(reloadMultiRegionCluster(multiRegionCluster)).NotTo(HaveOccurred())
+						})
+
+						It("should not reduce the usable regions", func() {
+							Expect(multiRegionCluster.Status.UsableRegions).To(Equal(2))
+						})
+					})
+
+					When("the configuration is applied but the database is still running in the previous primary data center", func() {
+						BeforeEach(func() {
+							markDataCenterClustersReconciled(multiRegionCluster)
+
+							remoteCluster, err := getDataCenterCluster(multiRegionCluster, "remote")
+							Expect(err).NotTo(HaveOccurred())
+							adminClient, err := mock.NewMockAdminClientUncast(remoteCluster, k8sClient)
+							Expect(err).NotTo(HaveOccurred())
+							adminClient.MockRunningPrimaryDataCenter("primary")
+
+							result, err := reconcileMultiRegionCluster(multiRegionCluster)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(result.RequeueAfter).To(Equal(multiRegionClusterRequeueDelay))
+							Expect(reloadMultiRegionCluster(multiRegionCluster)).NotTo(HaveOccurred())
+						})
+
+						It("should not reduce the usable regions", func() {
+							Expect(multiRegionCluster.Status.UsableRegions).To(Equal(2))
+						})
+					})
+
+					When("the database has failed over but the data distribution is not healthy", func() {
+						BeforeEach(func() {
+							markDataCenterClustersReconciled(multiRegionCluster)
+
+							remoteCluster, err := getDataCenterCluster(multiRegionCluster, "remote")
+							Expect(err).NotTo(HaveOccurred())
+							adminClient, err := mock.NewMockAdminClientUncast(remoteCluster, k8sClient)
+							Expect(err).NotTo(HaveOccurred())
+							adminClient.MockRunningPrimaryDataCenter("remote")
+							Expect(adminClient.FreezeStatus()).NotTo(HaveOccurred())
+							adminClient.FrozenStatus.Cluster.Data.State.Healthy = false
+							adminClient.FrozenStatus.Cluster.Data.State.Name = "healing"
+
+							result, err := reconcileMultiRegionCluster(multiRegionCluster)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(result.RequeueAfter).To(Equal(multiRegionClusterRequeueDelay))
+							Expect(reloadMultiRegionCluster(multiRegionCluster)).NotTo(HaveOccurred())
+						})
+
+						It("should not reduce the usable regions", func() {
+							Expect(multiRegionCluster.Status.UsableRegions).To(Equal(2))
+						})
+					})
+
+					When("the database has failed over", func() {
+						BeforeEach(func() {
+							markDataCenterClustersReconciled(multiRegionCluster)
+
+							remoteCluster, err := getDataCenterCluster(multiRegionCluster, "remote")
+							Expect(err).NotTo(HaveOccurred())
+							adminClient, err := mock.NewMockAdminClientUncast(remoteCluster, k8sClient)
+							Expect(err).NotTo(HaveOccurred())
+							adminClient.MockRunningPrimaryDataCenter("remote")
+
+							_, err = reconcileMultiRegionCluster(multiRegionCluster)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(reloadMultiRegionCluster(multiRegionCluster)).NotTo(HaveOccurred())
+						})
+
+						It("should reduce the usable regions", func() {
+							Expect(multiRegionCluster.Status.UsableRegions).To(Equal(1))
+
+							for _, dataCenterID := range []string{"primary", "remote"} {
+								cluster, err := getDataCenterCluster(multiRegionCluster, dataCenterID)
+								Expect(err).NotTo(HaveOccurred())
+								Expect(cluster.Spec.DatabaseConfiguration.UsableRegions).To(Equal(1))
+								Expect(cluster.Spec.DatabaseConfiguration.GetPrimaryDataCenter()).To(Equal("remote"))
+							}
+						})
+					})
+				})
+			})
+		})
+
+		When("the primary data center is changed before the clusters are reconciled", func() {
+			BeforeEach(func() {
+				multiRegionCluster.Spec.PrimaryDataCenter = "remote"
+				Expect(k8sClient.Update(context.TODO(), multiRegionCluster)).NotTo(HaveOccurred())
+				_, err := reconcileMultiRegionCluster(multiRegionCluster)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reloadMultiRegionCluster(multiRegionCluster)).NotTo(HaveOccurred())
+			})
+
+			It("should wait with the failover", func() {
+				Expect(multiRegionCluster.Status.PrimaryDataCenter).To(Equal("primary"))
+			})
+		})
+	})
+
+	When("the multi-region cluster is not valid", func() {
+		BeforeEach(func() {
+			multiRegionCluster.Spec.PrimaryDataCenter = "unknown"
+			Expect(k8sClient.Update(context.TODO(), multiRegionCluster)).NotTo(HaveOccurred())
+		})
+
+		It("should return an error", func() {
+			_, err := reconcileMultiRegionCluster(multiRegionCluster)
+			Expect(err).To(MatchError("MultiRegionClusterSpec is not valid: primary data center unknown is not a main data center of the regions"))
+		})
+	})
+})
@@ -0,0 +1,125 @@
+/*
+ * process_group_resources.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// loadProcessGroups replaces the process groups in the cluster status with the process groups from the
+// FoundationDBProcessGroup resources, see internal.LoadProcessGroups for more details.
+func (r *FoundationDBClusterReconciler) loadProcessGroups(ctx context.Context, cluster *fdbv1beta2.FoundationDBCluster) error {
+	return internal.LoadProcessGroups(ctx, r, cluster)
+}
+
+// updateProcessGroupResources creates, updates and deletes the FoundationDBProcessGroup resources to match the
+// process groups in the cluster status.
+func (r *FoundationDBClusterReconciler) updateProcessGroupResources(ctx context.Context, cluster *fdbv1beta2.FoundationDBCluster) error {
+	logger := log.WithValues("namespace", cluster.Namespace, "cluster", cluster.Name)
+
+	existingProcessGroups, err := internal.GetProcessGroupResources(ctx, r, cluster)
+	if err != nil {
+		return err
+	}
+
+	for _, processGroupStatus := range cluster.Status.ProcessGroups {
+		if processGroupStatus == nil || processGroupStatus.ProcessGroupID == "" {
+			continue
+		}
+
+		desired := internal.GetProcessGroup(cluster, processGroupStatus)
+		existing, ok := existingProcessGroups[processGroupStatus.ProcessGroupID]
+		delete(existingProcessGroups, processGroupStatus.ProcessGroupID)
+
+		if !ok {
+			logger.V(1).Info("Creating process group resource", "processGroupID", processGroupStatus.ProcessGroupID)
+			status := desired.Status
+			err = r.Create(ctx, desired)
+			if err != nil {
+				return err
+			}
+
+			if equality.Semantic.DeepEqual(status, fdbv1beta2.FoundationDBProcessGroupStatus{}) {
+				continue
+			}
+
+			desired.Status = status
+			err = r.Status().Update(ctx, desired)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if !equality.Semantic.DeepEqual(existing.Spec, desired.Spec) {
+			existing.Spec = desired.Spec
+			err = r.Update(ctx, existing)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !equality.Semantic.DeepEqual(existing.Status, desired.Status) {
+			existing.Status = desired.Status
+			err = r.Status().Update(ctx, existing)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for processGroupID, processGroup := range existingProcessGroups {
+		logger.V(1).Info("Deleting process group resource", "processGroupID", processGroupID)
+		err = r.Delete(ctx, processGroup)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteProcessGroupResources deletes all FoundationDBProcessGroup resources of the cluster. This is used once the
+// process groups are written to the cluster status again, after the FoundationDBProcessGroup resources were disabled.
+func (r *FoundationDBClusterReconciler) deleteProcessGroupResources(ctx context.Context, cluster *fdbv1beta2.FoundationDBCluster) error {
+	logger := log.WithValues("namespace", cluster.Namespace, "cluster", cluster.Name)
+
+	existingProcessGroups, err := internal.GetProcessGroupResources(ctx, r, cluster)
+	if err != nil {
+		return err
+	}
+
+	for processGroupID, processGroup := range existingProcessGroups {
+		logger.V(1).Info("Deleting process group resource after the process groups were moved into the cluster status", "processGroupID", processGroupID)
+		err = r.Delete(ctx, processGroup)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+/*
+ * process_group_resources_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func getProcessGroupResourceIDs(cluster *fdbv1beta2.FoundationDBCluster) []fdbv1beta2.ProcessGroupID {
+	processGroups := &fdbv1beta2.FoundationDBProcessGroupList{}
+	Expect(k8sClient.List(context.TODO(), processGroups, internal.GetPodListOptions(cluster, "", "")...)).NotTo(HaveOccurred())
+
+	processGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(processGroups.Items))
+	for _, processGroup := range processGroups.Items {
+		Expect(processGroup.Name).To(Equal(fdbv1beta2.GetProcessGroupResourceName(cluster.Name, processGroup.Spec.ProcessGroupID)))
+		Expect(processGroup.Spec.ClusterName).To(Equal(cluster.Name))
+		Expect(processGroup.OwnerReferences).To(HaveLen(1))
+		Expect(processGroup.OwnerReferences[0].UID).To(Equal(cluster.UID))
+		processGroupIDs = append(processGroupIDs, processGroup.Spec.ProcessGroupID)
+	}
+
+	return processGroupIDs
+}
+
+var _ = Describe("process_group_resources", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var originalProcessGroupIDs []fdbv1beta2.ProcessGroupID
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(setupClusterForTest(cluster)).NotTo(HaveOccurred())
+
+		originalProcessGroupIDs = make([]fdbv1beta2.ProcessGroupID, 0, len(cluster.Status.ProcessGroups))
+		for _, processGroup := range cluster.Status.ProcessGroups {
+			originalProcessGroupIDs = append(originalProcessGroupIDs, processGroup.ProcessGroupID)
+		}
+		Expect(originalProcessGroupIDs).To(HaveLen(17))
+	})
+
+	When("process group resources are disabled", func() {
+		It("should not create any process group resources", func() {
+			Expect(getProcessGroupResourceIDs(cluster)).To(BeEmpty())
+		})
+	})
+
+	When("process group resources are enabled", func() {
+		BeforeEach(func() {
+			cluster.Spec.AutomationOptions.UseProcessGroupResources = pointer.Bool(true)
+			Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+			result, err := reconcileCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Requeue).To(BeFalse())
+
+			_, err = reloadCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should migrate the process groups into process group resources", func() {
+			Expect(cluster.Status.ProcessGroups).To(BeEmpty())
+			Expect(cluster.Status.HasProcessGroupResources).To(BeTrue())
+			Expect(getProcessGroupResourceIDs(cluster)).To(ConsistOf(originalProcessGroupIDs))
+		})
+
+		It("should report the process group metrics", func() {
+			metrics := make(chan prometheus.Metric, 1000)
+			newFDBClusterCollector(clusterReconciler).Collect(metrics)
+			close(metrics)
+
+			readyProcessGroups := 0.0
+			for metric := range metrics {
+				if metric.Desc() != descProcessGroupStatus {
+					continue
+				}
+
+				data := &dto.Metric{}
+				Expect(metric.Write(data)).NotTo(HaveOccurred())
+				for _, label := range data.Label {
+					if label.GetName() == "condition" && label.GetValue() == string(fdbv1beta2.ReadyCondition) {
+						readyProcessGroups += data.Gauge.GetValue()
+					}
+				}
+			}
+			Expect(readyProcessGroups).To(BeNumerically("==", len(originalProcessGroupIDs)))
+		})
+
+		When("the match labels of the cluster are changed", func() {
+			BeforeEach(func() {
+				cluster.Spec.LabelConfig.MatchLabels = map[string]string{"custom-label": "custom-value"}
+			})
+
+			It("should load the process groups from the process group resources", func() {
+				Expect(clusterReconciler.loadProcessGroups(context.TODO(), cluster)).NotTo(HaveOccurred())
+				Expect(cluster.Status.ProcessGroups).To(HaveLen(len(originalProcessGroupIDs)))
+			})
+		})
+
+		It("should load the process groups from the process group resources", func() {
+			Expect(clusterReconciler.loadProcessGroups(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+			processGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(cluster.Status.ProcessGroups))
+			for _, processGroup := range cluster.Status.ProcessGroups {
+				processGroupIDs = append(processGroupIDs, processGroup.ProcessGroupID)
+				Expect(processGroup.Addresses).NotTo(BeEmpty())
+			}
+			Expect(processGroupIDs).To(Equal(originalProcessGroupIDs))
+		})
+
+		It("should load the process groups for other readers of the cluster", func() {
+			latestCluster := &fdbv1beta2.FoundationDBCluster{}
+			Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(cluster), latestCluster)).NotTo(HaveOccurred())
+			Expect(latestCluster.Status.ProcessGroups).To(BeEmpty())
+			Expect(internal.LoadProcessGroups(context.TODO(), k8sClient, latestCluster)).NotTo(HaveOccurred())
+
+			processGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(latestCluster.Status.ProcessGroups))
+			for _, processGroup := range latestCluster.Status.ProcessGroups {
+				processGroupIDs = append(processGroupIDs, processGroup.ProcessGroupID)
+			}
+			Expect(processGroupIDs).To(ConsistOf(originalProcessGroupIDs))
+		})
+
+		When("process group resources are disabled after the migration", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.UseProcessGroupResources = pointer.Bool(false)
+				Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+				result, err := reconcileCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Requeue).To(BeFalse())
+
+				_, err = reloadCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should move the process groups back into the cluster status", func() {
+				processGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(cluster.Status.ProcessGroups))
+				for _, processGroup := range cluster.Status.ProcessGroups {
+					processGroupIDs = append(processGroupIDs, processGroup.ProcessGroupID)
+					Expect(processGroup.IsMarkedForRemoval()).To(BeFalse())
+				}
+				Expect(processGroupIDs).To(ConsistOf(originalProcessGroupIDs))
+			})
+
+			It("should delete the process group resources", func() {
+				Expect(getProcessGroupResourceIDs(cluster)).To(BeEmpty())
+				Expect(cluster.Status.HasProcessGroupResources).To(BeFalse())
+			})
+		})
+
+		When("a process group is removed", func() {
+			BeforeEach(func() {
+				cluster.Spec.ProcessGroupsToRemove = []fdbv1beta2.ProcessGroupID{"storage-1"}
+				Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+				result, err := reconcileCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Requeue).To(BeFalse())
+
+				_, err = reloadCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should replace the process group resource", func() {
+				processGroupIDs := getProcessGroupResourceIDs(cluster)
+				Expect(processGroupIDs).To(HaveLen(17))
+				Expect(processGroupIDs).NotTo(ContainElement(fdbv1beta2.ProcessGroupID("storage-1")))
+				Expect(processGroupIDs).To(ContainElement(fdbv1beta2.ProcessGroupID("storage-5")))
+				Expect(cluster.Status.ProcessGroups).To(BeEmpty())
+			})
+		})
+
+		When("a process group is marked for removal", func() {
+			BeforeEach(func() {
+				Expect(clusterReconciler.loadProcessGroups(context.TODO(), cluster)).NotTo(HaveOccurred())
+				processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-2")
+				Expect(processGroup).NotTo(BeNil())
+				processGroup.MarkForRemoval()
+				Expect(clusterReconciler.updateOrApply(context.TODO(), cluster)).NotTo(HaveOccurred())
+			})
+
+			It("should update the status of the process group resource", func() {
+				processGroup := &fdbv1beta2.FoundationDBProcessGroup{}
+				Expect(k8sClient.Get(context.TODO(), client.ObjectKey{
+					Namespace: cluster.Namespace,
+					Name:      fdbv1beta2.GetProcessGroupResourceName(cluster.Name, "storage-2"),
+				}, processGroup)).NotTo(HaveOccurred())
+				Expect(processGroup.Status.RemovalTimestamp).NotTo(BeNil())
+				Expect(processGroup.GetProcessGroupStatus().IsMarkedForRemoval()).To(BeTrue())
+			})
+
+			It("should keep the process groups in memory", func() {
+				Expect(cluster.Status.ProcessGroups).To(HaveLen(17))
+			})
+		})
+	})
+})
@@ -380,6 +380,43 @@ var _ = Describe("replace_failed_process_groups", func() {
 			Expect(getRemovedProcessGroupIDs(cluster)).To(Equal([]fdbv1beta2.ProcessGroupID{}))
 		})
 	})
+
+	Context("with a process that is running on a tainted node", func() {
+		BeforeEach(func() {
+			processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-2")
+			processGroup.ProcessGroupConditions = append(processGroup.ProcessGroupConditions, &fdbv1beta2.ProcessGroupCondition{
+				ProcessGroupConditionType: fdbv1beta2.NodeTaintDetected,
+				Timestamp:                 time.Now().Unix(),
+			})
+		})
+
+		It("should return nil", func() {
+			Expect(result).To(BeNil())
+		})
+
+		It("should not mark the process group for removal", func() {
+			Expect(getRemovedProcessGroupIDs(cluster)).To(Equal([]fdbv1beta2.ProcessGroupID{}))
+		})
+
+		When("the taint was present for longer than the defined duration", func() {
+			BeforeEach(func() {
+				processGroup := fdbv1beta2.FindProcessGroupByID(cluster.Status.ProcessGroups, "storage-2")
+				processGroup.ProcessGroupConditions = append(processGroup.ProcessGroupConditions, &fdbv1beta2.ProcessGroupCondition{
+					ProcessGroupConditionType: fdbv1beta2.NodeTaintReplacing,
+					Timestamp:                 time.Now().Unix(),
+				})
+			})
+
+			It("should requeue", func() {
+				Expect(result).NotTo(BeNil())
+				Expect(result.message).To(Equal("Removals have been updated in the cluster status"))
+			})
+
+			It("should mark the process group for removal", func() {
+				Expect(getRemovedProcessGroupIDs(cluster)).To(Equal([]fdbv1beta2.ProcessGroupID{"storage-2"}))
+			})
+		})
+	})
 })
 
 // getRemovedProcessGroupIDs returns a list of ids for the process groups that
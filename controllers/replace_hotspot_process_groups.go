@@ -0,0 +1,142 @@
+/*
+ * replace_hotspot_process_groups.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// replaceHotspotProcessGroups replaces the process groups of storage processes that were overloaded for longer than
+// the defined duration, if the hotspot remediation is configured with the Replace action.
+type replaceHotspotProcessGroups struct{}
+
+// reconcile runs the reconciler's work.
+func (replaceHotspotProcessGroups) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster) *requeue {
+	logger := log.WithValues("namespace", cluster.Namespace, "cluster", cluster.Name, "reconciler", "replaceHotspotProcessGroups")
+
+	if !cluster.GetEnableHotspotRemediation() || cluster.GetHotspotAction() != fdbv1beta2.HotspotActionReplace {
+		return nil
+	}
+
+	maxReplacements := cluster.GetHotspotMaxConcurrentReplacements()
+	if maxReplacements <= 0 {
+		return nil
+	}
+
+	overloadedDuration := cluster.GetHotspotOverloadedDuration()
+	var processGroups []*fdbv1beta2.ProcessGroupStatus
+	var pendingHotspots int
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.ProcessClass != fdbv1beta2.ProcessClassStorage {
+			continue
+		}
+
+		if processGroup.IsMarkedForRemoval() {
+			// Count all storage removals that are in-flight.
+			if !processGroup.IsExcluded() {
+				maxReplacements--
+			}
+			continue
+		}
+
+		detectedTime := processGroup.GetConditionTime(fdbv1beta2.StorageHotspot)
+		if detectedTime == nil {
+			continue
+		}
+
+		if time.Since(time.Unix(*detectedTime, 0)) < overloadedDuration {
+			pendingHotspots++
+			continue
+		}
+
+		processGroups = append(processGroups, processGroup)
+	}
+
+	if len(processGroups) == 0 {
+		if pendingHotspots > 0 {
+			logger.V(1).Info("Waiting for storage hotspots to exceed the overloaded duration", "hotspots", pendingHotspots, "overloadedDuration", overloadedDuration.String())
+		}
+
+		return nil
+	}
+
+	if cluster.Status.LastHotspotReplacement != nil {
+		remaining := time.Until(cluster.Status.LastHotspotReplacement.Add(cluster.GetHotspotMinimumDurationBetweenReplacements()))
+		if remaining > 0 {
+			return &requeue{message: fmt.Sprintf("Waiting %s before the next storage hotspot is replaced", remaining.Round(time.Second).String()), delay: remaining, delayedRequeue: true}
+		}
+	}
+
+	if maxReplacements <= 0 {
+		return &requeue{message: "Waiting for ongoing storage replacements before storage hotspots are replaced", delay: podSchedulingDelayDuration, delayedRequeue: true}
+	}
+
+	adminClient, err := r.getDatabaseClientProvider().GetAdminClient(cluster, r)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+	defer adminClient.Close()
+
+	hasDesiredFaultTolerance, err := internal.HasDesiredFaultTolerance(logger, adminClient, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	if !hasDesiredFaultTolerance {
+		return &requeue{message: "Waiting for the desired fault tolerance before storage hotspots are replaced", delay: podSchedulingDelayDuration, delayedRequeue: true}
+	}
+
+	// Replace the process groups that are overloaded for the longest time first.
+	sort.SliceStable(processGroups, func(i, j int) bool {
+		return *processGroups[i].GetConditionTime(fdbv1beta2.StorageHotspot) < *processGroups[j].GetConditionTime(fdbv1beta2.StorageHotspot)
+	})
+
+	if len(processGroups) > maxReplacements {
+		processGroups = processGroups[:maxReplacements]
+	}
+
+	processGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(processGroups))
+	for _, processGroup := range processGroups {
+		logger.Info("Replace process group",
+			"processGroupID", processGroup.ProcessGroupID,
+			"reason", fmt.Sprintf("storage hotspot detected at: %s", time.Unix(*processGroup.GetConditionTime(fdbv1beta2.StorageHotspot), 0).UTC().String()))
+		processGroup.MarkForRemoval()
+		processGroupIDs = append(processGroupIDs, processGroup.ProcessGroupID)
+	}
+
+	cluster.Status.LastHotspotReplacement = &metav1.Time{Time: time.Now()}
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "ReplacingStorageHotspots", fmt.Sprintf("Replacing process groups of overloaded storage processes: %v", processGroupIDs))
+
+	err = r.updateOrApply(ctx, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return &requeue{message: "Removals have been updated in the cluster status"}
+}
@@ -0,0 +1,157 @@
+/*
+ * replace_hotspot_process_groups_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("replace_hotspot_process_groups", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var req *requeue
+
+	// setHotspot marks the process group as hotspot that was detected at the provided time.
+	setHotspot := func(processGroupID fdbv1beta2.ProcessGroupID, detectedTime time.Time) {
+		for _, processGroup := range cluster.Status.ProcessGroups {
+			if processGroup.ProcessGroupID != processGroupID {
+				continue
+			}
+
+			processGroup.ProcessGroupConditions = append(processGroup.ProcessGroupConditions, &fdbv1beta2.ProcessGroupCondition{
+				ProcessGroupConditionType: fdbv1beta2.StorageHotspot,
+				Timestamp:                 detectedTime.Unix(),
+			})
+		}
+	}
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(k8sClient.Create(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+		result, err := reconcileCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Requeue).To(BeFalse())
+
+		_, err = reloadCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+
+		cluster.Spec.AutomationOptions.HotspotRemediation = fdbv1beta2.HotspotRemediationOptions{
+			Enabled:           pointer.Bool(true),
+			OverloadedSeconds: pointer.Int(600),
+			Action:            fdbv1beta2.HotspotActionReplace,
+		}
+	})
+
+	JustBeforeEach(func() {
+		req = replaceHotspotProcessGroups{}.reconcile(context.TODO(), clusterReconciler, cluster)
+	})
+
+	When("no hotspot is detected", func() {
+		It("should not replace any process group", func() {
+			Expect(req).To(BeNil())
+			Expect(getRemovedProcessGroupIDs(cluster)).To(BeEmpty())
+		})
+	})
+
+	When("a hotspot was detected recently", func() {
+		BeforeEach(func() {
+			setHotspot("storage-1", time.Now())
+		})
+
+		It("should not replace the process group", func() {
+			Expect(req).To(BeNil())
+			Expect(getRemovedProcessGroupIDs(cluster)).To(BeEmpty())
+		})
+	})
+
+	When("multiple hotspots are overloaded for longer than the defined duration", func() {
+		BeforeEach(func() {
+			setHotspot("storage-1", time.Now().Add(-20*time.Minute))
+			setHotspot("storage-2", time.Now().Add(-30*time.Minute))
+		})
+
+		It("should replace the process group that is overloaded for the longest time", func() {
+			Expect(req).NotTo(BeNil())
+			Expect(req.message).To(Equal("Removals have been updated in the cluster status"))
+			Expect(getRemovedProcessGroupIDs(cluster)).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-2")))
+			Expect(cluster.Status.LastHotspotReplacement).NotTo(BeNil())
+		})
+
+		When("the action is None", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.HotspotRemediation.Action = fdbv1beta2.HotspotActionNone
+			})
+
+			It("should not replace any process group", func() {
+				Expect(req).To(BeNil())
+				Expect(getRemovedProcessGroupIDs(cluster)).To(BeEmpty())
+			})
+		})
+
+		When("a hotspot was replaced recently", func() {
+			BeforeEach(func() {
+				cluster.Status.LastHotspotReplacement = &metav1.Time{Time: time.Now().Add(-10 * time.Minute)}
+			})
+
+			It("should wait before replacing the next hotspot", func() {
+				Expect(req).NotTo(BeNil())
+				Expect(req.delayedRequeue).To(BeTrue())
+				Expect(getRemovedProcessGroupIDs(cluster)).To(BeEmpty())
+			})
+		})
+
+		When("another storage process group is being removed", func() {
+			BeforeEach(func() {
+				for _, processGroup := range cluster.Status.ProcessGroups {
+					if processGroup.ProcessGroupID == "storage-3" {
+						processGroup.MarkForRemoval()
+					}
+				}
+			})
+
+			It("should not replace more process groups than allowed", func() {
+				Expect(req).NotTo(BeNil())
+				Expect(req.delayedRequeue).To(BeTrue())
+				Expect(getRemovedProcessGroupIDs(cluster)).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-3")))
+			})
+
+			When("the concurrent replacements are increased", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.HotspotRemediation.MaxConcurrentReplacements = pointer.Int(3)
+				})
+
+				It("should replace both hotspots", func() {
+					Expect(req).NotTo(BeNil())
+					Expect(req.delayedRequeue).To(BeFalse())
+					Expect(getRemovedProcessGroupIDs(cluster)).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-1"), fdbv1beta2.ProcessGroupID("storage-2"), fdbv1beta2.ProcessGroupID("storage-3")))
+				})
+			})
+		})
+	})
+})
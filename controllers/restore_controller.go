@@ -22,6 +22,7 @@ package controllers
 
 import (
 	"context"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -39,6 +40,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// restoreStatusRequeueDelay defines the delay between the status updates of a running restore.
+const restoreStatusRequeueDelay = 1 * time.Minute
+
 // FoundationDBRestoreReconciler reconciles a FoundationDBRestore object
 type FoundationDBRestoreReconciler struct {
 	client.Client
@@ -71,6 +75,7 @@ func (r *FoundationDBRestoreReconciler) Reconcile(ctx context.Context, request c
 
 	subReconcilers := []restoreSubReconciler{
 		startRestore{},
+		updateRestoreStatus{},
 	}
 
 	for _, subReconciler := range subReconcilers {
@@ -84,6 +89,11 @@ func (r *FoundationDBRestoreReconciler) Reconcile(ctx context.Context, request c
 
 	restoreLog.Info("Reconciliation complete")
 
+	if restore.Status.Phase == fdbv1beta2.RestorePhaseRunning {
+		// Requeue the restore to update the progress in the status.
+		return ctrl.Result{RequeueAfter: restoreStatusRequeueDelay}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -157,24 +167,5 @@ type restoreSubReconciler interface {
 
 // updateOrApply updates the status either with server-side apply or if disabled with the normal update call.
 func (r *FoundationDBRestoreReconciler) updateOrApply(ctx context.Context, restore *fdbv1beta2.FoundationDBRestore) error {
-	if r.ServerSideApply {
-		// TODO(johscheuer): We have to set the TypeMeta otherwise the Patch command will fail. This is the rudimentary
-		// support for server side apply which should be enough for the status use case. The controller runtime will
-		// add some additional support in the future: https://github.com/kubernetes-sigs/controller-runtime/issues/347.
-		patch := &fdbv1beta2.FoundationDBRestore{
-			TypeMeta: metav1.TypeMeta{
-				Kind:       restore.Kind,
-				APIVersion: restore.APIVersion,
-			},
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      restore.Name,
-				Namespace: restore.Namespace,
-			},
-			Status: restore.Status,
-		}
-
-		return r.Status().Patch(ctx, patch, client.Apply, client.FieldOwner("fdb-operator"), client.ForceOwnership)
-	}
-
-	return r.Status().Update(ctx, restore)
+	return updateOrApplyStatus(ctx, r, r.ServerSideApply, restore)
 }
@@ -94,7 +94,49 @@ var _ = Describe("restore_controller", func() {
 			It("should start a restore", func() {
 				status, err := adminClient.GetRestoreStatus()
 				Expect(err).NotTo(HaveOccurred())
-				Expect(status).To(Equal("blobstore://test@test-service/test-backup?bucket=fdb-backups\n"))
+				Expect(status.URL).To(Equal("blobstore://test@test-service/test-backup?bucket=fdb-backups"))
+				Expect(restore.Status.Phase).To(Equal(fdbv1beta2.RestorePhaseRunning))
+			})
+		})
+
+		When("the restore makes progress", func() {
+			BeforeEach(func() {
+				adminClient.MockRestoreStatus(&fdbv1beta2.FoundationDBLiveRestoreStatus{
+					State:           "running",
+					URL:             restore.BackupURL(),
+					BlocksCompleted: 10,
+					BlocksTotal:     100,
+					BytesWritten:    1024,
+					LastError:       "Task execution stopped due to timeout",
+					TargetVersion:   1000,
+				})
+			})
+
+			It("should update the progress in the status", func() {
+				Expect(restore.Status.Running).To(BeTrue())
+				Expect(restore.Status.TargetVersion).To(BeNumerically("==", 1000))
+				Expect(restore.Status.Progress).To(Equal(&fdbv1beta2.FoundationDBRestoreProgress{
+					BlocksCompleted: 10,
+					BlocksTotal:     100,
+					BytesWritten:    1024,
+				}))
+				Expect(restore.Status.Errors).To(ConsistOf("Task execution stopped due to timeout"))
+			})
+		})
+
+		When("the restore is completed", func() {
+			BeforeEach(func() {
+				adminClient.MockRestoreStatus(&fdbv1beta2.FoundationDBLiveRestoreStatus{
+					State:           "completed",
+					URL:             restore.BackupURL(),
+					BlocksCompleted: 100,
+					BlocksTotal:     100,
+				})
+			})
+
+			It("should mark the restore as completed", func() {
+				Expect(restore.Status.Running).To(BeFalse())
+				Expect(restore.Status.Phase).To(Equal(fdbv1beta2.RestorePhaseCompleted))
 			})
 		})
 
@@ -113,4 +155,124 @@ var _ = Describe("restore_controller", func() {
 			})
 		})
 	})
+
+	Describe("Point-in-time restore", func() {
+		BeforeEach(func() {
+			Expect(k8sClient.Create(context.TODO(), cluster)).NotTo(HaveOccurred())
+			result, err := reconcileCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Requeue).To(BeFalse())
+
+			adminClient.MockBackupDescription(&fdbv1beta2.FoundationDBBackupDescription{
+				URL:                  restore.BackupURL(),
+				Restorable:           true,
+				MinRestorableVersion: &fdbv1beta2.FoundationDBBackupDescriptionVersion{Version: 100, Timestamp: "2023/01/01.00:00:00+0000"},
+				MaxRestorableVersion: &fdbv1beta2.FoundationDBBackupDescriptionVersion{Version: 500, Timestamp: "2023/01/05.00:00:00+0000"},
+			})
+		})
+
+		JustBeforeEach(func() {
+			Expect(k8sClient.Create(context.TODO(), restore)).NotTo(HaveOccurred())
+			result, err := reconcileRestore(restore)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Requeue).To(BeFalse())
+			Expect(reloadRestore(restore)).NotTo(HaveOccurred())
+		})
+
+		When("the target version is in the restorable range", func() {
+			BeforeEach(func() {
+				targetVersion := int64(300)
+				restore.Spec.TargetVersion = &targetVersion
+			})
+
+			It("should start the restore to the target version", func() {
+				Expect(restore.Status.Phase).To(Equal(fdbv1beta2.RestorePhaseRunning))
+				Expect(restore.Status.TargetVersion).To(BeNumerically("==", 300))
+				Expect(restore.Status.Errors).To(BeEmpty())
+			})
+		})
+
+		When("the target version is not in the restorable range", func() {
+			BeforeEach(func() {
+				targetVersion := int64(600)
+				restore.Spec.TargetVersion = &targetVersion
+			})
+
+			It("should not start the restore", func() {
+				status, err := adminClient.GetRestoreStatus()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.State).To(BeEmpty())
+
+				Expect(restore.Status.Running).To(BeFalse())
+				Expect(restore.Status.Phase).To(Equal(fdbv1beta2.RestorePhaseFailed))
+				Expect(restore.Status.Errors).To(ConsistOf("target version 600 is not in the restorable range from 100 to 500"))
+			})
+		})
+
+		When("the target timestamp is in the restorable range", func() {
+			BeforeEach(func() {
+				targetTimestamp := "2023/01/03.12:00:00+0000"
+				restore.Spec.TargetTimestamp = &targetTimestamp
+				restore.Spec.SourceClusterName = cluster.Name
+			})
+
+			It("should start the restore to the target timestamp", func() {
+				Expect(restore.Status.Phase).To(Equal(fdbv1beta2.RestorePhaseRunning))
+				Expect(adminClient.RestoreTargetTimestamp).To(Equal(restore.Spec.TargetTimestamp))
+				Expect(adminClient.RestoreSourceCluster).NotTo(BeNil())
+				Expect(adminClient.RestoreSourceCluster.Name).To(Equal(cluster.Name))
+			})
+
+			When("the source cluster is not defined", func() {
+				BeforeEach(func() {
+					restore.Spec.SourceClusterName = ""
+				})
+
+				It("should not start the restore", func() {
+					Expect(adminClient.RestoreTargetTimestamp).To(BeNil())
+					Expect(restore.Status.Phase).To(Equal(fdbv1beta2.RestorePhaseFailed))
+					Expect(restore.Status.Errors).To(ConsistOf("sourceClusterName must be defined to restore to a target timestamp"))
+				})
+			})
+		})
+
+		When("the restore defines a blob store and a file system source", func() {
+			BeforeEach(func() {
+				restore.Spec.FileSystemConfiguration = &fdbv1beta2.FileSystemConfiguration{
+					PersistentVolumeClaimName: "backup-pvc",
+				}
+			})
+
+			It("should not start the restore", func() {
+				Expect(restore.Status.Phase).To(Equal(fdbv1beta2.RestorePhaseFailed))
+				Expect(restore.Status.Errors).To(ConsistOf("only one of blobStoreConfiguration and fileSystemConfiguration can be defined"))
+			})
+		})
+
+		When("the restore defines a file system source", func() {
+			BeforeEach(func() {
+				restore.Spec.BlobStoreConfiguration = nil
+				restore.Spec.FileSystemConfiguration = &fdbv1beta2.FileSystemConfiguration{
+					PersistentVolumeClaimName: "backup-pvc",
+				}
+			})
+
+			It("should not start the restore", func() {
+				Expect(restore.Status.Phase).To(Equal(fdbv1beta2.RestorePhaseFailed))
+				Expect(restore.Status.Errors).To(ConsistOf("fileSystemConfiguration is not supported, as the operator can't access the persistent volume"))
+			})
+		})
+
+		When("the target timestamp is not in the restorable range", func() {
+			BeforeEach(func() {
+				targetTimestamp := "2022/12/31.00:00:00+0000"
+				restore.Spec.TargetTimestamp = &targetTimestamp
+			})
+
+			It("should not start the restore", func() {
+				Expect(restore.Status.Phase).To(Equal(fdbv1beta2.RestorePhaseFailed))
+				Expect(restore.Status.Errors).To(ConsistOf("target timestamp 2022/12/31.00:00:00+0000 is not in the restorable range from 2023/01/01.00:00:00+0000 to 2023/01/05.00:00:00+0000"))
+			})
+		})
+	})
 })
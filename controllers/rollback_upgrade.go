@@ -0,0 +1,128 @@
+/*
+ * rollback_upgrade.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// rollbackUpgrade provides a reconciliation step for rolling back a version incompatible upgrade if the database
+// doesn't become available after the processes were restarted with the new version.
+type rollbackUpgrade struct{}
+
+// reconcile runs the reconciler's work.
+func (rollbackUpgrade) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster) *requeue {
+	if !cluster.GetEnableUpgradeRollback() {
+		return nil
+	}
+
+	upgradeStatus := cluster.Status.Upgrade
+	if upgradeStatus == nil || upgradeStatus.RestartTimestamp == nil || upgradeStatus.TargetVersion != cluster.Spec.Version || upgradeStatus.PreviousVersion == "" {
+		return nil
+	}
+
+	// Once the database was available at the target version, transactions were committed with the new version and
+	// the data files can't be read by the previous version anymore. A rollback is never rolled back again.
+	if meta.IsStatusConditionTrue(upgradeStatus.Conditions, fdbv1beta2.UpgradeConditionCommitted) || meta.IsStatusConditionTrue(upgradeStatus.Conditions, fdbv1beta2.UpgradeConditionRolledBack) {
+		return nil
+	}
+
+	if cluster.Status.Health.Available {
+		return nil
+	}
+
+	previousVersion, err := fdbv1beta2.ParseFdbVersion(upgradeStatus.PreviousVersion)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	version, err := fdbv1beta2.ParseFdbVersion(cluster.Spec.Version)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	// Version compatible upgrades restart the processes one by one and don't require a rollback.
+	if version.IsProtocolCompatible(previousVersion) {
+		return nil
+	}
+
+	logger := log.WithValues("namespace", cluster.Namespace, "cluster", cluster.Name, "reconciler", "rollbackUpgrade")
+	unavailableDuration := time.Since(upgradeStatus.RestartTimestamp.Time)
+	deadline := cluster.GetUnavailableDurationBeforeRollback()
+	if unavailableDuration < deadline {
+		logger.Info("Database is unavailable after the upgrade", "unavailableDuration", unavailableDuration.String(), "deadline", deadline.String())
+		return &requeue{
+			message:        fmt.Sprintf("Database is unavailable after restarting processes with version %s, rollback in %s", cluster.Spec.Version, (deadline - unavailableDuration).Round(time.Second)),
+			delay:          deadline - unavailableDuration,
+			delayedRequeue: true,
+		}
+	}
+
+	message := fmt.Sprintf("Database was unavailable for %s after restarting processes with version %s, rolling back to version %s", unavailableDuration.Round(time.Second), cluster.Spec.Version, upgradeStatus.PreviousVersion)
+	logger.Info("Rolling back upgrade", "message", message)
+	r.Recorder.Event(cluster, corev1.EventTypeWarning, "UpgradeRollback", message)
+
+	// Only the version is changed in the spec, the cluster object in the reconciliation loop contains the normalized
+	// spec that should not be persisted.
+	latestCluster := &fdbv1beta2.FoundationDBCluster{}
+	err = r.Get(ctx, client.ObjectKeyFromObject(cluster), latestCluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	latestCluster.Spec.Version = upgradeStatus.PreviousVersion
+	err = r.Update(ctx, latestCluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	cluster.ObjectMeta = latestCluster.ObjectMeta
+	cluster.Spec.Version = upgradeStatus.PreviousVersion
+
+	rollbackStatus := &fdbv1beta2.UpgradeStatus{
+		TargetVersion: upgradeStatus.PreviousVersion,
+		Phase:         fdbv1beta2.UpgradePhaseRestarting,
+		Conditions:    upgradeStatus.Conditions,
+	}
+	meta.SetStatusCondition(&rollbackStatus.Conditions, metav1.Condition{
+		Type:               fdbv1beta2.UpgradeConditionRolledBack,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cluster.Generation,
+		Reason:             "DatabaseUnavailable",
+		Message:            message,
+	})
+	cluster.Status.Upgrade = rollbackStatus
+
+	err = r.updateOrApply(ctx, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return &requeue{message: fmt.Sprintf("Rolled back upgrade to version %s", upgradeStatus.PreviousVersion)}
+}
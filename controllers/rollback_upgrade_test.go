@@ -0,0 +1,181 @@
+/*
+ * rollback_upgrade_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("rollback_upgrade", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var adminClient *mock.AdminClient
+	var req *requeue
+	var restartTimestamp time.Time
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(k8sClient.Create(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+		result, err := reconcileCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Requeue).To(BeFalse())
+
+		_, err = reloadCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+
+		adminClient, err = mock.NewMockAdminClientUncast(cluster, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+
+		cluster.Spec.Version = fdbv1beta2.Versions.NextMajorVersion.String()
+		cluster.Spec.AutomationOptions.UpgradeRollback.Enabled = pointer.Bool(true)
+		Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+		restartTimestamp = time.Now().Add(-10 * time.Minute)
+	})
+
+	JustBeforeEach(func() {
+		// Simulate a version incompatible upgrade where the database didn't come back after the restart.
+		adminClient.MockDatabaseUnavailable(true)
+		cluster.Status.RunningVersion = fdbv1beta2.Versions.NextMajorVersion.String()
+		cluster.Status.Health.Available = false
+		if cluster.Status.Upgrade == nil {
+			cluster.Status.Upgrade = &fdbv1beta2.UpgradeStatus{
+				TargetVersion:    fdbv1beta2.Versions.NextMajorVersion.String(),
+				Phase:            fdbv1beta2.UpgradePhaseRestarting,
+				PreviousVersion:  fdbv1beta2.Versions.Default.String(),
+				RestartTimestamp: &metav1.Time{Time: restartTimestamp},
+			}
+		}
+		meta.SetStatusCondition(&cluster.Status.Upgrade.Conditions, metav1.Condition{
+			Type:   fdbv1beta2.UpgradeConditionProcessesRestarted,
+			Status: metav1.ConditionTrue,
+			Reason: "ProcessesBounced",
+		})
+		Expect(k8sClient.Status().Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+		req = rollbackUpgrade{}.reconcile(context.TODO(), clusterReconciler, cluster)
+		_, err := reloadCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	When("the database is unavailable longer than the deadline", func() {
+		It("should roll back the upgrade", func() {
+			Expect(req).NotTo(BeNil())
+			Expect(req.curError).NotTo(HaveOccurred())
+			Expect(cluster.Spec.Version).To(Equal(fdbv1beta2.Versions.Default.String()))
+			Expect(cluster.Status.Upgrade.TargetVersion).To(Equal(fdbv1beta2.Versions.Default.String()))
+			Expect(meta.IsStatusConditionTrue(cluster.Status.Upgrade.Conditions, fdbv1beta2.UpgradeConditionRolledBack)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(cluster.Status.Upgrade.Conditions, fdbv1beta2.UpgradeConditionProcessesRestarted)).To(BeTrue())
+			Expect(cluster.IsUpgradeRollback()).To(BeTrue())
+		})
+
+		It("should emit an event", func() {
+			events := &corev1.EventList{}
+			Expect(k8sClient.List(context.TODO(), events)).NotTo(HaveOccurred())
+
+			var matchingEvents []corev1.Event
+			for _, event := range events.Items {
+				if event.InvolvedObject.UID == cluster.ObjectMeta.UID && event.Reason == "UpgradeRollback" {
+					matchingEvents = append(matchingEvents, event)
+				}
+			}
+			Expect(matchingEvents).To(HaveLen(1))
+			Expect(matchingEvents[0].Type).To(Equal(corev1.EventTypeWarning))
+		})
+
+		When("the cluster is reconciled after the rollback", func() {
+			JustBeforeEach(func() {
+				adminClient.KilledAddresses = map[string]fdbv1beta2.None{}
+				adminClient.MockDatabaseUnavailable(false)
+				_, err := reconcileCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = reloadCluster(cluster)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should restart the processes with the previous version", func() {
+				Expect(adminClient.KilledAddresses).NotTo(BeEmpty())
+				Expect(cluster.Status.RunningVersion).To(Equal(fdbv1beta2.Versions.Default.String()))
+				Expect(cluster.Status.Upgrade.Phase).To(Equal(fdbv1beta2.UpgradePhaseDone))
+				Expect(meta.IsStatusConditionTrue(cluster.Status.Upgrade.Conditions, fdbv1beta2.UpgradeConditionRolledBack)).To(BeTrue())
+			})
+		})
+	})
+
+	When("the deadline has not passed", func() {
+		BeforeEach(func() {
+			restartTimestamp = time.Now()
+		})
+
+		It("should wait before rolling back the upgrade", func() {
+			Expect(req).NotTo(BeNil())
+			Expect(req.delayedRequeue).To(BeTrue())
+			Expect(req.delay).To(BeNumerically(">", 4*time.Minute))
+			Expect(cluster.Spec.Version).To(Equal(fdbv1beta2.Versions.NextMajorVersion.String()))
+		})
+	})
+
+	When("transactions were committed at the new version", func() {
+		BeforeEach(func() {
+			cluster.Status.Upgrade = &fdbv1beta2.UpgradeStatus{
+				TargetVersion:    fdbv1beta2.Versions.NextMajorVersion.String(),
+				Phase:            fdbv1beta2.UpgradePhaseRestarting,
+				PreviousVersion:  fdbv1beta2.Versions.Default.String(),
+				RestartTimestamp: &metav1.Time{Time: restartTimestamp},
+				Conditions: []metav1.Condition{
+					{
+						Type:               fdbv1beta2.UpgradeConditionCommitted,
+						Status:             metav1.ConditionTrue,
+						Reason:             "DatabaseAvailable",
+						LastTransitionTime: metav1.Now(),
+					},
+				},
+			}
+		})
+
+		It("should not roll back the upgrade", func() {
+			Expect(req).To(BeNil())
+			Expect(cluster.Spec.Version).To(Equal(fdbv1beta2.Versions.NextMajorVersion.String()))
+		})
+	})
+
+	When("the rollback is disabled", func() {
+		BeforeEach(func() {
+			cluster.Spec.AutomationOptions.UpgradeRollback.Enabled = pointer.Bool(false)
+			Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+		})
+
+		It("should not roll back the upgrade", func() {
+			Expect(req).To(BeNil())
+			Expect(cluster.Spec.Version).To(Equal(fdbv1beta2.Versions.NextMajorVersion.String()))
+		})
+	})
+})
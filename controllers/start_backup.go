@@ -22,6 +22,8 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 )
@@ -32,17 +34,31 @@ type startBackup struct {
 
 // reconcile runs the reconciler's work.
 func (s startBackup) reconcile(ctx context.Context, r *FoundationDBBackupReconciler, backup *fdbv1beta2.FoundationDBBackup) *requeue {
-	if !backup.ShouldRun() || (backup.Status.BackupDetails != nil && backup.Status.BackupDetails.Running) {
+	if !backup.ShouldRun() {
 		return nil
 	}
 
+	if backup.Status.BackupDetails != nil && backup.Status.BackupDetails.Running {
+		// Another backup in the same cluster could already use the same tag.
+		if !backup.OwnsBackupTag() {
+			return &requeue{message: fmt.Sprintf("Backup tag %s is already used by a backup to %s", backup.BackupTag(), backup.Status.BackupDetails.URL), delay: time.Minute}
+		}
+
+		return nil
+	}
+
+	err := backup.ValidateDestination()
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
 	adminClient, err := r.adminClientForBackup(ctx, backup)
 	if err != nil {
 		return &requeue{curError: err}
 	}
 	defer adminClient.Close()
 
-	err = adminClient.StartBackup(backup.BackupURL(), backup.SnapshotPeriodSeconds())
+	err = adminClient.StartBackup(backup.BackupTag(), backup.BackupURL(), backup.SnapshotPeriodSeconds())
 	if err != nil {
 		return &requeue{curError: err}
 	}
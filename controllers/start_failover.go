@@ -0,0 +1,216 @@
+/*
+ * start_failover.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// startFailover provides a reconciliation step for starting a failover. The primary data center will only be changed
+// once the remote data centers have caught up with the primary data center.
+type startFailover struct{}
+
+// reconcile runs the reconciler's work.
+func (s startFailover) reconcile(ctx context.Context, r *FoundationDBFailoverReconciler, failover *fdbv1beta2.FoundationDBFailover) *requeue {
+	if failover.Status.State != "" && failover.Status.State != fdbv1beta2.FailoverStateWaitingForRemote {
+		return nil
+	}
+
+	cluster, err := r.getCluster(ctx, failover)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	targetDataCenter := failover.Spec.TargetDataCenter
+	configuration := cluster.DesiredDatabaseConfiguration()
+	if !configuration.IsMainDataCenter(targetDataCenter) {
+		err = r.finishFailover(ctx, failover, fdbv1beta2.FailoverStateFailed, fmt.Sprintf("data center %s is not a main data center of the regions of cluster %s", targetDataCenter, cluster.Name))
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		return nil
+	}
+
+	logger := log.WithValues("namespace", failover.Namespace, "failover", failover.Name, "reconciler", "startFailover")
+	currentPrimary := configuration.GetPrimaryDataCenter()
+	if currentPrimary != targetDataCenter {
+		status, err := r.getStatus(cluster)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		err = checkRemoteDataCentersCaughtUp(status, failover.GetMaximumDataCenterLagSeconds())
+		if err != nil {
+			logger.Info("Waiting for remote data centers to catch up", "reason", err.Error())
+			if failover.Status.State != fdbv1beta2.FailoverStateWaitingForRemote || failover.Status.Message != err.Error() {
+				failover.Status.State = fdbv1beta2.FailoverStateWaitingForRemote
+				failover.Status.Message = err.Error()
+				updateErr := r.updateOrApply(ctx, failover)
+				if updateErr != nil {
+					return &requeue{curError: updateErr}
+				}
+			}
+
+			return &requeue{message: err.Error(), delay: failoverRequeueDelay}
+		}
+
+		logger.Info("Starting failover", "currentPrimary", currentPrimary, "targetDataCenter", targetDataCenter)
+		r.Recorder.Event(failover, corev1.EventTypeNormal, "StartingFailover", fmt.Sprintf("Changing primary data center from %s to %s", currentPrimary, targetDataCenter))
+		err = s.changePrimaryDataCenter(ctx, r, cluster, targetDataCenter)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+	}
+
+	failover.Status.State = fdbv1beta2.FailoverStateFailingOver
+	failover.Status.Message = ""
+	failover.Status.PreviousPrimaryDataCenter = currentPrimary
+	failover.Status.StartTimestamp = &metav1.Time{Time: time.Now()}
+	err = r.updateOrApply(ctx, failover)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
+
+// changePrimaryDataCenter records the target data center as primary data center in the status, the spec of the
+// resources is left unchanged. If the cluster is managed by a FoundationDBMultiRegionCluster the primary data center of
+// the multi-region cluster will be changed, otherwise the primary data center of the cluster and of all other clusters
+// in the namespace that manage the same database will be changed. Otherwise the operator would revert the change
+// through the database configuration of the other clusters.
+func (s startFailover) changePrimaryDataCenter(ctx context.Context, r *FoundationDBFailoverReconciler, cluster *fdbv1beta2.FoundationDBCluster, targetDataCenter string) error {
+	multiRegionClusterName, ok := cluster.Labels[fdbv1beta2.MultiRegionClusterLabel]
+	if ok {
+		multiRegionCluster := &fdbv1beta2.FoundationDBMultiRegionCluster{}
+		err := r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: multiRegionClusterName}, multiRegionCluster)
+		if err != nil {
+			return err
+		}
+
+		multiRegionCluster.Status.PrimaryDataCenterOverride = &fdbv1beta2.PrimaryDataCenterOverride{
+			DataCenter:            targetDataCenter,
+			SpecPrimaryDataCenter: multiRegionCluster.GetSpecPrimaryDataCenter(),
+		}
+
+		return r.Status().Update(ctx, multiRegionCluster)
+	}
+
+	// The cluster of the failover is updated last, so a failed update of another cluster will be retried, as the
+	// failover is only started if the target is not yet the primary data center of the cluster of the failover.
+	if cluster.Status.ConnectionString != "" {
+		clusters := &fdbv1beta2.FoundationDBClusterList{}
+		err := r.List(ctx, clusters, client.InNamespace(cluster.Namespace))
+		if err != nil {
+			return err
+		}
+
+		for _, otherCluster := range clusters.Items {
+			if otherCluster.Name == cluster.Name || otherCluster.Status.ConnectionString != cluster.Status.ConnectionString {
+				continue
+			}
+
+			if otherCluster.DesiredDatabaseConfiguration().GetPrimaryDataCenter() == targetDataCenter {
+				continue
+			}
+
+			log.Info("Changing primary data center of cluster with the same database", "namespace", otherCluster.Namespace, "cluster", otherCluster.Name, "targetDataCenter", targetDataCenter)
+			err = r.setPrimaryDataCenterOverride(ctx, &otherCluster, targetDataCenter)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return r.setPrimaryDataCenterOverride(ctx, cluster, targetDataCenter)
+}
+
+// setPrimaryDataCenterOverride records the target data center as primary data center in the status of the cluster.
+func (r *FoundationDBFailoverReconciler) setPrimaryDataCenterOverride(ctx context.Context, cluster *fdbv1beta2.FoundationDBCluster, targetDataCenter string) error {
+	cluster.Status.PrimaryDataCenterOverride = &fdbv1beta2.PrimaryDataCenterOverride{
+		DataCenter:            targetDataCenter,
+		SpecPrimaryDataCenter: cluster.Spec.DatabaseConfiguration.GetPrimaryDataCenter(),
+	}
+
+	return r.Status().Update(ctx, cluster)
+}
+
+// checkPrimaryDataCenterRunning returns an error if the database is not fully recovered in the provided data center,
+// based on the locality of the master process in the machine-readable status. If no master process is reported the
+// locality of the cluster controller process is used. The priorities of the database configuration are not
+// sufficient, as they change as soon as the configuration is applied and before the database has recovered in the new
+// primary data center.
+func checkPrimaryDataCenterRunning(status *fdbv1beta2.FoundationDBStatus, dataCenter string) error {
+	if status.Cluster.RecoveryState.Name != "fully_recovered" {
+		return fmt.Errorf("database is not fully recovered, current recovery state: %s", status.Cluster.RecoveryState.Name)
+	}
+
+	runningDataCenter := getRunningPrimaryDataCenter(status)
+	if runningDataCenter != dataCenter {
+		return fmt.Errorf("database is running in data center %s", runningDataCenter)
+	}
+
+	return nil
+}
+
+// getRunningPrimaryDataCenter returns the data center of the master process or, if no master process is reported, the
+// data center of the cluster controller process.
+func getRunningPrimaryDataCenter(status *fdbv1beta2.FoundationDBStatus) string {
+	clusterControllerDataCenter := ""
+	for _, process := range status.Cluster.Processes {
+		for _, role := range process.Roles {
+			switch fdbv1beta2.ProcessRole(role.Role) {
+			case fdbv1beta2.ProcessRoleMaster:
+				return process.Locality[fdbv1beta2.FDBLocalityDCIDKey]
+			case fdbv1beta2.ProcessRoleClusterController:
+				clusterControllerDataCenter = process.Locality[fdbv1beta2.FDBLocalityDCIDKey]
+			}
+		}
+	}
+
+	return clusterControllerDataCenter
+}
+
+// checkRemoteDataCentersCaughtUp returns an error if the remote data centers are not ready to take over the primary
+// role, based on the machine-readable status.
+func checkRemoteDataCentersCaughtUp(status *fdbv1beta2.FoundationDBStatus, maximumLagSeconds int) error {
+	if status.Cluster.DatabaseConfiguration.UsableRegions < 2 {
+		return fmt.Errorf("database has %d usable regions, a failover requires 2 usable regions", status.Cluster.DatabaseConfiguration.UsableRegions)
+	}
+
+	if !status.Cluster.Data.State.Healthy {
+		return fmt.Errorf("data distribution is not healthy, current state: %s", status.Cluster.Data.State.Name)
+	}
+
+	if status.Cluster.DatacenterLag.Seconds > float64(maximumLagSeconds) {
+		return fmt.Errorf("remote data centers are %.2f seconds behind, the maximum allowed lag is %d seconds", status.Cluster.DatacenterLag.Seconds, maximumLagSeconds)
+	}
+
+	return nil
+}
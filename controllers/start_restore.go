@@ -22,9 +22,10 @@ package controllers
 
 import (
 	"context"
-	"strings"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // startRestore provides a reconciliation step for starting a new restore.
@@ -33,6 +34,10 @@ type startRestore struct {
 
 // reconcile runs the reconciler's work.
 func (s startRestore) reconcile(ctx context.Context, r *FoundationDBRestoreReconciler, restore *fdbv1beta2.FoundationDBRestore) *requeue {
+	if restore.Status.Phase == fdbv1beta2.RestorePhaseCompleted {
+		return nil
+	}
+
 	adminClient, err := r.adminClientForRestore(ctx, restore)
 	if err != nil {
 		return &requeue{curError: err}
@@ -44,17 +49,58 @@ func (s startRestore) reconcile(ctx context.Context, r *FoundationDBRestoreRecon
 		return &requeue{curError: err}
 	}
 
-	if len(strings.TrimSpace(status)) == 0 {
-		err = adminClient.StartRestore(restore.BackupURL(), restore.Spec.KeyRanges)
+	if status.State != "" {
+		return nil
+	}
+
+	// Make sure the source of the restore is valid and that the target of the restore is part of the restorable range
+	// of the backup before starting the restore.
+	validationErr := restore.ValidateDestination()
+	if validationErr == nil && (restore.Spec.TargetVersion != nil || restore.Spec.TargetTimestamp != nil) {
+		description, err := adminClient.DescribeBackup(restore.BackupURL())
 		if err != nil {
 			return &requeue{curError: err}
 		}
 
-		restore.Status.Running = true
+		validationErr = restore.ValidateTarget(description)
+	}
+
+	if validationErr != nil {
+		log.Info("Restore is not valid", "namespace", restore.Namespace, "restore", restore.Name, "error", validationErr.Error())
+		r.Recorder.Event(restore, corev1.EventTypeWarning, "InvalidRestore", validationErr.Error())
+		restore.Status.Running = false
+		restore.Status.Phase = fdbv1beta2.RestorePhaseFailed
+		restore.Status.Errors = []string{validationErr.Error()}
+
 		err = r.updateOrApply(ctx, restore)
 		if err != nil {
 			return &requeue{curError: err}
 		}
+
+		// The restore will be validated again once the spec is changed.
+		return nil
+	}
+
+	var sourceCluster *fdbv1beta2.FoundationDBCluster
+	if restore.Spec.TargetTimestamp != nil {
+		sourceCluster = &fdbv1beta2.FoundationDBCluster{}
+		err = r.Get(ctx, types.NamespacedName{Namespace: restore.Namespace, Name: restore.Spec.SourceClusterName}, sourceCluster)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+	}
+
+	err = adminClient.StartRestore(restore.BackupURL(), restore.Spec.KeyRanges, restore.Spec.TargetVersion, restore.Spec.TargetTimestamp, sourceCluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	restore.Status.Running = true
+	restore.Status.Phase = fdbv1beta2.RestorePhaseRunning
+	restore.Status.Errors = nil
+	err = r.updateOrApply(ctx, restore)
+	if err != nil {
+		return &requeue{curError: err}
 	}
 
 	return nil
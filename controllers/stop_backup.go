@@ -36,13 +36,18 @@ func (s stopBackup) reconcile(ctx context.Context, r *FoundationDBBackupReconcil
 		return nil
 	}
 
+	// If the tag is not owned by this backup, the running backup belongs to another resource.
+	if !backup.OwnsBackupTag() {
+		return nil
+	}
+
 	adminClient, err := r.adminClientForBackup(ctx, backup)
 	if err != nil {
 		return &requeue{curError: err}
 	}
 	defer adminClient.Close()
 
-	err = adminClient.StopBackup(backup.BackupURL())
+	err = adminClient.StopBackup(backup.BackupTag())
 	if err != nil {
 		return &requeue{curError: err}
 	}
@@ -59,6 +59,10 @@ var k8sClient *mockclient.MockClient
 var clusterReconciler *FoundationDBClusterReconciler
 var backupReconciler *FoundationDBBackupReconciler
 var restoreReconciler *FoundationDBRestoreReconciler
+var multiRegionClusterReconciler *FoundationDBMultiRegionClusterReconciler
+var failoverReconciler *FoundationDBFailoverReconciler
+var autoscalerReconciler *FoundationDBAutoscalerReconciler
+var disasterRecoveryReconciler *FoundationDBDisasterRecoveryReconciler
 var requeueLimit = 20
 
 func TestAPIs(t *testing.T) {
@@ -94,6 +98,35 @@ var _ = BeforeSuite(func() {
 		Recorder:               k8sClient,
 		DatabaseClientProvider: mock.DatabaseClientProvider{},
 	}
+
+	multiRegionClusterReconciler = &FoundationDBMultiRegionClusterReconciler{
+		Client:                 k8sClient,
+		Log:                    ctrl.Log.WithName("controllers").WithName("FoundationDBMultiRegionCluster"),
+		Recorder:               k8sClient,
+		DatabaseClientProvider: mock.DatabaseClientProvider{},
+	}
+
+	failoverReconciler = &FoundationDBFailoverReconciler{
+		Client:                 k8sClient,
+		Log:                    ctrl.Log.WithName("controllers").WithName("FoundationDBFailover"),
+		Recorder:               k8sClient,
+		DatabaseClientProvider: mock.DatabaseClientProvider{},
+	}
+
+	disasterRecoveryReconciler = &FoundationDBDisasterRecoveryReconciler{
+		Client:                 k8sClient,
+		Log:                    ctrl.Log.WithName("controllers").WithName("FoundationDBDisasterRecovery"),
+		Recorder:               k8sClient,
+		InSimulation:           true,
+		DatabaseClientProvider: mock.DatabaseClientProvider{},
+	}
+
+	autoscalerReconciler = &FoundationDBAutoscalerReconciler{
+		Client:                 k8sClient,
+		Log:                    ctrl.Log.WithName("controllers").WithName("FoundationDBAutoscaler"),
+		Recorder:               k8sClient,
+		DatabaseClientProvider: mock.DatabaseClientProvider{},
+	}
 })
 
 var _ = AfterSuite(func() {
@@ -105,6 +138,7 @@ var _ = AfterEach(func() {
 	k8sClient.Clear()
 	mock.ClearMockAdminClients()
 	mock.ClearMockLockClients()
+	mockpodclient.ClearRestartedProcesses()
 })
 
 func createDefaultRestore(cluster *fdbv1beta2.FoundationDBCluster) *fdbv1beta2.FoundationDBRestore {
@@ -137,6 +171,22 @@ func reconcileRestore(restore *fdbv1beta2.FoundationDBRestore) (reconcile.Result
 	return reconcileObject(restoreReconciler, restore.ObjectMeta, requeueLimit)
 }
 
+func reconcileMultiRegionCluster(multiRegionCluster *fdbv1beta2.FoundationDBMultiRegionCluster) (reconcile.Result, error) {
+	return reconcileObject(multiRegionClusterReconciler, multiRegionCluster.ObjectMeta, requeueLimit)
+}
+
+func reconcileFailover(failover *fdbv1beta2.FoundationDBFailover) (reconcile.Result, error) {
+	return reconcileObject(failoverReconciler, failover.ObjectMeta, requeueLimit)
+}
+
+func reconcileDisasterRecovery(dr *fdbv1beta2.FoundationDBDisasterRecovery) (reconcile.Result, error) {
+	return reconcileObject(disasterRecoveryReconciler, dr.ObjectMeta, requeueLimit)
+}
+
+func reconcileAutoscaler(autoscaler *fdbv1beta2.FoundationDBAutoscaler) (reconcile.Result, error) {
+	return reconcileObject(autoscalerReconciler, autoscaler.ObjectMeta, requeueLimit)
+}
+
 func reconcileObject(reconciler reconcile.Reconciler, metadata metav1.ObjectMeta, requeueLimit int) (reconcile.Result, error) {
 	attempts := requeueLimit + 1
 	result := reconcile.Result{Requeue: true}
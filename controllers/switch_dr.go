@@ -0,0 +1,76 @@
+/*
+ * switch_dr.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// switchDR provides a reconciliation step for switching the source and the
+// destination cluster of a running DR.
+type switchDR struct{}
+
+// reconcile runs the reconciler's work.
+func (s switchDR) reconcile(ctx context.Context, r *FoundationDBDisasterRecoveryReconciler, dr *fdbv1beta2.FoundationDBDisasterRecovery) *requeue {
+	if !dr.NeedsSwitch() {
+		return nil
+	}
+
+	// fdbdr only allows to switch a DR once the destination cluster is a complete copy of the source cluster.
+	if dr.Status.DRDetails.Phase != fdbv1beta2.DRPhaseRunningDifferentially {
+		return &requeue{message: "DR must be a complete copy of the source cluster before it can be switched", delay: time.Minute}
+	}
+
+	sourceClusterName, destinationClusterName := dr.GetCurrentClusterNames()
+	source, destination, err := r.getClusters(ctx, dr, sourceClusterName, destinationClusterName)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	adminClient, err := r.adminClientForDR(dr, destination)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+	defer adminClient.Close()
+
+	err = adminClient.SwitchDR(source, dr.DRTag())
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	r.Recorder.Event(dr, corev1.EventTypeNormal, "SwitchedDR", fmt.Sprintf("Switched DR, %s is the new source cluster", destinationClusterName))
+
+	// Record the new direction directly, otherwise the status would be fetched from the previous destination
+	// cluster.
+	dr.Status.DRDetails.SourceClusterName = destinationClusterName
+	dr.Status.DRDetails.DestinationClusterName = sourceClusterName
+	err = r.updateOrApply(ctx, dr)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
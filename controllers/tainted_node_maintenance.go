@@ -0,0 +1,239 @@
+/*
+ * tainted_node_maintenance.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// taintedNodeMaintenance provides a reconciliation step for putting the zone of a tainted node into maintenance mode,
+// if the taint is configured with the MaintenanceMode action.
+type taintedNodeMaintenance struct{}
+
+// reconcile runs the reconciler's work.
+func (taintedNodeMaintenance) reconcile(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster) *requeue {
+	logger := log.WithValues("namespace", cluster.Namespace, "cluster", cluster.Name, "reconciler", "taintedNodeMaintenance")
+
+	// The operator already put a zone into maintenance mode, the maintenanceModeChecker will reset the maintenance
+	// mode once all processes in the zone are restarted.
+	if cluster.Status.MaintenanceModeInfo.ZoneID != "" {
+		return nil
+	}
+
+	var processGroups []*fdbv1beta2.ProcessGroupStatus
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.IsMarkedForRemoval() {
+			continue
+		}
+
+		if processGroup.GetConditionTime(fdbv1beta2.NodeTaintDetected) == nil || processGroup.GetConditionTime(fdbv1beta2.NodeTaintReplacing) != nil {
+			continue
+		}
+
+		processGroups = append(processGroups, processGroup)
+	}
+
+	if len(processGroups) == 0 {
+		return updateTaintedNodeMaintenanceZones(ctx, r, cluster, nil)
+	}
+
+	pods, err := r.PodLifecycleManager.GetPods(ctx, r, cluster, internal.GetPodListOptions(cluster, "", "")...)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+	podMap := internal.CreatePodMap(cluster, pods)
+
+	// The maintenance mode will end once the taint was present for the defined duration.
+	maintenanceTimeouts := make(map[fdbv1beta2.ProcessGroupID]time.Duration)
+	nodes := make(map[fdbv1beta2.ProcessGroupID]string)
+	for _, processGroup := range processGroups {
+		pod, ok := podMap[processGroup.ProcessGroupID]
+		if !ok || pod == nil {
+			continue
+		}
+
+		option, err := getNodeTaintReplacementOption(ctx, r, cluster, pod)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		if option == nil || option.GetTaintAction() != fdbv1beta2.TaintActionMaintenanceMode {
+			continue
+		}
+
+		detectedTime := time.Unix(*processGroup.GetConditionTime(fdbv1beta2.NodeTaintDetected), 0)
+		timeout := time.Until(detectedTime.Add(time.Duration(option.DurationInSeconds) * time.Second))
+		if timeout < time.Second {
+			continue
+		}
+
+		maintenanceTimeouts[processGroup.ProcessGroupID] = timeout
+		nodes[processGroup.ProcessGroupID] = pod.Spec.NodeName
+	}
+
+	if len(maintenanceTimeouts) == 0 {
+		return updateTaintedNodeMaintenanceZones(ctx, r, cluster, nil)
+	}
+
+	adminClient, err := r.getDatabaseClientProvider().GetAdminClient(cluster, r)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+	defer adminClient.Close()
+
+	status, err := adminClient.GetStatus()
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	zoneProcessGroups := make(map[string][]string)
+	for _, process := range status.Cluster.Processes {
+		processGroupID := fdbv1beta2.ProcessGroupID(process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey])
+		if _, ok := maintenanceTimeouts[processGroupID]; !ok {
+			continue
+		}
+
+		zone := process.Locality[fdbv1beta2.FDBLocalityZoneIDKey]
+		if zone == "" {
+			continue
+		}
+
+		zoneProcessGroups[zone] = append(zoneProcessGroups[zone], string(processGroupID))
+	}
+
+	req := updateTaintedNodeMaintenanceZones(ctx, r, cluster, zoneProcessGroups)
+	if req != nil {
+		return req
+	}
+
+	// Zones that were already put into maintenance mode for the current taint will not be put into maintenance mode
+	// again, otherwise the zone would be put into maintenance mode every time the maintenanceModeChecker resets it.
+	handledZones := make(map[string]fdbv1beta2.None, len(cluster.Status.TaintedNodeMaintenanceZones))
+	for _, zone := range cluster.Status.TaintedNodeMaintenanceZones {
+		handledZones[zone] = fdbv1beta2.None{}
+	}
+
+	// FoundationDB only supports a single zone in maintenance mode, so we pick the first zone and handle the other
+	// zones once the maintenance mode is reset.
+	zones := make([]string, 0, len(zoneProcessGroups))
+	for zone := range zoneProcessGroups {
+		if _, ok := handledZones[zone]; ok {
+			continue
+		}
+
+		zones = append(zones, zone)
+	}
+
+	if len(zones) == 0 {
+		return nil
+	}
+
+	sort.Strings(zones)
+	zone := zones[0]
+
+	maintenanceZone, err := adminClient.GetMaintenanceZone()
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	if maintenanceZone != "" && maintenanceZone != zone {
+		return &requeue{message: fmt.Sprintf("Waiting for maintenance of zone %s to finish before zone %s can be put into maintenance", maintenanceZone, zone), delay: podSchedulingDelayDuration, delayedRequeue: true}
+	}
+
+	var timeout time.Duration
+	processGroupIDs := zoneProcessGroups[zone]
+	sort.Strings(processGroupIDs)
+	for _, processGroupID := range processGroupIDs {
+		processGroupTimeout := maintenanceTimeouts[fdbv1beta2.ProcessGroupID(processGroupID)]
+		if timeout == 0 || processGroupTimeout < timeout {
+			timeout = processGroupTimeout
+		}
+	}
+
+	hasLock, err := r.takeLock(cluster, "maintenance mode for tainted node")
+	if !hasLock {
+		return &requeue{curError: err}
+	}
+
+	node := nodes[fdbv1beta2.ProcessGroupID(processGroupIDs[0])]
+	logger.Info("Setting maintenance mode for tainted node", "zone", zone, "node", node, "timeout", timeout.String())
+	cluster.Status.MaintenanceModeInfo = fdbv1beta2.MaintenanceModeInfo{
+		StartTimestamp: &metav1.Time{Time: time.Now()},
+		ZoneID:         zone,
+		ProcessGroups:  processGroupIDs,
+	}
+	cluster.Status.TaintedNodeMaintenanceZones = append(cluster.Status.TaintedNodeMaintenanceZones, zone)
+	err = r.updateOrApply(ctx, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	err = adminClient.SetMaintenanceZone(zone, int(timeout.Seconds()))
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "TaintedNodeMaintenance", fmt.Sprintf("Set maintenance mode for zone %s for %s because node %s is tainted", zone, timeout.Round(time.Second), node))
+
+	return nil
+}
+
+// updateTaintedNodeMaintenanceZones removes all zones from the TaintedNodeMaintenanceZones in the cluster status that
+// have no process groups on a tainted node anymore, so those zones can be put into maintenance mode again if a node
+// gets tainted in the future.
+func updateTaintedNodeMaintenanceZones(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, zoneProcessGroups map[string][]string) *requeue {
+	if len(cluster.Status.TaintedNodeMaintenanceZones) == 0 {
+		return nil
+	}
+
+	zones := make([]string, 0, len(cluster.Status.TaintedNodeMaintenanceZones))
+	for _, zone := range cluster.Status.TaintedNodeMaintenanceZones {
+		if _, ok := zoneProcessGroups[zone]; !ok {
+			continue
+		}
+
+		zones = append(zones, zone)
+	}
+
+	if len(zones) == len(cluster.Status.TaintedNodeMaintenanceZones) {
+		return nil
+	}
+
+	if len(zones) == 0 {
+		zones = nil
+	}
+
+	cluster.Status.TaintedNodeMaintenanceZones = zones
+	err := r.updateOrApply(ctx, cluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
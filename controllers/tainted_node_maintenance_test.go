@@ -0,0 +1,181 @@
+/*
+ * tainted_node_maintenance_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("tainted_node_maintenance", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var adminClient *mock.AdminClient
+	var req *requeue
+	var taintedPod *corev1.Pod
+
+	BeforeEach(func() {
+		clusterReconciler.EnableNodeWatch = true
+		cluster = internal.CreateDefaultCluster()
+		Expect(k8sClient.Create(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+		result, err := reconcileCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Requeue).To(BeFalse())
+
+		_, err = reloadCluster(cluster)
+		Expect(err).NotTo(HaveOccurred())
+
+		adminClient, err = mock.NewMockAdminClientUncast(cluster, k8sClient)
+		Expect(err).NotTo(HaveOccurred())
+
+		pods, err := clusterReconciler.PodLifecycleManager.GetPods(context.TODO(), clusterReconciler, cluster, internal.GetSinglePodListOptions(cluster, "storage-1")...)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pods).To(HaveLen(1))
+		taintedPod = pods[0]
+		taintedPod.Spec.NodeName = "node-1"
+		Expect(k8sClient.Update(context.TODO(), taintedPod)).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Create(context.TODO(), &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node-1",
+			},
+			Spec: corev1.NodeSpec{
+				Unschedulable: true,
+			},
+		})).NotTo(HaveOccurred())
+
+		cluster.Spec.AutomationOptions.Replacements.Taints = []fdbv1beta2.TaintReplacementOption{
+			{
+				Key:               corev1.TaintNodeUnschedulable,
+				DurationInSeconds: 3600,
+				Action:            fdbv1beta2.TaintActionMaintenanceMode,
+			},
+		}
+
+		for _, processGroup := range cluster.Status.ProcessGroups {
+			if processGroup.ProcessGroupID == "storage-1" {
+				processGroup.UpdateCondition(fdbv1beta2.NodeTaintDetected, true, nil, "")
+			}
+		}
+	})
+
+	AfterEach(func() {
+		clusterReconciler.EnableNodeWatch = false
+	})
+
+	JustBeforeEach(func() {
+		req = taintedNodeMaintenance{}.reconcile(context.TODO(), clusterReconciler, cluster)
+	})
+
+	When("the node is cordoned", func() {
+		It("should put the zone into maintenance mode", func() {
+			Expect(req).To(BeNil())
+			Expect(adminClient.MaintenanceZone).To(Equal(taintedPod.Name))
+			Expect(cluster.Status.MaintenanceModeInfo.ZoneID).To(Equal(taintedPod.Name))
+			Expect(cluster.Status.MaintenanceModeInfo.ProcessGroups).To(ConsistOf("storage-1"))
+			Expect(cluster.Status.MaintenanceModeInfo.StartTimestamp).NotTo(BeNil())
+			Expect(cluster.Status.TaintedNodeMaintenanceZones).To(ConsistOf(taintedPod.Name))
+		})
+
+		When("the zone was already put into maintenance mode for the taint", func() {
+			BeforeEach(func() {
+				cluster.Status.TaintedNodeMaintenanceZones = []string{taintedPod.Name}
+			})
+
+			It("should not put the zone into maintenance mode again", func() {
+				Expect(req).To(BeNil())
+				Expect(adminClient.MaintenanceZone).To(BeEmpty())
+				Expect(cluster.Status.MaintenanceModeInfo.ZoneID).To(BeEmpty())
+				Expect(cluster.Status.TaintedNodeMaintenanceZones).To(ConsistOf(taintedPod.Name))
+			})
+		})
+	})
+
+	When("the node watch is disabled", func() {
+		BeforeEach(func() {
+			clusterReconciler.EnableNodeWatch = false
+		})
+
+		It("should not put the zone into maintenance mode", func() {
+			Expect(req).To(BeNil())
+			Expect(adminClient.MaintenanceZone).To(BeEmpty())
+			Expect(cluster.Status.MaintenanceModeInfo.ZoneID).To(BeEmpty())
+		})
+	})
+
+	When("the taint should be handled by a replacement", func() {
+		BeforeEach(func() {
+			cluster.Spec.AutomationOptions.Replacements.Taints[0].Action = fdbv1beta2.TaintActionReplace
+		})
+
+		It("should not put the zone into maintenance mode", func() {
+			Expect(req).To(BeNil())
+			Expect(adminClient.MaintenanceZone).To(BeEmpty())
+			Expect(cluster.Status.MaintenanceModeInfo.ZoneID).To(BeEmpty())
+		})
+	})
+
+	When("the taint was present for longer than the defined duration", func() {
+		BeforeEach(func() {
+			for _, processGroup := range cluster.Status.ProcessGroups {
+				if processGroup.ProcessGroupID == "storage-1" {
+					processGroup.UpdateCondition(fdbv1beta2.NodeTaintReplacing, true, nil, "")
+				}
+			}
+		})
+
+		It("should not put the zone into maintenance mode", func() {
+			Expect(req).To(BeNil())
+			Expect(adminClient.MaintenanceZone).To(BeEmpty())
+		})
+
+		When("the zone was put into maintenance mode for the taint", func() {
+			BeforeEach(func() {
+				cluster.Status.TaintedNodeMaintenanceZones = []string{taintedPod.Name}
+			})
+
+			It("should remove the zone from the handled zones", func() {
+				Expect(req).To(BeNil())
+				Expect(cluster.Status.TaintedNodeMaintenanceZones).To(BeEmpty())
+			})
+		})
+	})
+
+	When("another zone is in maintenance mode", func() {
+		BeforeEach(func() {
+			Expect(adminClient.SetMaintenanceZone("other-zone", 600)).NotTo(HaveOccurred())
+		})
+
+		It("should wait for the other maintenance to finish", func() {
+			Expect(req).NotTo(BeNil())
+			Expect(req.delayedRequeue).To(BeTrue())
+			Expect(adminClient.MaintenanceZone).To(Equal("other-zone"))
+			Expect(cluster.Status.MaintenanceModeInfo.ZoneID).To(BeEmpty())
+		})
+	})
+})
@@ -22,8 +22,11 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // toggleBackupPaused provides a reconciliation step for pausing an unpausing
@@ -40,6 +43,19 @@ func (s toggleBackupPaused) reconcile(ctx context.Context, r *FoundationDBBackup
 	}
 
 	if backup.ShouldBePaused() && !backup.Status.BackupDetails.Paused {
+		// fdbbackup can only pause the backup agents for the whole cluster, so we only pause them if all other
+		// backups of the cluster should be paused too. Otherwise pausing this backup would pause the other tags.
+		runningBackup, err := r.getBackupForCluster(ctx, backup, func(otherBackup fdbv1beta2.FoundationDBBackup) bool {
+			return otherBackup.ShouldRun() && !otherBackup.ShouldBePaused()
+		})
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		if runningBackup != "" {
+			return &requeue{message: fmt.Sprintf("Cannot pause backup because backup %s of the same cluster should keep running and the backup agents can only be paused for all backups of a cluster", runningBackup), delay: time.Minute}
+		}
+
 		adminClient, err := r.adminClientForBackup(ctx, backup)
 		if err != nil {
 			return &requeue{curError: err}
@@ -52,6 +68,19 @@ func (s toggleBackupPaused) reconcile(ctx context.Context, r *FoundationDBBackup
 		}
 		return nil
 	} else if !backup.ShouldBePaused() && backup.Status.BackupDetails.Paused {
+		// The backup agents are paused for the whole cluster, so we only resume them if no other backup of the
+		// cluster should be paused.
+		pausedBackup, err := r.getBackupForCluster(ctx, backup, func(otherBackup fdbv1beta2.FoundationDBBackup) bool {
+			return otherBackup.ShouldBePaused()
+		})
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		if pausedBackup != "" {
+			return &requeue{message: fmt.Sprintf("Cannot resume backup because backup %s of the same cluster should be paused", pausedBackup), delay: time.Minute}
+		}
+
 		adminClient, err := r.adminClientForBackup(ctx, backup)
 		if err != nil {
 			return &requeue{curError: err}
@@ -67,3 +96,26 @@ func (s toggleBackupPaused) reconcile(ctx context.Context, r *FoundationDBBackup
 
 	return nil
 }
+
+// getBackupForCluster returns the name of another backup for the same cluster
+// that matches the provided filter. If no such backup exists, an empty string
+// will be returned.
+func (r *FoundationDBBackupReconciler) getBackupForCluster(ctx context.Context, backup *fdbv1beta2.FoundationDBBackup, filter func(otherBackup fdbv1beta2.FoundationDBBackup) bool) (string, error) {
+	backups := &fdbv1beta2.FoundationDBBackupList{}
+	err := r.List(ctx, backups, client.InNamespace(backup.Namespace))
+	if err != nil {
+		return "", err
+	}
+
+	for _, otherBackup := range backups.Items {
+		if otherBackup.Name == backup.Name || otherBackup.Spec.ClusterName != backup.Spec.ClusterName {
+			continue
+		}
+
+		if filter(otherBackup) {
+			return otherBackup.Name, nil
+		}
+	}
+
+	return "", nil
+}
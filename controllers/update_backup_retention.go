@@ -0,0 +1,261 @@
+/*
+ * update_backup_retention.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// backupRetentionTaskWait defines how long a reconciliation waits for the
+// commands that describe, expire or delete backup data. Commands that take
+// longer continue in the background and the backup will be requeued.
+const backupRetentionTaskWait = 5 * time.Second
+
+// backupRetentionTaskPollInterval defines how long the reconciliation is
+// delayed while the commands that describe, expire or delete backup data are
+// still running in the background.
+const backupRetentionTaskPollInterval = 30 * time.Second
+
+// updateBackupRetention provides a reconciliation step for reporting the
+// restorable points of a backup and for expiring or deleting backup data
+// based on the retention policy.
+type updateBackupRetention struct {
+}
+
+// reconcile runs the reconciler's work.
+func (u updateBackupRetention) reconcile(ctx context.Context, r *FoundationDBBackupReconciler, backup *fdbv1beta2.FoundationDBBackup) *requeue {
+	if backup.Spec.RetentionPolicy == nil {
+		return nil
+	}
+
+	err := backup.ValidateRetentionPolicy()
+	if err != nil {
+		log.Info("Retention policy is not valid", "namespace", backup.Namespace, "backup", backup.Name, "error", err.Error())
+		r.Recorder.Event(backup, corev1.EventTypeWarning, "InvalidRetentionPolicy", err.Error())
+		return nil
+	}
+
+	// A task that was started in an earlier reconciliation must be finished
+	// before the next task for this backup can be started.
+	if r.retentionTasks.get(client.ObjectKeyFromObject(backup)) != nil {
+		return u.waitForTask(ctx, r, backup)
+	}
+
+	retention := backup.Status.Retention
+	if !backup.ShouldRun() {
+		if !backup.ShouldDeleteDataWhenStopped() || (retention != nil && retention.DataDeleted) {
+			return nil
+		}
+
+		if backup.Status.BackupDetails != nil && backup.Status.BackupDetails.Running {
+			return &requeue{message: "Waiting for the backup to be stopped before deleting the backup data"}
+		}
+
+		return u.startTask(ctx, r, backup, deleteBackupData)
+	}
+
+	// The restorable points will only be checked once per interval, unless the
+	// data was deleted and the backup was started again.
+	if retention != nil && !retention.DataDeleted && retention.LastCheckTimestamp != nil {
+		if time.Since(retention.LastCheckTimestamp.Time) < time.Duration(backup.GetRetentionIntervalSeconds())*time.Second {
+			return nil
+		}
+	}
+
+	return u.startTask(ctx, r, backup, expireBackupData)
+}
+
+// startTask starts the provided task in the background and waits for it to
+// complete.
+func (u updateBackupRetention) startTask(ctx context.Context, r *FoundationDBBackupReconciler, backup *fdbv1beta2.FoundationDBBackup, task func(r *FoundationDBBackupReconciler, backup *fdbv1beta2.FoundationDBBackup, adminClient fdbadminclient.AdminClient) (*fdbv1beta2.BackupRetentionStatus, error)) *requeue {
+	adminClient, err := r.adminClientForBackup(ctx, backup)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	taskBackup := backup.DeepCopy()
+	r.retentionTasks.start(client.ObjectKeyFromObject(backup), func() (*fdbv1beta2.BackupRetentionStatus, error) {
+		defer adminClient.Close()
+		return task(r, taskBackup, adminClient)
+	})
+
+	return u.waitForTask(ctx, r, backup)
+}
+
+// waitForTask waits for the running task of the backup and stores the result
+// of the task in the backup status once the task is done.
+func (u updateBackupRetention) waitForTask(ctx context.Context, r *FoundationDBBackupReconciler, backup *fdbv1beta2.FoundationDBBackup) *requeue {
+	key := client.ObjectKeyFromObject(backup)
+	task := r.retentionTasks.get(key)
+	if !task.wait(backupRetentionTaskWait) {
+		return &requeue{message: "Waiting for the backup data to be described, expired or deleted", delay: backupRetentionTaskPollInterval}
+	}
+
+	r.retentionTasks.remove(key)
+	if task.err != nil {
+		return &requeue{curError: task.err}
+	}
+
+	backup.Status.Retention = task.retention
+	err := r.updateOrApply(ctx, backup)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
+
+// expireBackupData reports the restorable points of the backup and expires
+// the backup data that is older than the restorable points that should be
+// kept.
+func expireBackupData(r *FoundationDBBackupReconciler, backup *fdbv1beta2.FoundationDBBackup, adminClient fdbadminclient.AdminClient) (*fdbv1beta2.BackupRetentionStatus, error) {
+	url := backup.ContainerURL()
+	description, err := adminClient.DescribeBackup(url)
+	if err != nil {
+		return nil, err
+	}
+
+	retention := backup.Status.Retention
+	newRetention := &fdbv1beta2.BackupRetentionStatus{}
+	if retention != nil {
+		newRetention.ExpiredBeforeVersion = retention.ExpiredBeforeVersion
+	}
+
+	if backup.Spec.RetentionPolicy.RestorablePoints != nil {
+		expirationVersion := description.GetExpirationVersion(*backup.Spec.RetentionPolicy.RestorablePoints)
+		if expirationVersion > 0 {
+			log.Info("Expiring backup data", "namespace", backup.Namespace, "backup", backup.Name, "expireBeforeVersion", expirationVersion)
+			err = adminClient.ExpireBackup(url, expirationVersion)
+			if err != nil {
+				return nil, err
+			}
+
+			r.Recorder.Event(backup, corev1.EventTypeNormal, "ExpiredBackupData", fmt.Sprintf("Expired backup data before version %d", expirationVersion))
+			newRetention.ExpiredBeforeVersion = expirationVersion
+
+			description, err = adminClient.DescribeBackup(url)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	newRetention.RestorablePoints = description.GetRestorablePoints()
+	if description.MaxRestorableVersion != nil {
+		newRetention.MaxRestorableVersion = &fdbv1beta2.BackupRestorablePoint{
+			Version:   description.MaxRestorableVersion.Version,
+			Timestamp: description.MaxRestorableVersion.Timestamp,
+		}
+	}
+	newRetention.LastCheckTimestamp = &metav1.Time{Time: time.Now()}
+
+	return newRetention, nil
+}
+
+// deleteBackupData deletes all data of a stopped backup.
+func deleteBackupData(r *FoundationDBBackupReconciler, backup *fdbv1beta2.FoundationDBBackup, adminClient fdbadminclient.AdminClient) (*fdbv1beta2.BackupRetentionStatus, error) {
+	url := backup.ContainerURL()
+	log.Info("Deleting backup data", "namespace", backup.Namespace, "backup", backup.Name, "url", url)
+	err := adminClient.DeleteBackup(url)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Recorder.Event(backup, corev1.EventTypeNormal, "DeletedBackupData", fmt.Sprintf("Deleted all data of backup %s", url))
+
+	return &fdbv1beta2.BackupRetentionStatus{
+		DataDeleted:        true,
+		LastCheckTimestamp: &metav1.Time{Time: time.Now()},
+	}, nil
+}
+
+// backupRetentionTasks tracks the commands that describe, expire or delete
+// backup data. Those commands can take much longer than other commands, so
+// they run in the background instead of blocking the reconciliation. The zero
+// value is ready to use.
+type backupRetentionTasks struct {
+	lock  sync.Mutex
+	tasks map[types.NamespacedName]*backupRetentionTask
+}
+
+// backupRetentionTask represents a task that runs in the background.
+type backupRetentionTask struct {
+	// done is closed once the task has finished.
+	done chan struct{}
+	// retention defines the retention status that was reported by the task.
+	retention *fdbv1beta2.BackupRetentionStatus
+	// err defines the error that was returned by the task.
+	err error
+}
+
+// start runs the task for the backup in the background.
+func (tasks *backupRetentionTasks) start(key types.NamespacedName, run func() (*fdbv1beta2.BackupRetentionStatus, error)) {
+	task := &backupRetentionTask{done: make(chan struct{})}
+
+	tasks.lock.Lock()
+	if tasks.tasks == nil {
+		tasks.tasks = map[types.NamespacedName]*backupRetentionTask{}
+	}
+	tasks.tasks[key] = task
+	tasks.lock.Unlock()
+
+	go func() {
+		defer close(task.done)
+		task.retention, task.err = run()
+	}()
+}
+
+// get returns the task of the backup, or nil if no task was started.
+func (tasks *backupRetentionTasks) get(key types.NamespacedName) *backupRetentionTask {
+	tasks.lock.Lock()
+	defer tasks.lock.Unlock()
+
+	return tasks.tasks[key]
+}
+
+// remove removes the task of the backup.
+func (tasks *backupRetentionTasks) remove(key types.NamespacedName) {
+	tasks.lock.Lock()
+	defer tasks.lock.Unlock()
+
+	delete(tasks.tasks, key)
+}
+
+// wait waits up to the provided timeout for the task to finish and returns
+// whether the task is done.
+func (task *backupRetentionTask) wait(timeout time.Duration) bool {
+	select {
+	case <-task.done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
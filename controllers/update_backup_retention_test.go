@@ -0,0 +1,101 @@
+/*
+ * update_backup_retention_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("update_backup_retention", func() {
+	var backup *fdbv1beta2.FoundationDBBackup
+	var reconciler *FoundationDBBackupReconciler
+	var release chan struct{}
+	var result *requeue
+
+	BeforeEach(func() {
+		backup = internal.CreateDefaultBackup(internal.CreateDefaultCluster())
+		restorablePoints := 2
+		backup.Spec.RetentionPolicy = &fdbv1beta2.BackupRetentionPolicy{
+			RestorablePoints: &restorablePoints,
+		}
+		Expect(k8sClient.Create(context.TODO(), backup)).NotTo(HaveOccurred())
+
+		reconciler = &FoundationDBBackupReconciler{
+			Client:                 k8sClient,
+			Recorder:               k8sClient,
+			DatabaseClientProvider: mock.DatabaseClientProvider{},
+		}
+
+		release = make(chan struct{})
+		reconciler.retentionTasks.start(client.ObjectKeyFromObject(backup), func() (*fdbv1beta2.BackupRetentionStatus, error) {
+			<-release
+			return &fdbv1beta2.BackupRetentionStatus{ExpiredBeforeVersion: 300}, nil
+		})
+	})
+
+	AfterEach(func() {
+		select {
+		case <-release:
+		default:
+			close(release)
+		}
+	})
+
+	When("the task is still running", func() {
+		BeforeEach(func() {
+			result = updateBackupRetention{}.reconcile(context.TODO(), reconciler, backup)
+		})
+
+		It("should requeue without blocking until the task is done", func() {
+			Expect(result).NotTo(BeNil())
+			Expect(result.curError).NotTo(HaveOccurred())
+			Expect(result.message).To(Equal("Waiting for the backup data to be described, expired or deleted"))
+			Expect(result.delay).To(Equal(backupRetentionTaskPollInterval))
+			Expect(backup.Status.Retention).To(BeNil())
+			Expect(reconciler.retentionTasks.get(client.ObjectKeyFromObject(backup))).NotTo(BeNil())
+		})
+	})
+
+	When("the task is done", func() {
+		BeforeEach(func() {
+			close(release)
+			result = updateBackupRetention{}.reconcile(context.TODO(), reconciler, backup)
+		})
+
+		It("should store the result of the task in the status", func() {
+			Expect(result).To(BeNil())
+			Expect(reconciler.retentionTasks.get(client.ObjectKeyFromObject(backup))).To(BeNil())
+
+			storedBackup := &fdbv1beta2.FoundationDBBackup{}
+			Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(backup), storedBackup)).NotTo(HaveOccurred())
+			Expect(storedBackup.Status.Retention).NotTo(BeNil())
+			Expect(storedBackup.Status.Retention.ExpiredBeforeVersion).To(BeNumerically("==", 300))
+		})
+	})
+})
@@ -39,6 +39,7 @@ type updateBackupStatus struct{}
 func (s updateBackupStatus) reconcile(ctx context.Context, r *FoundationDBBackupReconciler, backup *fdbv1beta2.FoundationDBBackup) *requeue {
 	status := fdbv1beta2.FoundationDBBackupStatus{}
 	status.Generations.Reconciled = backup.Status.Generations.Reconciled
+	status.Retention = backup.Status.Retention
 
 	backupDeployments := &appsv1.DeploymentList{}
 	err := r.List(ctx, backupDeployments, client.InNamespace(backup.Namespace), client.MatchingLabels(map[string]string{fdbv1beta2.BackupDeploymentLabel: string(backup.ObjectMeta.UID)}))
@@ -82,12 +83,21 @@ func (s updateBackupStatus) reconcile(ctx context.Context, r *FoundationDBBackup
 	}
 	defer adminClient.Close()
 
-	liveStatus, err := adminClient.GetBackupStatus()
+	liveStatus, err := adminClient.GetBackupStatus(backup.BackupTag())
 	if err != nil {
 		return &requeue{curError: err}
 	}
 
+	// The backup owns the tag if it owned the tag before, if it started the running backup or if no backup is
+	// running under the tag. Only the owner of the tag will modify or stop the backup, so a change of the destination
+	// will not orphan the running backup.
+	var tag string
+	if backup.OwnsBackupTag() || !liveStatus.Status.Running || backup.MatchesURL(liveStatus.DestinationURL) {
+		tag = backup.BackupTag()
+	}
+
 	status.BackupDetails = &fdbv1beta2.FoundationDBBackupStatusBackupDetails{
+		Tag:                   tag,
 		URL:                   liveStatus.DestinationURL,
 		Running:               liveStatus.Status.Running,
 		Paused:                liveStatus.BackupAgentsPaused,
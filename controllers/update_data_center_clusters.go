@@ -0,0 +1,135 @@
+/*
+ * update_data_center_clusters.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/go-logr/logr"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// updateDataCenterClusters provides a reconciliation step for creating and updating the clusters of the data centers
+// of a multi-region cluster.
+type updateDataCenterClusters struct{}
+
+// reconcile runs the reconciler's work.
+func (u updateDataCenterClusters) reconcile(ctx context.Context, r *FoundationDBMultiRegionClusterReconciler, multiRegionCluster *fdbv1beta2.FoundationDBMultiRegionCluster) *requeue {
+	logger := log.WithValues("namespace", multiRegionCluster.Namespace, "multiRegionCluster", multiRegionCluster.Name, "reconciler", "updateDataCenterClusters")
+	seedDataCenter := multiRegionCluster.Status.SeedDataCenter
+
+	// The clusters in the other data centers can only be created once the seed cluster is reconciled and we know the
+	// connection string.
+	if multiRegionCluster.Status.ConnectionString == "" {
+		changed, err := u.ensureDataCenterCluster(ctx, logger, r, internal.GetDataCenterCluster(multiRegionCluster, seedDataCenter, multiRegionCluster.GetSeedDatabaseConfiguration(seedDataCenter), ""))
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		if changed {
+			return &requeue{message: "Seed cluster was updated"}
+		}
+
+		return &requeue{message: "Waiting for the cluster in the seed data center to be reconciled", delay: multiRegionClusterRequeueDelay}
+	}
+
+	databaseConfiguration := multiRegionCluster.GetDatabaseConfiguration(multiRegionCluster.Status.PrimaryDataCenter, multiRegionCluster.Status.UsableRegions)
+	anyChanged := false
+	for _, dataCenterID := range multiRegionCluster.GetDataCenterIDs() {
+		seedConnectionString := ""
+		if dataCenterID != seedDataCenter {
+			seedConnectionString = multiRegionCluster.Status.ConnectionString
+		}
+
+		changed, err := u.ensureDataCenterCluster(ctx, logger, r, internal.GetDataCenterCluster(multiRegionCluster, dataCenterID, databaseConfiguration, seedConnectionString))
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		anyChanged = anyChanged || changed
+	}
+
+	if anyChanged {
+		return &requeue{message: "Data center clusters were updated"}
+	}
+
+	return nil
+}
+
+// ensureDataCenterCluster creates the cluster if it doesn't exist or updates the spec of the existing cluster if it
+// differs from the desired spec. The seed connection string of an existing cluster will not be changed, as it's only
+// used when the cluster is created. This method returns true if the cluster was created or updated.
+func (u updateDataCenterClusters) ensureDataCenterCluster(ctx context.Context, logger logr.Logger, r *FoundationDBMultiRegionClusterReconciler, desired *fdbv1beta2.FoundationDBCluster) (bool, error) {
+	existing := &fdbv1beta2.FoundationDBCluster{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: desired.Namespace, Name: desired.Name}, existing)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return false, err
+		}
+
+		logger.Info("Creating data center cluster", "cluster", desired.Name, "dataCenter", desired.Spec.DataCenter)
+		return true, r.Create(ctx, desired)
+	}
+
+	desired.Spec.SeedConnectionString = existing.Spec.SeedConnectionString
+	if existing.Labels == nil {
+		existing.Labels = map[string]string{}
+	}
+	labelsChanged := mergeLabelsInMetadata(&existing.ObjectMeta, desired.ObjectMeta)
+
+	// The spec of the existing cluster could be modified by the defaulting webhook, so we compare the normalized specs.
+	specChanged, err := dataCenterClusterSpecChanged(existing, desired, r.DeprecationOptions)
+	if err != nil {
+		return false, err
+	}
+
+	if !labelsChanged && !specChanged {
+		return false, nil
+	}
+
+	logger.Info("Updating data center cluster", "cluster", desired.Name, "dataCenter", desired.Spec.DataCenter)
+	existing.Spec = desired.Spec
+
+	return true, r.Update(ctx, existing)
+}
+
+// dataCenterClusterSpecChanged returns true if the normalized spec of the existing cluster differs from the normalized
+// desired spec.
+func dataCenterClusterSpecChanged(existing *fdbv1beta2.FoundationDBCluster, desired *fdbv1beta2.FoundationDBCluster, deprecationOptions internal.DeprecationOptions) (bool, error) {
+	normalizedExisting := existing.DeepCopy()
+	err := internal.NormalizeClusterSpec(normalizedExisting, deprecationOptions)
+	if err != nil {
+		return false, err
+	}
+
+	normalizedDesired := desired.DeepCopy()
+	err = internal.NormalizeClusterSpec(normalizedDesired, deprecationOptions)
+	if err != nil {
+		return false, err
+	}
+
+	return !equality.Semantic.DeepEqual(normalizedExisting.Spec, normalizedDesired.Spec), nil
+}
@@ -75,6 +75,21 @@ func (u updateDatabaseConfiguration) reconcile(ctx context.Context, r *Foundatio
 		}
 		configurationString, _ := nextConfiguration.GetConfigurationString(cluster.Spec.Version)
 
+		// The storage engine must be supported by the running version, otherwise the storage servers cannot be
+		// migrated, e.g. during an upgrade that is required for the new storage engine.
+		if initialConfig || nextConfiguration.StorageEngine != currentConfiguration.StorageEngine {
+			runningVersion, err := fdbtypes.ParseFdbVersion(cluster.GetRunningVersion())
+			if err != nil {
+				return &requeue{curError: err}
+			}
+
+			if !runningVersion.IsStorageEngineSupported(nextConfiguration.StorageEngine) {
+				r.Recorder.Event(cluster, corev1.EventTypeWarning, "StorageEngineNotSupported",
+					fmt.Sprintf("Storage engine %s is not supported by the running version %s", nextConfiguration.StorageEngine, runningVersion))
+				return &requeue{message: fmt.Sprintf("storage engine %s is not supported by the running version %s", nextConfiguration.StorageEngine, runningVersion), delayedRequeue: true}
+			}
+		}
+
 		dataState := status.Cluster.Data.State
 		if !(initialConfig || dataState.Healthy) {
 			logger.Info("Waiting for data distribution to be healthy", "stateName", dataState.Name, "stateDescription", dataState.Description)
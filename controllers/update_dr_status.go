@@ -0,0 +1,124 @@
+/*
+ * update_dr_status.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// updateDRStatus provides a reconciliation step for updating the status of a
+// DR.
+type updateDRStatus struct{}
+
+// reconcile runs the reconciler's work.
+func (s updateDRStatus) reconcile(ctx context.Context, r *FoundationDBDisasterRecoveryReconciler, dr *fdbv1beta2.FoundationDBDisasterRecovery) *requeue {
+	status := fdbv1beta2.FoundationDBDisasterRecoveryStatus{}
+	status.Generations.Reconciled = dr.Status.Generations.Reconciled
+
+	agentDeployments := &appsv1.DeploymentList{}
+	err := r.List(ctx, agentDeployments, client.InNamespace(dr.Namespace), client.MatchingLabels(map[string]string{fdbv1beta2.DRDeploymentLabel: string(dr.ObjectMeta.UID)}))
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	desiredDeployment, err := internal.GetDRAgentDeployment(dr)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	if len(agentDeployments.Items) == 1 && desiredDeployment != nil {
+		agentDeployment := agentDeployments.Items[0]
+		status.AgentCount = int(agentDeployment.Status.ReadyReplicas)
+		if status.AgentCount > int(agentDeployment.Status.UpdatedReplicas) {
+			status.AgentCount = int(agentDeployment.Status.UpdatedReplicas)
+		}
+		generationsMatch := agentDeployment.Status.ObservedGeneration == agentDeployment.ObjectMeta.Generation
+
+		annotationChange := mergeAnnotations(&agentDeployment.ObjectMeta, desiredDeployment.ObjectMeta)
+
+		metadataMatch := !annotationChange &&
+			equality.Semantic.DeepEqual(agentDeployment.ObjectMeta.Labels, desiredDeployment.ObjectMeta.Labels)
+
+		status.DeploymentConfigured = generationsMatch && metadataMatch
+
+		if r.InSimulation {
+			status.AgentCount = int(*agentDeployment.Spec.Replicas)
+			status.DeploymentConfigured = metadataMatch
+		}
+	} else if len(agentDeployments.Items) == 0 && desiredDeployment == nil {
+		status.DeploymentConfigured = true
+	} else {
+		status.DeploymentConfigured = false
+	}
+
+	// The status of a running DR must be fetched from its current destination cluster, which differs from the
+	// spec until the DR is switched.
+	sourceClusterName, destinationClusterName := dr.GetCurrentClusterNames()
+	source, destination, err := r.getClusters(ctx, dr, sourceClusterName, destinationClusterName)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	adminClient, err := r.adminClientForDR(dr, destination)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+	defer adminClient.Close()
+
+	liveStatus, err := adminClient.GetDRStatus(source, dr.DRTag())
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	status.DRDetails = &fdbv1beta2.FoundationDBDisasterRecoveryStatusDRDetails{
+		Tag:                    dr.DRTag(),
+		SourceClusterName:      sourceClusterName,
+		DestinationClusterName: destinationClusterName,
+		Running:                liveStatus.IsRunning(),
+		Phase:                  liveStatus.Phase,
+		LagSeconds:             int64(liveStatus.SecondsBehind),
+	}
+
+	originalStatus := dr.Status.DeepCopy()
+
+	dr.Status = status
+
+	_, err = dr.CheckReconciliation()
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	if !equality.Semantic.DeepEqual(dr.Status, *originalStatus) {
+		err = r.updateOrApply(ctx, dr)
+		if err != nil {
+			log.Error(err, "Error updating DR status", "namespace", dr.Namespace, "disasterRecovery", dr.Name)
+			return &requeue{curError: err}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+/*
+ * update_multi_region_status.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// updateMultiRegionStatus provides a reconciliation step for updating the status of a multi-region cluster based on
+// the clusters of the data centers.
+type updateMultiRegionStatus struct{}
+
+// reconcile runs the reconciler's work.
+func (u updateMultiRegionStatus) reconcile(ctx context.Context, r *FoundationDBMultiRegionClusterReconciler, multiRegionCluster *fdbv1beta2.FoundationDBMultiRegionCluster) *requeue {
+	originalStatus := multiRegionCluster.Status.DeepCopy()
+	status := multiRegionCluster.Status.DeepCopy()
+
+	if status.SeedDataCenter == "" {
+		status.SeedDataCenter = multiRegionCluster.GetPrimaryDataCenter()
+	}
+
+	if status.PrimaryDataCenter == "" {
+		status.PrimaryDataCenter = status.SeedDataCenter
+	}
+
+	// The override of a failover is dropped once the primary data center in the spec was changed, so a later change
+	// back to the previous primary data center will not apply the override again.
+	if status.PrimaryDataCenterOverride != nil && status.PrimaryDataCenterOverride.SpecPrimaryDataCenter != multiRegionCluster.GetSpecPrimaryDataCenter() {
+		status.PrimaryDataCenterOverride = nil
+	}
+
+	if status.UsableRegions == 0 {
+		status.UsableRegions = 1
+	}
+
+	dataCenterIDs := multiRegionCluster.GetDataCenterIDs()
+	status.DataCenters = make([]fdbv1beta2.MultiRegionDataCenterStatus, 0, len(dataCenterIDs))
+	for _, dataCenterID := range dataCenterIDs {
+		dataCenterStatus := fdbv1beta2.MultiRegionDataCenterStatus{
+			ID:          dataCenterID,
+			ClusterName: multiRegionCluster.GetDataCenterClusterName(dataCenterID),
+		}
+
+		cluster := &fdbv1beta2.FoundationDBCluster{}
+		err := r.Get(ctx, client.ObjectKey{Namespace: multiRegionCluster.Namespace, Name: dataCenterStatus.ClusterName}, cluster)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return &requeue{curError: err}
+		}
+
+		if err == nil {
+			dataCenterStatus.Reconciled = cluster.Status.Generations.Reconciled == cluster.ObjectMeta.Generation
+
+			// The connection string will only be used for the other data centers once the seed cluster is
+			// reconciled, afterwards we keep track of the latest connection string.
+			if cluster.Status.ConnectionString != "" && dataCenterID == status.SeedDataCenter && (dataCenterStatus.Reconciled || status.ConnectionString != "") {
+				status.ConnectionString = cluster.Status.ConnectionString
+			}
+		}
+
+		status.DataCenters = append(status.DataCenters, dataCenterStatus)
+	}
+
+	multiRegionCluster.Status = *status
+	if equality.Semantic.DeepEqual(multiRegionCluster.Status, *originalStatus) {
+		return nil
+	}
+
+	err := r.updateOrApply(ctx, multiRegionCluster)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
@@ -23,6 +23,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
@@ -71,6 +72,15 @@ func (updatePods) reconcile(ctx context.Context, r *FoundationDBClusterReconcile
 	}
 
 	if len(updates) == 0 {
+		// All Pods are updated, so there is no rollout in progress.
+		if cluster.Status.Rollout != nil {
+			cluster.Status.Rollout = nil
+			err = r.updateOrApply(ctx, cluster)
+			if err != nil {
+				return &requeue{curError: err}
+			}
+		}
+
 		return nil
 	}
 
@@ -80,9 +90,171 @@ func (updatePods) reconcile(ctx context.Context, r *FoundationDBClusterReconcile
 	}
 	defer adminClient.Close()
 
+	updates, req := getRolloutUpdates(ctx, logger, r, cluster, adminClient, updates)
+	if req != nil {
+		return req
+	}
+
 	return deletePodsForUpdates(ctx, r, cluster, adminClient, updates, logger)
 }
 
+// getRolloutUpdates returns the Pod updates of the current stage if a rollout policy is defined. A stage will only be
+// started once the soak time of the previous stage has passed and the cluster is healthy, even if the previous stage
+// was started for an older generation of the cluster spec. If the cluster is not healthy after a stage was updated, the
+// rollout will be paused. A paused rollout is only resumed after the spec of the cluster was changed and the cluster
+// is healthy again.
+func getRolloutUpdates(ctx context.Context, logger logr.Logger, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, adminClient fdbadminclient.AdminClient, updates map[string][]*corev1.Pod) (map[string][]*corev1.Pod, *requeue) {
+	stages := cluster.GetRolloutStages()
+	if len(stages) == 0 {
+		return updates, nil
+	}
+
+	stageUpdates := getUpdatesByRolloutStage(cluster, updates)
+	var currentStage string
+	for _, stage := range stages {
+		if len(stageUpdates[stage]) > 0 {
+			currentStage = stage
+			break
+		}
+	}
+
+	rollout := cluster.Status.Rollout
+	if rollout != nil {
+		if rollout.Generation == cluster.Generation {
+			if rollout.Paused {
+				return nil, &requeue{message: fmt.Sprintf("Rollout is paused: %s", rollout.PausedReason), delay: time.Minute, delayedRequeue: true}
+			}
+
+			if rollout.Stage == currentStage {
+				return stageUpdates[currentStage], nil
+			}
+		}
+
+		// The Pods of the previous stage must soak before the next stage is started. This is also true if the
+		// previous stage was started for an older generation, as the Pods of this stage could already be updated.
+		if rollout.StageCompletedTimestamp == nil {
+			rollout.StageCompletedTimestamp = &metav1.Time{Time: time.Now()}
+			err := r.updateOrApply(ctx, cluster)
+			if err != nil {
+				return nil, &requeue{curError: err}
+			}
+		}
+
+		soakDuration := cluster.GetRolloutSoakDuration()
+		soakedDuration := time.Since(rollout.StageCompletedTimestamp.Time)
+		if soakedDuration < soakDuration {
+			return nil, &requeue{message: fmt.Sprintf("Waiting for the soak time of rollout stage %s", rollout.Stage), delay: soakDuration - soakedDuration, delayedRequeue: true}
+		}
+	}
+
+	// The health of the cluster is checked before every stage, so a change of the cluster spec will not resume a
+	// paused rollout while the cluster is unhealthy.
+	status, err := adminClient.GetStatus()
+	if err != nil {
+		return nil, &requeue{curError: err, delayedRequeue: true}
+	}
+
+	var reason string
+	if !internal.HasDesiredFaultToleranceFromStatus(logger, status, cluster) {
+		reason = "cluster doesn't have the desired fault tolerance"
+	} else if !status.Cluster.Data.State.Healthy {
+		reason = fmt.Sprintf("data state is not healthy: %s", status.Cluster.Data.State.Name)
+	}
+
+	if reason != "" {
+		if rollout == nil {
+			return nil, &requeue{message: fmt.Sprintf("Rollout can't be started: %s", reason), delay: time.Minute, delayedRequeue: true}
+		}
+
+		pausedReason := fmt.Sprintf("%s after rollout stage %s was updated", reason, rollout.Stage)
+		if !rollout.Paused || rollout.PausedReason != pausedReason {
+			rollout.Paused = true
+			rollout.PausedReason = pausedReason
+			logger.Info("Pausing rollout", "stage", rollout.Stage, "reason", rollout.PausedReason)
+			r.Recorder.Event(cluster, corev1.EventTypeWarning, "RolloutPaused", fmt.Sprintf("Pausing rollout: %s", rollout.PausedReason))
+			err = r.updateOrApply(ctx, cluster)
+			if err != nil {
+				return nil, &requeue{curError: err}
+			}
+		}
+
+		return nil, &requeue{message: fmt.Sprintf("Rollout is paused: %s", rollout.PausedReason), delay: time.Minute, delayedRequeue: true}
+	}
+
+	logger.Info("Starting rollout stage", "stage", currentStage)
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "RolloutStageStarted", fmt.Sprintf("Updating Pods of rollout stage %s", currentStage))
+	cluster.Status.Rollout = &fdbv1beta2.RolloutStatus{
+		Generation: cluster.Generation,
+		Stage:      currentStage,
+	}
+	err = r.updateOrApply(ctx, cluster)
+	if err != nil {
+		return nil, &requeue{curError: err}
+	}
+
+	return stageUpdates[currentStage], nil
+}
+
+// getUpdatesByRolloutStage groups the Pod updates by the stage of the rollout policy they belong to. The canary stage
+// contains the Pods of the first process class until the canary count is reached.
+func getUpdatesByRolloutStage(cluster *fdbv1beta2.FoundationDBCluster, updates map[string][]*corev1.Pod) map[string]map[string][]*corev1.Pod {
+	processClasses := cluster.Spec.AutomationOptions.RolloutPolicy.ProcessClasses
+	stageUpdates := make(map[string]map[string][]*corev1.Pod)
+	addUpdate := func(stage string, zone string, pod *corev1.Pod) {
+		if stageUpdates[stage] == nil {
+			stageUpdates[stage] = make(map[string][]*corev1.Pod)
+		}
+		stageUpdates[stage][zone] = append(stageUpdates[stage][zone], pod)
+	}
+
+	canaryClass := processClasses[0]
+	zones := make(map[string]string)
+	var canaryCandidates []*corev1.Pod
+	for zone, pods := range updates {
+		for _, pod := range pods {
+			processClass := internal.GetProcessClassFromMeta(cluster, pod.ObjectMeta)
+			if processClass == canaryClass {
+				zones[pod.Name] = zone
+				canaryCandidates = append(canaryCandidates, pod)
+				continue
+			}
+
+			stage := fdbv1beta2.RolloutStageRemaining
+			for _, rolloutClass := range processClasses {
+				if processClass == rolloutClass {
+					stage = string(rolloutClass)
+					break
+				}
+			}
+
+			addUpdate(stage, zone, pod)
+		}
+	}
+
+	// The Pods that are already updated count towards the canary count.
+	var processGroupCount int
+	for _, processGroup := range cluster.Status.ProcessGroups {
+		if processGroup.ProcessClass == canaryClass && !processGroup.IsMarkedForRemoval() {
+			processGroupCount++
+		}
+	}
+	canaries := cluster.GetRolloutCanaryCount() - (processGroupCount - len(canaryCandidates))
+
+	sort.Slice(canaryCandidates, func(i, j int) bool {
+		return canaryCandidates[i].Name < canaryCandidates[j].Name
+	})
+	for idx, pod := range canaryCandidates {
+		if idx < canaries {
+			addUpdate(fdbv1beta2.RolloutStageCanary, zones[pod.Name], pod)
+			continue
+		}
+
+		addUpdate(string(canaryClass), zones[pod.Name], pod)
+	}
+
+	return stageUpdates
+}
+
 // getPodsToUpdate returns a map of Zone to Pods mapping. The map has the fault domain as key and all Pods in that fault domain will be present as a slice of *corev1.Pod.
 func getPodsToUpdate(logger logr.Logger, reconciler *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, podMap map[fdbv1beta2.ProcessGroupID]*corev1.Pod) (map[string][]*corev1.Pod, error) {
 	updates := make(map[string][]*corev1.Pod)
@@ -100,7 +272,9 @@ func getPodsToUpdate(logger logr.Logger, reconciler *FoundationDBClusterReconcil
 			continue
 		}
 
-		if cluster.NeedsReplacement(processGroup) {
+		// Process groups that require a replacement could still be updated in place, if only the images have changed.
+		needsReplacement := cluster.NeedsReplacement(processGroup)
+		if needsReplacement && !cluster.GetUpdateImagesInPlace() {
 			logger.V(1).Info("Skip process group for deletion, requires a replacement",
 				"processGroupID", processGroup.ProcessGroupID)
 			continue
@@ -146,6 +320,15 @@ func getPodsToUpdate(logger logr.Logger, reconciler *FoundationDBClusterReconcil
 			continue
 		}
 
+		if needsReplacement {
+			imageUpdates, err := internal.GetImageUpdates(cluster, pod)
+			if err != nil || imageUpdates == nil {
+				logger.V(1).Info("Skip process group for deletion, requires a replacement",
+					"processGroupID", processGroup.ProcessGroupID)
+				continue
+			}
+		}
+
 		logger.Info("Update Pod",
 			"processGroupID", processGroup.ProcessGroupID,
 			"reason", fmt.Sprintf("specHash has changed from %s to %s", specHash, pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey]))
@@ -275,6 +458,22 @@ func deletePodsForUpdates(ctx context.Context, r *FoundationDBClusterReconciler,
 		}
 	}
 
+	if cluster.GetUpdateImagesInPlace() {
+		var updated int
+		deletions, updated, err = updateImagesInPlace(ctx, logger, r, cluster, deletions)
+		if err != nil {
+			return &requeue{curError: err}
+		}
+
+		if updated > 0 {
+			r.Recorder.Event(cluster, corev1.EventTypeNormal, "UpdatingImagesInPlace", fmt.Sprintf("Updated images of %d pods in zone %s in place", updated, zone))
+		}
+
+		if len(deletions) == 0 {
+			return &requeue{message: "Pods are updated in place", delayedRequeue: true}
+		}
+	}
+
 	logger.Info("Deleting pods", "zone", zone, "count", len(deletions), "deletionMode", string(cluster.Spec.AutomationOptions.DeletionMode))
 	r.Recorder.Event(cluster, corev1.EventTypeNormal, "UpdatingPods", fmt.Sprintf("Recreating pods in zone %s", zone))
 
@@ -285,3 +484,65 @@ func deletePodsForUpdates(ctx context.Context, r *FoundationDBClusterReconciler,
 
 	return &requeue{message: "Pods need to be recreated", delayedRequeue: true}
 }
+
+// updateImagesInPlace updates the images of the Pods in place, if the images are the only changes to the Pod spec. The
+// kubelet will restart the containers with the new images, so the Pods keep their node, IP and volumes. The Pods
+// that must be recreated are returned together with the number of Pods that were updated in place.
+func updateImagesInPlace(ctx context.Context, logger logr.Logger, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, pods []*corev1.Pod) ([]*corev1.Pod, int, error) {
+	var deletions []*corev1.Pod
+	var updated int
+
+	for _, pod := range pods {
+		imageUpdates, err := internal.GetImageUpdates(cluster, pod)
+		if err != nil {
+			return nil, updated, err
+		}
+
+		if imageUpdates == nil {
+			deletions = append(deletions, pod)
+			continue
+		}
+
+		processGroupID := podmanager.GetProcessGroupID(cluster, pod)
+		_, idNum, err := podmanager.ParseProcessGroupID(processGroupID)
+		if err != nil {
+			return nil, updated, err
+		}
+
+		specHash, err := internal.GetPodSpecHash(cluster, internal.GetProcessClassFromMeta(cluster, pod.ObjectMeta), idNum, nil)
+		if err != nil {
+			return nil, updated, err
+		}
+
+		// The images of the init containers can't be updated through the PodLifecycleManager, they will be changed
+		// together with the images of the containers.
+		for idx, container := range pod.Spec.InitContainers {
+			if image, ok := imageUpdates[container.Name]; ok {
+				pod.Spec.InitContainers[idx].Image = image
+			}
+		}
+
+		for idx, container := range pod.Spec.Containers {
+			image, ok := imageUpdates[container.Name]
+			if !ok {
+				continue
+			}
+
+			logger.Info("Updating image in place", "processGroupID", processGroupID, "container", container.Name, "oldImage", container.Image, "newImage", image)
+			err = r.PodLifecycleManager.UpdateImageVersion(ctx, r, cluster, pod, idx, image)
+			if err != nil {
+				return nil, updated, err
+			}
+		}
+
+		pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey] = specHash
+		err = r.PodLifecycleManager.UpdateMetadata(ctx, r, cluster, pod)
+		if err != nil {
+			return nil, updated, err
+		}
+
+		updated++
+	}
+
+	return deletions, updated, nil
+}
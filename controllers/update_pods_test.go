@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient/mock"
 	ctrlClient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"k8s.io/utils/pointer"
@@ -247,4 +248,278 @@ var _ = Describe("update_pods", func() {
 			})
 		})
 	})
+
+	When("a rollout policy is defined", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var adminClient *mock.AdminClient
+		var req *requeue
+		var logCount int
+
+		getPendingUpdates := func() map[string]map[string][]*corev1.Pod {
+			pods, err := clusterReconciler.PodLifecycleManager.GetPods(context.TODO(), k8sClient, cluster, internal.GetPodListOptions(cluster, "", "")...)
+			Expect(err).NotTo(HaveOccurred())
+
+			updates, err := getPodsToUpdate(log, clusterReconciler, cluster, internal.CreatePodMap(cluster, pods))
+			Expect(err).NotTo(HaveOccurred())
+
+			return getUpdatesByRolloutStage(cluster, updates)
+		}
+
+		BeforeEach(func() {
+			cluster = internal.CreateDefaultCluster()
+			Expect(k8sClient.Create(context.TODO(), cluster)).NotTo(HaveOccurred())
+			result, err := reconcileCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Requeue).To(BeFalse())
+			Expect(k8sClient.Get(context.TODO(), ctrlClient.ObjectKeyFromObject(cluster), cluster)).NotTo(HaveOccurred())
+
+			adminClient, err = mock.NewMockAdminClientUncast(cluster, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+
+			logCount = 0
+			for _, processGroup := range cluster.Status.ProcessGroups {
+				if processGroup.ProcessClass == fdbv1beta2.ProcessClassLog {
+					logCount++
+				}
+			}
+
+			cluster.Spec.AutomationOptions.RolloutPolicy = fdbv1beta2.RolloutPolicy{
+				ProcessClasses: []fdbv1beta2.ProcessClass{fdbv1beta2.ProcessClassLog, fdbv1beta2.ProcessClassStorage},
+				CanaryCount:    pointer.Int(1),
+				SoakSeconds:    pointer.Int(0),
+			}
+			// Transaction system Pods are only deleted with the delete strategy, otherwise they get replaced.
+			cluster.Spec.AutomationOptions.PodUpdateStrategy = fdbv1beta2.PodUpdateStrategyDelete
+			generalSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+			generalSettings.PodTemplate.Spec.Tolerations = []corev1.Toleration{{Key: "test", Operator: corev1.TolerationOpExists}}
+			cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = generalSettings
+			Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+			req = updatePods{}.reconcile(context.TODO(), clusterReconciler, cluster)
+		})
+
+		It("should only update the canary Pod", func() {
+			Expect(req).NotTo(BeNil())
+			Expect(req.message).To(Equal("Pods need to be recreated"))
+			Expect(cluster.Status.Rollout).To(Equal(&fdbv1beta2.RolloutStatus{
+				Generation: cluster.Generation,
+				Stage:      fdbv1beta2.RolloutStageCanary,
+			}))
+
+			stageUpdates := getPendingUpdates()
+			Expect(stageUpdates).NotTo(HaveKey(fdbv1beta2.RolloutStageCanary))
+			Expect(stageUpdates[string(fdbv1beta2.ProcessClassLog)]["simulation"]).To(HaveLen(logCount - 1))
+			Expect(stageUpdates[string(fdbv1beta2.ProcessClassStorage)]["simulation"]).To(HaveLen(4))
+			Expect(stageUpdates).To(HaveKey(fdbv1beta2.RolloutStageRemaining))
+		})
+
+		When("the canary stage is updated", func() {
+			JustBeforeEach(func() {
+				req = updatePods{}.reconcile(context.TODO(), clusterReconciler, cluster)
+			})
+
+			When("the cluster is healthy", func() {
+				It("should update the next stage", func() {
+					Expect(req).NotTo(BeNil())
+					Expect(req.message).To(Equal("Pods need to be recreated"))
+					Expect(cluster.Status.Rollout.Stage).To(Equal(string(fdbv1beta2.ProcessClassLog)))
+					Expect(cluster.Status.Rollout.Paused).To(BeFalse())
+
+					stageUpdates := getPendingUpdates()
+					Expect(stageUpdates).NotTo(HaveKey(string(fdbv1beta2.ProcessClassLog)))
+					Expect(stageUpdates[string(fdbv1beta2.ProcessClassStorage)]["simulation"]).To(HaveLen(4))
+				})
+			})
+
+			When("the soak time has not passed", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.RolloutPolicy.SoakSeconds = nil
+				})
+
+				It("should wait for the soak time", func() {
+					Expect(req).NotTo(BeNil())
+					Expect(req.delayedRequeue).To(BeTrue())
+					Expect(req.delay).To(BeNumerically(">", 9*time.Minute))
+					Expect(cluster.Status.Rollout.Stage).To(Equal(fdbv1beta2.RolloutStageCanary))
+					Expect(cluster.Status.Rollout.StageCompletedTimestamp).NotTo(BeNil())
+				})
+			})
+
+			When("the cluster is not healthy", func() {
+				BeforeEach(func() {
+					adminClient.MockDatabaseUnavailable(true)
+				})
+
+				AfterEach(func() {
+					adminClient.MockDatabaseUnavailable(false)
+				})
+
+				It("should pause the rollout", func() {
+					Expect(req).NotTo(BeNil())
+					Expect(req.message).To(HavePrefix("Rollout is paused"))
+					Expect(cluster.Status.Rollout.Stage).To(Equal(fdbv1beta2.RolloutStageCanary))
+					Expect(cluster.Status.Rollout.Paused).To(BeTrue())
+					Expect(cluster.Status.Rollout.PausedReason).To(Equal("cluster doesn't have the desired fault tolerance after rollout stage canary was updated"))
+
+					stageUpdates := getPendingUpdates()
+					Expect(stageUpdates[string(fdbv1beta2.ProcessClassLog)]["simulation"]).To(HaveLen(logCount - 1))
+				})
+
+				When("the cluster is healthy again", func() {
+					It("should stay paused", func() {
+						adminClient.MockDatabaseUnavailable(false)
+						req = updatePods{}.reconcile(context.TODO(), clusterReconciler, cluster)
+						Expect(req).NotTo(BeNil())
+						Expect(req.message).To(HavePrefix("Rollout is paused"))
+						Expect(cluster.Status.Rollout.Paused).To(BeTrue())
+					})
+				})
+
+				When("the cluster spec is changed", func() {
+					BeforeEach(func() {
+						cluster.Spec.AutomationOptions.RolloutPolicy.CanaryCount = pointer.Int(2)
+						Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+					})
+
+					It("should stay paused while the cluster is not healthy", func() {
+						req = updatePods{}.reconcile(context.TODO(), clusterReconciler, cluster)
+						Expect(req).NotTo(BeNil())
+						Expect(req.message).To(HavePrefix("Rollout is paused"))
+						Expect(cluster.Status.Rollout.Paused).To(BeTrue())
+						Expect(cluster.Status.Rollout.Stage).To(Equal(fdbv1beta2.RolloutStageCanary))
+					})
+
+					When("the cluster is healthy again", func() {
+						It("should resume the rollout", func() {
+							adminClient.MockDatabaseUnavailable(false)
+							req = updatePods{}.reconcile(context.TODO(), clusterReconciler, cluster)
+							Expect(req).NotTo(BeNil())
+							Expect(req.message).To(Equal("Pods need to be recreated"))
+							Expect(cluster.Status.Rollout.Paused).To(BeFalse())
+							Expect(cluster.Status.Rollout.Generation).To(Equal(cluster.Generation))
+						})
+					})
+				})
+			})
+		})
+	})
+
+	When("a rollout policy is defined and the cluster is not healthy", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var adminClient *mock.AdminClient
+		var req *requeue
+
+		BeforeEach(func() {
+			cluster = internal.CreateDefaultCluster()
+			Expect(k8sClient.Create(context.TODO(), cluster)).NotTo(HaveOccurred())
+			result, err := reconcileCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Requeue).To(BeFalse())
+			Expect(k8sClient.Get(context.TODO(), ctrlClient.ObjectKeyFromObject(cluster), cluster)).NotTo(HaveOccurred())
+
+			adminClient, err = mock.NewMockAdminClientUncast(cluster, k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+			adminClient.MockDatabaseUnavailable(true)
+
+			cluster.Spec.AutomationOptions.RolloutPolicy = fdbv1beta2.RolloutPolicy{
+				ProcessClasses: []fdbv1beta2.ProcessClass{fdbv1beta2.ProcessClassStorage},
+				SoakSeconds:    pointer.Int(0),
+			}
+			generalSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+			generalSettings.PodTemplate.Spec.Tolerations = []corev1.Toleration{{Key: "test", Operator: corev1.TolerationOpExists}}
+			cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = generalSettings
+			Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+			req = updatePods{}.reconcile(context.TODO(), clusterReconciler, cluster)
+		})
+
+		AfterEach(func() {
+			adminClient.MockDatabaseUnavailable(false)
+		})
+
+		It("should not start the rollout", func() {
+			Expect(req).NotTo(BeNil())
+			Expect(req.message).To(HavePrefix("Rollout can't be started"))
+			Expect(cluster.Status.Rollout).To(BeNil())
+		})
+	})
+
+	When("the images should be updated in place", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var originalPods map[fdbv1beta2.ProcessGroupID]*corev1.Pod
+		var req *requeue
+
+		BeforeEach(func() {
+			cluster = internal.CreateDefaultCluster()
+			Expect(k8sClient.Create(context.TODO(), cluster)).NotTo(HaveOccurred())
+			result, err := reconcileCluster(cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Requeue).To(BeFalse())
+			Expect(k8sClient.Get(context.TODO(), ctrlClient.ObjectKeyFromObject(cluster), cluster)).NotTo(HaveOccurred())
+
+			pods, err := clusterReconciler.PodLifecycleManager.GetPods(context.TODO(), k8sClient, cluster, internal.GetPodListOptions(cluster, "", "")...)
+			Expect(err).NotTo(HaveOccurred())
+			originalPods = internal.CreatePodMap(cluster, pods)
+
+			cluster.Spec.AutomationOptions.UpdateImagesInPlace = pointer.Bool(true)
+		})
+
+		JustBeforeEach(func() {
+			req = updatePods{}.reconcile(context.TODO(), clusterReconciler, cluster)
+		})
+
+		When("only the images have changed", func() {
+			BeforeEach(func() {
+				cluster.Spec.MainContainer.ImageConfigs = append([]fdbv1beta2.ImageConfig{{BaseImage: "registry.example/foundationdb"}}, cluster.Spec.MainContainer.ImageConfigs...)
+			})
+
+			It("should update the images without recreating the Pods", func() {
+				Expect(req).NotTo(BeNil())
+				Expect(req.message).To(Equal("Pods are updated in place"))
+
+				pods, err := clusterReconciler.PodLifecycleManager.GetPods(context.TODO(), k8sClient, cluster, internal.GetPodListOptions(cluster, "", "")...)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pods).To(HaveLen(len(originalPods)))
+
+				for _, pod := range pods {
+					originalPod := originalPods[internal.GetProcessGroupIDFromMeta(cluster, pod.ObjectMeta)]
+					Expect(originalPod).NotTo(BeNil())
+					Expect(pod.UID).To(Equal(originalPod.UID))
+					Expect(pod.Spec.Containers[0].Image).To(HavePrefix("registry.example/foundationdb:"))
+				}
+
+				updates, err := getPodsToUpdate(log, clusterReconciler, cluster, internal.CreatePodMap(cluster, pods))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(updates).To(BeEmpty())
+			})
+		})
+
+		When("the version has changed", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.PodUpdateStrategy = fdbv1beta2.PodUpdateStrategyDelete
+				cluster.Spec.Version = fdbv1beta2.Versions.NextPatchVersion.String()
+				cluster.Status.RunningVersion = fdbv1beta2.Versions.NextPatchVersion.String()
+			})
+
+			// The arguments of the sidecar depend on the version, so the Pods can't be updated in place.
+			It("should recreate the Pods", func() {
+				Expect(req).NotTo(BeNil())
+				Expect(req.message).To(Equal("Pods need to be recreated"))
+			})
+		})
+
+		When("other fields than the images have changed", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.PodUpdateStrategy = fdbv1beta2.PodUpdateStrategyDelete
+				generalSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+				generalSettings.PodTemplate.Spec.Tolerations = []corev1.Toleration{{Key: "test", Operator: corev1.TolerationOpExists}}
+				cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = generalSettings
+			})
+
+			It("should recreate the Pods", func() {
+				Expect(req).NotTo(BeNil())
+				Expect(req.message).To(Equal("Pods need to be recreated"))
+			})
+		})
+	})
 })
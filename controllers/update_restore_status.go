@@ -0,0 +1,93 @@
+/*
+ * update_restore_status.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controllers
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// updateRestoreStatus provides a reconciliation step for updating the status
+// of a restore based on the restore status command.
+type updateRestoreStatus struct {
+}
+
+// reconcile runs the reconciler's work.
+func (u updateRestoreStatus) reconcile(ctx context.Context, r *FoundationDBRestoreReconciler, restore *fdbv1beta2.FoundationDBRestore) *requeue {
+	if restore.Status.Phase == fdbv1beta2.RestorePhaseCompleted {
+		return nil
+	}
+
+	adminClient, err := r.adminClientForRestore(ctx, restore)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+	defer adminClient.Close()
+
+	liveStatus, err := adminClient.GetRestoreStatus()
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	// If no restore is running, the status will be kept as it is.
+	if liveStatus.State == "" {
+		return nil
+	}
+
+	originalStatus := restore.Status.DeepCopy()
+	restore.Status.Phase = liveStatus.GetRestorePhase()
+	restore.Status.Running = restore.Status.Phase == fdbv1beta2.RestorePhaseRunning
+	restore.Status.TargetVersion = liveStatus.TargetVersion
+	restore.Status.Progress = &fdbv1beta2.FoundationDBRestoreProgress{
+		BlocksCompleted:  liveStatus.BlocksCompleted,
+		BlocksTotal:      liveStatus.BlocksTotal,
+		BlocksInProgress: liveStatus.BlocksInProgress,
+		BytesWritten:     liveStatus.BytesWritten,
+		ApplyVersionLag:  liveStatus.ApplyVersionLag,
+	}
+
+	if liveStatus.LastError != "" {
+		knownError := false
+		for _, restoreError := range restore.Status.Errors {
+			if restoreError == liveStatus.LastError {
+				knownError = true
+				break
+			}
+		}
+
+		if !knownError {
+			restore.Status.Errors = append(restore.Status.Errors, liveStatus.LastError)
+		}
+	}
+
+	if equality.Semantic.DeepEqual(*originalStatus, restore.Status) {
+		return nil
+	}
+
+	err = r.updateOrApply(ctx, restore)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
+	return nil
+}
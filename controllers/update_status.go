@@ -38,6 +38,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -52,6 +54,17 @@ func (updateStatus) reconcile(ctx context.Context, r *FoundationDBClusterReconci
 	status := fdbv1beta2.FoundationDBClusterStatus{}
 	// Pass through Maintenance Mode Info as the maintenance_mode_checker reconciler takes care of updating it
 	originalStatus.MaintenanceModeInfo.DeepCopyInto(&status.MaintenanceModeInfo)
+	status.TaintedNodeMaintenanceZones = originalStatus.TaintedNodeMaintenanceZones
+	status.Rollout = originalStatus.Rollout
+	status.ExclusionBacklog = originalStatus.ExclusionBacklog
+	status.LastHotspotReplacement = originalStatus.LastHotspotReplacement
+	status.ResourceRecommendations = originalStatus.ResourceRecommendations
+	status.HasProcessGroupResources = originalStatus.HasProcessGroupResources
+	// The override of a failover is dropped once the primary data center in the spec was changed, so a later change
+	// back to the previous primary data center will not apply the override again.
+	if originalStatus.PrimaryDataCenterOverride != nil && originalStatus.PrimaryDataCenterOverride.SpecPrimaryDataCenter == cluster.Spec.DatabaseConfiguration.GetPrimaryDataCenter() {
+		status.PrimaryDataCenterOverride = originalStatus.PrimaryDataCenterOverride
+	}
 	status.Generations.Reconciled = cluster.Status.Generations.Reconciled
 
 	// Initialize with the current desired storage servers per Pod
@@ -172,6 +185,14 @@ func (updateStatus) reconcile(ctx context.Context, r *FoundationDBClusterReconci
 	if err != nil {
 		return &requeue{curError: err}
 	}
+	updateStorageHotspotConditions(logger, cluster, databaseStatus, status.ProcessGroups)
+	status.StorageEngineMigration = updateStorageEngineMigration(logger, cluster, databaseStatus, status.DatabaseConfiguration.StorageEngine, status.ProcessGroups, originalStatus.StorageEngineMigration)
+
+	if cluster.GetEnableResourceRecommendations() {
+		status.ResourceRecommendations = internal.UpdateResourceRecommendations(cluster, databaseStatus, originalStatus.ResourceRecommendations, time.Now())
+	} else {
+		status.ResourceRecommendations = nil
+	}
 	removeDuplicateConditions(status)
 
 	existingConfigMap := &corev1.ConfigMap{}
@@ -246,6 +267,11 @@ func (updateStatus) reconcile(ctx context.Context, r *FoundationDBClusterReconci
 		status.Locks.DenyList = denyList
 	}
 
+	status.Upgrade, err = getUpgradeStatus(r, cluster, databaseStatus, &status, originalStatus.Upgrade)
+	if err != nil {
+		return &requeue{curError: err}
+	}
+
 	// Sort slices that are assembled based on pods to prevent a reordering from
 	// issuing a new reconcile loop.
 	sort.Ints(status.StorageServersPerDisk)
@@ -280,6 +306,116 @@ func (updateStatus) reconcile(ctx context.Context, r *FoundationDBClusterReconci
 	return nil
 }
 
+// getUpgradeStatus returns the progress of a version change of the cluster. Once all processes run the target version
+// the upgrade status is kept in the Done phase until the next version change.
+func getUpgradeStatus(r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, databaseStatus *fdbv1beta2.FoundationDBStatus, status *fdbv1beta2.FoundationDBClusterStatus, previousUpgradeStatus *fdbv1beta2.UpgradeStatus) (*fdbv1beta2.UpgradeStatus, error) {
+	upgradeStatus := &fdbv1beta2.UpgradeStatus{
+		TargetVersion: cluster.Spec.Version,
+		Phase:         fdbv1beta2.UpgradePhasePrecheck,
+	}
+
+	// The information about the restart and the conditions are kept until the next version change.
+	if previousUpgradeStatus != nil && previousUpgradeStatus.TargetVersion == cluster.Spec.Version {
+		upgradeStatus.PreviousVersion = previousUpgradeStatus.PreviousVersion
+		upgradeStatus.RestartTimestamp = previousUpgradeStatus.RestartTimestamp
+		upgradeStatus.Conditions = append([]metav1.Condition(nil), previousUpgradeStatus.Conditions...)
+	}
+
+	// If the processes report the target version and the database has recovered far enough to accept commits after
+	// the processes were restarted, transactions have been committed at the target version.
+	if upgradeStatus.RestartTimestamp != nil && status.RunningVersion == cluster.Spec.Version && databaseStatus != nil && acceptsCommits(databaseStatus.Cluster.RecoveryState) {
+		meta.SetStatusCondition(&upgradeStatus.Conditions, metav1.Condition{
+			Type:               fdbv1beta2.UpgradeConditionCommitted,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: cluster.Generation,
+			Reason:             "DatabaseRecovered",
+			Message:            fmt.Sprintf("Database recovered with version %s", cluster.Spec.Version),
+		})
+	}
+
+	if status.RunningVersion == cluster.Spec.Version {
+		if previousUpgradeStatus == nil || previousUpgradeStatus.TargetVersion != cluster.Spec.Version {
+			return nil, nil
+		}
+
+		upgradeStatus.Phase = fdbv1beta2.UpgradePhaseDone
+		return upgradeStatus, nil
+	}
+
+	if !status.Configured {
+		return nil, nil
+	}
+
+	runningVersion, err := fdbv1beta2.ParseFdbVersion(status.RunningVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := fdbv1beta2.ParseFdbVersion(cluster.Spec.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	// A rollback of a failed upgrade is always allowed, even if the running version is not compatible with the
+	// previous version.
+	rollback := meta.IsStatusConditionTrue(upgradeStatus.Conditions, fdbv1beta2.UpgradeConditionRolledBack)
+	versionCompatible := version.IsProtocolCompatible(runningVersion)
+	if !version.IsAtLeast(runningVersion) && !versionCompatible && !rollback {
+		upgradeStatus.BlockedReason = fmt.Sprintf("cluster downgrade operation is only supported for protocol compatible versions, running version %s and desired version %s are not compatible", runningVersion, version)
+		return upgradeStatus, nil
+	}
+
+	// The incompatible clients are reported by the checkClientCompatibility reconciler, which already fetches the
+	// protocol version of the target version.
+	if !versionCompatible && !cluster.Spec.IgnoreUpgradabilityChecks && !rollback && previousUpgradeStatus != nil && previousUpgradeStatus.TargetVersion == cluster.Spec.Version && len(previousUpgradeStatus.IncompatibleClients) > 0 {
+		upgradeStatus.IncompatibleClients = previousUpgradeStatus.IncompatibleClients
+		upgradeStatus.BlockedReason = previousUpgradeStatus.BlockedReason
+		return upgradeStatus, nil
+	}
+
+	// The processes can only be restarted once all Pods have received the binaries and the configuration for the
+	// target version.
+	upgradeStatus.Phase = fdbv1beta2.UpgradePhaseRestarting
+	for _, processGroup := range status.ProcessGroups {
+		if processGroup.IsMarkedForRemoval() || cluster.SkipProcessGroup(processGroup) {
+			continue
+		}
+
+		if processGroup.GetConditionTime(fdbv1beta2.IncorrectConfigMap) != nil {
+			upgradeStatus.Phase = fdbv1beta2.UpgradePhaseStagingBinaries
+			break
+		}
+	}
+
+	if !versionCompatible && cluster.ShouldUseLocks() {
+		lockClient, err := r.getLockClient(cluster)
+		if err != nil {
+			return nil, err
+		}
+
+		pendingUpgrades, err := lockClient.GetPendingUpgrades(version)
+		if err != nil {
+			return nil, err
+		}
+
+		for processGroupID, pending := range pendingUpgrades {
+			if pending {
+				upgradeStatus.PendingRestarts = append(upgradeStatus.PendingRestarts, processGroupID)
+			}
+		}
+
+		sort.Slice(upgradeStatus.PendingRestarts, func(i, j int) bool {
+			return upgradeStatus.PendingRestarts[i] < upgradeStatus.PendingRestarts[j]
+		})
+	}
+
+	if !status.Health.Available {
+		upgradeStatus.BlockedReason = "database is unavailable"
+	}
+
+	return upgradeStatus, nil
+}
+
 // containsAll determines if one map contains all the keys and matching values
 // from another map.
 func containsAll(current map[string]string, desired map[string]string) bool {
@@ -539,6 +675,8 @@ func validateProcessGroup(ctx context.Context, r *FoundationDBClusterReconciler,
 		return nil
 	}
 
+	updateNodeTaintConditions(ctx, r, cluster, pod, processGroupStatus)
+
 	_, idNum, err := podmanager.ParseProcessGroupID(processGroupStatus.ProcessGroupID)
 	if err != nil {
 		return err
@@ -627,6 +765,125 @@ func validateProcessGroup(ctx context.Context, r *FoundationDBClusterReconciler,
 	return nil
 }
 
+// updateNodeTaintConditions sets the NodeTaintDetected and NodeTaintReplacing conditions for a process group whose Pod
+// is running on a node with a taint that is defined in the automatic replacement options.
+func updateNodeTaintConditions(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, pod *corev1.Pod, processGroupStatus *fdbv1beta2.ProcessGroupStatus) {
+	option, err := getNodeTaintReplacementOption(ctx, r, cluster, pod)
+	if err != nil {
+		log.Info("Could not fetch node of Pod",
+			"namespace", cluster.Namespace,
+			"cluster", cluster.Name,
+			"processGroupID", processGroupStatus.ProcessGroupID,
+			"node", pod.Spec.NodeName,
+			"error", err.Error())
+		return
+	}
+
+	processGroupStatus.UpdateCondition(fdbv1beta2.NodeTaintDetected, option != nil, cluster.Status.ProcessGroups, processGroupStatus.ProcessGroupID)
+
+	replacing := false
+	if option != nil {
+		detectedTime := processGroupStatus.GetConditionTime(fdbv1beta2.NodeTaintDetected)
+		replacing = detectedTime != nil && time.Since(time.Unix(*detectedTime, 0)) >= time.Duration(option.DurationInSeconds)*time.Second
+	}
+
+	processGroupStatus.UpdateCondition(fdbv1beta2.NodeTaintReplacing, replacing, cluster.Status.ProcessGroups, processGroupStatus.ProcessGroupID)
+}
+
+// updateStorageHotspotConditions sets the StorageHotspot condition for all storage process groups that are overloaded
+// based on the storage role metrics in the machine-readable status.
+func updateStorageHotspotConditions(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, databaseStatus *fdbv1beta2.FoundationDBStatus, processGroups []*fdbv1beta2.ProcessGroupStatus) {
+	hotspots := map[fdbv1beta2.ProcessGroupID]string{}
+	if cluster.GetEnableHotspotRemediation() {
+		hotspots = internal.GetStorageHotspots(databaseStatus, cluster.GetHotspotStoredBytesThresholdPercentage(), cluster.GetHotspotMaxQueueBytes())
+	}
+
+	for _, processGroup := range processGroups {
+		reason, isHotspot := hotspots[processGroup.ProcessGroupID]
+		isHotspot = isHotspot && processGroup.ProcessClass == fdbv1beta2.ProcessClassStorage
+		if isHotspot && processGroup.GetConditionTime(fdbv1beta2.StorageHotspot) == nil {
+			logger.Info("Detected storage hotspot", "processGroupID", processGroup.ProcessGroupID, "reason", reason)
+		}
+
+		processGroup.UpdateCondition(fdbv1beta2.StorageHotspot, isHotspot, cluster.Status.ProcessGroups, processGroup.ProcessGroupID)
+	}
+}
+
+// updateStorageEngineMigration sets the IncorrectStorageEngine condition for all storage process groups with a storage
+// server that uses a different storage engine than the configured one and returns the progress of the migration. A
+// completed migration is kept in the status until the storage engine is changed again.
+func updateStorageEngineMigration(logger logr.Logger, cluster *fdbv1beta2.FoundationDBCluster, databaseStatus *fdbv1beta2.FoundationDBStatus, storageEngine fdbv1beta2.StorageEngine, processGroups []*fdbv1beta2.ProcessGroupStatus, previousMigration *fdbv1beta2.StorageEngineMigrationStatus) *fdbv1beta2.StorageEngineMigrationStatus {
+	migrated, total, incorrectStorageEngines := internal.GetStorageEngineMigrationProgress(databaseStatus, storageEngine)
+
+	for _, processGroup := range processGroups {
+		currentStorageEngine, incorrect := incorrectStorageEngines[processGroup.ProcessGroupID]
+		incorrect = incorrect && processGroup.ProcessClass == fdbv1beta2.ProcessClassStorage
+		if incorrect && processGroup.GetConditionTime(fdbv1beta2.IncorrectStorageEngine) == nil {
+			logger.Info("Detected storage server with incorrect storage engine", "processGroupID", processGroup.ProcessGroupID, "currentStorageEngine", currentStorageEngine, "desiredStorageEngine", storageEngine)
+		}
+
+		processGroup.UpdateCondition(fdbv1beta2.IncorrectStorageEngine, incorrect, cluster.Status.ProcessGroups, processGroup.ProcessGroupID)
+	}
+
+	if total == 0 {
+		return previousMigration
+	}
+
+	if migrated == total && (previousMigration == nil || previousMigration.StorageEngine != storageEngine) {
+		return nil
+	}
+
+	return &fdbv1beta2.StorageEngineMigrationStatus{
+		StorageEngine:          storageEngine,
+		MigratedStorageServers: migrated,
+		TotalStorageServers:    total,
+		MigratedPercentage:     migrated * 100 / total,
+	}
+}
+
+// getNodeTaintReplacementOption returns the taint replacement option with the shortest duration that matches a taint
+// of the node the Pod is running on. If no taint matches or the Pod is not scheduled this will return nil. The nodes
+// are only read if the node watch is enabled, otherwise the operator has no read access to the nodes.
+func getNodeTaintReplacementOption(ctx context.Context, r *FoundationDBClusterReconciler, cluster *fdbv1beta2.FoundationDBCluster, pod *corev1.Pod) (*fdbv1beta2.TaintReplacementOption, error) {
+	if !r.EnableNodeWatch || len(cluster.Spec.AutomationOptions.Replacements.Taints) == 0 || pod.Spec.NodeName == "" {
+		return nil, nil
+	}
+
+	node := &corev1.Node{}
+	err := r.Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, node)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	taintKeys := make([]string, 0, len(node.Spec.Taints)+1)
+	for _, taint := range node.Spec.Taints {
+		taintKeys = append(taintKeys, taint.Key)
+	}
+
+	// Cordoned nodes normally get the unschedulable taint, but we don't want to depend on the node controller.
+	if node.Spec.Unschedulable {
+		taintKeys = append(taintKeys, corev1.TaintNodeUnschedulable)
+	}
+
+	var result *fdbv1beta2.TaintReplacementOption
+	for _, key := range taintKeys {
+		option := cluster.GetTaintReplacementOption(key)
+		if option == nil {
+			continue
+		}
+
+		if result == nil || option.DurationInSeconds < result.DurationInSeconds {
+			result = option
+		}
+	}
+
+	return result, nil
+}
+
 // removeDuplicateConditions will remove all duplicated conditions from the status and if a process group has the ResourcesTerminating
 // condition it will remove all other conditions on that process group.
 func removeDuplicateConditions(status fdbv1beta2.FoundationDBClusterStatus) {
@@ -719,6 +976,17 @@ func refreshProcessGroupStatus(ctx context.Context, r *FoundationDBClusterReconc
 	return pods, pvcs, nil
 }
 
+// acceptsCommits returns true if the database has recovered far enough to accept commits. The recovery states that
+// accept commits are reported once all transaction system processes were recruited.
+func acceptsCommits(recoveryState fdbv1beta2.RecoveryState) bool {
+	switch recoveryState.Name {
+	case "accepting_commits", "all_logs_recruited", "storage_recovered", "fully_recovered":
+		return true
+	default:
+		return false
+	}
+}
+
 func getRunningVersion(versionMap map[string]int, fallback string) (string, error) {
 	if len(versionMap) == 0 {
 		return fallback, nil
@@ -37,6 +37,8 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -410,6 +412,91 @@ var _ = Describe("update_status", func() {
 				Expect(pendingCount).To(BeNumerically("==", 1))
 			})
 		})
+
+		When("the Pod is running on a tainted node", func() {
+			var taintedProcessGroup fdbv1beta2.ProcessGroupID
+
+			BeforeEach(func() {
+				clusterReconciler.EnableNodeWatch = true
+				taintedProcessGroup = podmanager.GetProcessGroupID(cluster, pods[0])
+				pods[0].Spec.NodeName = "node-1"
+				Expect(k8sClient.Update(context.TODO(), pods[0])).NotTo(HaveOccurred())
+
+				node := &corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "node-1",
+					},
+					Spec: corev1.NodeSpec{
+						Taints: []corev1.Taint{
+							{
+								Key:    "example.org/maintenance",
+								Effect: corev1.TaintEffectNoSchedule,
+							},
+						},
+					},
+				}
+				Expect(k8sClient.Create(context.TODO(), node)).NotTo(HaveOccurred())
+
+				cluster.Spec.AutomationOptions.Replacements.Taints = []fdbv1beta2.TaintReplacementOption{
+					{
+						Key:               "example.org/maintenance",
+						DurationInSeconds: 3600,
+					},
+					{
+						Key:               "*",
+						DurationInSeconds: 0,
+					},
+				}
+			})
+
+			AfterEach(func() {
+				clusterReconciler.EnableNodeWatch = false
+			})
+
+			It("should mark the process group as running on a tainted node", func() {
+				processGroupStatus, err := validateProcessGroups(context.TODO(), clusterReconciler, cluster, &cluster.Status, processMap, configMap, allPods, allPvcs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fdbv1beta2.FilterByCondition(processGroupStatus, fdbv1beta2.NodeTaintDetected, false)).To(ConsistOf(taintedProcessGroup))
+				Expect(fdbv1beta2.FilterByCondition(processGroupStatus, fdbv1beta2.NodeTaintReplacing, false)).To(BeEmpty())
+			})
+
+			When("the taint has no exact match", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.Replacements.Taints = cluster.Spec.AutomationOptions.Replacements.Taints[1:]
+				})
+
+				It("should use the wildcard and mark the process group for replacement", func() {
+					processGroupStatus, err := validateProcessGroups(context.TODO(), clusterReconciler, cluster, &cluster.Status, processMap, configMap, allPods, allPvcs)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fdbv1beta2.FilterByCondition(processGroupStatus, fdbv1beta2.NodeTaintDetected, false)).To(ConsistOf(taintedProcessGroup))
+					Expect(fdbv1beta2.FilterByCondition(processGroupStatus, fdbv1beta2.NodeTaintReplacing, false)).To(ConsistOf(taintedProcessGroup))
+				})
+			})
+
+			When("the node watch is disabled", func() {
+				BeforeEach(func() {
+					clusterReconciler.EnableNodeWatch = false
+				})
+
+				It("should not mark the process group", func() {
+					processGroupStatus, err := validateProcessGroups(context.TODO(), clusterReconciler, cluster, &cluster.Status, processMap, configMap, allPods, allPvcs)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fdbv1beta2.FilterByCondition(processGroupStatus, fdbv1beta2.NodeTaintDetected, false)).To(BeEmpty())
+				})
+			})
+
+			When("no taints are configured", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.Replacements.Taints = nil
+				})
+
+				It("should not mark the process group", func() {
+					processGroupStatus, err := validateProcessGroups(context.TODO(), clusterReconciler, cluster, &cluster.Status, processMap, configMap, allPods, allPvcs)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fdbv1beta2.FilterByCondition(processGroupStatus, fdbv1beta2.NodeTaintDetected, false)).To(BeEmpty())
+				})
+			})
+		})
 	})
 
 	When("removing duplicated entries in process group status", func() {
@@ -510,6 +597,190 @@ var _ = Describe("update_status", func() {
 				})
 			})
 		})
+
+		When("the cluster is upgraded to a version incompatible version", func() {
+			BeforeEach(func() {
+				cluster.Spec.Version = fdbv1beta2.Versions.NextMajorVersion.String()
+				cluster.Spec.LockOptions.DisableLocks = pointer.Bool(false)
+				lockClient := mock.NewMockLockClientUncast(cluster)
+				Expect(lockClient.AddPendingUpgrades(fdbv1beta2.Versions.NextMajorVersion, []fdbv1beta2.ProcessGroupID{"storage-2", "storage-1"})).NotTo(HaveOccurred())
+			})
+
+			It("should report the pending restarts", func() {
+				Expect(cluster.Status.Upgrade).To(Equal(&fdbv1beta2.UpgradeStatus{
+					TargetVersion:   fdbv1beta2.Versions.NextMajorVersion.String(),
+					Phase:           fdbv1beta2.UpgradePhaseRestarting,
+					PendingRestarts: []fdbv1beta2.ProcessGroupID{"storage-1", "storage-2"},
+				}))
+			})
+		})
+
+		When("a storage process stores more data than the other storage processes", func() {
+			BeforeEach(func() {
+				adminClient, err := mock.NewMockAdminClientUncast(cluster, k8sClient)
+				Expect(err).NotTo(HaveOccurred())
+				adminClient.MockStorageMetrics("storage-1", 100, 0)
+				adminClient.MockStorageMetrics("storage-2", 100, 0)
+				adminClient.MockStorageMetrics("storage-3", 100, 0)
+				adminClient.MockStorageMetrics("storage-4", 500, 0)
+			})
+
+			When("the hotspot remediation is disabled", func() {
+				It("should not report a storage hotspot", func() {
+					Expect(fdbv1beta2.FilterByCondition(cluster.Status.ProcessGroups, fdbv1beta2.StorageHotspot, false)).To(BeEmpty())
+				})
+			})
+
+			When("the hotspot remediation is enabled", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.HotspotRemediation.Enabled = pointer.Bool(true)
+				})
+
+				It("should report the storage hotspot", func() {
+					Expect(fdbv1beta2.FilterByCondition(cluster.Status.ProcessGroups, fdbv1beta2.StorageHotspot, false)).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-4")))
+				})
+			})
+		})
+
+		When("some storage processes use a different storage engine", func() {
+			BeforeEach(func() {
+				adminClient, err := mock.NewMockAdminClientUncast(cluster, k8sClient)
+				Expect(err).NotTo(HaveOccurred())
+				adminClient.MockStorageEngine("storage-1", fdbv1beta2.StorageEngineMemory2)
+				adminClient.MockStorageEngine("storage-2", fdbv1beta2.StorageEngineMemory2)
+			})
+
+			It("should report the incorrect storage engine", func() {
+				Expect(fdbv1beta2.FilterByCondition(cluster.Status.ProcessGroups, fdbv1beta2.IncorrectStorageEngine, false)).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-1"), fdbv1beta2.ProcessGroupID("storage-2")))
+			})
+
+			It("should report the progress of the storage engine migration", func() {
+				Expect(cluster.Status.StorageEngineMigration).To(Equal(&fdbv1beta2.StorageEngineMigrationStatus{
+					StorageEngine:          fdbv1beta2.StorageEngineSSD2,
+					MigratedStorageServers: 2,
+					TotalStorageServers:    4,
+					MigratedPercentage:     50,
+				}))
+			})
+		})
+
+		When("the resource recommendations are enabled and the observation window is over", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.ResourceRecommendations.Enabled = pointer.Bool(true)
+				cluster.Status.ResourceRecommendations = []fdbv1beta2.ResourceRecommendation{
+					{
+						ProcessClass:  fdbv1beta2.ProcessClassStorage,
+						ObservedSince: metav1.NewTime(time.Now().Add(-25 * time.Hour)),
+						Samples: []fdbv1beta2.ResourceUsageSample{
+							{
+								Timestamp: metav1.NewTime(time.Now().Add(-12 * time.Hour)),
+								CPU:       resource.MustParse("2"),
+								Memory:    resource.MustParse("2Gi"),
+							},
+						},
+					},
+				}
+			})
+
+			It("should publish the resource recommendations", func() {
+				recommendation := cluster.Status.GetResourceRecommendation(fdbv1beta2.ProcessClassStorage)
+				Expect(recommendation).NotTo(BeNil())
+				Expect(recommendation.CPU.String()).To(Equal("2400m"))
+				Expect(recommendation.Memory.String()).To(Equal("2458Mi"))
+				Expect(recommendation.Samples).To(HaveLen(2))
+			})
+		})
+	})
+
+	When("getting the upgrade status after the processes were restarted", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var databaseStatus *fdbv1beta2.FoundationDBStatus
+		var upgradeStatus *fdbv1beta2.UpgradeStatus
+
+		BeforeEach(func() {
+			cluster = internal.CreateDefaultCluster()
+			cluster.Spec.Version = fdbv1beta2.Versions.NextMajorVersion.String()
+			databaseStatus = &fdbv1beta2.FoundationDBStatus{}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			upgradeStatus, err = getUpgradeStatus(clusterReconciler, cluster, databaseStatus, &fdbv1beta2.FoundationDBClusterStatus{
+				RunningVersion: cluster.Spec.Version,
+				Health: fdbv1beta2.ClusterHealth{
+					Available: true,
+				},
+			}, &fdbv1beta2.UpgradeStatus{
+				TargetVersion:    cluster.Spec.Version,
+				Phase:            fdbv1beta2.UpgradePhaseRestarting,
+				PreviousVersion:  fdbv1beta2.Versions.Default.String(),
+				RestartTimestamp: &metav1.Time{Time: time.Now()},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("the database accepts commits", func() {
+			BeforeEach(func() {
+				databaseStatus.Cluster.RecoveryState.Name = "fully_recovered"
+			})
+
+			It("should set the committed condition", func() {
+				Expect(upgradeStatus.Phase).To(Equal(fdbv1beta2.UpgradePhaseDone))
+				Expect(meta.IsStatusConditionTrue(upgradeStatus.Conditions, fdbv1beta2.UpgradeConditionCommitted)).To(BeTrue())
+			})
+		})
+
+		When("the database has not recovered", func() {
+			BeforeEach(func() {
+				databaseStatus.Cluster.RecoveryState.Name = "recruiting_transaction_servers"
+			})
+
+			It("should not set the committed condition", func() {
+				Expect(meta.IsStatusConditionTrue(upgradeStatus.Conditions, fdbv1beta2.UpgradeConditionCommitted)).To(BeFalse())
+			})
+		})
+	})
+
+	When("getting the upgrade status with incompatible clients", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var upgradeStatus *fdbv1beta2.UpgradeStatus
+		var incompatibleClients []fdbv1beta2.IncompatibleClientGroup
+
+		BeforeEach(func() {
+			cluster = internal.CreateDefaultCluster()
+			cluster.Spec.Version = fdbv1beta2.Versions.NextMajorVersion.String()
+			incompatibleClients = []fdbv1beta2.IncompatibleClientGroup{
+				{
+					LogGroup:  "app",
+					Addresses: []string{"10.1.1.1:4500"},
+					Count:     1,
+				},
+			}
+
+			var err error
+			upgradeStatus, err = getUpgradeStatus(clusterReconciler, cluster, &fdbv1beta2.FoundationDBStatus{}, &fdbv1beta2.FoundationDBClusterStatus{
+				RunningVersion: fdbv1beta2.Versions.Default.String(),
+				Configured:     true,
+				Health: fdbv1beta2.ClusterHealth{
+					Available: true,
+				},
+			}, &fdbv1beta2.UpgradeStatus{
+				TargetVersion:       cluster.Spec.Version,
+				Phase:               fdbv1beta2.UpgradePhasePrecheck,
+				IncompatibleClients: incompatibleClients,
+				BlockedReason:       getIncompatibleClientsBlockedReason(1, cluster.Spec.Version),
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should keep the incompatible clients reported by the client compatibility check", func() {
+			Expect(upgradeStatus).To(Equal(&fdbv1beta2.UpgradeStatus{
+				TargetVersion:       cluster.Spec.Version,
+				Phase:               fdbv1beta2.UpgradePhasePrecheck,
+				IncompatibleClients: incompatibleClients,
+				BlockedReason:       getIncompatibleClientsBlockedReason(1, cluster.Spec.Version),
+			}))
+		})
 	})
 
 	DescribeTable("when getting the running version from the running processes", func(versionMap map[string]int, fallback string, expected string) {
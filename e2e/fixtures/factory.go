@@ -30,6 +30,7 @@ import (
 	"time"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 	"github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
@@ -323,6 +324,11 @@ func (factory *Factory) getClusterStatus(
 		return nil, err
 	}
 
+	err = internal.LoadProcessGroups(ctx.Background(), factory.GetControllerRuntimeClient(), clusterRequest)
+	if err != nil {
+		return nil, err
+	}
+
 	return clusterRequest, nil
 }
 
@@ -46,6 +46,7 @@ const (
 	fdbcliStr     = "fdbcli"
 	fdbbackupStr  = "fdbbackup"
 	fdbrestoreStr = "fdbrestore"
+	fdbdrStr      = "fdbdr"
 )
 
 var adminClientMutex sync.Mutex
@@ -150,6 +151,10 @@ func (command cliCommand) getClusterFileFlag() string {
 		return "--dest_cluster_file"
 	}
 
+	if command.binary == fdbdrStr {
+		return "-d"
+	}
+
 	return "-C"
 }
 
@@ -689,8 +694,15 @@ func (client *cliAdminClient) GetProtocolVersion(version string) (string, error)
 	return protocolVersionMatch[1], nil
 }
 
-func (client *cliAdminClient) StartBackup(url string, snapshotPeriodSeconds int) error {
-	_, err := client.runCommand(cliCommand{
+// StartBackup starts a new backup with the provided tag.
+func (client *cliAdminClient) StartBackup(tag string, url string, snapshotPeriodSeconds int) error {
+	// fdbbackup creates the backup container from the client side.
+	err := checkBackupContainerAccess(url)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.runCommand(cliCommand{
 		binary: fdbbackupStr,
 		args: []string{
 			"start",
@@ -698,18 +710,22 @@ func (client *cliAdminClient) StartBackup(url string, snapshotPeriodSeconds int)
 			url,
 			"-s",
 			fmt.Sprintf("%d", snapshotPeriodSeconds),
+			"-t",
+			tag,
 			"-z",
 		},
 	})
 	return err
 }
 
-// StopBackup stops a backup.
-func (client *cliAdminClient) StopBackup(_ string) error {
+// StopBackup stops the backup with the provided tag.
+func (client *cliAdminClient) StopBackup(tag string) error {
 	_, err := client.runCommand(cliCommand{
 		binary: fdbbackupStr,
 		args: []string{
 			"discontinue",
+			"-t",
+			tag,
 		},
 	})
 	return err
@@ -737,12 +753,15 @@ func (client *cliAdminClient) ResumeBackups() error {
 	return err
 }
 
-// ModifyBackup updates the backup parameters.
-func (client *cliAdminClient) ModifyBackup(snapshotPeriodSeconds int) error {
+// ModifyBackup updates the backup parameters of the backup with the provided
+// tag.
+func (client *cliAdminClient) ModifyBackup(tag string, snapshotPeriodSeconds int) error {
 	_, err := client.runCommand(cliCommand{
 		binary: fdbbackupStr,
 		args: []string{
 			"modify",
+			"-t",
+			tag,
 			"-s",
 			fmt.Sprintf("%d", snapshotPeriodSeconds),
 		},
@@ -750,12 +769,14 @@ func (client *cliAdminClient) ModifyBackup(snapshotPeriodSeconds int) error {
 	return err
 }
 
-// GetBackupStatus gets the status of the current backup.
-func (client *cliAdminClient) GetBackupStatus() (*fdbv1beta2.FoundationDBLiveBackupStatus, error) {
+// GetBackupStatus gets the status of the backup with the provided tag.
+func (client *cliAdminClient) GetBackupStatus(tag string) (*fdbv1beta2.FoundationDBLiveBackupStatus, error) {
 	statusString, err := client.runCommand(cliCommand{
 		binary: fdbbackupStr,
 		args: []string{
 			"status",
+			"-t",
+			tag,
 			"--json",
 		},
 	})
@@ -778,8 +799,103 @@ func (client *cliAdminClient) GetBackupStatus() (*fdbv1beta2.FoundationDBLiveBac
 	return status, nil
 }
 
+// DescribeBackup describes the data of a backup in the blob store.
+func (client *cliAdminClient) DescribeBackup(url string) (*fdbv1beta2.FoundationDBBackupDescription, error) {
+	err := checkBackupContainerAccess(url)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptionString, err := client.runCommand(cliCommand{
+		binary:  fdbbackupStr,
+		timeout: BackupDataCLITimeout,
+		args: []string{
+			"describe",
+			"-d",
+			url,
+			"--version_timestamps",
+			"--json",
+		},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	description := &fdbv1beta2.FoundationDBBackupDescription{}
+	descriptionBytes, err := internal.RemoveWarningsInJSON(descriptionString)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(descriptionBytes, description)
+	if err != nil {
+		return nil, err
+	}
+
+	return description, nil
+}
+
+// ExpireBackup deletes the data of a backup that is older than the provided
+// version.
+func (client *cliAdminClient) ExpireBackup(url string, expireBeforeVersion int64) error {
+	err := checkBackupContainerAccess(url)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.runCommand(cliCommand{
+		binary:  fdbbackupStr,
+		timeout: BackupDataCLITimeout,
+		args: []string{
+			"expire",
+			"-d",
+			url,
+			"--expire_before_version",
+			strconv.FormatInt(expireBeforeVersion, 10),
+		},
+	})
+	return err
+}
+
+// DeleteBackup deletes all data of a backup.
+func (client *cliAdminClient) DeleteBackup(url string) error {
+	err := checkBackupContainerAccess(url)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.runCommand(cliCommand{
+		binary:  fdbbackupStr,
+		timeout: BackupDataCLITimeout,
+		args: []string{
+			"delete",
+			"-d",
+			url,
+		},
+	})
+	return err
+}
+
+// checkBackupContainerAccess checks if the backup container can be accessed by the operator. The fdbbackup and
+// fdbrestore commands open the backup container from the client side and run in the operator Pod, so file system
+// destinations that are only mounted into the backup agents can't be accessed.
+func checkBackupContainerAccess(url string) error {
+	if strings.HasPrefix(url, "file://") {
+		return fmt.Errorf("backup container %s is not accessible by the operator", url)
+	}
+
+	return nil
+}
+
 // StartRestore starts a new restore.
-func (client *cliAdminClient) StartRestore(url string, keyRanges []fdbv1beta2.FoundationDBKeyRange) error {
+func (client *cliAdminClient) StartRestore(url string, keyRanges []fdbv1beta2.FoundationDBKeyRange, targetVersion *int64, targetTimestamp *string, sourceCluster *fdbv1beta2.FoundationDBCluster) error {
+	// fdbrestore reads the backup description and the restore set from the client side.
+	err := checkBackupContainerAccess(url)
+	if err != nil {
+		return err
+	}
+
 	args := []string{
 		"start",
 		"-r",
@@ -796,7 +912,26 @@ func (client *cliAdminClient) StartRestore(url string, keyRanges []fdbv1beta2.Fo
 		}
 		args = append(args, "-k", keyRangeString)
 	}
-	_, err := client.runCommand(cliCommand{
+
+	if targetVersion != nil {
+		args = append(args, "-v", strconv.FormatInt(*targetVersion, 10))
+	}
+
+	if targetTimestamp != nil {
+		// fdbrestore needs the original cluster to convert the timestamp into a version.
+		if sourceCluster == nil {
+			return fmt.Errorf("restoring to the target timestamp %s requires the source cluster", *targetTimestamp)
+		}
+
+		sourceClusterFile, err := createClusterFile(sourceCluster)
+		if err != nil {
+			return err
+		}
+
+		args = append(args, "--timestamp", *targetTimestamp, "--orig_cluster_file", sourceClusterFile)
+	}
+
+	_, err = client.runCommand(cliCommand{
 		binary: fdbrestoreStr,
 		args:   args,
 	})
@@ -804,13 +939,182 @@ func (client *cliAdminClient) StartRestore(url string, keyRanges []fdbv1beta2.Fo
 }
 
 // GetRestoreStatus gets the status of the current restore.
-func (client *cliAdminClient) GetRestoreStatus() (string, error) {
-	return client.runCommand(cliCommand{
+func (client *cliAdminClient) GetRestoreStatus() (*fdbv1beta2.FoundationDBLiveRestoreStatus, error) {
+	output, err := client.runCommand(cliCommand{
 		binary: fdbrestoreStr,
 		args: []string{
 			"status",
 		},
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	return parseRestoreStatus(output)
+}
+
+// restoreStatusFieldRegex matches the field names in the output of the
+// restore status command.
+var restoreStatusFieldRegex = regexp.MustCompile(`(?:^|\s)(Tag|UID|State|Blocks|BlocksInProgress|Files|BytesWritten|ApplyVersionLag|LastError|URL|Range|AddPrefix|RemovePrefix|Version): `)
+
+// restoreLastErrorAgeRegex matches the age suffix of the last error in the
+// restore status, e.g. "'some error' 10s ago.".
+var restoreLastErrorAgeRegex = regexp.MustCompile(`\s+-?[0-9]+s ago\.?$`)
+
+// parseRestoreStatus parses the output of the restore status command. The
+// output contains the fields of the restore separated by two spaces, e.g.
+// "Tag: default  UID: 1234  State: running  Blocks: 10/100 ...".
+func parseRestoreStatus(output string) (*fdbv1beta2.FoundationDBLiveRestoreStatus, error) {
+	status := &fdbv1beta2.FoundationDBLiveRestoreStatus{}
+	output = strings.TrimSpace(output)
+	if output == "" {
+		return status, nil
+	}
+
+	matches := restoreStatusFieldRegex.FindAllStringSubmatchIndex(output, -1)
+	for idx, match := range matches {
+		end := len(output)
+		if idx+1 < len(matches) {
+			end = matches[idx+1][0]
+		}
+
+		field := output[match[2]:match[3]]
+		value := strings.TrimSpace(output[match[1]:end])
+
+		var err error
+		switch field {
+		case "Tag":
+			status.Tag = value
+		case "UID":
+			status.UID = value
+		case "State":
+			status.State = value
+		case "Blocks":
+			completed, total, found := strings.Cut(value, "/")
+			if !found {
+				return nil, fmt.Errorf("could not parse blocks %s of the restore status", value)
+			}
+
+			status.BlocksCompleted, err = strconv.ParseInt(completed, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+
+			status.BlocksTotal, err = strconv.ParseInt(total, 10, 64)
+		case "BlocksInProgress":
+			status.BlocksInProgress, err = strconv.ParseInt(value, 10, 64)
+		case "Files":
+			status.Files, err = strconv.ParseInt(value, 10, 64)
+		case "BytesWritten":
+			status.BytesWritten, err = strconv.ParseInt(value, 10, 64)
+		case "ApplyVersionLag":
+			status.ApplyVersionLag, err = strconv.ParseInt(value, 10, 64)
+		case "LastError":
+			status.LastError = strings.Trim(restoreLastErrorAgeRegex.ReplaceAllString(value, ""), "'")
+		case "URL":
+			status.URL = value
+		case "Version":
+			status.TargetVersion, err = strconv.ParseInt(value, 10, 64)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// If the output doesn't contain a state, we assume that no restore is running.
+	if status.State == "" {
+		return &fdbv1beta2.FoundationDBLiveRestoreStatus{}, nil
+	}
+
+	return status, nil
+}
+
+// runDRCommand runs a fdbdr command with this cluster as the destination
+// cluster and the provided cluster as the source cluster.
+func (client *cliAdminClient) runDRCommand(sourceCluster *fdbv1beta2.FoundationDBCluster, args ...string) (string, error) {
+	sourceClusterFile, err := createClusterFile(sourceCluster)
+	if err != nil {
+		return "", err
+	}
+
+	return client.runCommand(cliCommand{
+		binary: fdbdrStr,
+		args:   append(args, "-s", sourceClusterFile),
+	})
+}
+
+// StartDR starts a new DR from the source cluster into this cluster.
+func (client *cliAdminClient) StartDR(sourceCluster *fdbv1beta2.FoundationDBCluster, tag string) error {
+	_, err := client.runDRCommand(sourceCluster, "start", "-t", tag)
+	return err
+}
+
+// StopDR aborts the DR from the source cluster into this cluster.
+func (client *cliAdminClient) StopDR(sourceCluster *fdbv1beta2.FoundationDBCluster, tag string) error {
+	_, err := client.runDRCommand(sourceCluster, "abort", "-t", tag)
+	return err
+}
+
+// SwitchDR switches the source and the destination cluster of the DR.
+func (client *cliAdminClient) SwitchDR(sourceCluster *fdbv1beta2.FoundationDBCluster, tag string) error {
+	_, err := client.runDRCommand(sourceCluster, "switch", "-t", tag)
+	return err
+}
+
+// GetDRStatus gets the status of the DR from the source cluster into this
+// cluster.
+func (client *cliAdminClient) GetDRStatus(sourceCluster *fdbv1beta2.FoundationDBCluster, tag string) (*fdbv1beta2.FoundationDBLiveDRStatus, error) {
+	output, err := client.runDRCommand(sourceCluster, "status", "-t", tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseDRStatus(output, tag)
+}
+
+// drSecondsBehindRegex matches the lag of the DR in the output of the DR
+// status command, e.g. "The DR is 0.592934 seconds behind.".
+var drSecondsBehindRegex = regexp.MustCompile(`The DR is (-?[0-9.]+) seconds behind`)
+
+// parseDRStatus parses the output of the DR status command. The command only
+// provides a human-readable output, e.g.
+// "The DR on tag `default' is a complete copy of the primary database.".
+func parseDRStatus(output string, tag string) (*fdbv1beta2.FoundationDBLiveDRStatus, error) {
+	status := &fdbv1beta2.FoundationDBLiveDRStatus{
+		Tag: tag,
+	}
+
+	switch {
+	case strings.Contains(output, "No previous backups found"):
+		return status, nil
+	case strings.Contains(output, "is NOT a complete copy of the primary database (just started)"):
+		status.Phase = fdbv1beta2.DRPhaseSubmitted
+	case strings.Contains(output, "is NOT a complete copy of the primary database"):
+		status.Phase = fdbv1beta2.DRPhaseRunning
+	case strings.Contains(output, "is a complete copy of the primary database"):
+		status.Phase = fdbv1beta2.DRPhaseRunningDifferentially
+	case strings.Contains(output, "completed at version"):
+		status.Phase = fdbv1beta2.DRPhaseCompleted
+	case strings.Contains(output, "has been partially aborted"):
+		status.Phase = fdbv1beta2.DRPhasePartiallyAborted
+	case strings.Contains(output, "has been aborted"):
+		status.Phase = fdbv1beta2.DRPhaseAborted
+	default:
+		return nil, fmt.Errorf("could not parse DR status: %s", strings.TrimSpace(output))
+	}
+
+	secondsBehindMatch := drSecondsBehindRegex.FindStringSubmatch(output)
+	if secondsBehindMatch != nil {
+		secondsBehind, err := strconv.ParseFloat(secondsBehindMatch[1], 64)
+		if err != nil {
+			return nil, err
+		}
+
+		status.SecondsBehind = secondsBehind
+	}
+
+	return status, nil
 }
 
 // Close cleans up any pending resources.
@@ -35,6 +35,7 @@ import (
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var _ = Describe("admin_client_test", func() {
@@ -725,6 +726,133 @@ protocol fdb00b071010000`,
 		})
 	})
 
+	When("starting a restore", func() {
+		var mockRunner *mockCommandRunner
+		var cliClient *cliAdminClient
+		var sourceCluster *fdbv1beta2.FoundationDBCluster
+
+		BeforeEach(func() {
+			mockRunner = &mockCommandRunner{
+				mockedError:  nil,
+				mockedOutput: "",
+			}
+
+			cliClient = &cliAdminClient{
+				Cluster: &fdbv1beta2.FoundationDBCluster{
+					Spec: fdbv1beta2.FoundationDBClusterSpec{
+						Version: "7.1.25",
+					},
+				},
+				clusterFilePath: "test",
+				log:             logr.Discard(),
+				cmdRunner:       mockRunner,
+			}
+
+			sourceCluster = &fdbv1beta2.FoundationDBCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					UID: "source",
+				},
+				Status: fdbv1beta2.FoundationDBClusterStatus{
+					ConnectionString: "source:abcd@127.0.0.1:4501",
+				},
+			}
+		})
+
+		When("a target version is defined", func() {
+			It("should pass the version to fdbrestore", func() {
+				Expect(cliClient.StartRestore("blobstore://test", nil, pointer.Int64(300), nil, nil)).NotTo(HaveOccurred())
+				Expect(mockRunner.receivedBinary).To(HaveSuffix(fdbrestoreStr))
+				Expect(mockRunner.receivedArgs).To(Equal([]string{
+					"start",
+					"-r",
+					"blobstore://test",
+					"-v",
+					"300",
+					"--dest_cluster_file",
+					"test",
+					"--log",
+				}))
+			})
+		})
+
+		When("a target timestamp is defined", func() {
+			It("should pass the timestamp and the cluster file of the source cluster to fdbrestore", func() {
+				Expect(cliClient.StartRestore("blobstore://test", nil, nil, pointer.String("2023/01/03.12:00:00+0000"), sourceCluster)).NotTo(HaveOccurred())
+				Expect(mockRunner.receivedArgs).To(Equal([]string{
+					"start",
+					"-r",
+					"blobstore://test",
+					"--timestamp",
+					"2023/01/03.12:00:00+0000",
+					"--orig_cluster_file",
+					path.Join(os.TempDir(), "source"),
+					"--dest_cluster_file",
+					"test",
+					"--log",
+				}))
+
+				content, err := os.ReadFile(path.Join(os.TempDir(), "source"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(content)).To(Equal("source:abcd@127.0.0.1:4501"))
+			})
+
+			When("no source cluster is provided", func() {
+				It("should return an error without running fdbrestore", func() {
+					Expect(cliClient.StartRestore("blobstore://test", nil, nil, pointer.String("2023/01/03.12:00:00+0000"), nil)).To(MatchError("restoring to the target timestamp 2023/01/03.12:00:00+0000 requires the source cluster"))
+					Expect(mockRunner.receivedArgs).To(BeEmpty())
+				})
+			})
+		})
+	})
+
+	When("accessing the data of a backup", func() {
+		var mockRunner *mockCommandRunner
+		var cliClient *cliAdminClient
+
+		BeforeEach(func() {
+			mockRunner = &mockCommandRunner{
+				mockedError:  nil,
+				mockedOutput: "",
+			}
+
+			cliClient = &cliAdminClient{
+				Cluster: &fdbv1beta2.FoundationDBCluster{
+					Spec: fdbv1beta2.FoundationDBClusterSpec{
+						Version: "7.1.25",
+					},
+				},
+				clusterFilePath: "test",
+				log:             logr.Discard(),
+				cmdRunner:       mockRunner,
+			}
+		})
+
+		When("the backup has a file system destination", func() {
+			It("should return an error without running fdbbackup", func() {
+				url := "file:///var/fdb/backups/test/backup-2023-01-02-15-04-05.123456"
+				expectedErr := "backup container file:///var/fdb/backups/test/backup-2023-01-02-15-04-05.123456 is not accessible by the operator"
+
+				_, err := cliClient.DescribeBackup(url)
+				Expect(err).To(MatchError(expectedErr))
+				Expect(cliClient.ExpireBackup(url, 100)).To(MatchError(expectedErr))
+				Expect(cliClient.DeleteBackup(url)).To(MatchError(expectedErr))
+				Expect(cliClient.StartBackup("test", url, 10)).To(MatchError(expectedErr))
+				Expect(cliClient.StartRestore(url, nil, nil, nil, nil)).To(MatchError(expectedErr))
+				Expect(mockRunner.receivedArgs).To(BeEmpty())
+			})
+		})
+
+		When("the backup has a blob store destination", func() {
+			It("should run fdbbackup with the backup data timeout", func() {
+				Expect(cliClient.DeleteBackup("blobstore://test")).NotTo(HaveOccurred())
+				Expect(mockRunner.receivedBinary).To(HaveSuffix(fdbbackupStr))
+				Expect(mockRunner.receivedArgs).To(ContainElements("delete", "-d", "blobstore://test"))
+				Expect(time.Until(mockRunner.receivedDeadline)).To(BeNumerically(">", DefaultCLITimeout))
+				Expect(time.Until(mockRunner.receivedDeadline)).To(BeNumerically("<=", BackupDataCLITimeout))
+			})
+		})
+	})
+
 	When("checking if processes can safely be removed", func() {
 		var mockRunner *mockCommandRunner
 		var mockFdbClient *mockFdbLibClient
@@ -1010,4 +1138,77 @@ protocol fdb00b071010000`,
 	})
 
 	// TODO(johscheuer): Add test case for timeout.
+
+	When("parsing the restore status", func() {
+		It("should parse the fields of a running restore", func() {
+			status, err := parseRestoreStatus("Tag: default  UID: 0a1b2c  State: running  Blocks: 10/100  BlocksInProgress: 5  Files: 7  BytesWritten: 1024  ApplyVersionLag: 3  LastError: 'Task execution stopped due to timeout' 12s ago.  URL: blobstore://test@test-service/test-backup?bucket=fdb-backups  Range: ''-'\\xff'  AddPrefix: ''  RemovePrefix: ''  Version: 123456\n")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status).To(Equal(&fdbv1beta2.FoundationDBLiveRestoreStatus{
+				Tag:              "default",
+				UID:              "0a1b2c",
+				State:            "running",
+				BlocksCompleted:  10,
+				BlocksTotal:      100,
+				BlocksInProgress: 5,
+				Files:            7,
+				BytesWritten:     1024,
+				ApplyVersionLag:  3,
+				LastError:        "Task execution stopped due to timeout",
+				URL:              "blobstore://test@test-service/test-backup?bucket=fdb-backups",
+				TargetVersion:    123456,
+			}))
+			Expect(status.GetRestorePhase()).To(Equal(fdbv1beta2.RestorePhaseRunning))
+		})
+
+		It("should return an empty status if no restore is running", func() {
+			status, err := parseRestoreStatus("\n")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.State).To(BeEmpty())
+			Expect(status.GetRestorePhase()).To(BeEmpty())
+		})
+
+		It("should return an error if the blocks cannot be parsed", func() {
+			_, err := parseRestoreStatus("Tag: default  State: running  Blocks: 10")
+			Expect(err).To(MatchError("could not parse blocks 10 of the restore status"))
+		})
+	})
+
+	When("parsing the DR status", func() {
+		DescribeTable("it should return the phase and the lag of the DR",
+			func(output string, expected *fdbv1beta2.FoundationDBLiveDRStatus) {
+				status, err := parseDRStatus(output, "default")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status).To(Equal(expected))
+			},
+			Entry("no DR was started",
+				"No previous backups found.\n",
+				&fdbv1beta2.FoundationDBLiveDRStatus{Tag: "default"},
+			),
+			Entry("the DR was just started",
+				"The DR on tag `default' is NOT a complete copy of the primary database (just started).\n",
+				&fdbv1beta2.FoundationDBLiveDRStatus{Tag: "default", Phase: fdbv1beta2.DRPhaseSubmitted},
+			),
+			Entry("the DR copies the initial data",
+				"The DR on tag `default' is NOT a complete copy of the primary database.\n\nThe DR is 12.500000 seconds behind.\n",
+				&fdbv1beta2.FoundationDBLiveDRStatus{Tag: "default", Phase: fdbv1beta2.DRPhaseRunning, SecondsBehind: 12.5},
+			),
+			Entry("the DR is a complete copy",
+				"The DR on tag `default' is a complete copy of the primary database.\n\nThe DR is 0.592934 seconds behind.\n",
+				&fdbv1beta2.FoundationDBLiveDRStatus{Tag: "default", Phase: fdbv1beta2.DRPhaseRunningDifferentially, SecondsBehind: 0.592934},
+			),
+			Entry("the DR was aborted",
+				"The previous DR on tag `default' has been aborted.\n",
+				&fdbv1beta2.FoundationDBLiveDRStatus{Tag: "default", Phase: fdbv1beta2.DRPhaseAborted},
+			),
+			Entry("the DR was partially aborted",
+				"The previous DR on tag `default' has been partially aborted.\nAbort the DR with --cleanup before starting a new DR.\n",
+				&fdbv1beta2.FoundationDBLiveDRStatus{Tag: "default", Phase: fdbv1beta2.DRPhasePartiallyAborted},
+			),
+		)
+
+		It("should return an error for an unknown output", func() {
+			_, err := parseDRStatus("unknown\n", "default")
+			Expect(err).To(MatchError("could not parse DR status: unknown"))
+		})
+	})
 })
@@ -27,6 +27,7 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 )
 
 // commandRunner is an interface to run commands.
@@ -80,14 +81,17 @@ type mockCommandRunner struct {
 	receivedBinary string
 	// receivedArgs will be the args that were used to call runCommand.
 	receivedArgs []string
+	// receivedDeadline will be the deadline of the context that was used to call runCommand.
+	receivedDeadline time.Time
 	// mockedOutputPerBinary is the output returned if the binary is matching. This can be helpful to test the behaviour for
 	// different versions.
 	mockedOutputPerBinary map[string]string
 }
 
-func (runner *mockCommandRunner) runCommand(_ context.Context, name string, arg ...string) ([]byte, error) {
+func (runner *mockCommandRunner) runCommand(ctx context.Context, name string, arg ...string) ([]byte, error) {
 	runner.receivedBinary = name
 	runner.receivedArgs = arg
+	runner.receivedDeadline, _ = ctx.Deadline()
 
 	var mockedOutput string
 	if output, ok := runner.mockedOutputPerBinary[name]; ok {
@@ -37,8 +37,15 @@ import (
 // DefaultCLITimeout is the default timeout for CLI commands.
 var DefaultCLITimeout = 10 * time.Second
 
+// BackupDataCLITimeout is the timeout for CLI commands that read or delete the data of a backup container, those
+// commands have to list or delete all files in the container and can take much longer than other commands.
+var BackupDataCLITimeout = 10 * time.Minute
+
 const (
 	defaultTransactionTimeout = 5 * time.Second
+
+	// minimumManagementAPIVersion is the minimum API version that must be selected to use the management API.
+	minimumManagementAPIVersion = 710
 )
 
 // getFDBDatabase opens an FDB database.
@@ -119,11 +126,27 @@ func (p *realDatabaseClientProvider) GetLockClient(cluster *fdbv1beta2.Foundatio
 }
 
 // GetAdminClient generates a client for performing administrative actions
-// against the database.
+// against the database. If the cluster should use the management API and the selected API version supports it, the
+// management API based client will be returned, otherwise the fdbcli based client.
 func (p *realDatabaseClientProvider) GetAdminClient(cluster *fdbv1beta2.FoundationDBCluster, kubernetesClient client.Client) (fdbadminclient.AdminClient, error) {
+	if cluster.UseManagementAPI() && managementAPISupportedByAPIVersion() {
+		return NewManagementAPIAdminClient(cluster, kubernetesClient, p.log)
+	}
+
 	return NewCliAdminClient(cluster, kubernetesClient, p.log)
 }
 
+// managementAPISupportedByAPIVersion returns true if the API version selected by the operator allows to use the
+// management API for all operations of the managementAPIAdminClient.
+func managementAPISupportedByAPIVersion() bool {
+	apiVersion, err := fdb.GetAPIVersion()
+	if err != nil {
+		return false
+	}
+
+	return apiVersion >= minimumManagementAPIVersion
+}
+
 // NewDatabaseClientProvider generates a client provider for talking to real
 // databases.
 func NewDatabaseClientProvider(log logr.Logger) fdbadminclient.DatabaseClientProvider {
@@ -26,6 +26,7 @@ import (
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	"github.com/apple/foundationdb/bindings/go/src/fdb"
 	"github.com/go-logr/logr"
+	"strings"
 	"time"
 )
 
@@ -33,6 +34,43 @@ import (
 type fdbLibClient interface {
 	// getValueFromDBUsingKey returns the value of the provided key.
 	getValueFromDBUsingKey(fdbKey string, timeout time.Duration) ([]byte, error)
+
+	// getValuesFromDBUsingPrefix returns all key value pairs with the provided prefix. The prefix will be removed from
+	// the returned keys.
+	getValuesFromDBUsingPrefix(prefix string, timeout time.Duration) (map[string][]byte, error)
+
+	// executeSpecialKeyMutations applies the provided mutations to the special key space in a single transaction.
+	executeSpecialKeyMutations(mutations []specialKeyMutation, timeout time.Duration) error
+}
+
+// specialKeyMutation represents a modification of a key in the special key space.
+type specialKeyMutation struct {
+	// key is the key in the special key space that should be modified.
+	key string
+	// value is the value that will be set for the key.
+	value []byte
+	// clear defines if the key should be cleared instead of set.
+	clear bool
+	// clearPrefix defines if all keys that have key as prefix should be cleared. This requires clear to be true.
+	clearPrefix bool
+}
+
+// specialKeysAPIFailureErrorCode is the error code that FDB returns if a change in the special key space was rejected.
+// The details will be stored in the special key \xff\xff/error_message of the same transaction.
+const specialKeysAPIFailureErrorCode = 2117
+
+// convertTimeoutError converts the FDB timeout error into the TimeoutError of the operator.
+func convertTimeoutError(err error) error {
+	var fdbError fdb.Error
+	if errors.As(err, &fdbError) {
+		// See: https://apple.github.io/foundationdb/api-error-codes.html
+		// 1031: Operation aborted because the transaction timed out
+		if fdbError.Code == 1031 {
+			return fdbv1beta2.TimeoutError{Err: err}
+		}
+	}
+
+	return err
 }
 
 // realFdbLibClient represents the actual FDB client that will interact with FDB.
@@ -70,16 +108,7 @@ func (fdbClient *realFdbLibClient) getValueFromDBUsingKey(fdbKey string, timeout
 	})
 
 	if err != nil {
-		var fdbError *fdb.Error
-		if errors.As(err, &fdbError) {
-			// See: https://apple.github.io/foundationdb/api-error-codes.html
-			// 1031: Operation aborted because the transaction timed out
-			if fdbError.Code == 1031 {
-				return nil, fdbv1beta2.TimeoutError{Err: err}
-			}
-		}
-
-		return nil, err
+		return nil, convertTimeoutError(err)
 	}
 
 	byteResult, ok := result.([]byte)
@@ -90,6 +119,146 @@ func (fdbClient *realFdbLibClient) getValueFromDBUsingKey(fdbKey string, timeout
 	return byteResult, nil
 }
 
+func (fdbClient *realFdbLibClient) getValuesFromDBUsingPrefix(prefix string, timeout time.Duration) (map[string][]byte, error) {
+	fdbClient.logger.Info("Fetch values from FDB", "prefix", prefix)
+	defer func() {
+		fdbClient.logger.Info("Done fetching values from FDB", "prefix", prefix)
+	}()
+	database, err := getFDBDatabase(fdbClient.cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := database.Transact(func(transaction fdb.Transaction) (interface{}, error) {
+		err := transaction.Options().SetTimeout(timeout.Milliseconds())
+		if err != nil {
+			return nil, err
+		}
+
+		keyRange, err := fdb.PrefixRange([]byte(prefix))
+		if err != nil {
+			return nil, err
+		}
+
+		keyValues, err := transaction.GetRange(keyRange, fdb.RangeOptions{}).GetSliceWithError()
+		if err != nil {
+			return nil, err
+		}
+
+		values := make(map[string][]byte, len(keyValues))
+		for _, keyValue := range keyValues {
+			values[strings.TrimPrefix(keyValue.Key.String(), prefix)] = keyValue.Value
+		}
+
+		return values, nil
+	})
+
+	if err != nil {
+		return nil, convertTimeoutError(err)
+	}
+
+	values, ok := result.(map[string][]byte)
+	if !ok {
+		return nil, fmt.Errorf("could not cast result into map")
+	}
+
+	return values, nil
+}
+
+func (fdbClient *realFdbLibClient) executeSpecialKeyMutations(mutations []specialKeyMutation, timeout time.Duration) error {
+	fdbClient.logger.Info("Update special keys in FDB", "mutations", len(mutations))
+	defer func() {
+		fdbClient.logger.Info("Done updating special keys in FDB", "mutations", len(mutations))
+	}()
+	database, err := getFDBDatabase(fdbClient.cluster)
+	if err != nil {
+		return err
+	}
+
+	transaction, err := database.CreateTransaction()
+	if err != nil {
+		return err
+	}
+
+	// We are not using database.Transact here, as we have to read the error message of the special key space from the
+	// same transaction if the commit was rejected.
+	for {
+		err = applySpecialKeyMutations(transaction, mutations, timeout)
+		if err == nil {
+			err = transaction.Commit().Get()
+		}
+
+		if err == nil {
+			return nil
+		}
+
+		var fdbError fdb.Error
+		if !errors.As(err, &fdbError) {
+			return err
+		}
+
+		if fdbError.Code == specialKeysAPIFailureErrorCode {
+			errorMessage, getErr := transaction.Get(fdb.Key("\xff\xff/error_message")).Get()
+			if getErr != nil {
+				return err
+			}
+
+			return fmt.Errorf("%w: %s", err, string(errorMessage))
+		}
+
+		err = transaction.OnError(fdbError).Get()
+		if err != nil {
+			return convertTimeoutError(err)
+		}
+	}
+}
+
+// specialKeySpaceWriteOptions is implemented by the transaction options of the FDB Go bindings that support writes
+// to the special key space. The bindings used by the operator only provide this option once they are built for API
+// version 710 or newer.
+type specialKeySpaceWriteOptions interface {
+	SetSpecialKeySpaceEnableWrites() error
+}
+
+// applySpecialKeyMutations sets the required transaction options and applies all mutations to the transaction.
+func applySpecialKeyMutations(transaction fdb.Transaction, mutations []specialKeyMutation, timeout time.Duration) error {
+	options, ok := interface{}(transaction.Options()).(specialKeySpaceWriteOptions)
+	if !ok {
+		return fmt.Errorf("the FDB Go bindings don't support writes to the special key space")
+	}
+
+	err := options.SetSpecialKeySpaceEnableWrites()
+	if err != nil {
+		return err
+	}
+
+	err = transaction.Options().SetTimeout(timeout.Milliseconds())
+	if err != nil {
+		return err
+	}
+
+	for _, mutation := range mutations {
+		if !mutation.clear {
+			transaction.Set(fdb.Key(mutation.key), mutation.value)
+			continue
+		}
+
+		if !mutation.clearPrefix {
+			transaction.Clear(fdb.Key(mutation.key))
+			continue
+		}
+
+		keyRange, err := fdb.PrefixRange([]byte(mutation.key))
+		if err != nil {
+			return err
+		}
+
+		transaction.ClearRange(keyRange)
+	}
+
+	return nil
+}
+
 // mockFdbLibClient is a mock for unit testing.
 type mockFdbLibClient struct {
 	// mockedOutput is the output returned by getValueFromDBUsingKey.
@@ -98,6 +267,12 @@ type mockFdbLibClient struct {
 	mockedError error
 	// requestedKey will be the key that was used to call getValueFromDBUsingKey.
 	requestedKey string
+	// mockedValues is the output returned by getValuesFromDBUsingPrefix for the requested prefix.
+	mockedValues map[string]map[string][]byte
+	// requestedPrefix will be the prefix that was used to call getValuesFromDBUsingPrefix.
+	requestedPrefix string
+	// mutations contains all mutations that were passed to executeSpecialKeyMutations.
+	mutations []specialKeyMutation
 }
 
 func (fdbClient *mockFdbLibClient) getValueFromDBUsingKey(fdbKey string, _ time.Duration) ([]byte, error) {
@@ -105,3 +280,19 @@ func (fdbClient *mockFdbLibClient) getValueFromDBUsingKey(fdbKey string, _ time.
 
 	return fdbClient.mockedOutput, fdbClient.mockedError
 }
+
+func (fdbClient *mockFdbLibClient) getValuesFromDBUsingPrefix(prefix string, _ time.Duration) (map[string][]byte, error) {
+	fdbClient.requestedPrefix = prefix
+
+	return fdbClient.mockedValues[prefix], fdbClient.mockedError
+}
+
+func (fdbClient *mockFdbLibClient) executeSpecialKeyMutations(mutations []specialKeyMutation, _ time.Duration) error {
+	if fdbClient.mockedError != nil {
+		return fdbClient.mockedError
+	}
+
+	fdbClient.mutations = append(fdbClient.mutations, mutations...)
+
+	return nil
+}
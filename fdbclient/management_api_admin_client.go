@@ -0,0 +1,243 @@
+/*
+ * management_api_admin_client.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fdbclient
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/fdbadminclient"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// excludedPrefix is the prefix of the management module in the special key space for address based exclusions.
+	excludedPrefix = "\xff\xff/management/excluded/"
+	// excludedLocalityPrefix is the prefix of the management module in the special key space for locality based
+	// exclusions.
+	excludedLocalityPrefix = "\xff\xff/management/excluded_locality/"
+	// inProgressExclusionPrefix is the prefix of the management module in the special key space that lists all
+	// exclusions that still have data or roles assigned.
+	inProgressExclusionPrefix = "\xff\xff/management/in_progress_exclusion/"
+	// maintenancePrefix is the prefix of the management module in the special key space for maintenance zones.
+	maintenancePrefix = "\xff\xff/management/maintenance/"
+	// coordinatorsKey is the key of the configuration module in the special key space to change the coordinators.
+	coordinatorsKey = "\xff\xff/configuration/coordinators/processes"
+	// ignoreSSFailuresZone is the zone that FDB reports in the maintenance module if data distribution ignores
+	// storage server failures.
+	ignoreSSFailuresZone = "IgnoreSSFailures"
+)
+
+// managementAPIAdminClient provides an implementation of the admin interface that uses the management API in the
+// special key space for exclusions, includes, coordinator changes and maintenance zones. All other methods will be
+// handled by the fdbcli based implementation.
+//
+// ConfigureDatabase is intentionally not implemented with the special key space: the configuration module in
+// \xff\xff/configuration/ only exposes the process classes and the coordinators, there are no special keys for the
+// redundancy mode, storage engine, role counts or regions. fdbcli writes those values directly into the \xff/conf/
+// system keys, which requires the same parsing and validation that fdbcli performs.
+type managementAPIAdminClient struct {
+	*cliAdminClient
+}
+
+// NewManagementAPIAdminClient generates an Admin client for a cluster that uses the management API.
+func NewManagementAPIAdminClient(cluster *fdbv1beta2.FoundationDBCluster, kubernetesClient client.Client, log logr.Logger) (fdbadminclient.AdminClient, error) {
+	adminClient, err := NewCliAdminClient(cluster, kubernetesClient, log)
+	if err != nil {
+		return nil, err
+	}
+
+	cliClient, ok := adminClient.(*cliAdminClient)
+	if !ok {
+		return nil, fmt.Errorf("could not cast admin client into cliAdminClient")
+	}
+
+	return &managementAPIAdminClient{
+		cliAdminClient: cliClient,
+	}, nil
+}
+
+// GetMaintenanceZone gets current maintenance zone, if any. Returns empty string if maintenance mode is off
+func (client *managementAPIAdminClient) GetMaintenanceZone() (string, error) {
+	zones, err := client.fdbLibClient.getValuesFromDBUsingPrefix(maintenancePrefix, DefaultCLITimeout)
+	if err != nil {
+		return "", err
+	}
+
+	for zone := range zones {
+		if zone == ignoreSSFailuresZone {
+			continue
+		}
+
+		return zone, nil
+	}
+
+	return "", nil
+}
+
+// SetMaintenanceZone places zone into maintenance mode
+func (client *managementAPIAdminClient) SetMaintenanceZone(zone string, timeoutSeconds int) error {
+	return client.fdbLibClient.executeSpecialKeyMutations([]specialKeyMutation{
+		{
+			key:   maintenancePrefix + zone,
+			value: []byte(strconv.Itoa(timeoutSeconds)),
+		},
+	}, DefaultCLITimeout)
+}
+
+// ResetMaintenanceMode switches of maintenance mode
+func (client *managementAPIAdminClient) ResetMaintenanceMode() error {
+	return client.fdbLibClient.executeSpecialKeyMutations([]specialKeyMutation{
+		{
+			key:         maintenancePrefix,
+			clear:       true,
+			clearPrefix: true,
+		},
+	}, DefaultCLITimeout)
+}
+
+// getExclusionKey returns the key in the special key space for the provided address.
+func getExclusionKey(address fdbv1beta2.ProcessAddress) string {
+	if address.IPAddress == nil && strings.HasPrefix(address.StringAddress, "locality_") {
+		return excludedLocalityPrefix + address.StringAddress
+	}
+
+	return excludedPrefix + address.StringWithoutFlags()
+}
+
+// ExcludeProcesses starts evacuating processes so that they can be removed from the database.
+//
+// The management API will not wait until the data is moved away from the excluded processes. If the cluster is not
+// allowed to use non-blocking excludes, this method will return a timeout error if any of the addresses is still in
+// progress of being excluded. This matches the behaviour of the exclude command in fdbcli.
+func (client *managementAPIAdminClient) ExcludeProcesses(addresses []fdbv1beta2.ProcessAddress) error {
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	mutations := make([]specialKeyMutation, 0, len(addresses))
+	for _, address := range addresses {
+		mutations = append(mutations, specialKeyMutation{
+			key: getExclusionKey(address),
+		})
+	}
+
+	err := client.fdbLibClient.executeSpecialKeyMutations(mutations, DefaultCLITimeout)
+	if err != nil {
+		return err
+	}
+
+	version, err := fdbv1beta2.ParseFdbVersion(client.Cluster.GetRunningVersion())
+	if err != nil {
+		return err
+	}
+
+	if version.HasNonBlockingExcludes(client.Cluster.GetUseNonBlockingExcludes()) {
+		return nil
+	}
+
+	inProgress, err := client.fdbLibClient.getValuesFromDBUsingPrefix(inProgressExclusionPrefix, DefaultCLITimeout)
+	if err != nil {
+		return err
+	}
+
+	pendingAddresses := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		if _, ok := inProgress[address.StringWithoutFlags()]; ok {
+			pendingAddresses = append(pendingAddresses, address.String())
+			continue
+		}
+
+		if _, ok := inProgress[address.MachineAddress()]; ok {
+			pendingAddresses = append(pendingAddresses, address.String())
+		}
+	}
+
+	if len(pendingAddresses) > 0 {
+		return fdbv1beta2.TimeoutError{Err: fmt.Errorf("exclusion is still in progress for: %s", strings.Join(pendingAddresses, ", "))}
+	}
+
+	return nil
+}
+
+// IncludeProcesses removes processes from the exclusion list and allows them to take on roles again.
+func (client *managementAPIAdminClient) IncludeProcesses(addresses []fdbv1beta2.ProcessAddress) error {
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	mutations := make([]specialKeyMutation, 0, len(addresses))
+	for _, address := range addresses {
+		mutations = append(mutations, specialKeyMutation{
+			key:   getExclusionKey(address),
+			clear: true,
+		})
+	}
+
+	return client.fdbLibClient.executeSpecialKeyMutations(mutations, DefaultCLITimeout)
+}
+
+// GetExclusions gets a list of the addresses currently excluded from the
+// database.
+func (client *managementAPIAdminClient) GetExclusions() ([]fdbv1beta2.ProcessAddress, error) {
+	excludedAddresses, err := client.fdbLibClient.getValuesFromDBUsingPrefix(excludedPrefix, DefaultCLITimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	excludedLocalities, err := client.fdbLibClient.getValuesFromDBUsingPrefix(excludedLocalityPrefix, DefaultCLITimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	exclusions := make([]fdbv1beta2.ProcessAddress, 0, len(excludedAddresses)+len(excludedLocalities))
+	for excludedAddress := range excludedAddresses {
+		pAddr, err := fdbv1beta2.ParseProcessAddress(excludedAddress)
+		if err != nil {
+			return nil, err
+		}
+		exclusions = append(exclusions, pAddr)
+	}
+
+	for excludedLocality := range excludedLocalities {
+		exclusions = append(exclusions, fdbv1beta2.ProcessAddress{StringAddress: excludedLocality})
+	}
+
+	return exclusions, nil
+}
+
+// ChangeCoordinators changes the coordinator set
+func (client *managementAPIAdminClient) ChangeCoordinators(addresses []fdbv1beta2.ProcessAddress) (string, error) {
+	err := client.fdbLibClient.executeSpecialKeyMutations([]specialKeyMutation{
+		{
+			key:   coordinatorsKey,
+			value: []byte(fdbv1beta2.ProcessAddressesString(addresses, ",")),
+		},
+	}, DefaultCLITimeout)
+	if err != nil {
+		return "", err
+	}
+
+	return client.GetConnectionString()
+}
@@ -0,0 +1,283 @@
+/*
+ * management_api_admin_client_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fdbclient
+
+import (
+	"fmt"
+	"net"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/apple/foundationdb/bindings/go/src/fdb"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("management_api_admin_client_test", func() {
+	var mockFdbClient *mockFdbLibClient
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var managementClient *managementAPIAdminClient
+
+	BeforeEach(func() {
+		mockFdbClient = &mockFdbLibClient{}
+		cluster = &fdbv1beta2.FoundationDBCluster{
+			Spec: fdbv1beta2.FoundationDBClusterSpec{
+				Version: "7.1.27",
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		managementClient = &managementAPIAdminClient{
+			cliAdminClient: &cliAdminClient{
+				Cluster:         cluster,
+				clusterFilePath: "test",
+				log:             logr.Discard(),
+				cmdRunner:       &mockCommandRunner{},
+				fdbLibClient:    mockFdbClient,
+			},
+		}
+	})
+
+	When("getting the maintenance zone", func() {
+		var zone string
+		var err error
+
+		JustBeforeEach(func() {
+			zone, err = managementClient.GetMaintenanceZone()
+		})
+
+		When("no maintenance zone is set", func() {
+			It("should return an empty zone", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(zone).To(BeEmpty())
+				Expect(mockFdbClient.requestedPrefix).To(Equal(maintenancePrefix))
+			})
+		})
+
+		When("a maintenance zone is set", func() {
+			BeforeEach(func() {
+				mockFdbClient.mockedValues = map[string]map[string][]byte{
+					maintenancePrefix: {
+						"zone1": []byte("3600"),
+					},
+				}
+			})
+
+			It("should return the zone", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(zone).To(Equal("zone1"))
+			})
+		})
+
+		When("storage server failures are ignored", func() {
+			BeforeEach(func() {
+				mockFdbClient.mockedValues = map[string]map[string][]byte{
+					maintenancePrefix: {
+						ignoreSSFailuresZone: []byte("0"),
+					},
+				}
+			})
+
+			It("should return an empty zone", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(zone).To(BeEmpty())
+			})
+		})
+	})
+
+	When("setting and resetting the maintenance zone", func() {
+		It("should write the zone with the timeout and clear the maintenance range", func() {
+			Expect(managementClient.SetMaintenanceZone("zone1", 3600)).NotTo(HaveOccurred())
+			Expect(managementClient.ResetMaintenanceMode()).NotTo(HaveOccurred())
+			Expect(mockFdbClient.mutations).To(ConsistOf(
+				specialKeyMutation{
+					key:   maintenancePrefix + "zone1",
+					value: []byte("3600"),
+				},
+				specialKeyMutation{
+					key:         maintenancePrefix,
+					clear:       true,
+					clearPrefix: true,
+				},
+			))
+		})
+	})
+
+	When("excluding processes", func() {
+		var addresses []fdbv1beta2.ProcessAddress
+		var err error
+
+		BeforeEach(func() {
+			addresses = []fdbv1beta2.ProcessAddress{
+				{
+					IPAddress: net.ParseIP("192.168.0.1"),
+					Port:      4500,
+					Flags:     map[string]bool{"tls": true},
+				},
+				{
+					StringAddress: "locality_instance_id:storage-1",
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			err = managementClient.ExcludeProcesses(addresses)
+		})
+
+		It("should write the exclusion keys", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockFdbClient.mutations).To(ConsistOf(
+				specialKeyMutation{
+					key: excludedPrefix + "192.168.0.1:4500",
+				},
+				specialKeyMutation{
+					key: excludedLocalityPrefix + "locality_instance_id:storage-1",
+				},
+			))
+			Expect(mockFdbClient.requestedPrefix).To(Equal(inProgressExclusionPrefix))
+		})
+
+		When("the exclusion is still in progress", func() {
+			BeforeEach(func() {
+				mockFdbClient.mockedValues = map[string]map[string][]byte{
+					inProgressExclusionPrefix: {
+						"192.168.0.1:4500": nil,
+					},
+				}
+			})
+
+			It("should return a timeout error", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(internal.IsTimeoutError(err)).To(BeTrue())
+			})
+
+			When("non-blocking excludes are enabled", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.UseNonBlockingExcludes = pointer.Bool(true)
+				})
+
+				It("should not return an error", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(mockFdbClient.requestedPrefix).To(BeEmpty())
+				})
+			})
+		})
+
+		When("the special key space rejects the mutation", func() {
+			BeforeEach(func() {
+				mockFdbClient.mockedError = fmt.Errorf("special keys API failure")
+			})
+
+			It("should return the error", func() {
+				Expect(err).To(MatchError("special keys API failure"))
+			})
+		})
+	})
+
+	When("including processes", func() {
+		It("should clear the exclusion keys", func() {
+			Expect(managementClient.IncludeProcesses([]fdbv1beta2.ProcessAddress{
+				{
+					IPAddress: net.ParseIP("192.168.0.1"),
+				},
+				{
+					StringAddress: "locality_instance_id:storage-1",
+				},
+			})).NotTo(HaveOccurred())
+			Expect(mockFdbClient.mutations).To(ConsistOf(
+				specialKeyMutation{
+					key:   excludedPrefix + "192.168.0.1",
+					clear: true,
+				},
+				specialKeyMutation{
+					key:   excludedLocalityPrefix + "locality_instance_id:storage-1",
+					clear: true,
+				},
+			))
+		})
+	})
+
+	When("getting the exclusions", func() {
+		BeforeEach(func() {
+			mockFdbClient.mockedValues = map[string]map[string][]byte{
+				excludedPrefix: {
+					"192.168.0.1:4500": nil,
+				},
+				excludedLocalityPrefix: {
+					"locality_instance_id:storage-1": nil,
+				},
+			}
+		})
+
+		It("should return the parsed addresses", func() {
+			exclusions, err := managementClient.GetExclusions()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exclusions).To(ConsistOf(
+				fdbv1beta2.ProcessAddress{
+					IPAddress: net.ParseIP("192.168.0.1"),
+					Port:      4500,
+				},
+				fdbv1beta2.ProcessAddress{
+					StringAddress: "locality_instance_id:storage-1",
+				},
+			))
+		})
+	})
+
+	When("changing the coordinators", func() {
+		var connectionString string
+		var err error
+
+		BeforeEach(func() {
+			mockFdbClient.mockedOutput = []byte("test:abcdefg@192.168.0.1:4500,192.168.0.2:4500,192.168.0.3:4500")
+		})
+
+		JustBeforeEach(func() {
+			connectionString, err = managementClient.ChangeCoordinators([]fdbv1beta2.ProcessAddress{
+				{IPAddress: net.ParseIP("192.168.0.1"), Port: 4500},
+				{IPAddress: net.ParseIP("192.168.0.2"), Port: 4500},
+				{IPAddress: net.ParseIP("192.168.0.3"), Port: 4500},
+			})
+		})
+
+		It("should set the coordinators and return the new connection string", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockFdbClient.mutations).To(ConsistOf(specialKeyMutation{
+				key:   coordinatorsKey,
+				value: []byte("192.168.0.1:4500,192.168.0.2:4500,192.168.0.3:4500"),
+			}))
+			Expect(mockFdbClient.requestedKey).To(Equal("\xff/coordinators"))
+			Expect(connectionString).To(Equal("test:abcdefg@192.168.0.1:4500,192.168.0.2:4500,192.168.0.3:4500"))
+		})
+	})
+
+	DescribeTable("converting FDB errors", func(err error, expected bool) {
+		Expect(internal.IsTimeoutError(convertTimeoutError(err))).To(Equal(expected))
+	},
+		Entry("a transaction timeout", fdb.Error{Code: 1031}, true),
+		Entry("a wrapped transaction timeout", fmt.Errorf("reading exclusions: %w", fdb.Error{Code: 1031}), true),
+		Entry("a different FDB error", fdb.Error{Code: 1020}, false),
+		Entry("a non FDB error", fmt.Errorf("timed out"), false),
+	)
+})
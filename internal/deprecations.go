@@ -127,6 +127,17 @@ func NormalizeClusterSpec(cluster *fdbv1beta2.FoundationDBCluster, options Depre
 	return nil
 }
 
+// ApplyDeprecationDefaults sets the default image configs that the operator would otherwise apply implicitly during
+// every reconciliation. In contrast to NormalizeClusterSpec this doesn't set the default resources and doesn't move
+// configuration from deprecated fields, so the stored spec only changes in the image configs.
+func ApplyDeprecationDefaults(cluster *fdbv1beta2.FoundationDBCluster, options DeprecationOptions) {
+	if options.OnlyShowChanges {
+		return
+	}
+
+	updateImageConfigs(&cluster.Spec, cluster.GetUseUnifiedImage())
+}
+
 func updateImageConfigs(spec *fdbv1beta2.FoundationDBClusterSpec, useUnifiedImage bool) {
 	if useUnifiedImage {
 		ensureImageConfigPresent(&spec.MainContainer.ImageConfigs, fdbv1beta2.ImageConfig{BaseImage: "foundationdb/foundationdb-kubernetes"})
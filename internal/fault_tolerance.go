@@ -58,8 +58,68 @@ func HasDesiredFaultToleranceFromStatus(log logr.Logger, status *fdbv1beta2.Foun
 		"maxZoneFailuresWithoutLosingData", status.Cluster.FaultTolerance.MaxZoneFailuresWithoutLosingData,
 		"maxZoneFailuresWithoutLosingAvailability", status.Cluster.FaultTolerance.MaxZoneFailuresWithoutLosingAvailability)
 
-	return hasDesiredFaultTolerance(
+	if !hasDesiredFaultTolerance(
 		expectedFaultTolerance,
 		status.Cluster.FaultTolerance.MaxZoneFailuresWithoutLosingData,
-		status.Cluster.FaultTolerance.MaxZoneFailuresWithoutLosingAvailability)
+		status.Cluster.FaultTolerance.MaxZoneFailuresWithoutLosingAvailability) {
+		return false
+	}
+
+	if cluster.Spec.DatabaseConfiguration.RedundancyMode != fdbv1beta2.RedundancyModeThreeDataHall {
+		return true
+	}
+
+	zonesPerDataHall := getZonesPerDataHall(status)
+	log.Info("Check data hall distribution",
+		"namespace", cluster.Namespace,
+		"cluster", cluster.Name,
+		"zonesPerDataHall", zonesPerDataHall)
+
+	return hasDesiredDataHallDistribution(zonesPerDataHall)
+}
+
+// getZonesPerDataHall returns the number of zones in every data hall based on the stateful processes that are not
+// excluded.
+func getZonesPerDataHall(status *fdbv1beta2.FoundationDBStatus) map[string]int {
+	zones := map[string]map[string]fdbv1beta2.None{}
+	for _, process := range status.Cluster.Processes {
+		if process.Excluded || !process.ProcessClass.IsStateful() {
+			continue
+		}
+
+		dataHall := process.Locality[fdbv1beta2.FDBLocalityDataHallKey]
+		if _, ok := zones[dataHall]; !ok {
+			zones[dataHall] = map[string]fdbv1beta2.None{}
+		}
+
+		zones[dataHall][process.Locality[fdbv1beta2.FDBLocalityZoneIDKey]] = fdbv1beta2.None{}
+	}
+
+	zonesPerDataHall := make(map[string]int, len(zones))
+	for dataHall, zonesInDataHall := range zones {
+		zonesPerDataHall[dataHall] = len(zonesInDataHall)
+	}
+
+	return zonesPerDataHall
+}
+
+// hasDesiredDataHallDistribution checks if the processes are spread across 3 data halls with at least 2 zones per data
+// hall. This ensures that the cluster can lose a whole data hall and an additional zone, as the logs will be
+// replicated across 2 zones in each of the 2 remaining data halls.
+func hasDesiredDataHallDistribution(zonesPerDataHall map[string]int) bool {
+	if _, ok := zonesPerDataHall[""]; ok {
+		return false
+	}
+
+	if len(zonesPerDataHall) < 3 {
+		return false
+	}
+
+	for _, zones := range zonesPerDataHall {
+		if zones < 2 {
+			return false
+		}
+	}
+
+	return true
 }
@@ -21,6 +21,8 @@
 package internal
 
 import (
+	"fmt"
+
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
@@ -163,8 +165,63 @@ var _ = Describe("fault_tolerance", func() {
 					},
 				},
 				false),
+			Entry("three_data_hall cluster with processes in three data halls",
+				getThreeDataHallStatus(map[string]int{"dh1": 2, "dh2": 2, "dh3": 2}),
+				threeDataHallCluster,
+				true),
+			Entry("three_data_hall cluster with processes in two data halls",
+				getThreeDataHallStatus(map[string]int{"dh1": 3, "dh2": 3}),
+				threeDataHallCluster,
+				false),
+			Entry("three_data_hall cluster with a single zone in a data hall",
+				getThreeDataHallStatus(map[string]int{"dh1": 2, "dh2": 2, "dh3": 1}),
+				threeDataHallCluster,
+				false),
+			Entry("three_data_hall cluster with processes without a data hall",
+				getThreeDataHallStatus(map[string]int{"dh1": 2, "dh2": 2, "dh3": 2, "": 1}),
+				threeDataHallCluster,
+				false),
 		)
 	})
-
-	// HasDesiredFaultToleranceFromStatus
 })
+
+var threeDataHallCluster = &fdbv1beta2.FoundationDBCluster{
+	Spec: fdbv1beta2.FoundationDBClusterSpec{
+		DataHall: "dh1",
+		DatabaseConfiguration: fdbv1beta2.DatabaseConfiguration{
+			RedundancyMode: fdbv1beta2.RedundancyModeThreeDataHall,
+		},
+	},
+}
+
+// getThreeDataHallStatus returns a fully replicated status with one storage process per zone in the provided data halls.
+func getThreeDataHallStatus(zonesPerDataHall map[string]int) *fdbv1beta2.FoundationDBStatus {
+	processes := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{}
+	for dataHall, zones := range zonesPerDataHall {
+		for i := 0; i < zones; i++ {
+			zone := fmt.Sprintf("%s-zone-%d", dataHall, i)
+			processes[fdbv1beta2.ProcessGroupID(zone)] = fdbv1beta2.FoundationDBStatusProcessInfo{
+				ProcessClass: fdbv1beta2.ProcessClassStorage,
+				Locality: map[string]string{
+					fdbv1beta2.FDBLocalityZoneIDKey:   zone,
+					fdbv1beta2.FDBLocalityDataHallKey: dataHall,
+				},
+			}
+		}
+	}
+
+	return &fdbv1beta2.FoundationDBStatus{
+		Client: fdbv1beta2.FoundationDBStatusLocalClientInfo{
+			DatabaseStatus: fdbv1beta2.FoundationDBStatusClientDBStatus{
+				Available: true,
+			},
+		},
+		Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+			FaultTolerance: fdbv1beta2.FaultTolerance{
+				MaxZoneFailuresWithoutLosingData:         2,
+				MaxZoneFailuresWithoutLosingAvailability: 2,
+			},
+			Processes: processes,
+		},
+	}
+}
@@ -23,6 +23,8 @@ package internal
 import (
 	"fmt"
 	"strings"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 )
 
 // RemoveWarningsInJSON removes any warning messages that might appear in the status output from the fdbcli and returns
@@ -35,3 +37,100 @@ func RemoveWarningsInJSON(jsonString string) ([]byte, error) {
 
 	return []byte(strings.TrimSpace(jsonString[idx:])), nil
 }
+
+// GetStorageHotspots returns the process groups with a storage process that stores more than storedBytesThresholdPercentage
+// percent above the average of all storage processes or that has more than maxQueueBytes bytes in its queue. The
+// value of the map contains the reason why the process group is considered a hotspot. Excluded processes are ignored.
+func GetStorageHotspots(status *fdbv1beta2.FoundationDBStatus, storedBytesThresholdPercentage int, maxQueueBytes int) map[fdbv1beta2.ProcessGroupID]string {
+	hotspots := map[fdbv1beta2.ProcessGroupID]string{}
+	if status == nil {
+		return hotspots
+	}
+
+	storageRoles := map[fdbv1beta2.ProcessGroupID][]fdbv1beta2.FoundationDBStatusProcessRoleInfo{}
+	totalStoredBytes := 0
+	storageServers := 0
+	for _, process := range status.Cluster.Processes {
+		if process.Excluded {
+			continue
+		}
+
+		processGroupID := fdbv1beta2.ProcessGroupID(process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey])
+		if processGroupID == "" {
+			continue
+		}
+
+		for _, role := range process.Roles {
+			if role.Role != string(fdbv1beta2.ProcessRoleStorage) {
+				continue
+			}
+
+			storageRoles[processGroupID] = append(storageRoles[processGroupID], role)
+			totalStoredBytes += role.StoredBytes
+			storageServers++
+		}
+	}
+
+	if storageServers == 0 {
+		return hotspots
+	}
+
+	averageStoredBytes := totalStoredBytes / storageServers
+	storedBytesThreshold := averageStoredBytes + averageStoredBytes*storedBytesThresholdPercentage/100
+
+	for processGroupID, roles := range storageRoles {
+		for _, role := range roles {
+			if maxQueueBytes > 0 && role.GetQueueBytes() > maxQueueBytes {
+				hotspots[processGroupID] = fmt.Sprintf("storage server %s has %d bytes in its queue, the limit is %d bytes", role.ID, role.GetQueueBytes(), maxQueueBytes)
+				break
+			}
+
+			if averageStoredBytes > 0 && role.StoredBytes > storedBytesThreshold {
+				hotspots[processGroupID] = fmt.Sprintf("storage server %s stores %d bytes, the average is %d bytes", role.ID, role.StoredBytes, averageStoredBytes)
+				break
+			}
+		}
+	}
+
+	return hotspots
+}
+
+// GetStorageEngineMigrationProgress returns the number of storage servers that use the provided storage engine, the
+// number of storage servers that report their storage engine and the process groups with a storage server that uses a
+// different storage engine. The value of the map contains the storage engine of that storage server. Excluded
+// processes and storage servers that don't report their storage engine are ignored.
+func GetStorageEngineMigrationProgress(status *fdbv1beta2.FoundationDBStatus, storageEngine fdbv1beta2.StorageEngine) (int, int, map[fdbv1beta2.ProcessGroupID]fdbv1beta2.StorageEngine) {
+	incorrectStorageEngines := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.StorageEngine{}
+	if status == nil {
+		return 0, 0, incorrectStorageEngines
+	}
+
+	migrated := 0
+	total := 0
+	for _, process := range status.Cluster.Processes {
+		if process.Excluded {
+			continue
+		}
+
+		processGroupID := fdbv1beta2.ProcessGroupID(process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey])
+		if processGroupID == "" {
+			continue
+		}
+
+		for _, role := range process.Roles {
+			if role.Role != string(fdbv1beta2.ProcessRoleStorage) || role.StorageMetadata.StorageEngine == "" {
+				continue
+			}
+
+			total++
+			if role.StorageMetadata.StorageEngine == storageEngine {
+				migrated++
+				continue
+			}
+
+			incorrectStorageEngines[processGroupID] = role.StorageMetadata.StorageEngine
+		}
+	}
+
+	return migrated, total, incorrectStorageEngines
+}
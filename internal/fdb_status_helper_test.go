@@ -22,6 +22,8 @@ package internal
 
 import (
 	"fmt"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -75,4 +77,169 @@ var _ = Describe("fdb_status_helper_test", func() {
 			),
 		)
 	})
+
+	When("getting the storage hotspots", func() {
+		var status *fdbv1beta2.FoundationDBStatus
+
+		newStorageProcess := func(processGroupID string, storedBytes int, inputBytes int, durableBytes int) fdbv1beta2.FoundationDBStatusProcessInfo {
+			return fdbv1beta2.FoundationDBStatusProcessInfo{
+				ProcessClass: fdbv1beta2.ProcessClassStorage,
+				Locality: map[string]string{
+					fdbv1beta2.FDBLocalityInstanceIDKey: processGroupID,
+				},
+				Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+					{
+						Role:         string(fdbv1beta2.ProcessRoleStorage),
+						ID:           processGroupID,
+						StoredBytes:  storedBytes,
+						InputBytes:   fdbv1beta2.FoundationDBStatusCounter{Counter: inputBytes},
+						DurableBytes: fdbv1beta2.FoundationDBStatusCounter{Counter: durableBytes},
+					},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			status = &fdbv1beta2.FoundationDBStatus{
+				Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+					Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+						"1": newStorageProcess("storage-1", 100, 10, 10),
+						"2": newStorageProcess("storage-2", 100, 10, 10),
+						"3": newStorageProcess("storage-3", 100, 10, 10),
+						"4": {
+							ProcessClass: fdbv1beta2.ProcessClassLog,
+							Locality: map[string]string{
+								fdbv1beta2.FDBLocalityInstanceIDKey: "log-1",
+							},
+							Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+								{
+									Role:         string(fdbv1beta2.ProcessRoleLog),
+									InputBytes:   fdbv1beta2.FoundationDBStatusCounter{Counter: 5000},
+									DurableBytes: fdbv1beta2.FoundationDBStatusCounter{Counter: 0},
+								},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		When("all storage processes are balanced", func() {
+			It("should return no hotspots", func() {
+				Expect(GetStorageHotspots(status, 50, 1000)).To(BeEmpty())
+			})
+		})
+
+		When("a storage process stores more data than the threshold", func() {
+			BeforeEach(func() {
+				status.Cluster.Processes["3"] = newStorageProcess("storage-3", 400, 10, 10)
+			})
+
+			It("should return the process group as hotspot", func() {
+				hotspots := GetStorageHotspots(status, 50, 1000)
+				Expect(hotspots).To(HaveLen(1))
+				Expect(hotspots).To(HaveKeyWithValue(fdbv1beta2.ProcessGroupID("storage-3"), "storage server storage-3 stores 400 bytes, the average is 200 bytes"))
+			})
+
+			When("the storage process is excluded", func() {
+				BeforeEach(func() {
+					process := status.Cluster.Processes["3"]
+					process.Excluded = true
+					status.Cluster.Processes["3"] = process
+				})
+
+				It("should return no hotspots", func() {
+					Expect(GetStorageHotspots(status, 50, 1000)).To(BeEmpty())
+				})
+			})
+		})
+
+		When("a storage process has a large queue", func() {
+			BeforeEach(func() {
+				status.Cluster.Processes["2"] = newStorageProcess("storage-2", 100, 5000, 1000)
+			})
+
+			It("should return the process group as hotspot", func() {
+				hotspots := GetStorageHotspots(status, 50, 1000)
+				Expect(hotspots).To(HaveLen(1))
+				Expect(hotspots).To(HaveKeyWithValue(fdbv1beta2.ProcessGroupID("storage-2"), "storage server storage-2 has 4000 bytes in its queue, the limit is 1000 bytes"))
+			})
+
+			When("the queue limit is disabled", func() {
+				It("should return no hotspots", func() {
+					Expect(GetStorageHotspots(status, 50, 0)).To(BeEmpty())
+				})
+			})
+		})
+	})
+
+	When("getting the storage engine migration progress", func() {
+		var status *fdbv1beta2.FoundationDBStatus
+
+		newStorageProcess := func(processGroupID string, storageEngine fdbv1beta2.StorageEngine) fdbv1beta2.FoundationDBStatusProcessInfo {
+			return fdbv1beta2.FoundationDBStatusProcessInfo{
+				ProcessClass: fdbv1beta2.ProcessClassStorage,
+				Locality: map[string]string{
+					fdbv1beta2.FDBLocalityInstanceIDKey: processGroupID,
+				},
+				Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+					{
+						Role: string(fdbv1beta2.ProcessRoleStorage),
+						ID:   processGroupID,
+						StorageMetadata: fdbv1beta2.FoundationDBStatusStorageMetadata{
+							StorageEngine: storageEngine,
+						},
+					},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			status = &fdbv1beta2.FoundationDBStatus{
+				Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+					Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+						"1": newStorageProcess("storage-1", fdbv1beta2.StorageEngineRedwood1),
+						"2": newStorageProcess("storage-2", fdbv1beta2.StorageEngineSSD2),
+						"3": newStorageProcess("storage-3", fdbv1beta2.StorageEngineSSD2),
+						"4": newStorageProcess("storage-4", ""),
+					},
+				},
+			}
+		})
+
+		It("should return the progress and the process groups with a different storage engine", func() {
+			migrated, total, incorrectStorageEngines := GetStorageEngineMigrationProgress(status, fdbv1beta2.StorageEngineRedwood1)
+			Expect(migrated).To(Equal(1))
+			Expect(total).To(Equal(3))
+			Expect(incorrectStorageEngines).To(Equal(map[fdbv1beta2.ProcessGroupID]fdbv1beta2.StorageEngine{
+				"storage-2": fdbv1beta2.StorageEngineSSD2,
+				"storage-3": fdbv1beta2.StorageEngineSSD2,
+			}))
+		})
+
+		When("a storage process with a different storage engine is excluded", func() {
+			BeforeEach(func() {
+				process := status.Cluster.Processes["2"]
+				process.Excluded = true
+				status.Cluster.Processes["2"] = process
+			})
+
+			It("should ignore the excluded process", func() {
+				migrated, total, incorrectStorageEngines := GetStorageEngineMigrationProgress(status, fdbv1beta2.StorageEngineRedwood1)
+				Expect(migrated).To(Equal(1))
+				Expect(total).To(Equal(2))
+				Expect(incorrectStorageEngines).To(HaveLen(1))
+				Expect(incorrectStorageEngines).To(HaveKey(fdbv1beta2.ProcessGroupID("storage-3")))
+			})
+		})
+
+		When("the status is nil", func() {
+			It("should return no progress", func() {
+				migrated, total, incorrectStorageEngines := GetStorageEngineMigrationProgress(nil, fdbv1beta2.StorageEngineRedwood1)
+				Expect(migrated).To(BeZero())
+				Expect(total).To(BeZero())
+				Expect(incorrectStorageEngines).To(BeEmpty())
+			})
+		})
+	})
 })
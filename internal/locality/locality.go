@@ -155,6 +155,10 @@ func ChooseDistributedProcesses(cluster *fdbv1beta2.FoundationDBCluster, process
 	fields := constraint.Fields
 	if len(fields) == 0 {
 		fields = []string{fdbv1beta2.FDBLocalityZoneIDKey, fdbv1beta2.FDBLocalityDCIDKey}
+
+		if cluster.Spec.DatabaseConfiguration.RedundancyMode == fdbv1beta2.RedundancyModeThreeDataHall {
+			fields = []string{fdbv1beta2.FDBLocalityZoneIDKey, fdbv1beta2.FDBLocalityDataHallKey, fdbv1beta2.FDBLocalityDCIDKey}
+		}
 	}
 
 	chosenCounts := make(map[string]map[string]int, len(fields))
@@ -235,6 +239,10 @@ func ChooseDistributedProcesses(cluster *fdbv1beta2.FoundationDBCluster, process
 
 // GetHardLimits returns the distribution of localities.
 func GetHardLimits(cluster *fdbv1beta2.FoundationDBCluster) map[string]int {
+	if cluster.Spec.DatabaseConfiguration.RedundancyMode == fdbv1beta2.RedundancyModeThreeDataHall {
+		return map[string]int{fdbv1beta2.FDBLocalityZoneIDKey: 1, fdbv1beta2.FDBLocalityDataHallKey: getMaxCoordinatorsPerDataHall(cluster)}
+	}
+
 	if cluster.Spec.DatabaseConfiguration.UsableRegions <= 1 {
 		return map[string]int{fdbv1beta2.FDBLocalityZoneIDKey: 1}
 	}
@@ -245,6 +253,12 @@ func GetHardLimits(cluster *fdbv1beta2.FoundationDBCluster) map[string]int {
 	return map[string]int{fdbv1beta2.FDBLocalityZoneIDKey: 1, fdbv1beta2.FDBLocalityDCIDKey: maxCoordinatorsPerDC}
 }
 
+// getMaxCoordinatorsPerDataHall returns the maximum number of coordinators in a single data hall. The coordinators
+// must be spread across 3 data halls to survive the loss of a data hall.
+func getMaxCoordinatorsPerDataHall(cluster *fdbv1beta2.FoundationDBCluster) int {
+	return int(math.Floor(float64(cluster.DesiredCoordinatorCount()) / 3.0))
+}
+
 // CheckCoordinatorValidity determines if the cluster's current coordinators
 // meet the fault tolerance requirements.
 //
@@ -264,6 +278,7 @@ func CheckCoordinatorValidity(logger logr.Logger, cluster *fdbv1beta2.Foundation
 
 	coordinatorZones := make(map[string]int, len(coordinatorStatus))
 	coordinatorDCs := make(map[string]int, len(coordinatorStatus))
+	coordinatorDataHalls := make(map[string]int, len(coordinatorStatus))
 	processGroups := make(map[fdbv1beta2.ProcessGroupID]*fdbv1beta2.ProcessGroupStatus)
 	for _, processGroup := range cluster.Status.ProcessGroups {
 		processGroups[processGroup.ProcessGroupID] = processGroup
@@ -348,6 +363,7 @@ func CheckCoordinatorValidity(logger logr.Logger, cluster *fdbv1beta2.Foundation
 		if coordinatorAddress != "" {
 			coordinatorZones[process.Locality[fdbv1beta2.FDBLocalityZoneIDKey]]++
 			coordinatorDCs[process.Locality[fdbv1beta2.FDBLocalityDCIDKey]]++
+			coordinatorDataHalls[process.Locality[fdbv1beta2.FDBLocalityDataHallKey]]++
 
 			if !cluster.IsEligibleAsCandidate(process.ProcessClass) {
 				pLogger.Info("Process class of process is not eligible as coordinator", "class", process.ProcessClass, "address", coordinatorAddress)
@@ -386,6 +402,18 @@ func CheckCoordinatorValidity(logger logr.Logger, cluster *fdbv1beta2.Foundation
 		}
 	}
 
+	hasEnoughDataHalls := true
+	if cluster.Spec.DatabaseConfiguration.RedundancyMode == fdbv1beta2.RedundancyModeThreeDataHall {
+		maxCoordinatorsPerDataHall := getMaxCoordinatorsPerDataHall(cluster)
+
+		for dataHall, count := range coordinatorDataHalls {
+			if count > maxCoordinatorsPerDataHall {
+				logger.Info("Cluster has too many coordinators in a single data hall", "dataHall", dataHall, "count", count, "max", maxCoordinatorsPerDataHall)
+				hasEnoughDataHalls = false
+			}
+		}
+	}
+
 	allHealthy := true
 	for address, healthy := range coordinatorStatus {
 		if !healthy {
@@ -394,5 +422,5 @@ func CheckCoordinatorValidity(logger logr.Logger, cluster *fdbv1beta2.Foundation
 		}
 	}
 
-	return hasEnoughDCs && hasEnoughZones && allHealthy && allUsingCorrectAddress && allEligible, allAddressesValid, nil
+	return hasEnoughDCs && hasEnoughDataHalls && hasEnoughZones && allHealthy && allUsingCorrectAddress && allEligible, allAddressesValid, nil
 }
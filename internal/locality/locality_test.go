@@ -350,6 +350,52 @@ var _ = Describe("Change coordinators", func() {
 				})
 			})
 		})
+
+		When("using the three_data_hall redundancy mode", func() {
+			BeforeEach(func() {
+				cluster.Spec.DatabaseConfiguration.RedundancyMode = fdbv1beta2.RedundancyModeThreeDataHall
+				candidates = make([]Info, 0, 12)
+				for _, dataHall := range []string{"dh1", "dh2", "dh3"} {
+					for i := 1; i <= 4; i++ {
+						candidates = append(candidates, Info{
+							ID: fmt.Sprintf("%s-p%d", dataHall, i),
+							LocalityData: map[string]string{
+								fdbv1beta2.FDBLocalityZoneIDKey:   fmt.Sprintf("%s-z%d", dataHall, i),
+								fdbv1beta2.FDBLocalityDataHallKey: dataHall,
+							},
+						})
+					}
+				}
+			})
+
+			It("should recruit the processes across all data halls", func() {
+				result, err = ChooseDistributedProcesses(cluster, candidates, cluster.DesiredCoordinatorCount(), ProcessSelectionConstraint{
+					HardLimits: GetHardLimits(cluster),
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(HaveLen(9))
+
+				dataHalls := map[string]int{}
+				zones := map[string]int{}
+				for _, process := range result {
+					dataHalls[process.LocalityData[fdbv1beta2.FDBLocalityDataHallKey]]++
+					zones[process.LocalityData[fdbv1beta2.FDBLocalityZoneIDKey]]++
+				}
+
+				Expect(dataHalls).To(Equal(map[string]int{"dh1": 3, "dh2": 3, "dh3": 3}))
+				Expect(zones).To(HaveLen(9))
+			})
+
+			When("only two data halls are available", func() {
+				It("should give an error", func() {
+					result, err = ChooseDistributedProcesses(cluster, candidates[:8], cluster.DesiredCoordinatorCount(), ProcessSelectionConstraint{
+						HardLimits: GetHardLimits(cluster),
+					})
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(Equal("Could only select 6 processes, but 9 are required"))
+				})
+			})
+		})
 	})
 
 	DescribeTable("when getting the hard limits", func(cluster *fdbv1beta2.FoundationDBCluster, expected map[string]int) {
@@ -374,6 +420,19 @@ var _ = Describe("Change coordinators", func() {
 				fdbv1beta2.FDBLocalityDCIDKey:   4,
 			},
 		),
+		Entry("cluster with the three_data_hall redundancy mode",
+			&fdbv1beta2.FoundationDBCluster{
+				Spec: fdbv1beta2.FoundationDBClusterSpec{
+					DatabaseConfiguration: fdbv1beta2.DatabaseConfiguration{
+						RedundancyMode: fdbv1beta2.RedundancyModeThreeDataHall,
+					},
+				},
+			},
+			map[string]int{
+				fdbv1beta2.FDBLocalityZoneIDKey:   1,
+				fdbv1beta2.FDBLocalityDataHallKey: 3,
+			},
+		),
 	)
 
 	DescribeTable("when getting the locality info from a process", func(process fdbv1beta2.FoundationDBStatusProcessInfo, mainContainerTLS bool, expected Info, expectedError bool) {
@@ -857,6 +916,50 @@ var _ = Describe("Change coordinators", func() {
 			})
 		})
 
+		When("using the three_data_hall redundancy mode", func() {
+			BeforeEach(func() {
+				cluster.Spec.DatabaseConfiguration.RedundancyMode = fdbv1beta2.RedundancyModeThreeDataHall
+
+				status.Cluster.Processes = map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{}
+				status.Client.Coordinators.Coordinators = nil
+				for i := 1; i <= 9; i++ {
+					process := generateDummyProcessInfo(fmt.Sprintf("test-%d", i), "", 4501, false)
+					process.Locality[fdbv1beta2.FDBLocalityDataHallKey] = fmt.Sprintf("dh%d", (i-1)/3+1)
+					status.Cluster.Processes[fdbv1beta2.ProcessGroupID(fmt.Sprintf("%d", i))] = process
+					status.Client.Coordinators.Coordinators = append(status.Client.Coordinators.Coordinators, fdbv1beta2.FoundationDBStatusCoordinator{
+						Address:   process.Address,
+						Reachable: true,
+					})
+				}
+			})
+
+			When("the coordinators are divided across three data halls", func() {
+				It("should report the coordinators as valid", func() {
+					coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus)
+					Expect(coordinatorsValid).To(BeTrue())
+					Expect(addressesValid).To(BeTrue())
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			When("the coordinators are divided across two data halls", func() {
+				BeforeEach(func() {
+					for _, process := range status.Cluster.Processes {
+						if process.Locality[fdbv1beta2.FDBLocalityDataHallKey] == "dh3" {
+							process.Locality[fdbv1beta2.FDBLocalityDataHallKey] = "dh1"
+						}
+					}
+				})
+
+				It("should report the coordinators as not valid", func() {
+					coordinatorsValid, addressesValid, err := CheckCoordinatorValidity(logr.Discard(), cluster, status, coordinatorStatus)
+					Expect(coordinatorsValid).To(BeFalse())
+					Expect(addressesValid).To(BeTrue())
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+		})
+
 		When("changing the TLS setting", func() {
 			BeforeEach(func() {
 				cluster.Status.RequiredAddresses = fdbv1beta2.RequiredAddressSet{
@@ -0,0 +1,47 @@
+/*
+ * multi_region_cluster_helper.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetDataCenterCluster builds the FoundationDBCluster for the provided data center of a multi-region cluster.
+func GetDataCenterCluster(multiRegionCluster *fdbv1beta2.FoundationDBMultiRegionCluster, dataCenterID string, databaseConfiguration fdbv1beta2.DatabaseConfiguration, seedConnectionString string) *fdbv1beta2.FoundationDBCluster {
+	spec := multiRegionCluster.Spec.ClusterTemplate.DeepCopy()
+	spec.DataCenter = dataCenterID
+	spec.ProcessGroupIDPrefix = dataCenterID
+	spec.SeedConnectionString = seedConnectionString
+	spec.DatabaseConfiguration = databaseConfiguration
+
+	return &fdbv1beta2.FoundationDBCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      multiRegionCluster.GetDataCenterClusterName(dataCenterID),
+			Namespace: multiRegionCluster.Namespace,
+			Labels: map[string]string{
+				fdbv1beta2.MultiRegionClusterLabel: multiRegionCluster.Name,
+			},
+			OwnerReferences: BuildOwnerReference(multiRegionCluster.TypeMeta, multiRegionCluster.ObjectMeta),
+		},
+		Spec: *spec,
+	}
+}
@@ -35,6 +35,7 @@ import (
 	"os"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"k8s.io/utils/pointer"
@@ -70,8 +71,89 @@ const (
 	// EnvironmentAnnotation is the annotation we use to store the environment
 	// variables.
 	EnvironmentAnnotation = "foundationdb.org/launcher-environment"
+
+	// sidecarAPIVersion defines the version of the versioned sidecar API that
+	// is used by the operator.
+	sidecarAPIVersion = 2
 )
 
+// sidecarCapabilities represents the response of the capabilities endpoint of
+// the versioned sidecar API.
+type sidecarCapabilities struct {
+	// APIVersion defines the version of the sidecar API.
+	APIVersion int `json:"apiVersion"`
+
+	// Capabilities defines the features that are supported by the sidecar.
+	Capabilities []podclient.Capability `json:"capabilities,omitempty"`
+}
+
+// monitorConfHash represents the response of the monitor conf hash endpoint of
+// the versioned sidecar API.
+type monitorConfHash struct {
+	// Hash is the SHA256 hash of the current monitor conf.
+	Hash string `json:"hash"`
+}
+
+// SidecarCapabilitiesCache caches the capabilities of the sidecar API per
+// cluster and running sidecar image, so the capabilities are not fetched again for
+// every pod client.
+type SidecarCapabilitiesCache struct {
+	lock         sync.RWMutex
+	capabilities map[string][]podclient.Capability
+}
+
+// NewSidecarCapabilitiesCache creates an empty SidecarCapabilitiesCache.
+func NewSidecarCapabilitiesCache() *SidecarCapabilitiesCache {
+	return &SidecarCapabilitiesCache{capabilities: map[string][]podclient.Capability{}}
+}
+
+// get returns the cached capabilities for the provided key.
+func (cache *SidecarCapabilitiesCache) get(key string) ([]podclient.Capability, bool) {
+	if cache == nil || key == "" {
+		return nil, false
+	}
+
+	cache.lock.RLock()
+	defer cache.lock.RUnlock()
+
+	capabilities, ok := cache.capabilities[key]
+	return capabilities, ok
+}
+
+// set caches the capabilities for the provided key.
+func (cache *SidecarCapabilitiesCache) set(key string, capabilities []podclient.Capability) {
+	if cache == nil || key == "" {
+		return
+	}
+
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	cache.capabilities[key] = capabilities
+}
+
+// getSidecarCapabilitiesCacheKey returns the key for the capabilities of the
+// sidecar in the provided Pod. The capabilities only depend on the image of
+// the running sidecar, so Pods of the same cluster with the same image share
+// the key. The image ID from the container status is used, as the image in
+// the Pod spec can be updated before the sidecar is restarted. If the image
+// ID of the running sidecar is unknown, this will return an empty key.
+func getSidecarCapabilitiesCacheKey(cluster *fdbv1beta2.FoundationDBCluster, pod *corev1.Pod) string {
+	for _, container := range pod.Status.ContainerStatuses {
+		if container.Name != fdbv1beta2.SidecarContainerName {
+			continue
+		}
+
+		if container.ImageID == "" {
+			return ""
+		}
+
+		return fmt.Sprintf("%s/%s/%s", cluster.Namespace, cluster.Name, container.ImageID)
+	}
+
+	return ""
+}
+
 // realPodSidecarClient provides a client for use in real environments, using
 // the Kubernetes sidecar.
 type realFdbPodSidecarClient struct {
@@ -96,6 +178,17 @@ type realFdbPodSidecarClient struct {
 
 	// postTimeout defines the timeout for post requests
 	postTimeout time.Duration
+
+	// apiToken defines the token to authenticate requests against the sidecar.
+	apiToken string
+
+	// capabilities contains the capabilities of the sidecar API once they
+	// were fetched.
+	capabilities []podclient.Capability
+
+	// capabilitiesCache caches the capabilities across clients for the same
+	// cluster.
+	capabilitiesCache *SidecarCapabilitiesCache
 }
 
 // realPodSidecarClient provides a client for use in real environments, using
@@ -111,8 +204,11 @@ type realFdbPodAnnotationClient struct {
 	logger logr.Logger
 }
 
-// NewFdbPodClient builds a client for working with an FDB Pod
-func NewFdbPodClient(cluster *fdbv1beta2.FoundationDBCluster, pod *corev1.Pod, log logr.Logger, getTimeout time.Duration, postTimeout time.Duration) (podclient.FdbPodClient, error) {
+// NewFdbPodClient builds a client for working with an FDB Pod. If an apiToken
+// is provided, it will be used to authenticate requests against the sidecar,
+// as long as the sidecar uses TLS. If a capabilitiesCache is provided, the
+// capabilities of the sidecar are shared with other clients for the cluster.
+func NewFdbPodClient(cluster *fdbv1beta2.FoundationDBCluster, pod *corev1.Pod, log logr.Logger, getTimeout time.Duration, postTimeout time.Duration, apiToken string, capabilitiesCache *SidecarCapabilitiesCache) (podclient.FdbPodClient, error) {
 	if GetImageType(pod) == FDBImageTypeUnified {
 		return &realFdbPodAnnotationClient{Cluster: cluster, Pod: pod, logger: log}, nil
 	}
@@ -128,6 +224,12 @@ func NewFdbPodClient(cluster *fdbv1beta2.FoundationDBCluster, pod *corev1.Pod, l
 
 	useTLS := podHasSidecarTLS(pod)
 
+	// The token must never be sent in plain text.
+	if apiToken != "" && !useTLS {
+		log.Info("Ignoring the sidecar API token because the sidecar doesn't use TLS")
+		apiToken = ""
+	}
+
 	var tlsConfig = &tls.Config{}
 	if useTLS {
 		certFile := os.Getenv("FDB_TLS_CERTIFICATE_FILE")
@@ -158,7 +260,7 @@ func NewFdbPodClient(cluster *fdbv1beta2.FoundationDBCluster, pod *corev1.Pod, l
 		tlsConfig.RootCAs = certPool
 	}
 
-	return &realFdbPodSidecarClient{Cluster: cluster, Pod: pod, useTLS: useTLS, tlsConfig: tlsConfig, logger: log, getTimeout: getTimeout, postTimeout: postTimeout}, nil
+	return &realFdbPodSidecarClient{Cluster: cluster, Pod: pod, useTLS: useTLS, tlsConfig: tlsConfig, logger: log, getTimeout: getTimeout, postTimeout: postTimeout, apiToken: apiToken, capabilitiesCache: capabilitiesCache}, nil
 }
 
 // getListenIP gets the IP address that a pod listens on.
@@ -217,6 +319,10 @@ func (client *realFdbPodSidecarClient) makeRequest(method, path string) (string,
 		return "", 0, err
 	}
 
+	if client.apiToken != "" {
+		req.Header.Set("Authorization", "Bearer "+client.apiToken)
+	}
+
 	resp, err := retryClient.Do(req)
 	if resp != nil {
 		defer resp.Body.Close()
@@ -236,6 +342,160 @@ func (client *realFdbPodSidecarClient) makeRequest(method, path string) (string,
 	return bodyText, resp.StatusCode, nil
 }
 
+// makeAPIRequest submits a request to the versioned sidecar API and returns
+// the response body.
+func (client *realFdbPodSidecarClient) makeAPIRequest(method string, path string) (string, error) {
+	body, code, err := client.makeRequest(method, fmt.Sprintf("v%d/%s", sidecarAPIVersion, path))
+	if err != nil {
+		return "", err
+	}
+
+	return body, checkAPIResponse(code)
+}
+
+// checkAPIResponse returns an error if the status code of a response from the
+// versioned sidecar API indicates a failed request.
+func checkAPIResponse(code int) error {
+	switch code {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return podclient.ErrCapabilityNotSupported
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("request to sidecar API was not authorized, response code: %d", code)
+	}
+
+	return fmt.Errorf("request to sidecar API failed, response code: %d", code)
+}
+
+// parseCapabilities parses the response of the capabilities endpoint. Sidecars
+// that don't serve the versioned API respond with http.StatusNotFound, in this
+// case no capabilities are returned.
+func parseCapabilities(body string, code int) ([]podclient.Capability, error) {
+	if code == http.StatusNotFound {
+		return []podclient.Capability{}, nil
+	}
+
+	err := checkAPIResponse(code)
+	if err != nil {
+		return nil, err
+	}
+
+	response := sidecarCapabilities{}
+	err = json.Unmarshal([]byte(body), &response)
+	if err != nil {
+		return nil, err
+	}
+
+	// A sidecar with a different major version of the API doesn't provide
+	// the endpoints that the operator expects.
+	if response.APIVersion != sidecarAPIVersion {
+		return []podclient.Capability{}, nil
+	}
+
+	return response.Capabilities, nil
+}
+
+// GetCapabilities returns the capabilities of the versioned sidecar API.
+func (client *realFdbPodSidecarClient) GetCapabilities() ([]podclient.Capability, error) {
+	if client.capabilities != nil {
+		return client.capabilities, nil
+	}
+
+	cacheKey := getSidecarCapabilitiesCacheKey(client.Cluster, client.Pod)
+	capabilities, ok := client.capabilitiesCache.get(cacheKey)
+	if ok {
+		client.capabilities = capabilities
+		return capabilities, nil
+	}
+
+	body, code, err := client.makeRequest("GET", fmt.Sprintf("v%d/capabilities", sidecarAPIVersion))
+	if err != nil {
+		return nil, err
+	}
+
+	capabilities, err = parseCapabilities(body, code)
+	if err != nil {
+		return nil, err
+	}
+
+	client.capabilities = capabilities
+	client.capabilitiesCache.set(cacheKey, capabilities)
+	return capabilities, nil
+}
+
+// requireCapability returns podclient.ErrCapabilityNotSupported if the sidecar
+// doesn't support the provided capability.
+func (client *realFdbPodSidecarClient) requireCapability(capability podclient.Capability) error {
+	supported, err := podclient.HasCapability(client, capability)
+	if err != nil {
+		return err
+	}
+
+	if !supported {
+		return fmt.Errorf("%w: %s", podclient.ErrCapabilityNotSupported, capability)
+	}
+
+	return nil
+}
+
+// GetProcessStatus returns the status of the fdbserver processes.
+func (client *realFdbPodSidecarClient) GetProcessStatus() (*podclient.ProcessStatus, error) {
+	err := client.requireCapability(podclient.CapabilityProcessStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := client.makeAPIRequest("GET", "process_status")
+	if err != nil {
+		return nil, err
+	}
+
+	status := &podclient.ProcessStatus{}
+	err = json.Unmarshal([]byte(body), status)
+	if err != nil {
+		client.logger.Error(err, "Error deserializing process status", "responseBody", body)
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// GetMonitorConfHash returns the hash of the monitor conf that is currently
+// used.
+func (client *realFdbPodSidecarClient) GetMonitorConfHash() (string, error) {
+	err := client.requireCapability(podclient.CapabilityMonitorConfHash)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := client.makeAPIRequest("GET", "monitor_conf_hash")
+	if err != nil {
+		return "", err
+	}
+
+	response := monitorConfHash{}
+	err = json.Unmarshal([]byte(body), &response)
+	if err != nil {
+		client.logger.Error(err, "Error deserializing monitor conf hash", "responseBody", body)
+		return "", err
+	}
+
+	return response.Hash, nil
+}
+
+// RestartProcess restarts the fdbserver process with the provided process
+// number.
+func (client *realFdbPodSidecarClient) RestartProcess(processNumber int) error {
+	err := client.requireCapability(podclient.CapabilityRestartProcess)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.makeAPIRequest("POST", fmt.Sprintf("processes/%d/restart", processNumber))
+	return err
+}
+
 // IsPresent checks whether a file in the sidecar is present.
 func (client *realFdbPodSidecarClient) IsPresent(filename string) (bool, error) {
 	version, err := fdbv1beta2.ParseFdbVersion(client.Cluster.Spec.Version)
@@ -396,6 +656,31 @@ func (client *realFdbPodAnnotationClient) IsPresent(_ string) (bool, error) {
 	return true, nil
 }
 
+// GetCapabilities returns the capabilities of the versioned sidecar API. The
+// unified image doesn't provide the versioned sidecar API, so no capabilities
+// are returned.
+func (client *realFdbPodAnnotationClient) GetCapabilities() ([]podclient.Capability, error) {
+	return []podclient.Capability{}, nil
+}
+
+// GetProcessStatus returns the status of the fdbserver processes. This is not
+// supported by the unified image.
+func (client *realFdbPodAnnotationClient) GetProcessStatus() (*podclient.ProcessStatus, error) {
+	return nil, fmt.Errorf("%w: %s", podclient.ErrCapabilityNotSupported, podclient.CapabilityProcessStatus)
+}
+
+// GetMonitorConfHash returns the hash of the monitor conf that is currently
+// used. This is not supported by the unified image.
+func (client *realFdbPodAnnotationClient) GetMonitorConfHash() (string, error) {
+	return "", fmt.Errorf("%w: %s", podclient.ErrCapabilityNotSupported, podclient.CapabilityMonitorConfHash)
+}
+
+// RestartProcess restarts the fdbserver process with the provided process
+// number. This is not supported by the unified image.
+func (client *realFdbPodAnnotationClient) RestartProcess(_ int) error {
+	return fmt.Errorf("%w: %s", podclient.ErrCapabilityNotSupported, podclient.CapabilityRestartProcess)
+}
+
 // podHasSidecarTLS determines whether a pod currently has TLS enabled for the
 // sidecar process.
 func podHasSidecarTLS(pod *corev1.Pod) bool {
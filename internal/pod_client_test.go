@@ -21,14 +21,18 @@
 package internal
 
 import (
+	"errors"
 	"net/http"
 	"net/url"
 	"time"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/podclient"
 	"github.com/hashicorp/go-retryablehttp"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
 )
 
 var _ = Describe("pod_client", func() {
@@ -106,4 +110,151 @@ var _ = Describe("pod_client", func() {
 			})
 		})
 	})
+
+	DescribeTable("parsing the capabilities of the sidecar API",
+		func(body string, code int, expected []podclient.Capability, expectedErr bool) {
+			capabilities, err := parseCapabilities(body, code)
+			if expectedErr {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(capabilities).To(Equal(expected))
+		},
+		Entry("a sidecar that supports the versioned API",
+			`{"apiVersion": 2, "capabilities": ["process_status", "restart_process"]}`,
+			http.StatusOK,
+			[]podclient.Capability{podclient.CapabilityProcessStatus, podclient.CapabilityRestartProcess},
+			false,
+		),
+		Entry("a sidecar that doesn't support the versioned API",
+			"",
+			http.StatusNotFound,
+			[]podclient.Capability{},
+			false,
+		),
+		Entry("a sidecar with a different API version",
+			`{"apiVersion": 3, "capabilities": ["process_status"]}`,
+			http.StatusOK,
+			[]podclient.Capability{},
+			false,
+		),
+		Entry("an unauthorized request",
+			"",
+			http.StatusUnauthorized,
+			nil,
+			true,
+		),
+		Entry("an invalid response",
+			"{",
+			http.StatusOK,
+			nil,
+			true,
+		),
+	)
+
+	When("using the split image", func() {
+		var pod *corev1.Pod
+
+		BeforeEach(func() {
+			var err error
+			pod, err = GetPod(cluster, fdbv1beta2.ProcessClassStorage, 1)
+			Expect(err).NotTo(HaveOccurred())
+			pod.Status.PodIP = "192.168.0.1"
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+				{
+					Name:    fdbv1beta2.SidecarContainerName,
+					Ready:   true,
+					ImageID: "docker.io/foundationdb/foundationdb-kubernetes-sidecar@sha256:1234",
+				},
+			}
+		})
+
+		When("an API token is provided and the sidecar doesn't use TLS", func() {
+			It("should not send the token", func() {
+				client, err := NewFdbPodClient(cluster, pod, GinkgoLogr, time.Second, time.Second, "secret-token", nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				sidecarClient, ok := client.(*realFdbPodSidecarClient)
+				Expect(ok).To(BeTrue())
+				Expect(sidecarClient.apiToken).To(BeEmpty())
+			})
+		})
+
+		When("the capabilities are cached for the cluster", func() {
+			var capabilitiesCache *SidecarCapabilitiesCache
+
+			BeforeEach(func() {
+				capabilitiesCache = NewSidecarCapabilitiesCache()
+				capabilitiesCache.set(getSidecarCapabilitiesCacheKey(cluster, pod), []podclient.Capability{podclient.CapabilityProcessStatus})
+			})
+
+			It("should return the cached capabilities without querying the sidecar", func() {
+				client, err := NewFdbPodClient(cluster, pod, GinkgoLogr, time.Second, time.Second, "", capabilitiesCache)
+				Expect(err).NotTo(HaveOccurred())
+
+				capabilities, err := client.GetCapabilities()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(capabilities).To(ConsistOf(podclient.CapabilityProcessStatus))
+			})
+		})
+	})
+
+	When("getting the key for the sidecar capabilities cache", func() {
+		var pod *corev1.Pod
+
+		BeforeEach(func() {
+			var err error
+			pod, err = GetPod(cluster, fdbv1beta2.ProcessClassStorage, 1)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should use the image ID of the running sidecar", func() {
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+				{Name: fdbv1beta2.MainContainerName, ImageID: "main@sha256:1234"},
+				{Name: fdbv1beta2.SidecarContainerName, ImageID: "sidecar@sha256:1234"},
+			}
+			key := getSidecarCapabilitiesCacheKey(cluster, pod)
+			Expect(key).To(HaveSuffix("/sidecar@sha256:1234"))
+
+			// The image in the spec is updated before the sidecar is restarted.
+			pod.Spec.Containers[1].Image = "sidecar:new"
+			Expect(getSidecarCapabilitiesCacheKey(cluster, pod)).To(Equal(key))
+
+			pod.Status.ContainerStatuses[1].ImageID = "sidecar@sha256:5678"
+			Expect(getSidecarCapabilitiesCacheKey(cluster, pod)).NotTo(Equal(key))
+		})
+
+		It("should not return a key if the running sidecar is unknown", func() {
+			Expect(getSidecarCapabilitiesCacheKey(cluster, pod)).To(BeEmpty())
+
+			capabilitiesCache := NewSidecarCapabilitiesCache()
+			capabilitiesCache.set(getSidecarCapabilitiesCacheKey(cluster, pod), []podclient.Capability{podclient.CapabilityProcessStatus})
+			_, ok := capabilitiesCache.get(getSidecarCapabilitiesCacheKey(cluster, pod))
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	When("using the unified image", func() {
+		var client podclient.FdbPodClient
+
+		BeforeEach(func() {
+			cluster.Spec.UseUnifiedImage = pointer.Bool(true)
+			pod, err := GetPod(cluster, fdbv1beta2.ProcessClassStorage, 1)
+			Expect(err).NotTo(HaveOccurred())
+
+			client, err = NewFdbPodClient(cluster, pod, GinkgoLogr, time.Second, time.Second, "", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should not support any capabilities of the versioned sidecar API", func() {
+			capabilities, err := client.GetCapabilities()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(capabilities).To(BeEmpty())
+
+			err = client.RestartProcess(1)
+			Expect(errors.Is(err, podclient.ErrCapabilityNotSupported)).To(BeTrue())
+		})
+	})
 })
@@ -266,3 +266,65 @@ func GetPublicIPSource(pod *corev1.Pod) (fdbv1beta2.PublicIPSource, error) {
 	}
 	return fdbv1beta2.PublicIPSource(source), nil
 }
+
+// GetImageUpdates returns the images of the containers and init containers that must be changed to match the desired
+// spec of the Pod. If the Pod differs from the desired spec in other fields than the images, e.g. the version
+// dependent arguments of the sidecar for a version change, nil will be returned, as those changes can't be applied in
+// place.
+func GetImageUpdates(cluster *fdbv1beta2.FoundationDBCluster, pod *corev1.Pod) (map[string]string, error) {
+	_, idNum, err := ParseProcessGroupID(GetProcessGroupIDFromMeta(cluster, pod.ObjectMeta))
+	if err != nil {
+		return nil, err
+	}
+
+	desiredSpec, err := GetPodSpec(cluster, GetProcessClassFromMeta(cluster, pod.ObjectMeta), idNum)
+	if err != nil {
+		return nil, err
+	}
+
+	// We build the desired spec with the current images of the Pod, if the hash matches the spec hash of the Pod only
+	// the images have changed.
+	currentSpec := desiredSpec.DeepCopy()
+	setContainerImages(currentSpec.InitContainers, pod.Spec.InitContainers)
+	setContainerImages(currentSpec.Containers, pod.Spec.Containers)
+	currentHash, err := GetJSONHash(currentSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	if currentHash != pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey] {
+		return nil, nil
+	}
+
+	updates := make(map[string]string)
+	addImageUpdates(updates, desiredSpec.InitContainers, pod.Spec.InitContainers)
+	addImageUpdates(updates, desiredSpec.Containers, pod.Spec.Containers)
+
+	return updates, nil
+}
+
+// setContainerImages sets the images of the target containers to the images of the source containers with the same
+// name.
+func setContainerImages(target []corev1.Container, source []corev1.Container) {
+	for idx, targetContainer := range target {
+		for _, sourceContainer := range source {
+			if targetContainer.Name == sourceContainer.Name {
+				target[idx].Image = sourceContainer.Image
+				break
+			}
+		}
+	}
+}
+
+// addImageUpdates adds the images of the desired containers to the updates, if the image differs from the image of
+// the current container with the same name.
+func addImageUpdates(updates map[string]string, desired []corev1.Container, current []corev1.Container) {
+	for _, desiredContainer := range desired {
+		for _, currentContainer := range current {
+			if desiredContainer.Name == currentContainer.Name && desiredContainer.Image != currentContainer.Image {
+				updates[desiredContainer.Name] = desiredContainer.Image
+				break
+			}
+		}
+	}
+}
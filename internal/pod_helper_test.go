@@ -0,0 +1,112 @@
+/*
+ * pod_helper_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("pod_helper", func() {
+	When("getting the image updates of a Pod", func() {
+		var cluster *fdbv1beta2.FoundationDBCluster
+		var pod *corev1.Pod
+		var imageUpdates map[string]string
+
+		BeforeEach(func() {
+			cluster = CreateDefaultCluster()
+			Expect(NormalizeClusterSpec(cluster, DeprecationOptions{})).NotTo(HaveOccurred())
+
+			var err error
+			pod, err = GetPod(cluster, fdbv1beta2.ProcessClassStorage, 1)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			imageUpdates, err = GetImageUpdates(cluster, pod)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("the Pod is up to date", func() {
+			It("should not return any image updates", func() {
+				Expect(imageUpdates).NotTo(BeNil())
+				Expect(imageUpdates).To(BeEmpty())
+			})
+		})
+
+		When("the image configuration of the cluster is changed", func() {
+			BeforeEach(func() {
+				cluster.Spec.MainContainer.ImageConfigs = append([]fdbv1beta2.ImageConfig{{BaseImage: "registry.example/foundationdb"}}, cluster.Spec.MainContainer.ImageConfigs...)
+				cluster.Spec.SidecarContainer.ImageConfigs = append([]fdbv1beta2.ImageConfig{{TagSuffix: "-2"}}, cluster.Spec.SidecarContainer.ImageConfigs...)
+			})
+
+			It("should return the new images", func() {
+				Expect(imageUpdates).To(HaveKeyWithValue(fdbv1beta2.MainContainerName, "registry.example/foundationdb:"+cluster.Spec.Version))
+				Expect(imageUpdates).To(HaveKeyWithValue(fdbv1beta2.SidecarContainerName, "foundationdb/foundationdb-kubernetes-sidecar:"+cluster.Spec.Version+"-2"))
+			})
+
+			When("the images of the Pod were already updated in place", func() {
+				BeforeEach(func() {
+					specHash, err := GetPodSpecHash(cluster, fdbv1beta2.ProcessClassStorage, 1, nil)
+					Expect(err).NotTo(HaveOccurred())
+
+					desiredSpec, err := GetPodSpec(cluster, fdbv1beta2.ProcessClassStorage, 1)
+					Expect(err).NotTo(HaveOccurred())
+
+					setContainerImages(pod.Spec.InitContainers, desiredSpec.InitContainers)
+					setContainerImages(pod.Spec.Containers, desiredSpec.Containers)
+					pod.Annotations[fdbv1beta2.LastSpecKey] = specHash
+				})
+
+				It("should not return any image updates", func() {
+					Expect(imageUpdates).NotTo(BeNil())
+					Expect(imageUpdates).To(BeEmpty())
+				})
+			})
+		})
+
+		When("the version of the cluster is changed", func() {
+			BeforeEach(func() {
+				cluster.Spec.Version = fdbv1beta2.Versions.NextPatchVersion.String()
+				cluster.Status.RunningVersion = fdbv1beta2.Versions.NextPatchVersion.String()
+			})
+
+			// The sidecar arguments contain the version of the main container, so the Pod must be recreated.
+			It("should not return any image updates", func() {
+				Expect(imageUpdates).To(BeNil())
+			})
+		})
+
+		When("other fields than the images are changed", func() {
+			BeforeEach(func() {
+				cluster.Spec.MainContainer.ImageConfigs = append([]fdbv1beta2.ImageConfig{{BaseImage: "registry.example/foundationdb"}}, cluster.Spec.MainContainer.ImageConfigs...)
+				cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.NodeSelector = map[string]string{"foo": "bar"}
+			})
+
+			It("should not return any image updates", func() {
+				Expect(imageUpdates).To(BeNil())
+			})
+		})
+	})
+})
@@ -140,14 +140,16 @@ func GetPod(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.Pro
 // GetImage returns the image for container
 func GetImage(image string, configs []fdbv1beta2.ImageConfig, versionString string, allowTagOverride bool) (string, error) {
 	if image != "" {
-		imageComponents := strings.Split(image, ":")
-		if len(imageComponents) > 1 {
-			if allowTagOverride {
-				return image, nil
-			}
-			// If the specified image contains a tag and allowOverride is false return an error
-			return "", fmt.Errorf("image should not contain a tag but contains the tag \"%s\", please remove the tag", imageComponents[1])
+		// If the specified image contains a tag or a digest and allowOverride is false return an error
+		err := fdbv1beta2.ValidateImageTag(image, allowTagOverride)
+		if err != nil {
+			return "", err
+		}
+
+		if fdbv1beta2.GetImageTag(image) != "" || fdbv1beta2.GetImageDigest(image) != "" {
+			return image, nil
 		}
+
 		configs = append([]fdbv1beta2.ImageConfig{{BaseImage: image}}, configs...)
 	}
 
@@ -304,6 +306,47 @@ func setAffinityForFaultDomain(cluster *fdbv1beta2.FoundationDBCluster, podSpec
 				},
 			})
 	}
+
+	setAffinityForDataHall(cluster, podSpec)
+}
+
+// setAffinityForDataHall restricts the Pods to the nodes in the data hall of the cluster, if the cluster uses the
+// three_data_hall redundancy mode. The data hall must match the value of the data hall node label of the nodes. Every
+// data hall is managed by its own FoundationDBCluster resource, so the processes are spread across the data halls
+// and within a data hall the processes are spread across the fault domains.
+func setAffinityForDataHall(cluster *fdbv1beta2.FoundationDBCluster, podSpec *corev1.PodSpec) {
+	if cluster.Spec.DatabaseConfiguration.RedundancyMode != fdbv1beta2.RedundancyModeThreeDataHall || cluster.Spec.DataHall == "" {
+		return
+	}
+
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+
+	if podSpec.Affinity.NodeAffinity == nil {
+		podSpec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+
+	if podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+
+	requirement := corev1.NodeSelectorRequirement{
+		Key:      cluster.GetDataHallNodeLabel(),
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{cluster.Spec.DataHall},
+	}
+
+	// The node selector terms are ORed, so the requirement must be added to every term that is defined in the Pod
+	// template.
+	nodeSelector := podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(nodeSelector.NodeSelectorTerms) == 0 {
+		nodeSelector.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+	}
+
+	for idx := range nodeSelector.NodeSelectorTerms {
+		nodeSelector.NodeSelectorTerms[idx].MatchExpressions = append(nodeSelector.NodeSelectorTerms[idx].MatchExpressions, requirement)
+	}
 }
 
 func configureVolumesForContainers(cluster *fdbv1beta2.FoundationDBCluster, podSpec *corev1.PodSpec, volumeClaimTemplate *corev1.PersistentVolumeClaim, podName string, processClass fdbv1beta2.ProcessClass) {
@@ -514,6 +557,20 @@ func configureSidecarContainerForBackup(backup *fdbv1beta2.FoundationDBBackup, c
 	return configureSidecarContainer(container, true, "", "", backup.Spec.Version, nil, backup.Spec.SidecarContainer.ImageConfigs, pointer.BoolDeref(backup.Spec.AllowTagOverride, false))
 }
 
+// configureSidecarContainerForDR sets up a sidecar container for the init
+// container for a DR agent. The DR agent needs the cluster files of the source
+// and the destination cluster.
+func configureSidecarContainerForDR(dr *fdbv1beta2.FoundationDBDisasterRecovery, container *corev1.Container) error {
+	err := configureSidecarContainer(container, true, "", "", dr.Spec.Version, nil, dr.Spec.SidecarContainer.ImageConfigs, false)
+	if err != nil {
+		return err
+	}
+
+	container.Args = append(container.Args, "--copy-file", drSourceClusterFile, "--require-not-empty", drSourceClusterFile)
+
+	return nil
+}
+
 // configureSidecarContainer sets up a foundationdb-kubernetes-sidecar container.
 func configureSidecarContainer(container *corev1.Container, initMode bool, processGroupID fdbv1beta2.ProcessGroupID, podName string, versionString string, optionalCluster *fdbv1beta2.FoundationDBCluster, imageConfigs []fdbv1beta2.ImageConfig, allowTagOverride bool) error {
 	sidecarEnv := make([]corev1.EnvVar, 0, 4)
@@ -648,6 +705,18 @@ func configureSidecarContainer(container *corev1.Container, initMode bool, proce
 
 	extendEnv(container, corev1.EnvVar{Name: "FDB_TLS_VERIFY_PEERS", Value: overrides.PeerVerificationRules})
 
+	if overrides.APITokenSecretName != nil {
+		extendEnv(container, corev1.EnvVar{
+			Name: "FDB_SIDECAR_API_TOKEN",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: *overrides.APITokenSecretName},
+					Key:                  fdbv1beta2.SidecarAPITokenSecretKey,
+				},
+			},
+		})
+	}
+
 	if hasTrustedCAs {
 		extendEnv(container, corev1.EnvVar{Name: "FDB_TLS_CA_FILE", Value: "/var/input-files/ca.pem"})
 	}
@@ -820,37 +889,98 @@ func extendEnv(container *corev1.Container, env ...corev1.EnvVar) {
 	}
 }
 
-// GetBackupDeployment builds a deployment for backup agents for a cluster.
-func GetBackupDeployment(backup *fdbv1beta2.FoundationDBBackup) (*appsv1.Deployment, error) {
-	agentCount := int32(backup.GetDesiredAgentCount())
-	if agentCount == 0 {
+// agentDeploymentOptions defines the parts of a deployment for backup or DR
+// agents that differ between the agent types.
+type agentDeploymentOptions struct {
+	// name defines the name of the deployment.
+	name string
+
+	// typeMeta defines the type of the resource that owns the deployment.
+	typeMeta metav1.TypeMeta
+
+	// objectMeta defines the metadata of the resource that owns the
+	// deployment.
+	objectMeta metav1.ObjectMeta
+
+	// replicas defines the number of agents.
+	replicas int32
+
+	// deploymentMetadata defines additional labels and annotations for the
+	// deployment.
+	deploymentMetadata *metav1.ObjectMeta
+
+	// ownerLabel defines the label that contains the UID of the owner.
+	ownerLabel string
+
+	// podTemplateSpec defines the pod template provided by the user.
+	podTemplateSpec *corev1.PodTemplateSpec
+
+	// imageConfigs defines the image configs for the main container.
+	imageConfigs []fdbv1beta2.ImageConfig
+
+	// version defines the FDB version of the agents.
+	version string
+
+	// allowTagOverride defines if the image tag can be overridden.
+	allowTagOverride bool
+
+	// command defines the command of the main container.
+	command string
+
+	// args defines the arguments of the main container, the custom
+	// parameters are appended to them.
+	args []string
+
+	// customParameters defines the custom parameters for the agents.
+	customParameters fdbv1beta2.FoundationDBCustomParameters
+
+	// env defines the environment variables of the main container.
+	env []corev1.EnvVar
+
+	// volumeMounts defines additional volume mounts of the main container.
+	volumeMounts []corev1.VolumeMount
+
+	// configMapVolumeSource defines the source of the volume that provides the
+	// cluster files to the init container.
+	configMapVolumeSource corev1.VolumeSource
+
+	// volumes defines additional volumes of the pods.
+	volumes []corev1.Volume
+
+	// configureInitContainer sets up the init container.
+	configureInitContainer func(container *corev1.Container) error
+}
+
+// getAgentDeployment builds a deployment for backup or DR agents.
+func getAgentDeployment(options agentDeploymentOptions) (*appsv1.Deployment, error) {
+	if options.replicas == 0 {
 		return nil, nil
 	}
-	deploymentName := fmt.Sprintf("%s-backup-agents", backup.ObjectMeta.Name)
+
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace:   backup.ObjectMeta.Namespace,
-			Name:        deploymentName,
+			Namespace:   options.objectMeta.Namespace,
+			Name:        options.name,
 			Annotations: map[string]string{},
 			Labels:      map[string]string{},
 		},
 	}
-	deployment.Spec.Replicas = &agentCount
-	deployment.ObjectMeta.OwnerReferences = BuildOwnerReference(backup.TypeMeta, backup.ObjectMeta)
+	deployment.Spec.Replicas = &options.replicas
+	deployment.ObjectMeta.OwnerReferences = BuildOwnerReference(options.typeMeta, options.objectMeta)
 
-	if backup.Spec.BackupDeploymentMetadata != nil {
-		for key, value := range backup.Spec.BackupDeploymentMetadata.Labels {
+	if options.deploymentMetadata != nil {
+		for key, value := range options.deploymentMetadata.Labels {
 			deployment.ObjectMeta.Labels[key] = value
 		}
-		for key, value := range backup.Spec.BackupDeploymentMetadata.Annotations {
+		for key, value := range options.deploymentMetadata.Annotations {
 			deployment.ObjectMeta.Annotations[key] = value
 		}
 	}
-	deployment.ObjectMeta.Labels[fdbv1beta2.BackupDeploymentLabel] = string(backup.ObjectMeta.UID)
+	deployment.ObjectMeta.Labels[options.ownerLabel] = string(options.objectMeta.UID)
 
 	var podTemplate *corev1.PodTemplateSpec
-	if backup.Spec.PodTemplateSpec != nil {
-		podTemplate = backup.Spec.PodTemplateSpec.DeepCopy()
+	if options.podTemplateSpec != nil {
+		podTemplate = options.podTemplateSpec.DeepCopy()
 	} else {
 		podTemplate = &corev1.PodTemplateSpec{}
 	}
@@ -881,42 +1011,40 @@ func GetBackupDeployment(backup *fdbv1beta2.FoundationDBBackup) (*appsv1.Deploym
 		podTemplate.Spec.Containers = containers
 	}
 
-	if len(backup.Spec.MainContainer.ImageConfigs) == 0 {
-		backup.Spec.MainContainer.ImageConfigs = []fdbv1beta2.ImageConfig{
+	imageConfigs := options.imageConfigs
+	if len(imageConfigs) == 0 {
+		imageConfigs = []fdbv1beta2.ImageConfig{
 			{BaseImage: "foundationdb/foundationdb"},
 		}
 	}
 
-	image, err := GetImage(mainContainer.Image, backup.Spec.MainContainer.ImageConfigs, backup.Spec.Version, pointer.BoolDeref(backup.Spec.AllowTagOverride, false))
+	image, err := GetImage(mainContainer.Image, imageConfigs, options.version, options.allowTagOverride)
 	if err != nil {
 		return nil, err
 	}
 	mainContainer.Image = image
-	mainContainer.Command = []string{"backup_agent"}
-	args := []string{"--log", "--logdir", "/var/log/fdb-trace-logs"}
+	mainContainer.Command = []string{options.command}
+	args := append([]string{}, options.args...)
 
-	if len(backup.Spec.CustomParameters) > 0 {
-		err := backup.Spec.CustomParameters.ValidateCustomParameters()
+	if len(options.customParameters) > 0 {
+		err := options.customParameters.ValidateCustomParameters()
 		if err != nil {
 			return nil, err
 		}
 
-		for _, customParameter := range backup.Spec.CustomParameters {
+		for _, customParameter := range options.customParameters {
 			args = append(args, fmt.Sprintf("--%s", customParameter))
 		}
 	}
 
 	mainContainer.Args = args
-	if mainContainer.Env == nil {
-		mainContainer.Env = make([]corev1.EnvVar, 0, 1)
-	}
-
-	extendEnv(mainContainer, corev1.EnvVar{Name: "FDB_CLUSTER_FILE", Value: "/var/dynamic-conf/fdb.cluster"})
+	extendEnv(mainContainer, options.env...)
 
 	mainContainer.VolumeMounts = append(mainContainer.VolumeMounts,
 		corev1.VolumeMount{Name: "logs", MountPath: "/var/log/fdb-trace-logs"},
 		corev1.VolumeMount{Name: "dynamic-conf", MountPath: "/var/dynamic-conf"},
 	)
+	mainContainer.VolumeMounts = append(mainContainer.VolumeMounts, options.volumeMounts...)
 
 	if mainContainer.Resources.Requests == nil {
 		mainContainer.Resources.Requests = corev1.ResourceList{
@@ -934,7 +1062,7 @@ func GetBackupDeployment(backup *fdbv1beta2.FoundationDBBackup) (*appsv1.Deploym
 		initContainer = &podTemplate.Spec.InitContainers[0]
 	}
 
-	err = configureSidecarContainerForBackup(backup, initContainer)
+	err = options.configureInitContainer(initContainer)
 	if err != nil {
 		return nil, err
 	}
@@ -950,16 +1078,9 @@ func GetBackupDeployment(backup *fdbv1beta2.FoundationDBBackup) (*appsv1.Deploym
 	podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes,
 		corev1.Volume{Name: "logs", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
 		corev1.Volume{Name: "dynamic-conf", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
-		corev1.Volume{
-			Name: "config-map",
-			VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
-				LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf("%s-config", backup.Spec.ClusterName)},
-				Items: []corev1.KeyToPath{
-					{Key: ClusterFileKey, Path: "fdb.cluster"},
-				},
-			}},
-		},
+		corev1.Volume{Name: "config-map", VolumeSource: options.configMapVolumeSource},
 	)
+	podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes, options.volumes...)
 
 	deployment.Spec.Template = *podTemplate
 
@@ -973,6 +1094,109 @@ func GetBackupDeployment(backup *fdbv1beta2.FoundationDBBackup) (*appsv1.Deploym
 	return deployment, nil
 }
 
+// GetBackupDeployment builds a deployment for backup agents for a cluster.
+func GetBackupDeployment(backup *fdbv1beta2.FoundationDBBackup) (*appsv1.Deployment, error) {
+	options := agentDeploymentOptions{
+		name:               fmt.Sprintf("%s-backup-agents", backup.ObjectMeta.Name),
+		typeMeta:           backup.TypeMeta,
+		objectMeta:         backup.ObjectMeta,
+		replicas:           int32(backup.GetDesiredAgentCount()),
+		deploymentMetadata: backup.Spec.BackupDeploymentMetadata,
+		ownerLabel:         fdbv1beta2.BackupDeploymentLabel,
+		podTemplateSpec:    backup.Spec.PodTemplateSpec,
+		imageConfigs:       backup.Spec.MainContainer.ImageConfigs,
+		version:            backup.Spec.Version,
+		allowTagOverride:   pointer.BoolDeref(backup.Spec.AllowTagOverride, false),
+		command:            "backup_agent",
+		args:               []string{"--log", "--logdir", "/var/log/fdb-trace-logs"},
+		customParameters:   backup.Spec.CustomParameters,
+		env:                []corev1.EnvVar{{Name: "FDB_CLUSTER_FILE", Value: "/var/dynamic-conf/fdb.cluster"}},
+		configMapVolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf("%s-config", backup.Spec.ClusterName)},
+			Items: []corev1.KeyToPath{
+				{Key: ClusterFileKey, Path: "fdb.cluster"},
+			},
+		}},
+		configureInitContainer: func(container *corev1.Container) error {
+			return configureSidecarContainerForBackup(backup, container)
+		},
+	}
+
+	if backup.Spec.FileSystemConfiguration != nil {
+		options.volumeMounts = []corev1.VolumeMount{
+			{Name: "backup-data", MountPath: backup.Spec.FileSystemConfiguration.GetMountPath()},
+		}
+		options.volumes = []corev1.Volume{
+			{
+				Name: "backup-data",
+				VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: backup.Spec.FileSystemConfiguration.PersistentVolumeClaimName,
+				}},
+			},
+		}
+	}
+
+	return getAgentDeployment(options)
+}
+
+// drSourceClusterFile defines the name of the cluster file of the source
+// cluster in the DR agent pods.
+const drSourceClusterFile = "source.cluster"
+
+// GetDRAgentDeploymentName returns the name of the deployment for the DR
+// agents.
+func GetDRAgentDeploymentName(dr *fdbv1beta2.FoundationDBDisasterRecovery) string {
+	return fmt.Sprintf("%s-dr-agents", dr.ObjectMeta.Name)
+}
+
+// GetDRAgentDeployment builds a deployment for the DR agents of a DR. The DR
+// agents are connected to the source and the destination cluster.
+func GetDRAgentDeployment(dr *fdbv1beta2.FoundationDBDisasterRecovery) (*appsv1.Deployment, error) {
+	return getAgentDeployment(agentDeploymentOptions{
+		name:               GetDRAgentDeploymentName(dr),
+		typeMeta:           dr.TypeMeta,
+		objectMeta:         dr.ObjectMeta,
+		replicas:           int32(dr.GetDesiredAgentCount()),
+		deploymentMetadata: dr.Spec.AgentDeploymentMetadata,
+		ownerLabel:         fdbv1beta2.DRDeploymentLabel,
+		podTemplateSpec:    dr.Spec.PodTemplateSpec,
+		imageConfigs:       dr.Spec.MainContainer.ImageConfigs,
+		version:            dr.Spec.Version,
+		command:            "dr_agent",
+		args: []string{
+			"--log", "--logdir", "/var/log/fdb-trace-logs",
+			"-d", "/var/dynamic-conf/fdb.cluster",
+			"-s", "/var/dynamic-conf/" + drSourceClusterFile,
+		},
+		customParameters: dr.Spec.CustomParameters,
+		// The config map volume combines the cluster files of the destination
+		// and the source cluster.
+		configMapVolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+			Sources: []corev1.VolumeProjection{
+				{
+					ConfigMap: &corev1.ConfigMapProjection{
+						LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf("%s-config", dr.Spec.DestinationClusterName)},
+						Items: []corev1.KeyToPath{
+							{Key: ClusterFileKey, Path: "fdb.cluster"},
+						},
+					},
+				},
+				{
+					ConfigMap: &corev1.ConfigMapProjection{
+						LocalObjectReference: corev1.LocalObjectReference{Name: fmt.Sprintf("%s-config", dr.Spec.SourceClusterName)},
+						Items: []corev1.KeyToPath{
+							{Key: ClusterFileKey, Path: drSourceClusterFile},
+						},
+					},
+				},
+			},
+		}},
+		configureInitContainer: func(container *corev1.Container) error {
+			return configureSidecarContainerForDR(dr, container)
+		},
+	})
+}
+
 // GetStorageServersPerPodForPod returns the value of STORAGE_SERVERS_PER_POD from the sidecar or 1
 func GetStorageServersPerPodForPod(pod *corev1.Pod) (int, error) {
 	// If not specified we will default to 1
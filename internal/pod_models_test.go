@@ -1714,6 +1714,85 @@ var _ = Describe("pod_models", func() {
 			})
 		})
 
+		When("using the three_data_hall redundancy mode", func() {
+			BeforeEach(func() {
+				cluster.Spec.DatabaseConfiguration.RedundancyMode = fdbv1beta2.RedundancyModeThreeDataHall
+				cluster.Spec.DataHall = "az1"
+				cluster.Spec.FaultDomain = fdbv1beta2.FoundationDBClusterFaultDomain{}
+				spec, err = GetPodSpec(cluster, fdbv1beta2.ProcessClassStorage, 1)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should require the nodes in the data hall", func() {
+				Expect(spec.Affinity.NodeAffinity).To(Equal(&corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+						NodeSelectorTerms: []corev1.NodeSelectorTerm{
+							{
+								MatchExpressions: []corev1.NodeSelectorRequirement{
+									{
+										Key:      corev1.LabelTopologyZone,
+										Operator: corev1.NodeSelectorOpIn,
+										Values:   []string{"az1"},
+									},
+								},
+							},
+						},
+					},
+				}))
+			})
+
+			When("a custom data hall node label and a node affinity are defined", func() {
+				BeforeEach(func() {
+					cluster.Spec.DataHallNodeLabel = "example.com/data-hall"
+					generalSettings := cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral]
+					generalSettings.PodTemplate.Spec.Affinity = &corev1.Affinity{
+						NodeAffinity: &corev1.NodeAffinity{
+							RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+								NodeSelectorTerms: []corev1.NodeSelectorTerm{
+									{
+										MatchExpressions: []corev1.NodeSelectorRequirement{
+											{
+												Key:      "example.com/pool",
+												Operator: corev1.NodeSelectorOpIn,
+												Values:   []string{"fdb"},
+											},
+										},
+									},
+								},
+							},
+						},
+					}
+					cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral] = generalSettings
+					spec, err = GetPodSpec(cluster, fdbv1beta2.ProcessClassStorage, 1)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("should add the data hall to the node selector terms", func() {
+					Expect(spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(Equal([]corev1.NodeSelectorTerm{
+						{
+							MatchExpressions: []corev1.NodeSelectorRequirement{
+								{
+									Key:      "example.com/pool",
+									Operator: corev1.NodeSelectorOpIn,
+									Values:   []string{"fdb"},
+								},
+								{
+									Key:      "example.com/data-hall",
+									Operator: corev1.NodeSelectorOpIn,
+									Values:   []string{"az1"},
+								},
+							},
+						},
+					}))
+				})
+			})
+
+			It("should spread the processes across the fault domains in the data hall", func() {
+				Expect(spec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(HaveLen(1))
+				Expect(spec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].PodAffinityTerm.TopologyKey).To(Equal(corev1.LabelHostname))
+			})
+		})
+
 		Context("with cross-Kubernetes replication", func() {
 			BeforeEach(func() {
 				cluster.Spec.FaultDomain = fdbv1beta2.FoundationDBClusterFaultDomain{
@@ -1999,6 +2078,75 @@ var _ = Describe("pod_models", func() {
 			})
 		})
 
+		Context("with a token for the sidecar API", func() {
+			BeforeEach(func() {
+				cluster.Spec.SidecarContainer.APITokenSecretName = pointer.String("sidecar-token")
+
+				spec, err = GetPodSpec(cluster, fdbv1beta2.ProcessClassStorage, 1)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("does not pass the token to the init container", func() {
+				initContainer := spec.InitContainers[0]
+				Expect(initContainer.Name).To(Equal(fdbv1beta2.InitContainerName))
+				for _, envVar := range initContainer.Env {
+					Expect(envVar.Name).NotTo(Equal("FDB_SIDECAR_API_TOKEN"))
+				}
+			})
+
+			It("passes the token from the secret to the sidecar", func() {
+				sidecarContainer := spec.Containers[1]
+				Expect(sidecarContainer.Name).To(Equal(fdbv1beta2.SidecarContainerName))
+				Expect(sidecarContainer.Env).To(ContainElement(corev1.EnvVar{
+					Name: "FDB_SIDECAR_API_TOKEN",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "sidecar-token"},
+							Key:                  "token",
+						},
+					},
+				}))
+			})
+		})
+
+		Context("with resource recommendations", func() {
+			BeforeEach(func() {
+				cluster.Spec.AutomationOptions.ResourceRecommendations.Enabled = pointer.Bool(true)
+				cluster.Status.ResourceRecommendations = []fdbv1beta2.ResourceRecommendation{
+					{
+						ProcessClass: fdbv1beta2.ProcessClassStorage,
+						CPU:          resource.NewMilliQuantity(500, resource.DecimalSI),
+						Memory:       resource.NewQuantity(2*1024*1024*1024, resource.BinarySI),
+					},
+				}
+			})
+
+			JustBeforeEach(func() {
+				spec, err = GetPodSpec(cluster, fdbv1beta2.ProcessClassStorage, 1)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("does not change the requests", func() {
+				mainContainer := spec.Containers[0]
+				Expect(mainContainer.Name).To(Equal(fdbv1beta2.MainContainerName))
+				Expect(*mainContainer.Resources.Requests.Cpu()).To(Equal(resource.MustParse("1")))
+				Expect(*mainContainer.Resources.Requests.Memory()).To(Equal(resource.MustParse("1Gi")))
+			})
+
+			When("the recommendations should be applied", func() {
+				BeforeEach(func() {
+					cluster.Spec.AutomationOptions.ResourceRecommendations.Apply = pointer.Bool(true)
+				})
+
+				It("does not change the requests", func() {
+					mainContainer := spec.Containers[0]
+					Expect(mainContainer.Name).To(Equal(fdbv1beta2.MainContainerName))
+					Expect(*mainContainer.Resources.Requests.Cpu()).To(Equal(resource.MustParse("1")))
+					Expect(*mainContainer.Resources.Requests.Memory()).To(Equal(resource.MustParse("1Gi")))
+				})
+			})
+		})
+
 		Context("with custom volumes", func() {
 			BeforeEach(func() {
 				cluster = CreateDefaultCluster()
@@ -3070,6 +3218,29 @@ var _ = Describe("pod_models", func() {
 			})
 		})
 
+		When("a file system destination is defined", func() {
+			BeforeEach(func() {
+				backup.Spec.BlobStoreConfiguration = nil
+				backup.Spec.FileSystemConfiguration = &fdbv1beta2.FileSystemConfiguration{
+					PersistentVolumeClaimName: "backup-pvc",
+				}
+				deployment, err = GetBackupDeployment(backup)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deployment).NotTo(BeNil())
+			})
+
+			It("should mount the persistent volume claim into the backup agents", func() {
+				container := deployment.Spec.Template.Spec.Containers[0]
+				Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: "backup-data", MountPath: "/var/fdb/backups"}))
+				Expect(deployment.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+					Name: "backup-data",
+					VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: "backup-pvc",
+					}},
+				}))
+			})
+		})
+
 		Context("with customParameters", func() {
 			BeforeEach(func() {
 				backup.Spec.CustomParameters = []fdbv1beta2.FoundationDBCustomParameter{"customParameter=1337"}
@@ -3189,6 +3360,22 @@ var _ = Describe("pod_models", func() {
 					},
 					versionString: "6.3.10",
 				}, true, "test/curImage:dev"),
+			Entry("image digest is set but not allowOverride",
+				testCase{
+					imageName: "test/curImage@sha256:abc",
+					imageConfigs: []fdbv1beta2.ImageConfig{
+						{BaseImage: "test/test"},
+					},
+					versionString: "6.3.10",
+				}, false, ""),
+			Entry("image digest is set and allowOverride",
+				testCase{
+					imageName: "test/curImage@sha256:abc",
+					imageConfigs: []fdbv1beta2.ImageConfig{
+						{BaseImage: "test/test"},
+					},
+					versionString: "6.3.10",
+				}, true, "test/curImage@sha256:abc"),
 		)
 
 		Context("Configure the sidecar image", func() {
@@ -3230,6 +3417,105 @@ var _ = Describe("pod_models", func() {
 		})
 	})
 
+	Describe("GetDRAgentDeployment", func() {
+		var dr *fdbv1beta2.FoundationDBDisasterRecovery
+		var deployment *appsv1.Deployment
+
+		BeforeEach(func() {
+			dr = &fdbv1beta2.FoundationDBDisasterRecovery{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "operator-test-dr",
+					Namespace: cluster.Namespace,
+					UID:       "dr-uid",
+				},
+				Spec: fdbv1beta2.FoundationDBDisasterRecoverySpec{
+					Version:                cluster.Spec.Version,
+					SourceClusterName:      "source",
+					DestinationClusterName: "destination",
+				},
+			}
+		})
+
+		When("using the default settings", func() {
+			BeforeEach(func() {
+				deployment, err = GetDRAgentDeployment(dr)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deployment).NotTo(BeNil())
+			})
+
+			It("should set the metadata and the replicas", func() {
+				Expect(deployment.ObjectMeta.Name).To(Equal("operator-test-dr-dr-agents"))
+				Expect(deployment.ObjectMeta.Labels).To(Equal(map[string]string{
+					fdbv1beta2.DRDeploymentLabel: "dr-uid",
+				}))
+				Expect(*deployment.Spec.Replicas).To(Equal(int32(2)))
+			})
+
+			It("should run the DR agent with the cluster files of both clusters", func() {
+				container := deployment.Spec.Template.Spec.Containers[0]
+				Expect(container.Image).To(Equal(fmt.Sprintf("foundationdb/foundationdb:%s", cluster.Spec.Version)))
+				Expect(container.Command).To(Equal([]string{"dr_agent"}))
+				Expect(container.Args).To(Equal([]string{
+					"--log",
+					"--logdir",
+					"/var/log/fdb-trace-logs",
+					"-d",
+					"/var/dynamic-conf/fdb.cluster",
+					"-s",
+					"/var/dynamic-conf/source.cluster",
+				}))
+			})
+
+			It("should copy both cluster files in the init container", func() {
+				Expect(deployment.Spec.Template.Spec.InitContainers[0].Args).To(Equal([]string{
+					"--copy-file",
+					"fdb.cluster",
+					"--require-not-empty",
+					"fdb.cluster",
+					"--init-mode",
+					"--copy-file",
+					"source.cluster",
+					"--require-not-empty",
+					"source.cluster",
+				}))
+			})
+
+			It("should combine the config maps of both clusters", func() {
+				Expect(deployment.Spec.Template.Spec.Volumes[2]).To(Equal(corev1.Volume{
+					Name: "config-map",
+					VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+						Sources: []corev1.VolumeProjection{
+							{
+								ConfigMap: &corev1.ConfigMapProjection{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "destination-config"},
+									Items:                []corev1.KeyToPath{{Key: ClusterFileKey, Path: "fdb.cluster"}},
+								},
+							},
+							{
+								ConfigMap: &corev1.ConfigMapProjection{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "source-config"},
+									Items:                []corev1.KeyToPath{{Key: ClusterFileKey, Path: "source.cluster"}},
+								},
+							},
+						},
+					}},
+				}))
+			})
+		})
+
+		When("the agent count is zero", func() {
+			BeforeEach(func() {
+				dr.Spec.AgentCount = pointer.Int(0)
+			})
+
+			It("should not create a deployment", func() {
+				deployment, err = GetDRAgentDeployment(dr)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deployment).To(BeNil())
+			})
+		})
+	})
+
 	Describe("GetStorageServersPerPodForPod", func() {
 		Context("when env var is set with 1", func() {
 			It("should return 1", func() {
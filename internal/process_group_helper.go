@@ -0,0 +1,107 @@
+/*
+ * process_group_helper.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"context"
+	"sort"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetProcessGroup builds the FoundationDBProcessGroup resource for the provided process group status.
+func GetProcessGroup(cluster *fdbv1beta2.FoundationDBCluster, processGroupStatus *fdbv1beta2.ProcessGroupStatus) *fdbv1beta2.FoundationDBProcessGroup {
+	metadata := GetObjectMetadata(cluster, nil, processGroupStatus.ProcessClass, processGroupStatus.ProcessGroupID)
+	metadata.Name = fdbv1beta2.GetProcessGroupResourceName(cluster.Name, processGroupStatus.ProcessGroupID)
+	metadata.OwnerReferences = BuildOwnerReference(cluster.TypeMeta, cluster.ObjectMeta)
+	// The resources are selected by the cluster name label and not by the match labels of the cluster, as changing the
+	// match labels would otherwise orphan the resources.
+	if metadata.Labels == nil {
+		metadata.Labels = map[string]string{}
+	}
+	metadata.Labels[fdbv1beta2.FDBClusterLabel] = cluster.Name
+
+	processGroup := &fdbv1beta2.FoundationDBProcessGroup{
+		ObjectMeta: metadata,
+		Spec: fdbv1beta2.FoundationDBProcessGroupSpec{
+			ClusterName: cluster.Name,
+		},
+	}
+	processGroup.SetProcessGroupStatus(processGroupStatus)
+
+	return processGroup
+}
+
+// GetProcessGroupResources returns all FoundationDBProcessGroup resources that belong to the cluster. The resources
+// are selected by the cluster name label, independent of the match labels of the cluster.
+func GetProcessGroupResources(ctx context.Context, reader client.Reader, cluster *fdbv1beta2.FoundationDBCluster) (map[fdbv1beta2.ProcessGroupID]*fdbv1beta2.FoundationDBProcessGroup, error) {
+	processGroupList := &fdbv1beta2.FoundationDBProcessGroupList{}
+	err := reader.List(ctx, processGroupList, client.InNamespace(cluster.Namespace), client.MatchingLabels{fdbv1beta2.FDBClusterLabel: cluster.Name})
+	if err != nil {
+		return nil, err
+	}
+
+	processGroups := make(map[fdbv1beta2.ProcessGroupID]*fdbv1beta2.FoundationDBProcessGroup, len(processGroupList.Items))
+	for idx, processGroup := range processGroupList.Items {
+		if processGroup.Spec.ClusterName != cluster.Name {
+			continue
+		}
+
+		processGroups[processGroup.Spec.ProcessGroupID] = &processGroupList.Items[idx]
+	}
+
+	return processGroups, nil
+}
+
+// LoadProcessGroups replaces the process groups in the cluster status with the process groups from the
+// FoundationDBProcessGroup resources. This must be called by every reader that gets the cluster from the API and
+// makes use of the process groups, as the operator doesn't write the process groups into the cluster status if
+// the cluster makes use of FoundationDBProcessGroup resources. If no FoundationDBProcessGroup resources exist the
+// process groups from the cluster status will be used. If the FoundationDBProcessGroup resources were disabled after
+// the migration, the process groups will be loaded from the resources until the status contains the process groups
+// again. Clusters that never made use of FoundationDBProcessGroup resources will not list the resources.
+func LoadProcessGroups(ctx context.Context, reader client.Reader, cluster *fdbv1beta2.FoundationDBCluster) error {
+	if !cluster.UseProcessGroupResources() && (!cluster.Status.HasProcessGroupResources || len(cluster.Status.ProcessGroups) > 0) {
+		return nil
+	}
+
+	processGroupResources, err := GetProcessGroupResources(ctx, reader, cluster)
+	if err != nil {
+		return err
+	}
+
+	if len(processGroupResources) == 0 {
+		return nil
+	}
+
+	processGroups := make([]*fdbv1beta2.ProcessGroupStatus, 0, len(processGroupResources))
+	for _, processGroup := range processGroupResources {
+		processGroups = append(processGroups, processGroup.GetProcessGroupStatus())
+	}
+
+	sort.SliceStable(processGroups, func(i, j int) bool {
+		return processGroups[i].ProcessGroupID < processGroups[j].ProcessGroupID
+	})
+	cluster.Status.ProcessGroups = processGroups
+
+	return nil
+}
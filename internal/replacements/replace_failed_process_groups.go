@@ -84,7 +84,14 @@ ProcessGroupLoop:
 
 		needsReplacement, missingTime := processGroupStatus.NeedsReplacement(cluster.GetFailureDetectionTimeSeconds())
 		if !needsReplacement {
-			continue
+			// Process groups running on a node that was tainted for longer than the defined duration are replaced
+			// without waiting for the failure detection time.
+			taintTime := processGroupStatus.GetConditionTime(fdbv1beta2.NodeTaintReplacing)
+			if taintTime == nil {
+				continue
+			}
+
+			missingTime = *taintTime
 		}
 
 		skipExclusion := false
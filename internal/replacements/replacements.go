@@ -209,6 +209,18 @@ func processGroupNeedsRemoval(cluster *fdbv1beta2.FoundationDBCluster, pod *core
 		}
 
 		if pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey] != specHash {
+			// If only the images have changed the Pod will be updated in place and doesn't require a replacement.
+			if cluster.GetUpdateImagesInPlace() {
+				imageUpdates, err := internal.GetImageUpdates(cluster, pod)
+				if err != nil {
+					return false, err
+				}
+
+				if imageUpdates != nil {
+					return false, nil
+				}
+			}
+
 			logger.Info("Replace process group",
 				"reason", fmt.Sprintf("specHash has changed from %s to %s", specHash, pod.ObjectMeta.Annotations[fdbv1beta2.LastSpecKey]))
 			return true, nil
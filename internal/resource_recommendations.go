@@ -0,0 +1,300 @@
+/*
+ * resource_recommendations.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// mebibyte is used to round up the memory recommendations.
+	mebibyte = 1024 * 1024
+
+	// resourceUsageSampleParts defines in how many parts the observation window is divided, every part is stored
+	// as a single sample.
+	resourceUsageSampleParts = 24
+)
+
+// GetResourceUsage returns the highest CPU and memory usage of a process group per process class. The usage of all
+// processes of a process group is summed up, as the resources are requested for the whole Pod.
+func GetResourceUsage(status *fdbv1beta2.FoundationDBStatus) map[fdbv1beta2.ProcessClass]corev1.ResourceList {
+	usage := map[fdbv1beta2.ProcessClass]corev1.ResourceList{}
+	if status == nil {
+		return usage
+	}
+
+	processGroupCPU := map[fdbv1beta2.ProcessGroupID]float64{}
+	processGroupMemory := map[fdbv1beta2.ProcessGroupID]int64{}
+	processGroupClasses := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.ProcessClass{}
+	for _, process := range status.Cluster.Processes {
+		processGroupID := fdbv1beta2.ProcessGroupID(process.Locality[fdbv1beta2.FDBLocalityInstanceIDKey])
+		if processGroupID == "" {
+			continue
+		}
+
+		processGroupCPU[processGroupID] += process.CPU.UsageCores
+		processGroupMemory[processGroupID] += int64(process.Memory.UsedBytes)
+		processGroupClasses[processGroupID] = process.ProcessClass
+	}
+
+	for processGroupID, processClass := range processGroupClasses {
+		cpu := resource.NewMilliQuantity(int64(math.Ceil(processGroupCPU[processGroupID]*1000)), resource.DecimalSI)
+		memory := resource.NewQuantity(processGroupMemory[processGroupID], resource.BinarySI)
+
+		current, ok := usage[processClass]
+		if !ok {
+			usage[processClass] = corev1.ResourceList{
+				corev1.ResourceCPU:    *cpu,
+				corev1.ResourceMemory: *memory,
+			}
+			continue
+		}
+
+		if cpu.Cmp(current[corev1.ResourceCPU]) > 0 {
+			current[corev1.ResourceCPU] = *cpu
+		}
+
+		if memory.Cmp(current[corev1.ResourceMemory]) > 0 {
+			current[corev1.ResourceMemory] = *memory
+		}
+	}
+
+	return usage
+}
+
+// UpdateResourceRecommendations adds the current resource usage from the machine-readable status to the samples of the
+// previous recommendations. The observation window is divided into resourceUsageSampleParts parts and every sample
+// keeps the highest usage of a Pod in its part, samples that are older than the window are removed. Once the usage
+// of a process class was observed for the whole window, a new recommendation is calculated from the highest sample.
+// The new recommendation is only used if it differs by at least the minimum change percentage from the current
+// recommendation, or the configured requests if no recommendation exists.
+func UpdateResourceRecommendations(cluster *fdbv1beta2.FoundationDBCluster, status *fdbv1beta2.FoundationDBStatus, previous []fdbv1beta2.ResourceRecommendation, now time.Time) []fdbv1beta2.ResourceRecommendation {
+	usage := GetResourceUsage(status)
+	recommendations := make(map[fdbv1beta2.ProcessClass]*fdbv1beta2.ResourceRecommendation, len(previous))
+	for _, recommendation := range previous {
+		recommendations[recommendation.ProcessClass] = recommendation.DeepCopy()
+	}
+
+	window := cluster.GetResourceRecommendationWindow()
+	sampleDuration := window / resourceUsageSampleParts
+	sampleStart := metav1.NewTime(now.Truncate(sampleDuration))
+	for processClass, resources := range usage {
+		recommendation, ok := recommendations[processClass]
+		if !ok {
+			recommendation = &fdbv1beta2.ResourceRecommendation{
+				ProcessClass:  processClass,
+				ObservedSince: metav1.NewTime(now),
+			}
+			recommendations[processClass] = recommendation
+		}
+
+		lastIndex := len(recommendation.Samples) - 1
+		if lastIndex < 0 || !recommendation.Samples[lastIndex].Timestamp.Equal(&sampleStart) {
+			recommendation.Samples = append(recommendation.Samples, fdbv1beta2.ResourceUsageSample{
+				Timestamp: sampleStart,
+			})
+			lastIndex++
+		}
+
+		sample := &recommendation.Samples[lastIndex]
+		if resources.Cpu().Cmp(sample.CPU) > 0 {
+			sample.CPU = *resources.Cpu()
+		}
+
+		if resources.Memory().Cmp(sample.Memory) > 0 {
+			sample.Memory = *resources.Memory()
+		}
+	}
+
+	headroom := int64(cluster.GetResourceRecommendationHeadroomPercentage())
+	minimumChange := int64(cluster.GetResourceRecommendationMinimumChangePercentage())
+	result := make([]fdbv1beta2.ResourceRecommendation, 0, len(recommendations))
+	for processClass, recommendation := range recommendations {
+		// Remove all samples that are completely outside of the window.
+		samples := make([]fdbv1beta2.ResourceUsageSample, 0, len(recommendation.Samples))
+		for _, sample := range recommendation.Samples {
+			if now.Sub(sample.Timestamp.Time) >= window+sampleDuration {
+				continue
+			}
+
+			samples = append(samples, sample)
+		}
+		recommendation.Samples = samples
+
+		if len(samples) == 0 || now.Sub(recommendation.ObservedSince.Time) < window {
+			result = append(result, *recommendation)
+			continue
+		}
+
+		var peakCPU, peakMemory resource.Quantity
+		for _, sample := range samples {
+			if sample.CPU.Cmp(peakCPU) > 0 {
+				peakCPU = sample.CPU
+			}
+
+			if sample.Memory.Cmp(peakMemory) > 0 {
+				peakMemory = sample.Memory
+			}
+		}
+
+		configuredRequests := getConfiguredRequests(cluster, processClass)
+		if !peakCPU.IsZero() {
+			cpu := resource.NewMilliQuantity(peakCPU.MilliValue()*(100+headroom)/100, resource.DecimalSI)
+			current := recommendation.CPU
+			if current == nil {
+				current = configuredRequests.Cpu()
+			}
+
+			if exceedsMinimumChange(cpu.MilliValue(), current.MilliValue(), minimumChange) {
+				recommendation.CPU = cpu
+			}
+		}
+
+		if !peakMemory.IsZero() {
+			memoryBytes := peakMemory.Value() * (100 + headroom) / 100
+			memory := resource.NewQuantity(int64(math.Ceil(float64(memoryBytes)/mebibyte))*mebibyte, resource.BinarySI)
+			current := recommendation.Memory
+			if current == nil {
+				current = configuredRequests.Memory()
+			}
+
+			if exceedsMinimumChange(memory.Value(), current.Value(), minimumChange) {
+				recommendation.Memory = memory
+			}
+		}
+
+		result = append(result, *recommendation)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ProcessClass < result[j].ProcessClass
+	})
+
+	return result
+}
+
+// GetRecommendedRequests returns the requests of the main container for the process class with the recommended
+// requests applied. The recommended requests are capped at the limits of the main container. If no recommendation
+// exists for the process class or the requests already match the recommendation, this will return nil. The provided
+// cluster must have a normalized spec.
+func GetRecommendedRequests(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass) corev1.ResourceList {
+	recommendation := cluster.Status.GetResourceRecommendation(processClass)
+	if recommendation == nil || (recommendation.CPU == nil && recommendation.Memory == nil) {
+		return nil
+	}
+
+	mainContainer := getMainContainer(cluster.GetProcessSettings(processClass).PodTemplate)
+	requests := corev1.ResourceList{}
+	for name, quantity := range mainContainer.Resources.Requests {
+		requests[name] = quantity.DeepCopy()
+	}
+
+	for name, recommended := range map[corev1.ResourceName]*resource.Quantity{
+		corev1.ResourceCPU:    recommendation.CPU,
+		corev1.ResourceMemory: recommendation.Memory,
+	} {
+		if recommended == nil {
+			continue
+		}
+
+		request := recommended.DeepCopy()
+		if limit, ok := mainContainer.Resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+			request = limit.DeepCopy()
+		}
+
+		requests[name] = request
+	}
+
+	if equality.Semantic.DeepEqual(requests, mainContainer.Resources.Requests) {
+		return nil
+	}
+
+	return requests
+}
+
+// SetMainContainerRequests sets the requests of the main container in the process settings of the process class. If
+// the process class has no own Pod template, the Pod template that is currently used for the process class will be
+// copied into the process settings of the process class.
+func SetMainContainerRequests(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass, requests corev1.ResourceList) {
+	if cluster.Spec.Processes == nil {
+		cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{}
+	}
+
+	settings := cluster.Spec.Processes[processClass]
+	if settings.PodTemplate == nil {
+		podTemplate := cluster.GetProcessSettings(processClass).PodTemplate
+		if podTemplate == nil {
+			podTemplate = &corev1.PodTemplateSpec{}
+		}
+
+		settings.PodTemplate = podTemplate.DeepCopy()
+	}
+
+	settings.PodTemplate.Spec.Containers, _ = ensureContainerPresent(settings.PodTemplate.Spec.Containers, fdbv1beta2.MainContainerName, 0)
+	settings.PodTemplate.Spec.Containers = customizeContainerFromList(settings.PodTemplate.Spec.Containers, fdbv1beta2.MainContainerName, func(container *corev1.Container) {
+		container.Resources.Requests = requests
+	})
+	cluster.Spec.Processes[processClass] = settings
+}
+
+// getConfiguredRequests returns the requests of the main container in the process settings of the process class.
+func getConfiguredRequests(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass) corev1.ResourceList {
+	return getMainContainer(cluster.GetProcessSettings(processClass).PodTemplate).Resources.Requests
+}
+
+// getMainContainer returns the main container of the Pod template or an empty container if the Pod template has no
+// main container.
+func getMainContainer(podTemplate *corev1.PodTemplateSpec) corev1.Container {
+	if podTemplate == nil {
+		return corev1.Container{}
+	}
+
+	for _, container := range podTemplate.Spec.Containers {
+		if container.Name == fdbv1beta2.MainContainerName {
+			return container
+		}
+	}
+
+	return corev1.Container{}
+}
+
+// exceedsMinimumChange returns true if the recommended value differs by at least the minimum change percentage from
+// the current value.
+func exceedsMinimumChange(recommended int64, current int64, minimumChange int64) bool {
+	if current == 0 {
+		return recommended != 0
+	}
+
+	difference := recommended - current
+	if difference < 0 {
+		difference = -difference
+	}
+
+	return difference*100 >= current*minimumChange
+}
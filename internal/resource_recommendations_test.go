@@ -0,0 +1,254 @@
+/*
+ * resource_recommendations_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package internal
+
+import (
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("resource_recommendations", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var status *fdbv1beta2.FoundationDBStatus
+	var now time.Time
+
+	newProcess := func(processClass fdbv1beta2.ProcessClass, processGroupID string, cores float64, memory int) fdbv1beta2.FoundationDBStatusProcessInfo {
+		return fdbv1beta2.FoundationDBStatusProcessInfo{
+			ProcessClass: processClass,
+			Locality: map[string]string{
+				fdbv1beta2.FDBLocalityInstanceIDKey: processGroupID,
+			},
+			CPU:    fdbv1beta2.FoundationDBStatusProcessCPUStatistics{UsageCores: cores},
+			Memory: fdbv1beta2.FoundationDBStatusProcessMemoryStatistics{UsedBytes: memory},
+		}
+	}
+
+	BeforeEach(func() {
+		cluster = CreateDefaultCluster()
+		Expect(NormalizeClusterSpec(cluster, DeprecationOptions{})).NotTo(HaveOccurred())
+		cluster.Spec.AutomationOptions.ResourceRecommendations.Enabled = pointer.Bool(true)
+		now = time.Now()
+
+		status = &fdbv1beta2.FoundationDBStatus{
+			Cluster: fdbv1beta2.FoundationDBStatusClusterInfo{
+				Processes: map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessInfo{
+					"1": newProcess(fdbv1beta2.ProcessClassStorage, "storage-1", 0.5, 512*1024*1024),
+					"2": newProcess(fdbv1beta2.ProcessClassStorage, "storage-1", 0.5, 512*1024*1024),
+					"3": newProcess(fdbv1beta2.ProcessClassStorage, "storage-2", 0.25, 256*1024*1024),
+					"4": newProcess(fdbv1beta2.ProcessClassLog, "log-1", 0.1, 100*1024*1024),
+				},
+			},
+		}
+	})
+
+	When("getting the resource usage", func() {
+		It("should return the highest usage of a process group per process class", func() {
+			usage := GetResourceUsage(status)
+			Expect(usage).To(HaveLen(2))
+			storageUsage := usage[fdbv1beta2.ProcessClassStorage]
+			Expect(storageUsage.Cpu().String()).To(Equal("1"))
+			Expect(storageUsage.Memory().String()).To(Equal("1Gi"))
+			logUsage := usage[fdbv1beta2.ProcessClassLog]
+			Expect(logUsage.Cpu().String()).To(Equal("100m"))
+			Expect(logUsage.Memory().String()).To(Equal("100Mi"))
+		})
+	})
+
+	When("updating the resource recommendations", func() {
+		var previous []fdbv1beta2.ResourceRecommendation
+		var recommendations []fdbv1beta2.ResourceRecommendation
+
+		JustBeforeEach(func() {
+			recommendations = UpdateResourceRecommendations(cluster, status, previous, now)
+		})
+
+		When("no previous recommendations exist", func() {
+			BeforeEach(func() {
+				previous = nil
+			})
+
+			It("should start observing every process class", func() {
+				Expect(recommendations).To(HaveLen(2))
+				Expect(recommendations[0].ProcessClass).To(Equal(fdbv1beta2.ProcessClassLog))
+				Expect(recommendations[1].ProcessClass).To(Equal(fdbv1beta2.ProcessClassStorage))
+				Expect(recommendations[1].ObservedSince.Time).To(Equal(now))
+				Expect(recommendations[1].Samples).To(HaveLen(1))
+				Expect(recommendations[1].Samples[0].Timestamp.Time).To(Equal(now.Truncate(time.Hour)))
+				Expect(recommendations[1].Samples[0].CPU.String()).To(Equal("1"))
+				Expect(recommendations[1].Samples[0].Memory.String()).To(Equal("1Gi"))
+				Expect(recommendations[1].CPU).To(BeNil())
+				Expect(recommendations[1].Memory).To(BeNil())
+			})
+		})
+
+		When("the usage was already sampled in the current part of the window", func() {
+			BeforeEach(func() {
+				previous = []fdbv1beta2.ResourceRecommendation{
+					{
+						ProcessClass:  fdbv1beta2.ProcessClassStorage,
+						ObservedSince: metav1.NewTime(now.Add(-1 * time.Hour)),
+						Samples: []fdbv1beta2.ResourceUsageSample{
+							{
+								Timestamp: metav1.NewTime(now.Truncate(time.Hour)),
+								CPU:       resource.MustParse("2"),
+								Memory:    resource.MustParse("512Mi"),
+							},
+						},
+					},
+				}
+			})
+
+			It("should keep the highest usage in the sample", func() {
+				Expect(recommendations).To(HaveLen(2))
+				Expect(recommendations[1].Samples).To(HaveLen(1))
+				Expect(recommendations[1].Samples[0].CPU.String()).To(Equal("2"))
+				Expect(recommendations[1].Samples[0].Memory.String()).To(Equal("1Gi"))
+				Expect(recommendations[1].CPU).To(BeNil())
+				Expect(recommendations[1].Memory).To(BeNil())
+			})
+		})
+
+		When("the usage was observed for the whole window", func() {
+			BeforeEach(func() {
+				previous = []fdbv1beta2.ResourceRecommendation{
+					{
+						ProcessClass:  fdbv1beta2.ProcessClassStorage,
+						ObservedSince: metav1.NewTime(now.Add(-30 * time.Hour)),
+						Samples: []fdbv1beta2.ResourceUsageSample{
+							{
+								Timestamp: metav1.NewTime(now.Add(-27 * time.Hour)),
+								CPU:       resource.MustParse("4"),
+								Memory:    resource.MustParse("4Gi"),
+							},
+							{
+								Timestamp: metav1.NewTime(now.Add(-12 * time.Hour)),
+								CPU:       resource.MustParse("2"),
+								Memory:    resource.MustParse("512Mi"),
+							},
+						},
+					},
+				}
+			})
+
+			It("should recommend the highest sample in the window with the headroom", func() {
+				Expect(recommendations).To(HaveLen(2))
+				Expect(recommendations[1].CPU.String()).To(Equal("2400m"))
+				Expect(recommendations[1].Memory.String()).To(Equal("1229Mi"))
+				Expect(recommendations[1].ObservedSince.Time).To(Equal(now.Add(-30 * time.Hour)))
+			})
+
+			It("should remove the samples outside of the window", func() {
+				Expect(recommendations[1].Samples).To(HaveLen(2))
+				Expect(recommendations[1].Samples[0].Timestamp.Time).To(Equal(now.Add(-12 * time.Hour)))
+				Expect(recommendations[1].Samples[1].Timestamp.Time).To(Equal(now.Truncate(time.Hour)))
+			})
+
+			When("the recommendation is close to the current recommendation", func() {
+				BeforeEach(func() {
+					previous[0].CPU = resource.NewMilliQuantity(2300, resource.DecimalSI)
+					previous[0].Memory = resource.NewQuantity(2*1024*1024*1024, resource.BinarySI)
+				})
+
+				It("should only update the recommendation that changed enough", func() {
+					Expect(recommendations[1].CPU.String()).To(Equal("2300m"))
+					Expect(recommendations[1].Memory.String()).To(Equal("1229Mi"))
+				})
+			})
+		})
+	})
+
+	When("getting the recommended requests", func() {
+		var requests corev1.ResourceList
+
+		BeforeEach(func() {
+			cluster.Status.ResourceRecommendations = []fdbv1beta2.ResourceRecommendation{
+				{
+					ProcessClass: fdbv1beta2.ProcessClassStorage,
+					CPU:          resource.NewMilliQuantity(500, resource.DecimalSI),
+					Memory:       resource.NewQuantity(2*1024*1024*1024, resource.BinarySI),
+				},
+			}
+		})
+
+		It("should return the recommended requests capped at the limits", func() {
+			requests = GetRecommendedRequests(cluster, fdbv1beta2.ProcessClassStorage)
+			Expect(requests.Cpu().String()).To(Equal("500m"))
+			Expect(requests.Memory().String()).To(Equal("1Gi"))
+		})
+
+		It("should return nil for process classes without a recommendation", func() {
+			Expect(GetRecommendedRequests(cluster, fdbv1beta2.ProcessClassLog)).To(BeNil())
+		})
+
+		When("the requests already match the recommendation", func() {
+			BeforeEach(func() {
+				cluster.Status.ResourceRecommendations[0].CPU = resource.NewQuantity(1, resource.DecimalSI)
+			})
+
+			It("should return nil", func() {
+				Expect(GetRecommendedRequests(cluster, fdbv1beta2.ProcessClassStorage)).To(BeNil())
+			})
+		})
+	})
+
+	When("setting the requests of the main container", func() {
+		BeforeEach(func() {
+			cluster = CreateDefaultCluster()
+			cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+				fdbv1beta2.ProcessClassGeneral: {
+					PodTemplate: &corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  fdbv1beta2.MainContainerName,
+									Image: "foundationdb/foundationdb",
+								},
+							},
+						},
+					},
+				},
+			}
+
+			SetMainContainerRequests(cluster, fdbv1beta2.ProcessClassStorage, corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("500m"),
+			})
+		})
+
+		It("should copy the Pod template of the general process class", func() {
+			podTemplate := cluster.Spec.Processes[fdbv1beta2.ProcessClassStorage].PodTemplate
+			Expect(podTemplate).NotTo(BeNil())
+			Expect(podTemplate.Spec.Containers).To(HaveLen(1))
+			Expect(podTemplate.Spec.Containers[0].Image).To(Equal("foundationdb/foundationdb"))
+			Expect(podTemplate.Spec.Containers[0].Resources.Requests.Cpu().String()).To(Equal("500m"))
+		})
+
+		It("should not change the general process class", func() {
+			Expect(cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate.Spec.Containers[0].Resources.Requests).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,227 @@
+/*
+ * cluster_webhook.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:path=/mutate-apps-foundationdb-org-v1beta2-foundationdbcluster,mutating=true,failurePolicy=fail,sideEffects=None,groups=apps.foundationdb.org,resources=foundationdbclusters,verbs=create;update,versions=v1beta2,name=mfoundationdbcluster.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-apps-foundationdb-org-v1beta2-foundationdbcluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=apps.foundationdb.org,resources=foundationdbclusters,verbs=create;update,versions=v1beta2,name=vfoundationdbcluster.kb.io,admissionReviewVersions=v1
+
+// ClusterWebhook applies the deprecation defaults to a FoundationDBCluster and rejects invalid cluster specs before
+// they are stored. The webhook uses the same checks that are run at the beginning of every reconciliation and some
+// additional checks that are only run during admission.
+type ClusterWebhook struct {
+	// DeprecationOptions defines how the deprecation defaults will be applied to the cluster spec.
+	DeprecationOptions internal.DeprecationOptions
+}
+
+// SetupWithManager registers the defaulting, validating and conversion webhook for the FoundationDBCluster.
+func (clusterWebhook *ClusterWebhook) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&fdbv1beta2.FoundationDBCluster{}).
+		WithDefaulter(clusterWebhook).
+		WithValidator(clusterWebhook).
+		Complete()
+}
+
+// Default applies the deprecation defaults to the cluster spec.
+func (clusterWebhook *ClusterWebhook) Default(_ context.Context, obj runtime.Object) error {
+	cluster, err := toCluster(obj)
+	if err != nil {
+		return err
+	}
+
+	internal.ApplyDeprecationDefaults(cluster, clusterWebhook.DeprecationOptions)
+
+	return nil
+}
+
+// ValidateCreate validates the cluster spec of a new cluster.
+func (clusterWebhook *ClusterWebhook) ValidateCreate(_ context.Context, obj runtime.Object) error {
+	cluster, err := toCluster(obj)
+	if err != nil {
+		return err
+	}
+
+	return clusterWebhook.validate(cluster, nil)
+}
+
+// ValidateUpdate validates the cluster spec of an updated cluster.
+func (clusterWebhook *ClusterWebhook) ValidateUpdate(_ context.Context, oldObj runtime.Object, newObj runtime.Object) error {
+	cluster, err := toCluster(newObj)
+	if err != nil {
+		return err
+	}
+
+	// Don't block the removal of finalizers when the cluster is being deleted.
+	if !cluster.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	oldCluster, err := toCluster(oldObj)
+	if err != nil {
+		return err
+	}
+
+	return clusterWebhook.validate(cluster, oldCluster)
+}
+
+// ValidateDelete allows the deletion of every cluster.
+func (clusterWebhook *ClusterWebhook) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+// validate runs the same checks on the normalized cluster spec that the cluster controller runs before the
+// sub-reconcilers and the additional checks that are only done during admission. If oldCluster is provided the
+// admission checks only reject issues that are not already present in the old cluster spec, so that clusters that
+// were created before those checks existed can still be updated.
+func (clusterWebhook *ClusterWebhook) validate(cluster *fdbv1beta2.FoundationDBCluster, oldCluster *fdbv1beta2.FoundationDBCluster) error {
+	normalized := cluster.DeepCopy()
+	err := internal.NormalizeClusterSpec(normalized, clusterWebhook.DeprecationOptions)
+	if err != nil {
+		return err
+	}
+
+	var validations []string
+	err = normalized.Validate()
+	if err != nil {
+		validations = append(validations, err.Error())
+	}
+
+	existingIssues := map[string]fdbv1beta2.None{}
+	if oldCluster != nil {
+		normalizedOld := oldCluster.DeepCopy()
+		err = internal.NormalizeClusterSpec(normalizedOld, clusterWebhook.DeprecationOptions)
+		if err == nil {
+			for _, issue := range getAdmissionIssues(normalizedOld) {
+				existingIssues[issue] = fdbv1beta2.None{}
+			}
+		}
+	}
+
+	for _, issue := range getAdmissionIssues(normalized) {
+		if _, ok := existingIssues[issue]; ok {
+			continue
+		}
+
+		validations = append(validations, issue)
+	}
+
+	if len(validations) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("ClusterSpec is not valid: %s", strings.Join(validations, ", "))
+}
+
+// getAdmissionIssues returns the issues of the cluster spec that are only checked during admission. Those checks are
+// not part of the cluster validation in the reconciler, as existing clusters would otherwise stop reconciling.
+func getAdmissionIssues(cluster *fdbv1beta2.FoundationDBCluster) []string {
+	var issues []string
+
+	version, err := fdbv1beta2.ParseFdbVersion(cluster.Spec.Version)
+	if err == nil && !version.IsSupported() {
+		issues = append(issues, fmt.Sprintf("version %s is not supported", cluster.Spec.Version))
+	}
+
+	// Check if the process counts can satisfy the redundancy mode. If the cluster is spread across multiple
+	// Kubernetes clusters, regions or data halls the process counts only represent a part of the processes.
+	redundancyMode := cluster.Spec.DatabaseConfiguration.NormalizeConfiguration().RedundancyMode
+	if cluster.Spec.FaultDomain.Key != fdbv1beta2.KubernetesClusterFaultDomainKey && len(cluster.Spec.DatabaseConfiguration.Regions) == 0 && redundancyMode != fdbv1beta2.RedundancyModeThreeDataHall {
+		minimumFaultDomains := fdbv1beta2.MinimumFaultDomains(redundancyMode)
+		processCounts := cluster.Spec.ProcessCounts.Map()
+		for _, processClass := range []fdbv1beta2.ProcessClass{fdbv1beta2.ProcessClassStorage, fdbv1beta2.ProcessClassLog} {
+			count := processCounts[processClass]
+			if count > 0 && count < minimumFaultDomains {
+				issues = append(issues, fmt.Sprintf("%d %s processes are not enough for redundancy mode %s, at least %d processes are required", count, processClass, redundancyMode, minimumFaultDomains))
+			}
+		}
+	}
+
+	// Check if any of the images contains a tag or a digest. The images are resolved in the same way as during the
+	// creation of the Pods, the operator doesn't allow to override the tag for clusters.
+	processClasses := make([]fdbv1beta2.ProcessClass, 0, len(cluster.Spec.Processes))
+	for processClass := range cluster.Spec.Processes {
+		processClasses = append(processClasses, processClass)
+	}
+	sort.Slice(processClasses, func(i, j int) bool {
+		return processClasses[i] < processClasses[j]
+	})
+
+	for _, processClass := range processClasses {
+		podTemplate := cluster.Spec.Processes[processClass].PodTemplate
+		if podTemplate == nil {
+			continue
+		}
+
+		containers := make([]corev1.Container, 0, len(podTemplate.Spec.InitContainers)+len(podTemplate.Spec.Containers))
+		containers = append(containers, podTemplate.Spec.InitContainers...)
+		containers = append(containers, podTemplate.Spec.Containers...)
+		for _, container := range containers {
+			if container.Name != fdbv1beta2.MainContainerName && container.Name != fdbv1beta2.SidecarContainerName && container.Name != fdbv1beta2.InitContainerName {
+				continue
+			}
+
+			_, err = internal.GetImage(container.Image, getImageConfigs(cluster, container.Name), cluster.Spec.Version, false)
+			if err != nil {
+				issues = append(issues, fmt.Sprintf("invalid image for container %s in process class %s: %s", container.Name, processClass, err.Error()))
+			}
+		}
+	}
+
+	return issues
+}
+
+// getImageConfigs returns the image configs that are used to resolve the image of the container.
+func getImageConfigs(cluster *fdbv1beta2.FoundationDBCluster, containerName string) []fdbv1beta2.ImageConfig {
+	if containerName == fdbv1beta2.MainContainerName || pointer.BoolDeref(cluster.Spec.UseUnifiedImage, false) {
+		return cluster.Spec.MainContainer.ImageConfigs
+	}
+
+	return cluster.Spec.SidecarContainer.ImageConfigs
+}
+
+// toCluster casts the object to a FoundationDBCluster.
+func toCluster(obj runtime.Object) (*fdbv1beta2.FoundationDBCluster, error) {
+	cluster, ok := obj.(*fdbv1beta2.FoundationDBCluster)
+	if !ok {
+		return nil, fmt.Errorf("expected a FoundationDBCluster but got %T", obj)
+	}
+
+	return cluster, nil
+}
+
+// Ensure that the webhook implements the admission interfaces.
+var _ admission.CustomDefaulter = &ClusterWebhook{}
+var _ admission.CustomValidator = &ClusterWebhook{}
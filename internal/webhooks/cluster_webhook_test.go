@@ -0,0 +1,234 @@
+/*
+ * cluster_webhook_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package webhooks
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("cluster_webhook", func() {
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var clusterWebhook *ClusterWebhook
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		clusterWebhook = &ClusterWebhook{}
+	})
+
+	When("applying the defaults", func() {
+		BeforeEach(func() {
+			Expect(clusterWebhook.Default(context.TODO(), cluster)).NotTo(HaveOccurred())
+		})
+
+		It("should set the default image configs", func() {
+			Expect(cluster.Spec.MainContainer.ImageConfigs).To(ConsistOf(fdbv1beta2.ImageConfig{BaseImage: "foundationdb/foundationdb"}))
+			Expect(cluster.Spec.SidecarContainer.ImageConfigs).To(ConsistOf(fdbv1beta2.ImageConfig{BaseImage: "foundationdb/foundationdb-kubernetes-sidecar", TagSuffix: "-1"}))
+		})
+
+		It("should not set the default resources", func() {
+			Expect(cluster.Spec.Processes[fdbv1beta2.ProcessClassGeneral].PodTemplate).To(BeNil())
+		})
+	})
+
+	When("validating a cluster", func() {
+		When("the cluster is valid", func() {
+			It("should accept the cluster", func() {
+				Expect(clusterWebhook.ValidateCreate(context.TODO(), cluster)).NotTo(HaveOccurred())
+				Expect(clusterWebhook.ValidateUpdate(context.TODO(), cluster, cluster)).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the version is not supported", func() {
+			BeforeEach(func() {
+				cluster.Spec.Version = "6.1.12"
+			})
+
+			It("should reject the cluster", func() {
+				Expect(clusterWebhook.ValidateCreate(context.TODO(), cluster)).To(MatchError("ClusterSpec is not valid: version 6.1.12 is not supported"))
+			})
+		})
+
+		When("an existing cluster with an unsupported version is updated", func() {
+			var oldCluster *fdbv1beta2.FoundationDBCluster
+
+			BeforeEach(func() {
+				cluster.Spec.Version = "6.1.12"
+				oldCluster = cluster.DeepCopy()
+				cluster.Spec.AutomationOptions.Replacements.Enabled = pointer.Bool(true)
+			})
+
+			It("should accept the update", func() {
+				Expect(clusterWebhook.ValidateUpdate(context.TODO(), oldCluster, cluster)).NotTo(HaveOccurred())
+			})
+
+			When("the version is changed to another unsupported version", func() {
+				BeforeEach(func() {
+					cluster.Spec.Version = "6.1.13"
+				})
+
+				It("should reject the update", func() {
+					Expect(clusterWebhook.ValidateUpdate(context.TODO(), oldCluster, cluster)).To(MatchError("ClusterSpec is not valid: version 6.1.13 is not supported"))
+				})
+			})
+		})
+
+		When("the storage engine is not supported by the version", func() {
+			BeforeEach(func() {
+				cluster.Spec.Version = "6.3.24"
+				cluster.Spec.DatabaseConfiguration.StorageEngine = fdbv1beta2.StorageEngineRocksDbV1
+			})
+
+			It("should reject the cluster", func() {
+				Expect(clusterWebhook.ValidateCreate(context.TODO(), cluster)).To(MatchError("ClusterSpec is not valid: storage engine ssd-rocksdb-v1 is not supported on version 6.3.24"))
+			})
+		})
+
+		When("the process counts are not sufficient for the redundancy mode", func() {
+			BeforeEach(func() {
+				cluster.Spec.DatabaseConfiguration.RedundancyMode = fdbv1beta2.RedundancyModeTriple
+				cluster.Spec.ProcessCounts.Storage = 2
+			})
+
+			It("should reject the cluster", func() {
+				Expect(clusterWebhook.ValidateCreate(context.TODO(), cluster)).To(MatchError("ClusterSpec is not valid: 2 storage processes are not enough for redundancy mode triple, at least 3 processes are required"))
+			})
+		})
+
+		When("the image of the main container contains a tag", func() {
+			BeforeEach(func() {
+				cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+					fdbv1beta2.ProcessClassGeneral: {
+						PodTemplate: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:  fdbv1beta2.MainContainerName,
+										Image: "foundationdb/foundationdb:7.1.26",
+									},
+								},
+							},
+						},
+					},
+				}
+			})
+
+			It("should reject the cluster", func() {
+				Expect(clusterWebhook.ValidateCreate(context.TODO(), cluster)).To(MatchError("ClusterSpec is not valid: invalid image for container foundationdb in process class general: image should not contain a tag but contains the tag \"7.1.26\", please remove the tag"))
+			})
+		})
+
+		When("the image of the sidecar container contains a digest", func() {
+			BeforeEach(func() {
+				cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+					fdbv1beta2.ProcessClassGeneral: {
+						PodTemplate: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:  fdbv1beta2.SidecarContainerName,
+										Image: "foundationdb/foundationdb-kubernetes-sidecar@sha256:abc",
+									},
+								},
+							},
+						},
+					},
+				}
+			})
+
+			It("should reject the cluster", func() {
+				Expect(clusterWebhook.ValidateCreate(context.TODO(), cluster)).To(MatchError("ClusterSpec is not valid: invalid image for container foundationdb-kubernetes-sidecar in process class general: image should not contain a digest but contains the digest \"sha256:abc\", please remove the digest"))
+			})
+		})
+
+		When("the cluster is spread across multiple Kubernetes clusters", func() {
+			BeforeEach(func() {
+				cluster.Spec.FaultDomain.Key = fdbv1beta2.KubernetesClusterFaultDomainKey
+				cluster.Spec.DatabaseConfiguration.RedundancyMode = fdbv1beta2.RedundancyModeTriple
+				cluster.Spec.ProcessCounts.Storage = 2
+			})
+
+			It("should accept the process counts", func() {
+				Expect(clusterWebhook.ValidateCreate(context.TODO(), cluster)).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the image of the main container is pulled from a registry with a port", func() {
+			BeforeEach(func() {
+				cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+					fdbv1beta2.ProcessClassGeneral: {
+						PodTemplate: &corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name:  fdbv1beta2.MainContainerName,
+										Image: "registry:5000/foundationdb/foundationdb",
+									},
+								},
+							},
+						},
+					},
+				}
+			})
+
+			It("should accept the cluster", func() {
+				Expect(clusterWebhook.ValidateCreate(context.TODO(), cluster)).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the custom parameters contain duplicates", func() {
+			BeforeEach(func() {
+				cluster.Spec.Processes = map[fdbv1beta2.ProcessClass]fdbv1beta2.ProcessSettings{
+					fdbv1beta2.ProcessClassGeneral: {
+						CustomParameters: fdbv1beta2.FoundationDBCustomParameters{
+							"knob_test=1",
+							"knob_test=2",
+						},
+					},
+				}
+			})
+
+			It("should reject the cluster", func() {
+				Expect(clusterWebhook.ValidateCreate(context.TODO(), cluster)).To(HaveOccurred())
+			})
+		})
+
+		When("an invalid cluster is being deleted", func() {
+			BeforeEach(func() {
+				cluster.Spec.Version = "6.1.12"
+				now := metav1.Now()
+				cluster.DeletionTimestamp = &now
+			})
+
+			It("should accept the update", func() {
+				Expect(clusterWebhook.ValidateUpdate(context.TODO(), cluster, cluster)).NotTo(HaveOccurred())
+				Expect(clusterWebhook.ValidateDelete(context.TODO(), cluster)).NotTo(HaveOccurred())
+			})
+		})
+	})
+})
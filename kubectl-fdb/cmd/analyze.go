@@ -249,6 +249,21 @@ func analyzeCluster(cmd *cobra.Command, kubeClient client.Client, cluster *fdbv1
 		printStatement(cmd, "Cluster is not reconciled", errorMessage)
 	}
 
+	// Check the progress of a version change
+	if upgrade := cluster.Status.Upgrade; upgrade != nil && upgrade.Phase != fdbv1beta2.UpgradePhaseDone {
+		statement := fmt.Sprintf("Cluster is being upgraded to %s, phase: %s", upgrade.TargetVersion, upgrade.Phase)
+		if upgrade.BlockedReason != "" {
+			foundIssues = true
+			printStatement(cmd, fmt.Sprintf("%s, blocked: %s", statement, upgrade.BlockedReason), errorMessage)
+		} else {
+			printStatement(cmd, statement, warnMessage)
+		}
+
+		for _, group := range upgrade.IncompatibleClients {
+			printStatement(cmd, fmt.Sprintf("Log group %s has %d clients that don't support version %s: %s", group.LogGroup, group.Count, upgrade.TargetVersion, strings.Join(group.Addresses, ", ")), errorMessage)
+		}
+	}
+
 	// We could add here more fields from cluster.Status.Generations and check if they are present.
 	var failedProcessGroups []string
 	processGroupMap := map[fdbv1beta2.ProcessGroupID]fdbv1beta2.None{}
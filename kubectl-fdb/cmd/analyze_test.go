@@ -203,6 +203,42 @@ var _ = Describe("[plugin] analyze cluster", func() {
 ✔ Cluster is available
 ✔ Cluster is fully replicated
 ✔ ProcessGroups are all in ready condition
+✔ Pods are all running and available`,
+					AutoFix:        false,
+					HasErrors:      true,
+					IgnoreRemovals: true,
+				}),
+			Entry("Cluster upgrade is blocked by incompatible clients",
+				testCase{
+					cluster: func() *fdbv1beta2.FoundationDBCluster {
+						cluster := getCluster(clusterName, namespace, true, true, true, 0, []*fdbv1beta2.ProcessGroupStatus{
+							{ProcessGroupID: "instance-1"},
+						})
+						cluster.Status.Upgrade = &fdbv1beta2.UpgradeStatus{
+							TargetVersion: "7.1.26",
+							Phase:         fdbv1beta2.UpgradePhasePrecheck,
+							IncompatibleClients: []fdbv1beta2.IncompatibleClientGroup{
+								{
+									LogGroup:  "app",
+									Addresses: []string{"10.1.1.1:4500", "10.1.1.2:4500"},
+									Count:     2,
+								},
+							},
+							BlockedReason: "2 clients do not support version 7.1.26",
+						}
+
+						return cluster
+					}(),
+					podList: getPodList(clusterName, namespace, corev1.PodStatus{
+						Phase: corev1.PodRunning,
+					}, nil),
+					ExpectedErrMsg: `✖ Cluster is not reconciled
+✖ Cluster is being upgraded to 7.1.26, phase: Precheck, blocked: 2 clients do not support version 7.1.26
+✖ Log group app has 2 clients that don't support version 7.1.26: 10.1.1.1:4500, 10.1.1.2:4500`,
+					ExpectedStdoutMsg: `Checking cluster: test/test
+✔ Cluster is available
+✔ Cluster is fully replicated
+✔ ProcessGroups are all in ready condition
 ✔ Pods are all running and available`,
 					AutoFix:        false,
 					HasErrors:      true,
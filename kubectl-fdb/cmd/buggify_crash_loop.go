@@ -112,7 +112,7 @@ func updateCrashLoopContainerList(kubeClient client.Client, clusterName string,
 		return err
 	}
 
-	processGroupIDs, err := getProcessGroupIDsFromPodName(cluster, pods)
+	processGroupIDs, err := getProcessGroupIDsFromPodName(kubeClient, cluster, pods)
 	if err != nil {
 		return err
 	}
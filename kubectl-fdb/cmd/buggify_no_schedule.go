@@ -109,7 +109,7 @@ func updateNoScheduleList(kubeClient client.Client, clusterName string, pods []s
 		return err
 	}
 
-	processGroupIDs, err := getProcessGroupIDsFromPodName(cluster, pods)
+	processGroupIDs, err := getProcessGroupIDsFromPodName(kubeClient, cluster, pods)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,139 @@
+/*
+ * failover.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	ctx "context"
+	"fmt"
+	"log"
+	"time"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newFailoverCmd(streams genericclioptions.IOStreams) *cobra.Command {
+	o := newFDBOptions(streams)
+
+	cmd := &cobra.Command{
+		Use:   "failover",
+		Short: "Creates a FoundationDBFailover to make the provided data center the primary data center of the given cluster",
+		Long:  "Creates a FoundationDBFailover to make the provided data center the primary data center of the given cluster. The operator will only change the priorities once the remote data centers have caught up.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			wait, err := cmd.Root().Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+			clusterName, err := cmd.Flags().GetString("fdb-cluster")
+			if err != nil {
+				return err
+			}
+			maximumLag, err := cmd.Flags().GetInt("maximum-lag")
+			if err != nil {
+				return err
+			}
+
+			kubeClient, err := getKubeClient(o)
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespace(*o.configFlags.Namespace)
+			if err != nil {
+				return err
+			}
+
+			failover, err := createFailover(kubeClient, clusterName, namespace, args[0], maximumLag, wait)
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Created failover %s, the progress can be checked with: kubectl -n %s get fdbfailover %s\n", failover.Name, namespace, failover.Name)
+
+			return nil
+		},
+		Example: `
+# Fail over cluster c1 in the current namespace to the data center dc2
+kubectl fdb failover -c c1 dc2
+
+# Fail over cluster c1 in the namespace default to the data center dc2
+kubectl fdb -n default failover -c c1 dc2
+
+# Fail over cluster c1 to the data center dc2 if the remote data centers are at most 10 seconds behind
+kubectl fdb failover -c c1 --maximum-lag 10 dc2
+`,
+	}
+	cmd.SetOut(o.Out)
+	cmd.SetErr(o.ErrOut)
+	cmd.SetIn(o.In)
+
+	cmd.Flags().StringP("fdb-cluster", "c", "", "fail over the provided cluster.")
+	cmd.Flags().Int("maximum-lag", 5, "maximum lag in seconds of the remote data centers before the failover is started.")
+	err := cmd.MarkFlagRequired("fdb-cluster")
+	if err != nil {
+		log.Fatal(err)
+	}
+	o.configFlags.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+// createFailover creates a FoundationDBFailover for the cluster with the provided data center as target.
+func createFailover(kubeClient client.Client, clusterName string, namespace string, targetDataCenter string, maximumLag int, wait bool) (*fdbv1beta2.FoundationDBFailover, error) {
+	cluster, err := loadCluster(kubeClient, namespace, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !cluster.Spec.DatabaseConfiguration.IsMainDataCenter(targetDataCenter) {
+		return nil, fmt.Errorf("data center %s is not a main data center of the regions of cluster %s", targetDataCenter, clusterName)
+	}
+
+	currentPrimary := cluster.Spec.DatabaseConfiguration.GetPrimaryDataCenter()
+	if currentPrimary == targetDataCenter {
+		return nil, fmt.Errorf("data center %s is already the primary data center of cluster %s", targetDataCenter, clusterName)
+	}
+
+	if wait {
+		confirmed := confirmAction(fmt.Sprintf("Fail over cluster %s/%s from %s to %s", namespace, clusterName, currentPrimary, targetDataCenter))
+		if !confirmed {
+			return nil, fmt.Errorf("user aborted the failover")
+		}
+	}
+
+	failover := &fdbv1beta2.FoundationDBFailover{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-failover-%d", clusterName, time.Now().Unix()),
+			Namespace: namespace,
+		},
+		Spec: fdbv1beta2.FoundationDBFailoverSpec{
+			ClusterName:                 clusterName,
+			TargetDataCenter:            targetDataCenter,
+			MaximumDataCenterLagSeconds: &maximumLag,
+		},
+	}
+
+	return failover, kubeClient.Create(ctx.Background(), failover)
+}
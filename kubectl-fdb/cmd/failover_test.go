@@ -0,0 +1,76 @@
+/*
+ * failover_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("[plugin] failover command", func() {
+	When("creating a failover", func() {
+		BeforeEach(func() {
+			cluster.Spec.DatabaseConfiguration = fdbv1beta2.DatabaseConfiguration{
+				UsableRegions: 2,
+				Regions: []fdbv1beta2.Region{
+					{
+						DataCenters: []fdbv1beta2.DataCenter{
+							{ID: "primary", Priority: 1},
+							{ID: "primary-sat", Priority: 1, Satellite: 1},
+						},
+					},
+					{
+						DataCenters: []fdbv1beta2.DataCenter{
+							{ID: "remote", Priority: 0},
+						},
+					},
+				},
+			}
+		})
+
+		It("should create a failover for a main data center", func() {
+			failover, err := createFailover(k8sClient, clusterName, namespace, "remote", 10, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			failovers := &fdbv1beta2.FoundationDBFailoverList{}
+			Expect(k8sClient.List(context.TODO(), failovers, client.InNamespace(namespace))).NotTo(HaveOccurred())
+			Expect(failovers.Items).To(HaveLen(1))
+			Expect(failovers.Items[0].Name).To(Equal(failover.Name))
+			Expect(failovers.Items[0].Spec.ClusterName).To(Equal(clusterName))
+			Expect(failovers.Items[0].Spec.TargetDataCenter).To(Equal("remote"))
+			Expect(failovers.Items[0].GetMaximumDataCenterLagSeconds()).To(Equal(10))
+		})
+
+		It("should return an error for a satellite", func() {
+			_, err := createFailover(k8sClient, clusterName, namespace, "primary-sat", 5, false)
+			Expect(err).To(MatchError("data center primary-sat is not a main data center of the regions of cluster test"))
+		})
+
+		It("should return an error for the current primary", func() {
+			_, err := createFailover(k8sClient, clusterName, namespace, "primary", 5, false)
+			Expect(err).To(MatchError("data center primary is already the primary data center of cluster test"))
+		})
+	})
+})
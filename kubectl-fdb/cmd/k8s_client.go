@@ -36,6 +36,8 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -93,6 +95,12 @@ func loadCluster(kubeClient client.Client, namespace string, clusterName string)
 	if err != nil {
 		return nil, err
 	}
+	// Installations without the FoundationDBProcessGroup CRD or without the permission to list those resources can't
+	// make use of them, so the process groups from the cluster status are used.
+	err = internal.LoadProcessGroups(ctx.Background(), kubeClient, cluster)
+	if err != nil && !k8serrors.IsNotFound(err) && !k8serrors.IsForbidden(err) && !meta.IsNoMatchError(err) {
+		return nil, err
+	}
 	return cluster, err
 }
 
@@ -201,8 +209,10 @@ func getAllPodsFromClusterWithCondition(kubeClient client.Client, clusterName st
 	return podNames, nil
 }
 
-// getProcessGroupIDsFromPodName returns the process group IDs based on the cluster configuration.
-func getProcessGroupIDsFromPodName(cluster *fdbv1beta2.FoundationDBCluster, podNames []string) ([]fdbv1beta2.ProcessGroupID, error) {
+// getProcessGroupIDsFromPodName returns the process group IDs based on the cluster configuration. If the Pod exists the
+// process group ID is read from the Pod labels, as the Pod name can differ from the name generated by the operator,
+// e.g. when the Pods are managed by StatefulSets.
+func getProcessGroupIDsFromPodName(kubeClient client.Client, cluster *fdbv1beta2.FoundationDBCluster, podNames []string) ([]fdbv1beta2.ProcessGroupID, error) {
 	processGroupIDs := make([]fdbv1beta2.ProcessGroupID, 0, len(podNames))
 
 	// TODO(johscheuer): We could validate if the provided process group is actually part of the cluster
@@ -215,6 +225,20 @@ func getProcessGroupIDsFromPodName(cluster *fdbv1beta2.FoundationDBCluster, podN
 			return nil, fmt.Errorf("cluster name %s is not set as prefix for Pod name %s, please ensure the specified Pod is part of the cluster", cluster.Name, podName)
 		}
 
+		pod := &corev1.Pod{}
+		err := kubeClient.Get(ctx.Background(), client.ObjectKey{Namespace: cluster.Namespace, Name: podName}, pod)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+
+		if err == nil {
+			processGroupID := internal.GetProcessGroupIDFromMeta(cluster, pod.ObjectMeta)
+			if processGroupID != "" {
+				processGroupIDs = append(processGroupIDs, processGroupID)
+				continue
+			}
+		}
+
 		processGroupIDs = append(processGroupIDs, internal.GetProcessGroupIDFromPodName(cluster, podName))
 	}
 
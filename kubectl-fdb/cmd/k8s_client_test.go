@@ -177,7 +177,7 @@ var _ = Describe("[plugin] using the Kubernetes client", func() {
 		When("the cluster doesn't have a prefix", func() {
 			DescribeTable("should get all process groups IDs",
 				func(podNames []string, expected []fdbv1beta2.ProcessGroupID) {
-					instances, err := getProcessGroupIDsFromPodName(cluster, podNames)
+					instances, err := getProcessGroupIDsFromPodName(k8sClient, cluster, podNames)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(instances).To(ContainElements(expected))
 					Expect(len(instances)).To(BeNumerically("==", len(expected)))
@@ -215,7 +215,7 @@ var _ = Describe("[plugin] using the Kubernetes client", func() {
 
 			DescribeTable("should get all process groups IDs",
 				func(podNames []string, expected []fdbv1beta2.ProcessGroupID) {
-					instances, err := getProcessGroupIDsFromPodName(cluster, podNames)
+					instances, err := getProcessGroupIDsFromPodName(k8sClient, cluster, podNames)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(instances).To(ContainElements(expected))
 					Expect(len(instances)).To(BeNumerically("==", len(expected)))
@@ -234,5 +234,26 @@ var _ = Describe("[plugin] using the Kubernetes client", func() {
 				),
 			)
 		})
+
+		When("the Pod name differs from the generated name", func() {
+			BeforeEach(func() {
+				pod := &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-storage-1-0",
+						Namespace: namespace,
+						Labels: map[string]string{
+							fdbv1beta2.FDBProcessGroupIDLabel: "storage-1",
+						},
+					},
+				}
+				Expect(k8sClient.Create(context.TODO(), pod)).NotTo(HaveOccurred())
+			})
+
+			It("should get the process group ID from the Pod labels", func() {
+				instances, err := getProcessGroupIDsFromPodName(k8sClient, cluster, []string{"test-storage-1-0", "test-storage-2"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(instances).To(ConsistOf(fdbv1beta2.ProcessGroupID("storage-1"), fdbv1beta2.ProcessGroupID("storage-2")))
+			})
+		})
 	})
 })
@@ -129,7 +129,7 @@ func replaceProcessGroups(kubeClient client.Client, clusterName string, ids []st
 	// In this case the user has Pod name specified
 	var processGroupIDs []fdbv1beta2.ProcessGroupID
 	if !useProcessGroupID {
-		processGroupIDs, err = getProcessGroupIDsFromPodName(cluster, ids)
+		processGroupIDs, err = getProcessGroupIDsFromPodName(kubeClient, cluster, ids)
 		if err != nil {
 			return err
 		}
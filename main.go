@@ -57,6 +57,13 @@ func main() {
 	logOpts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
+	operatorOpts.AdditionalReconcilers = setup.AdditionalReconcilers{
+		MultiRegionClusterReconciler: &controllers.FoundationDBMultiRegionClusterReconciler{},
+		FailoverReconciler:           &controllers.FoundationDBFailoverReconciler{},
+		DisasterRecoveryReconciler:   &controllers.FoundationDBDisasterRecoveryReconciler{},
+		AutoscalerReconciler:         &controllers.FoundationDBAutoscalerReconciler{},
+	}
+
 	mgr, file := setup.StartManager(
 		scheme,
 		operatorOpts,
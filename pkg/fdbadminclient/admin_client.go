@@ -69,29 +69,62 @@ type AdminClient interface {
 	// version of FDB.
 	GetProtocolVersion(version string) (string, error)
 
-	// StartBackup starts a new backup.
-	StartBackup(url string, snapshotPeriodSeconds int) error
+	// StartBackup starts a new backup with the provided tag.
+	StartBackup(tag string, url string, snapshotPeriodSeconds int) error
 
-	// StopBackup stops a backup.
-	StopBackup(url string) error
+	// StopBackup stops the backup with the provided tag.
+	StopBackup(tag string) error
 
-	// PauseBackups pauses the backups.
+	// PauseBackups pauses the backups. This affects the backups of all tags
+	// in the cluster, as the backup agents are paused for the whole cluster.
 	PauseBackups() error
 
-	// ResumeBackups resumes the backups.
+	// ResumeBackups resumes the backups. This affects the backups of all
+	// tags in the cluster.
 	ResumeBackups() error
 
-	// ModifyBackup modifies the configuration of the backup.
-	ModifyBackup(int) error
+	// ModifyBackup modifies the configuration of the backup with the
+	// provided tag.
+	ModifyBackup(tag string, snapshotPeriodSeconds int) error
 
-	// GetBackupStatus gets the status of the current backup.
-	GetBackupStatus() (*fdbv1beta2.FoundationDBLiveBackupStatus, error)
+	// GetBackupStatus gets the status of the backup with the provided tag.
+	GetBackupStatus(tag string) (*fdbv1beta2.FoundationDBLiveBackupStatus, error)
 
-	// StartRestore starts a new restore.
-	StartRestore(url string, keyRanges []fdbv1beta2.FoundationDBKeyRange) error
+	// DescribeBackup describes the data of a backup in the blob store.
+	DescribeBackup(url string) (*fdbv1beta2.FoundationDBBackupDescription, error)
+
+	// ExpireBackup deletes the data of a backup that is older than the
+	// provided version.
+	ExpireBackup(url string, expireBeforeVersion int64) error
+
+	// DeleteBackup deletes all data of a backup.
+	DeleteBackup(url string) error
+
+	// StartRestore starts a new restore. If a target version or a target
+	// timestamp is provided, the backup will be restored to that point in
+	// time, otherwise to the latest restorable version. A target timestamp
+	// requires the source cluster the backup was taken from.
+	StartRestore(url string, keyRanges []fdbv1beta2.FoundationDBKeyRange, targetVersion *int64, targetTimestamp *string, sourceCluster *fdbv1beta2.FoundationDBCluster) error
 
 	// GetRestoreStatus gets the status of the current restore.
-	GetRestoreStatus() (string, error)
+	GetRestoreStatus() (*fdbv1beta2.FoundationDBLiveRestoreStatus, error)
+
+	// StartDR starts a new DR with the provided tag from the source cluster
+	// into this cluster. This cluster will be locked while the DR is running.
+	StartDR(sourceCluster *fdbv1beta2.FoundationDBCluster, tag string) error
+
+	// StopDR aborts the DR with the provided tag from the source cluster into
+	// this cluster.
+	StopDR(sourceCluster *fdbv1beta2.FoundationDBCluster, tag string) error
+
+	// SwitchDR switches the DR with the provided tag, this cluster will
+	// become the source of the DR and the source cluster will become the
+	// destination.
+	SwitchDR(sourceCluster *fdbv1beta2.FoundationDBCluster, tag string) error
+
+	// GetDRStatus gets the status of the DR with the provided tag from the
+	// source cluster into this cluster.
+	GetDRStatus(sourceCluster *fdbv1beta2.FoundationDBCluster, tag string) (*fdbv1beta2.FoundationDBLiveDRStatus, error)
 
 	// Close shuts down any resources for the client once it is no longer
 	// needed.
@@ -36,6 +36,7 @@ import (
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/podmanager"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -62,8 +63,28 @@ type AdminClient struct {
 	MaxZoneFailuresWithoutLosingAvailability *int
 	MaintenanceZone                          string
 	restoreURL                               string
+	restoreStatus                            *fdbv1beta2.FoundationDBLiveRestoreStatus
+	RestoreTargetTimestamp                   *string
+	RestoreSourceCluster                     *fdbv1beta2.FoundationDBCluster
 	maintenanceZoneStartTimestamp            time.Time
 	uptimeSecondsForMaintenanceZone          float64
+	dataCenterLagSeconds                     float64
+	runningPrimaryDataCenter                 string
+	movingData                               fdbv1beta2.FoundationDBStatusMovingData
+	latencyProbe                             fdbv1beta2.FoundationDBStatusLatencyProbe
+	qos                                      fdbv1beta2.FoundationDBStatusQosInfo
+	storageRoles                             map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessRoleInfo
+	storageEngines                           map[fdbv1beta2.ProcessGroupID]fdbv1beta2.StorageEngine
+	backupDescriptions                       map[string]*fdbv1beta2.FoundationDBBackupDescription
+	backupsPaused                            bool
+	drs                                      map[string]mockDR
+	databaseUnavailable                      bool
+}
+
+// mockDR describes a DR into the cluster of the mock admin client.
+type mockDR struct {
+	sourceClusterName string
+	status            fdbv1beta2.FoundationDBLiveDRStatus
 }
 
 // adminClientCache provides a cache of mock admin clients.
@@ -82,6 +103,12 @@ func NewMockAdminClientUncast(cluster *fdbv1beta2.FoundationDBCluster, kubeClien
 	adminClientMutex.Lock()
 	defer adminClientMutex.Unlock()
 
+	return getCachedAdminClient(cluster, kubeClient), nil
+}
+
+// getCachedAdminClient returns the cached mock admin client for the cluster or
+// creates a new one. The caller must hold the adminClientMutex.
+func getCachedAdminClient(cluster *fdbv1beta2.FoundationDBCluster, kubeClient client.Client) *AdminClient {
 	cachedClient := adminClientCache[cluster.Name]
 
 	if cachedClient == nil {
@@ -101,11 +128,13 @@ func NewMockAdminClientUncast(cluster *fdbv1beta2.FoundationDBCluster, kubeClien
 		}
 		adminClientCache[cluster.Name] = cachedClient
 		cachedClient.Backups = make(map[string]fdbv1beta2.FoundationDBBackupStatusBackupDetails)
+		cachedClient.backupDescriptions = make(map[string]*fdbv1beta2.FoundationDBBackupDescription)
+		cachedClient.drs = make(map[string]mockDR)
 	} else {
 		cachedClient.Cluster = cluster.DeepCopy()
 	}
 
-	return cachedClient, nil
+	return cachedClient
 }
 
 // ClearMockAdminClients clears the cache of mock Admin clients
@@ -229,6 +258,23 @@ func (client *AdminClient) GetStatus() (*fdbv1beta2.FoundationDBStatus, error) {
 				}
 			}
 
+			storageRole, hasStorageRole := client.storageRoles[processGroupID]
+			if client.storageEngines != nil && pClass == fdbv1beta2.ProcessClassStorage {
+				if !hasStorageRole {
+					storageRole = fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+						Role: string(fdbv1beta2.ProcessRoleStorage),
+						ID:   string(processGroupID),
+					}
+					hasStorageRole = true
+				}
+
+				storageRole.StorageMetadata.StorageEngine = client.getStorageEngine(processGroupID)
+			}
+
+			if hasStorageRole && !excluded {
+				fdbRoles = append(fdbRoles, storageRole)
+			}
+
 			var uptimeSeconds float64 = 60000
 			if client.MaintenanceZone == pod.Name || client.MaintenanceZone == "simulation" {
 				if client.uptimeSecondsForMaintenanceZone != 0.0 {
@@ -342,8 +388,12 @@ func (client *AdminClient) GetStatus() (*fdbv1beta2.FoundationDBStatus, error) {
 		})
 	}
 
-	status.Client.DatabaseStatus.Available = true
-	status.Client.DatabaseStatus.Healthy = true
+	status.Client.DatabaseStatus.Available = !client.databaseUnavailable
+	status.Client.DatabaseStatus.Healthy = !client.databaseUnavailable
+	status.Cluster.RecoveryState.Name = "fully_recovered"
+	if client.databaseUnavailable {
+		status.Cluster.RecoveryState.Name = "recruiting_transaction_servers"
+	}
 
 	if client.DatabaseConfiguration == nil {
 		status.Cluster.Layers.Error = "configurationMissing"
@@ -351,6 +401,18 @@ func (client *AdminClient) GetStatus() (*fdbv1beta2.FoundationDBStatus, error) {
 		status.Cluster.DatabaseConfiguration = *client.DatabaseConfiguration
 	}
 
+	if client.runningPrimaryDataCenter != "" {
+		status.Cluster.Processes["mock-master"] = fdbv1beta2.FoundationDBStatusProcessInfo{
+			Locality: map[string]string{
+				fdbv1beta2.FDBLocalityDCIDKey: client.runningPrimaryDataCenter,
+			},
+			Roles: []fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+				{Role: string(fdbv1beta2.ProcessRoleMaster)},
+				{Role: string(fdbv1beta2.ProcessRoleClusterController)},
+			},
+		}
+	}
+
 	if status.Cluster.DatabaseConfiguration.LogSpill == 0 {
 		status.Cluster.DatabaseConfiguration.VersionFlags.LogSpill = 2
 	}
@@ -358,6 +420,7 @@ func (client *AdminClient) GetStatus() (*fdbv1beta2.FoundationDBStatus, error) {
 	status.Cluster.FullReplication = true
 	status.Cluster.Data.State.Healthy = true
 	status.Cluster.Data.State.Name = "healthy"
+	status.Cluster.Data.MovingData = client.movingData
 
 	if len(client.Backups) > 0 {
 		status.Cluster.Layers.Backup.Tags = make(map[string]fdbv1beta2.FoundationDBStatusBackupTag, len(client.Backups))
@@ -367,9 +430,9 @@ func (client *AdminClient) GetStatus() (*fdbv1beta2.FoundationDBStatus, error) {
 				RunningBackup:    tagStatus.Running,
 				Restorable:       true,
 			}
-			status.Cluster.Layers.Backup.Paused = tagStatus.Paused
 		}
 	}
+	status.Cluster.Layers.Backup.Paused = client.backupsPaused
 	faultToleranceSubtractor := 0
 	if client.MaintenanceZone != "" {
 		faultToleranceSubtractor = 1
@@ -382,6 +445,13 @@ func (client *AdminClient) GetStatus() (*fdbv1beta2.FoundationDBStatus, error) {
 		status.Cluster.FaultTolerance.MaxZoneFailuresWithoutLosingAvailability = client.Cluster.DesiredFaultTolerance() - faultToleranceSubtractor
 	}
 	status.Cluster.MaintenanceZone = client.MaintenanceZone
+	status.Cluster.DatacenterLag.Seconds = client.dataCenterLagSeconds
+	status.Cluster.LatencyProbe = client.latencyProbe
+	status.Cluster.Qos = client.qos
+	if status.Cluster.Qos.PerformanceLimitedBy.Name == "" {
+		status.Cluster.Qos.PerformanceLimitedBy.Name = "workload"
+	}
+
 	return status, nil
 }
 
@@ -507,12 +577,20 @@ func (client *AdminClient) KillProcesses(addresses []fdbv1beta2.ProcessAddress)
 
 	if client.Cluster.Status.RunningVersion != client.Cluster.Spec.Version {
 		// We have to do this in the mock client, in the real world the tryConnectionOptions in update_status,
-		// will update the version.
-		client.Cluster.Status.RunningVersion = client.Cluster.Spec.Version
-		err := client.KubeClient.Status().Update(context.TODO(), client.Cluster)
+		// will update the version. The latest version of the cluster is fetched to not overwrite status changes
+		// that were made after the admin client was created.
+		cluster := &fdbv1beta2.FoundationDBCluster{}
+		err := client.KubeClient.Get(context.TODO(), types.NamespacedName{Namespace: client.Cluster.Namespace, Name: client.Cluster.Name}, cluster)
 		if err != nil {
 			return err
 		}
+
+		cluster.Status.RunningVersion = client.Cluster.Spec.Version
+		err = client.KubeClient.Status().Update(context.TODO(), cluster)
+		if err != nil {
+			return err
+		}
+		client.Cluster.Status.RunningVersion = client.Cluster.Spec.Version
 	}
 
 	client.UnfreezeStatus()
@@ -571,13 +649,20 @@ func (client *AdminClient) GetProtocolVersion(version string) (string, error) {
 }
 
 // StartBackup starts a new backup.
-func (client *AdminClient) StartBackup(url string, snapshotPeriodSeconds int) error {
+func (client *AdminClient) StartBackup(tag string, url string, snapshotPeriodSeconds int) error {
 	adminClientMutex.Lock()
 	defer adminClientMutex.Unlock()
 
-	client.Backups["default"] = fdbv1beta2.FoundationDBBackupStatusBackupDetails{
+	// fdbbackup creates a new directory for every backup to a file system destination.
+	if strings.HasPrefix(url, "file://") {
+		url = fmt.Sprintf("%s/backup-%s", url, time.Now().UTC().Format("2006-01-02-15-04-05.000000"))
+	}
+
+	client.Backups[tag] = fdbv1beta2.FoundationDBBackupStatusBackupDetails{
+		Tag:                   tag,
 		URL:                   url,
 		Running:               true,
+		Paused:                client.backupsPaused,
 		SnapshotPeriodSeconds: snapshotPeriodSeconds,
 	}
 	return nil
@@ -588,6 +673,7 @@ func (client *AdminClient) PauseBackups() error {
 	adminClientMutex.Lock()
 	defer adminClientMutex.Unlock()
 
+	client.backupsPaused = true
 	for tag, backup := range client.Backups {
 		backup.Paused = true
 		client.Backups[tag] = backup
@@ -600,6 +686,7 @@ func (client *AdminClient) ResumeBackups() error {
 	adminClientMutex.Lock()
 	defer adminClientMutex.Unlock()
 
+	client.backupsPaused = false
 	for tag, backup := range client.Backups {
 		backup.Paused = false
 		client.Backups[tag] = backup
@@ -608,65 +695,251 @@ func (client *AdminClient) ResumeBackups() error {
 }
 
 // ModifyBackup reconfigures the backup.
-func (client *AdminClient) ModifyBackup(snapshotPeriodSeconds int) error {
+func (client *AdminClient) ModifyBackup(tag string, snapshotPeriodSeconds int) error {
 	adminClientMutex.Lock()
 	defer adminClientMutex.Unlock()
 
-	backup := client.Backups["default"]
+	backup, present := client.Backups[tag]
+	if !present {
+		return fmt.Errorf("no backup found for tag %s", tag)
+	}
+
 	backup.SnapshotPeriodSeconds = snapshotPeriodSeconds
-	client.Backups["default"] = backup
+	client.Backups[tag] = backup
 	return nil
 }
 
 // StopBackup stops a backup.
-func (client *AdminClient) StopBackup(url string) error {
+func (client *AdminClient) StopBackup(tag string) error {
 	adminClientMutex.Lock()
 	defer adminClientMutex.Unlock()
 
-	for tag, backup := range client.Backups {
-		if backup.URL == url {
-			backup.Running = false
-			client.Backups[tag] = backup
-			return nil
-		}
+	backup, present := client.Backups[tag]
+	if !present {
+		return fmt.Errorf("no backup found for tag %s", tag)
 	}
-	return fmt.Errorf("no backup found for URL %s", url)
+
+	backup.Running = false
+	client.Backups[tag] = backup
+	return nil
 }
 
 // GetBackupStatus gets the status of the current backup.
-func (client *AdminClient) GetBackupStatus() (*fdbv1beta2.FoundationDBLiveBackupStatus, error) {
+func (client *AdminClient) GetBackupStatus(tag string) (*fdbv1beta2.FoundationDBLiveBackupStatus, error) {
 	adminClientMutex.Lock()
 	defer adminClientMutex.Unlock()
 
-	status := &fdbv1beta2.FoundationDBLiveBackupStatus{}
+	status := &fdbv1beta2.FoundationDBLiveBackupStatus{
+		BackupAgentsPaused: client.backupsPaused,
+	}
 
-	tag := "default"
 	backup, present := client.Backups[tag]
 	if present {
 		status.DestinationURL = backup.URL
 		status.Status.Running = backup.Running
-		status.BackupAgentsPaused = backup.Paused
 		status.SnapshotIntervalSeconds = backup.SnapshotPeriodSeconds
 	}
 
 	return status, nil
 }
 
+// DescribeBackup describes the data of a backup in the blob store.
+func (client *AdminClient) DescribeBackup(url string) (*fdbv1beta2.FoundationDBBackupDescription, error) {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	description, present := client.backupDescriptions[url]
+	if !present {
+		return &fdbv1beta2.FoundationDBBackupDescription{URL: url}, nil
+	}
+
+	return description.DeepCopy(), nil
+}
+
+// ExpireBackup removes all snapshots that were started before the provided
+// version.
+func (client *AdminClient) ExpireBackup(url string, expireBeforeVersion int64) error {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	description, present := client.backupDescriptions[url]
+	if !present {
+		return fmt.Errorf("no backup found for URL %s", url)
+	}
+
+	snapshots := make([]fdbv1beta2.FoundationDBBackupDescriptionSnapshot, 0, len(description.Snapshots))
+	for _, snapshot := range description.Snapshots {
+		if snapshot.Start.Version < expireBeforeVersion {
+			continue
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+	description.Snapshots = snapshots
+
+	return nil
+}
+
+// DeleteBackup deletes all data of a backup.
+func (client *AdminClient) DeleteBackup(url string) error {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	delete(client.backupDescriptions, url)
+	return nil
+}
+
+// MockBackupDescription mocks the description of the backup data in the blob
+// store.
+func (client *AdminClient) MockBackupDescription(description *fdbv1beta2.FoundationDBBackupDescription) {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	client.backupDescriptions[description.URL] = description
+}
+
 // StartRestore starts a new restore.
-func (client *AdminClient) StartRestore(url string, _ []fdbv1beta2.FoundationDBKeyRange) error {
+func (client *AdminClient) StartRestore(url string, _ []fdbv1beta2.FoundationDBKeyRange, targetVersion *int64, targetTimestamp *string, sourceCluster *fdbv1beta2.FoundationDBCluster) error {
 	adminClientMutex.Lock()
 	defer adminClientMutex.Unlock()
 
 	client.restoreURL = url
+	client.restoreStatus = &fdbv1beta2.FoundationDBLiveRestoreStatus{
+		Tag:   "default",
+		State: "running",
+		URL:   url,
+	}
+	client.RestoreTargetTimestamp = targetTimestamp
+	client.RestoreSourceCluster = sourceCluster
+
+	if targetVersion != nil {
+		client.restoreStatus.TargetVersion = *targetVersion
+	} else if description, ok := client.backupDescriptions[url]; ok && description.MaxRestorableVersion != nil {
+		client.restoreStatus.TargetVersion = description.MaxRestorableVersion.Version
+	}
+
 	return nil
 }
 
 // GetRestoreStatus gets the status of the current restore.
-func (client *AdminClient) GetRestoreStatus() (string, error) {
+func (client *AdminClient) GetRestoreStatus() (*fdbv1beta2.FoundationDBLiveRestoreStatus, error) {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	if client.restoreStatus == nil {
+		return &fdbv1beta2.FoundationDBLiveRestoreStatus{}, nil
+	}
+
+	return client.restoreStatus.DeepCopy(), nil
+}
+
+// MockRestoreStatus mocks the status of the current restore.
+func (client *AdminClient) MockRestoreStatus(status *fdbv1beta2.FoundationDBLiveRestoreStatus) {
 	adminClientMutex.Lock()
 	defer adminClientMutex.Unlock()
 
-	return fmt.Sprintf("%s\n", client.restoreURL), nil
+	client.restoreStatus = status
+}
+
+// StartDR starts a new DR from the source cluster into this cluster.
+func (client *AdminClient) StartDR(sourceCluster *fdbv1beta2.FoundationDBCluster, tag string) error {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	dr, present := client.drs[tag]
+	if present && dr.status.IsRunning() {
+		return fmt.Errorf("a DR with tag %s is already running", tag)
+	}
+
+	client.drs[tag] = mockDR{
+		sourceClusterName: sourceCluster.Name,
+		status: fdbv1beta2.FoundationDBLiveDRStatus{
+			Tag:   tag,
+			Phase: fdbv1beta2.DRPhaseRunningDifferentially,
+		},
+	}
+
+	return nil
+}
+
+// StopDR aborts the DR from the source cluster into this cluster.
+func (client *AdminClient) StopDR(sourceCluster *fdbv1beta2.FoundationDBCluster, tag string) error {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	dr, present := client.drs[tag]
+	if !present || dr.sourceClusterName != sourceCluster.Name || !dr.status.IsRunning() {
+		return fmt.Errorf("no DR found for tag %s from cluster %s", tag, sourceCluster.Name)
+	}
+
+	dr.status.Phase = fdbv1beta2.DRPhaseAborted
+	dr.status.SecondsBehind = 0
+	client.drs[tag] = dr
+
+	return nil
+}
+
+// SwitchDR switches the DR, the DR will be moved to the mock admin client of
+// the source cluster.
+func (client *AdminClient) SwitchDR(sourceCluster *fdbv1beta2.FoundationDBCluster, tag string) error {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	dr, present := client.drs[tag]
+	if !present || dr.sourceClusterName != sourceCluster.Name || !dr.status.IsRunning() {
+		return fmt.Errorf("no DR found for tag %s from cluster %s", tag, sourceCluster.Name)
+	}
+
+	if dr.status.Phase != fdbv1beta2.DRPhaseRunningDifferentially {
+		return fmt.Errorf("the DR with tag %s is not a complete copy of the source cluster", tag)
+	}
+
+	delete(client.drs, tag)
+	sourceClient := getCachedAdminClient(sourceCluster, client.KubeClient)
+	sourceClient.drs[tag] = mockDR{
+		sourceClusterName: client.Cluster.Name,
+		status: fdbv1beta2.FoundationDBLiveDRStatus{
+			Tag:   tag,
+			Phase: fdbv1beta2.DRPhaseRunningDifferentially,
+		},
+	}
+
+	return nil
+}
+
+// GetDRStatus gets the status of the DR from the source cluster into this
+// cluster.
+func (client *AdminClient) GetDRStatus(sourceCluster *fdbv1beta2.FoundationDBCluster, tag string) (*fdbv1beta2.FoundationDBLiveDRStatus, error) {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	dr, present := client.drs[tag]
+	if !present || dr.sourceClusterName != sourceCluster.Name {
+		return &fdbv1beta2.FoundationDBLiveDRStatus{Tag: tag}, nil
+	}
+
+	return dr.status.DeepCopy(), nil
+}
+
+// MockDRStatus mocks the phase and the lag of a running DR into this
+// cluster.
+func (client *AdminClient) MockDRStatus(tag string, phase fdbv1beta2.DRPhase, secondsBehind float64) {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	dr := client.drs[tag]
+	dr.status.Tag = tag
+	dr.status.Phase = phase
+	dr.status.SecondsBehind = secondsBehind
+	client.drs[tag] = dr
+}
+
+// MockDatabaseUnavailable mocks the availability of the database that is reported in the status.
+func (client *AdminClient) MockDatabaseUnavailable(unavailable bool) {
+	adminClientMutex.Lock()
+	defer adminClientMutex.Unlock()
+
+	client.databaseUnavailable = unavailable
 }
 
 // MockClientVersion returns a mocked client version
@@ -796,3 +1069,69 @@ func (client *AdminClient) ResetMaintenanceMode() error {
 func (client *AdminClient) MockUptimeSecondsForMaintenanceZone(seconds float64) {
 	client.uptimeSecondsForMaintenanceZone = seconds
 }
+
+// MockDataCenterLag mocks the lag of the remote data centers in seconds.
+func (client *AdminClient) MockDataCenterLag(seconds float64) {
+	client.dataCenterLagSeconds = seconds
+}
+
+// MockRunningPrimaryDataCenter mocks the data center the database is running in. The status will report an additional
+// process in this data center that has the master and the cluster controller role.
+func (client *AdminClient) MockRunningPrimaryDataCenter(dataCenter string) {
+	client.runningPrimaryDataCenter = dataCenter
+}
+
+// MockStorageMetrics mocks the stored bytes and the queue size of the storage role of a process group.
+func (client *AdminClient) MockStorageMetrics(processGroupID fdbv1beta2.ProcessGroupID, storedBytes int, queueBytes int) {
+	if client.storageRoles == nil {
+		client.storageRoles = map[fdbv1beta2.ProcessGroupID]fdbv1beta2.FoundationDBStatusProcessRoleInfo{}
+	}
+
+	client.storageRoles[processGroupID] = fdbv1beta2.FoundationDBStatusProcessRoleInfo{
+		Role:         string(fdbv1beta2.ProcessRoleStorage),
+		ID:           string(processGroupID),
+		StoredBytes:  storedBytes,
+		InputBytes:   fdbv1beta2.FoundationDBStatusCounter{Counter: queueBytes},
+		DurableBytes: fdbv1beta2.FoundationDBStatusCounter{Counter: 0},
+	}
+}
+
+// MockStorageEngine mocks the storage engine that is reported by the storage role of a process group. Once a storage
+// engine is mocked, all other storage roles report the configured storage engine, like new storage servers would do.
+func (client *AdminClient) MockStorageEngine(processGroupID fdbv1beta2.ProcessGroupID, storageEngine fdbv1beta2.StorageEngine) {
+	if client.storageEngines == nil {
+		client.storageEngines = map[fdbv1beta2.ProcessGroupID]fdbv1beta2.StorageEngine{}
+	}
+
+	client.storageEngines[processGroupID] = storageEngine
+}
+
+// getStorageEngine returns the mocked storage engine of the process group or the configured storage engine.
+func (client *AdminClient) getStorageEngine(processGroupID fdbv1beta2.ProcessGroupID) fdbv1beta2.StorageEngine {
+	if storageEngine, ok := client.storageEngines[processGroupID]; ok {
+		return storageEngine
+	}
+
+	if client.DatabaseConfiguration == nil {
+		return client.Cluster.Spec.DatabaseConfiguration.StorageEngine
+	}
+
+	return client.DatabaseConfiguration.StorageEngine
+}
+
+// MockLatencyProbe mocks the commit latency in seconds measured by the latency probe.
+func (client *AdminClient) MockLatencyProbe(commitSeconds float64) {
+	client.latencyProbe.CommitSeconds = commitSeconds
+}
+
+// MockQos mocks the reason why the ratekeeper limits the transaction rate and the largest log server queue.
+func (client *AdminClient) MockQos(limitedBy string, worstQueueBytesLogServer int) {
+	client.qos.PerformanceLimitedBy.Name = limitedBy
+	client.qos.WorstQueueBytesLogServer = worstQueueBytesLogServer
+}
+
+// MockMovingData mocks the bytes that are moved by data distribution.
+func (client *AdminClient) MockMovingData(inFlightBytes int, inQueueBytes int) {
+	client.movingData.InFlightBytes = inFlightBytes
+	client.movingData.InQueueBytes = inQueueBytes
+}
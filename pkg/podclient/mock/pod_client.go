@@ -21,6 +21,10 @@
 package mock
 
 import (
+	"fmt"
+	"net"
+	"sync"
+
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
 	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/podclient"
@@ -29,6 +33,28 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+// restartedProcesses contains the process numbers that were restarted for every Pod.
+var restartedProcesses = map[string][]int{}
+
+// restartedProcessesMutex protects the restartedProcesses map.
+var restartedProcessesMutex sync.Mutex
+
+// GetRestartedProcesses returns the process numbers that were restarted in the provided Pod.
+func GetRestartedProcesses(pod *corev1.Pod) []int {
+	restartedProcessesMutex.Lock()
+	defer restartedProcessesMutex.Unlock()
+
+	return restartedProcesses[pod.Namespace+"/"+pod.Name]
+}
+
+// ClearRestartedProcesses clears the restarted processes of all Pods.
+func ClearRestartedProcesses() {
+	restartedProcessesMutex.Lock()
+	defer restartedProcessesMutex.Unlock()
+
+	restartedProcesses = map[string][]int{}
+}
+
 // FdbPodClient provides a mock connection to a pod
 type FdbPodClient struct {
 	Cluster *fdbv1beta2.FoundationDBCluster
@@ -56,3 +82,62 @@ func (client *FdbPodClient) IsPresent(_ string) (bool, error) {
 func (client *FdbPodClient) GetVariableSubstitutions() (map[string]string, error) {
 	return internal.GetSubstitutionsFromClusterAndPod(client.logger, client.Cluster, client.Pod)
 }
+
+// GetCapabilities returns the capabilities of the versioned sidecar API. The
+// mock client supports all capabilities.
+func (client *FdbPodClient) GetCapabilities() ([]podclient.Capability, error) {
+	return []podclient.Capability{
+		podclient.CapabilityProcessStatus,
+		podclient.CapabilityMonitorConfHash,
+		podclient.CapabilityRestartProcess,
+	}, nil
+}
+
+// GetProcessStatus returns the status of the fdbserver processes.
+func (client *FdbPodClient) GetProcessStatus() (*podclient.ProcessStatus, error) {
+	if _, ok := client.Pod.Annotations[internal.MockUnreachableAnnotation]; ok {
+		return nil, &net.OpError{Op: "mock", Err: fmt.Errorf("not reachable")}
+	}
+
+	processCount, err := internal.GetStorageServersPerPodForPod(client.Pod)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &podclient.ProcessStatus{
+		Version:   client.Cluster.GetRunningVersion(),
+		Processes: make([]podclient.ProcessState, 0, processCount),
+	}
+
+	for processNumber := 1; processNumber <= processCount; processNumber++ {
+		status.Processes = append(status.Processes, podclient.ProcessState{
+			ProcessNumber: processNumber,
+			Running:       true,
+		})
+	}
+
+	return status, nil
+}
+
+// GetMonitorConfHash returns the hash of the monitor conf that is currently
+// used. The mock client doesn't track the monitor conf, so an empty hash is
+// returned.
+func (client *FdbPodClient) GetMonitorConfHash() (string, error) {
+	return "", nil
+}
+
+// RestartProcess restarts the fdbserver process with the provided process
+// number.
+func (client *FdbPodClient) RestartProcess(processNumber int) error {
+	if _, ok := client.Pod.Annotations[internal.MockUnreachableAnnotation]; ok {
+		return &net.OpError{Op: "mock", Err: fmt.Errorf("not reachable")}
+	}
+
+	restartedProcessesMutex.Lock()
+	defer restartedProcessesMutex.Unlock()
+
+	key := client.Pod.Namespace + "/" + client.Pod.Name
+	restartedProcesses[key] = append(restartedProcesses[key], processNumber)
+
+	return nil
+}
@@ -20,6 +20,46 @@
 
 package podclient
 
+import "errors"
+
+// ErrCapabilityNotSupported is returned if the sidecar doesn't support the requested capability of the versioned API.
+var ErrCapabilityNotSupported = errors.New("capability is not supported by the sidecar")
+
+// Capability defines a feature of the versioned sidecar API.
+type Capability string
+
+const (
+	// CapabilityProcessStatus indicates that the sidecar can report the status of the fdbserver processes.
+	CapabilityProcessStatus Capability = "process_status"
+
+	// CapabilityMonitorConfHash indicates that the sidecar can report the hash of the current monitor conf.
+	CapabilityMonitorConfHash Capability = "monitor_conf_hash"
+
+	// CapabilityRestartProcess indicates that the sidecar can restart a single fdbserver process.
+	CapabilityRestartProcess Capability = "restart_process"
+)
+
+// ProcessStatus represents the status of the fdbserver processes reported by the sidecar.
+type ProcessStatus struct {
+	// Version of the fdbserver binary that is used by the processes.
+	Version string `json:"version,omitempty"`
+
+	// Processes contains the status of the fdbserver processes.
+	Processes []ProcessState `json:"processes,omitempty"`
+}
+
+// ProcessState represents the status of a single fdbserver process.
+type ProcessState struct {
+	// ProcessNumber defines the process number of the process inside the Pod, starting with 1.
+	ProcessNumber int `json:"processNumber"`
+
+	// Running indicates if the process is currently running.
+	Running bool `json:"running"`
+
+	// StartTimestamp defines the unix timestamp when the process was started.
+	StartTimestamp int64 `json:"startTimestamp,omitempty"`
+}
+
 // FdbPodClient provides methods for working with a FoundationDB pod
 type FdbPodClient interface {
 	// IsPresent checks whether a file is present.
@@ -31,4 +71,36 @@ type FdbPodClient interface {
 	// GetVariableSubstitutions gets the current keys and values that this
 	// process group will substitute into its monitor conf.
 	GetVariableSubstitutions() (map[string]string, error)
+
+	// GetCapabilities returns the capabilities of the versioned sidecar API.
+	// Sidecars that don't support the versioned API will return no
+	// capabilities.
+	GetCapabilities() ([]Capability, error)
+
+	// GetProcessStatus returns the status of the fdbserver processes.
+	GetProcessStatus() (*ProcessStatus, error)
+
+	// GetMonitorConfHash returns the hash of the monitor conf that is
+	// currently used.
+	GetMonitorConfHash() (string, error)
+
+	// RestartProcess restarts the fdbserver process with the provided
+	// process number.
+	RestartProcess(processNumber int) error
+}
+
+// HasCapability checks if the sidecar supports the provided capability.
+func HasCapability(client FdbPodClient, capability Capability) (bool, error) {
+	capabilities, err := client.GetCapabilities()
+	if err != nil {
+		return false, err
+	}
+
+	for _, supported := range capabilities {
+		if supported == capability {
+			return true, nil
+		}
+	}
+
+	return false, nil
 }
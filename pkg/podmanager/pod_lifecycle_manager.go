@@ -22,6 +22,7 @@ package podmanager
 
 import (
 	"context"
+	"strings"
 
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
@@ -144,11 +145,48 @@ func (manager StandardPodLifecycleManager) UpdateMetadata(ctx context.Context, r
 //
 // This does not need to check the metadata or the pod spec hash. This only
 // needs to check aspects of the rollout that are not available in the
-// PodIsUpdated metadata.
-func (manager StandardPodLifecycleManager) PodIsUpdated(context.Context, client.Client, *fdbv1beta2.FoundationDBCluster, *corev1.Pod) (bool, error) {
+// PodIsUpdated metadata. If the images of the containers are updated in place,
+// the Pod is only up to date once all containers are running with the image
+// defined in the Pod spec.
+func (manager StandardPodLifecycleManager) PodIsUpdated(_ context.Context, _ client.Client, cluster *fdbv1beta2.FoundationDBCluster, pod *corev1.Pod) (bool, error) {
+	// Container runtimes could report the image differently, so the images are only compared if they are updated
+	// in place.
+	if !cluster.GetUpdateImagesInPlace() {
+		return true, nil
+	}
+
+	for _, container := range pod.Spec.Containers {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != container.Name {
+				continue
+			}
+
+			if !imageMatches(container.Image, status.Image) {
+				return false, nil
+			}
+		}
+	}
+
 	return true, nil
 }
 
+// imageMatches checks if the image reported in the container status matches the image of the container spec. The
+// container runtime could report the image with the default registry or as image ID, the image ID can't be compared
+// and will be treated as matching.
+func imageMatches(image string, statusImage string) bool {
+	if statusImage == "" || statusImage == image || strings.HasPrefix(statusImage, "sha256:") {
+		return true
+	}
+
+	for _, prefix := range []string{"docker.io/", "docker.io/library/"} {
+		if strings.TrimPrefix(statusImage, prefix) == image {
+			return true
+		}
+	}
+
+	return false
+}
+
 // GetPodSpec provides an external interface for the internal GetPodSpec method
 // This is necessary for compatibility reasons.
 func GetPodSpec(cluster *fdbv1beta2.FoundationDBCluster, processClass fdbv1beta2.ProcessClass, idNum int) (*corev1.PodSpec, error) {
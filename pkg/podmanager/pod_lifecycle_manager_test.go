@@ -21,9 +21,13 @@
 package podmanager
 
 import (
+	"context"
+
 	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
 )
 
 var _ = Describe("pod_lifecycle_manager", func() {
@@ -68,4 +72,67 @@ var _ = Describe("pod_lifecycle_manager", func() {
 			fdbv1beta2.PodUpdateModeProcessGroup,
 		),
 	)
+
+	DescribeTable("checking if the Pod is updated",
+		func(statusImage string, updateImagesInPlace bool, expected bool) {
+			pod := &corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  fdbv1beta2.MainContainerName,
+							Image: "foundationdb/foundationdb:7.1.26",
+						},
+					},
+				},
+				Status: corev1.PodStatus{
+					ContainerStatuses: []corev1.ContainerStatus{
+						{
+							Name:  fdbv1beta2.MainContainerName,
+							Image: statusImage,
+						},
+					},
+				},
+			}
+
+			cluster := &fdbv1beta2.FoundationDBCluster{
+				Spec: fdbv1beta2.FoundationDBClusterSpec{
+					AutomationOptions: fdbv1beta2.FoundationDBClusterAutomationOptions{
+						UpdateImagesInPlace: pointer.Bool(updateImagesInPlace),
+					},
+				},
+			}
+
+			Expect(manager.PodIsUpdated(context.TODO(), nil, cluster, pod)).To(Equal(expected))
+		},
+		Entry("the container status has no image",
+			"",
+			true,
+			true,
+		),
+		Entry("the container status has the same image",
+			"foundationdb/foundationdb:7.1.26",
+			true,
+			true,
+		),
+		Entry("the container status has the image with the default registry",
+			"docker.io/foundationdb/foundationdb:7.1.26",
+			true,
+			true,
+		),
+		Entry("the container status has an image ID",
+			"sha256:0123456789abcdef",
+			true,
+			true,
+		),
+		Entry("the container status has the previous image",
+			"foundationdb/foundationdb:6.3.24",
+			true,
+			false,
+		),
+		Entry("the container status has the previous image and the images are not updated in place",
+			"foundationdb/foundationdb:6.3.24",
+			false,
+			true,
+		),
+	)
 })
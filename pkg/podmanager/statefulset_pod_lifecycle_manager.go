@@ -0,0 +1,344 @@
+/*
+ * statefulset_pod_lifecycle_manager.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package podmanager
+
+import (
+	"context"
+	"fmt"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// StandardPodLifecycleManagerName is the name of the StandardPodLifecycleManager that can be used to select the
+	// PodLifecycleManager.
+	StandardPodLifecycleManagerName = "Standard"
+
+	// StatefulSetPodLifecycleManagerName is the name of the StatefulSetPodLifecycleManager that can be used to select
+	// the PodLifecycleManager.
+	StatefulSetPodLifecycleManagerName = "StatefulSet"
+)
+
+// GetPodLifecycleManager returns the PodLifecycleManager for the provided name.
+func GetPodLifecycleManager(name string) (PodLifecycleManager, error) {
+	switch name {
+	case StandardPodLifecycleManagerName:
+		return StandardPodLifecycleManager{}, nil
+	case StatefulSetPodLifecycleManagerName:
+		return StatefulSetPodLifecycleManager{}, nil
+	}
+
+	return nil, fmt.Errorf("unknown pod lifecycle manager: %s, supported values are %s and %s", name, StandardPodLifecycleManagerName, StatefulSetPodLifecycleManagerName)
+}
+
+// StatefulSetPodLifecycleManager provides an implementation of PodLifecycleManager
+// that manages every Pod through a StatefulSet with a single replica.
+//
+// The StatefulSet has the same name as the Pod definition of the operator, so the
+// Pod created by the StatefulSet controller will have the suffix "-0". The
+// StatefulSets use the OnDelete update strategy, changes to the template will
+// only be applied when the operator updates the Pods.
+type StatefulSetPodLifecycleManager struct {
+	StandardPodLifecycleManager
+}
+
+// GetPods returns a list of Pods for FDB pods that have been
+// created.
+func (manager StatefulSetPodLifecycleManager) GetPods(ctx context.Context, r client.Client, cluster *fdbv1beta2.FoundationDBCluster, options ...client.ListOption) ([]*corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	err := r.List(ctx, pods, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	// The Pods are owned by the StatefulSets, so we have to check the owner references of the StatefulSets.
+	var ownedStatefulSets map[string]fdbv1beta2.None
+	if cluster.ShouldFilterOnOwnerReferences() {
+		statefulSets := &appsv1.StatefulSetList{}
+		err = r.List(ctx, statefulSets, options...)
+		if err != nil {
+			return nil, err
+		}
+
+		ownedStatefulSets = make(map[string]fdbv1beta2.None, len(statefulSets.Items))
+		for _, statefulSet := range statefulSets.Items {
+			for _, reference := range statefulSet.OwnerReferences {
+				if reference.UID == cluster.UID {
+					ownedStatefulSets[statefulSet.Name] = fdbv1beta2.None{}
+					break
+				}
+			}
+		}
+	}
+
+	resPods := make([]*corev1.Pod, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		if ownedStatefulSets != nil {
+			owner := metav1.GetControllerOf(&pod)
+			if owner == nil || owner.Kind != "StatefulSet" {
+				continue
+			}
+
+			if _, ok := ownedStatefulSets[owner.Name]; !ok {
+				continue
+			}
+		}
+
+		resPod := pod
+		resPods = append(resPods, &resPod)
+	}
+
+	return resPods, nil
+}
+
+// CreatePod creates a new StatefulSet based on a Pod definition
+func (manager StatefulSetPodLifecycleManager) CreatePod(ctx context.Context, r client.Client, pod *corev1.Pod) error {
+	cluster, err := getOwningCluster(ctx, r, pod)
+	if err != nil {
+		return err
+	}
+
+	// The StatefulSet controller will set the hostname to the name of the Pod, which is different from the name
+	// used for the DNS entries in the cluster file.
+	if cluster.UseDNSInClusterFile() {
+		return fmt.Errorf("cluster %s/%s uses DNS names in the cluster file, which is not supported by the StatefulSet pod lifecycle manager", cluster.Namespace, cluster.Name)
+	}
+
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            pod.Name,
+			Namespace:       pod.Namespace,
+			Labels:          pod.Labels,
+			OwnerReferences: pod.OwnerReferences,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: pointer.Int32(1),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: internal.GetPodMatchLabels(cluster, "", string(internal.GetProcessGroupIDFromMeta(cluster, pod.ObjectMeta))),
+			},
+			ServiceName:         pod.Spec.Subdomain,
+			PodManagementPolicy: appsv1.ParallelPodManagement,
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type: appsv1.OnDeleteStatefulSetStrategyType,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      pod.Labels,
+					Annotations: pod.Annotations,
+				},
+				Spec: getStatefulSetPodSpec(pod),
+			},
+		},
+	}
+
+	logr.FromContextOrDiscard(ctx).V(1).Info("Creating StatefulSet", "name", statefulSet.Name)
+	err = r.Create(ctx, statefulSet)
+	if err == nil || !k8serrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	// The StatefulSet exists but the Pod is missing, e.g. because the StatefulSet controller is recreating it, so we
+	// make sure that the Pod will be created with the latest spec.
+	existing := &appsv1.StatefulSet{}
+	err = r.Get(ctx, client.ObjectKeyFromObject(statefulSet), existing)
+	if err != nil {
+		return err
+	}
+
+	existing.Labels = statefulSet.Labels
+	existing.Spec.Template = statefulSet.Spec.Template
+
+	return r.Update(ctx, existing)
+}
+
+// DeletePod shuts down a Pod and deletes the StatefulSet that manages the Pod.
+func (manager StatefulSetPodLifecycleManager) DeletePod(ctx context.Context, r client.Client, pod *corev1.Pod) error {
+	statefulSet, err := getStatefulSetForPod(ctx, r, pod)
+	if err != nil {
+		return err
+	}
+
+	logger := logr.FromContextOrDiscard(ctx)
+	if statefulSet != nil {
+		logger.V(1).Info("Deleting StatefulSet", "name", statefulSet.Name)
+		err = r.Delete(ctx, statefulSet)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	logger.V(1).Info("Deleting pod", "name", pod.Name)
+	err = r.Delete(ctx, pod)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// UpdatePods updates a list of Pods to match the latest specs.
+//
+// The StatefulSets will be deleted together with the Pods and recreated by the
+// operator with the latest spec.
+func (manager StatefulSetPodLifecycleManager) UpdatePods(ctx context.Context, r client.Client, _ *fdbv1beta2.FoundationDBCluster, pods []*corev1.Pod, _ bool) error {
+	for _, pod := range pods {
+		err := manager.DeletePod(ctx, r, pod)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UpdateImageVersion updates a Pod container's image and the image in the template of the StatefulSet.
+func (manager StatefulSetPodLifecycleManager) UpdateImageVersion(ctx context.Context, r client.Client, cluster *fdbv1beta2.FoundationDBCluster, pod *corev1.Pod, containerIndex int, image string) error {
+	statefulSet, err := getStatefulSetForPod(ctx, r, pod)
+	if err != nil {
+		return err
+	}
+
+	if statefulSet != nil {
+		containerName := pod.Spec.Containers[containerIndex].Name
+		for idx, container := range statefulSet.Spec.Template.Spec.Containers {
+			if container.Name != containerName {
+				continue
+			}
+
+			statefulSet.Spec.Template.Spec.Containers[idx].Image = image
+		}
+
+		// The images of the init containers could be changed in the Pod spec together with the container images, so
+		// they are synced with the template.
+		for idx, container := range statefulSet.Spec.Template.Spec.InitContainers {
+			for _, podContainer := range pod.Spec.InitContainers {
+				if container.Name == podContainer.Name {
+					statefulSet.Spec.Template.Spec.InitContainers[idx].Image = podContainer.Image
+					break
+				}
+			}
+		}
+
+		err = r.Update(ctx, statefulSet)
+		if err != nil {
+			return err
+		}
+	}
+
+	return manager.StandardPodLifecycleManager.UpdateImageVersion(ctx, r, cluster, pod, containerIndex, image)
+}
+
+// UpdateMetadata updates a Pod's metadata and the metadata in the template of the StatefulSet.
+func (manager StatefulSetPodLifecycleManager) UpdateMetadata(ctx context.Context, r client.Client, cluster *fdbv1beta2.FoundationDBCluster, pod *corev1.Pod) error {
+	statefulSet, err := getStatefulSetForPod(ctx, r, pod)
+	if err != nil {
+		return err
+	}
+
+	if statefulSet != nil {
+		statefulSet.Labels = pod.Labels
+		statefulSet.Spec.Template.Labels = pod.Labels
+		statefulSet.Spec.Template.Annotations = pod.Annotations
+		err = r.Update(ctx, statefulSet)
+		if err != nil {
+			return err
+		}
+	}
+
+	return manager.StandardPodLifecycleManager.UpdateMetadata(ctx, r, cluster, pod)
+}
+
+// localityEnvironmentVariables contains the environment variables for the locality of the processes that can be
+// derived from the Pod name.
+var localityEnvironmentVariables = map[string]fdbv1beta2.None{
+	"FDB_MACHINE_ID": {},
+	"FDB_ZONE_ID":    {},
+}
+
+// getStatefulSetPodSpec returns the Pod spec for the template of the StatefulSet. The StatefulSet controller will add
+// the suffix "-0" to the Pod name, so the locality environment variables that are read from the Pod name are set to
+// the name generated by the operator. FDB_POD_NAME still references the actual Pod name, as it's used to access the
+// Pod through the Kubernetes API.
+func getStatefulSetPodSpec(pod *corev1.Pod) corev1.PodSpec {
+	spec := pod.Spec.DeepCopy()
+	for _, containers := range [][]corev1.Container{spec.InitContainers, spec.Containers} {
+		for containerIdx := range containers {
+			for envIdx, env := range containers[containerIdx].Env {
+				if _, ok := localityEnvironmentVariables[env.Name]; !ok {
+					continue
+				}
+
+				if env.ValueFrom == nil || env.ValueFrom.FieldRef == nil || env.ValueFrom.FieldRef.FieldPath != "metadata.name" {
+					continue
+				}
+
+				containers[containerIdx].Env[envIdx] = corev1.EnvVar{Name: env.Name, Value: pod.Name}
+			}
+		}
+	}
+
+	return *spec
+}
+
+// getStatefulSetForPod returns the StatefulSet that controls the Pod or nil if the Pod is not controlled by a
+// StatefulSet.
+func getStatefulSetForPod(ctx context.Context, r client.Client, pod *corev1.Pod) (*appsv1.StatefulSet, error) {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil || owner.Kind != "StatefulSet" {
+		return nil, nil
+	}
+
+	statefulSet := &appsv1.StatefulSet{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: owner.Name}, statefulSet)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return statefulSet, nil
+}
+
+// getOwningCluster returns the FoundationDBCluster that owns the Pod definition.
+func getOwningCluster(ctx context.Context, r client.Client, pod *corev1.Pod) (*fdbv1beta2.FoundationDBCluster, error) {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return nil, fmt.Errorf("pod %s/%s has no owner reference to a FoundationDBCluster", pod.Namespace, pod.Name)
+	}
+
+	cluster := &fdbv1beta2.FoundationDBCluster{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: owner.Name}, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return cluster, nil
+}
@@ -0,0 +1,267 @@
+/*
+ * statefulset_pod_lifecycle_manager_test.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2023 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package podmanager
+
+import (
+	"context"
+
+	fdbv1beta2 "github.com/FoundationDB/fdb-kubernetes-operator/api/v1beta2"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("statefulset_pod_lifecycle_manager", func() {
+	var manager StatefulSetPodLifecycleManager
+	var cluster *fdbv1beta2.FoundationDBCluster
+	var pod *corev1.Pod
+	var statefulSet *appsv1.StatefulSet
+
+	BeforeEach(func() {
+		cluster = internal.CreateDefaultCluster()
+		Expect(k8sClient.Create(context.TODO(), cluster)).NotTo(HaveOccurred())
+
+		Expect(internal.NormalizeClusterSpec(cluster, internal.DeprecationOptions{})).NotTo(HaveOccurred())
+
+		var err error
+		pod, err = internal.GetPod(cluster, fdbv1beta2.ProcessClassStorage, 1)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	When("creating a Pod", func() {
+		JustBeforeEach(func() {
+			Expect(manager.CreatePod(context.TODO(), k8sClient, pod)).NotTo(HaveOccurred())
+
+			statefulSet = &appsv1.StatefulSet{}
+			Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(pod), statefulSet)).NotTo(HaveOccurred())
+		})
+
+		It("should create a StatefulSet for the Pod", func() {
+			Expect(statefulSet.Spec.Replicas).To(Equal(pointer.Int32(1)))
+			Expect(statefulSet.Spec.UpdateStrategy.Type).To(Equal(appsv1.OnDeleteStatefulSetStrategyType))
+			Expect(statefulSet.Spec.Template.Spec.Containers).To(HaveLen(len(pod.Spec.Containers)))
+			Expect(statefulSet.Spec.Template.Spec.Volumes).To(Equal(pod.Spec.Volumes))
+			Expect(statefulSet.Spec.Template.Annotations).To(Equal(pod.Annotations))
+			Expect(statefulSet.OwnerReferences).To(Equal(pod.OwnerReferences))
+			Expect(statefulSet.Spec.Selector.MatchLabels).To(Equal(internal.GetPodMatchLabels(cluster, "", "storage-1")))
+			for key, value := range statefulSet.Spec.Selector.MatchLabels {
+				Expect(statefulSet.Spec.Template.Labels).To(HaveKeyWithValue(key, value))
+			}
+		})
+
+		It("should not create the Pod directly", func() {
+			pods := &corev1.PodList{}
+			Expect(k8sClient.List(context.TODO(), pods)).NotTo(HaveOccurred())
+			Expect(pods.Items).To(BeEmpty())
+		})
+
+		When("the fault domain is based on the Pod name", func() {
+			It("should set the locality to the name of the Pod definition", func() {
+				Expect(cluster.Spec.FaultDomain.Key).To(Equal(fdbv1beta2.NoneFaultDomainKey))
+
+				for _, container := range statefulSet.Spec.Template.Spec.Containers {
+					for _, env := range container.Env {
+						switch env.Name {
+						case "FDB_MACHINE_ID", "FDB_ZONE_ID":
+							Expect(env.ValueFrom).To(BeNil())
+							Expect(env.Value).To(Equal(pod.Name))
+						case "FDB_POD_NAME":
+							Expect(env.ValueFrom.FieldRef.FieldPath).To(Equal("metadata.name"))
+						}
+					}
+				}
+			})
+		})
+
+		When("the fault domain is not based on the Pod name", func() {
+			BeforeEach(func() {
+				cluster.Spec.FaultDomain = fdbv1beta2.FoundationDBClusterFaultDomain{}
+
+				var err error
+				pod, err = internal.GetPod(cluster, fdbv1beta2.ProcessClassStorage, 1)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("should use the Pod spec as template", func() {
+				Expect(statefulSet.Spec.Template.Spec).To(Equal(pod.Spec))
+			})
+		})
+
+		When("the StatefulSet already exists", func() {
+			JustBeforeEach(func() {
+				pod.Annotations[fdbv1beta2.LastSpecKey] = "updated"
+				Expect(manager.CreatePod(context.TODO(), k8sClient, pod)).NotTo(HaveOccurred())
+				Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(pod), statefulSet)).NotTo(HaveOccurred())
+			})
+
+			It("should update the template of the StatefulSet", func() {
+				Expect(statefulSet.Spec.Template.Annotations).To(HaveKeyWithValue(fdbv1beta2.LastSpecKey, "updated"))
+			})
+		})
+	})
+
+	When("the cluster uses DNS in the cluster file", func() {
+		BeforeEach(func() {
+			cluster.Spec.Routing.UseDNSInClusterFile = pointer.Bool(true)
+			Expect(k8sClient.Update(context.TODO(), cluster)).NotTo(HaveOccurred())
+		})
+
+		It("should return an error", func() {
+			Expect(manager.CreatePod(context.TODO(), k8sClient, pod)).To(HaveOccurred())
+		})
+	})
+
+	When("the StatefulSet controller created the Pod", func() {
+		var createdPod *corev1.Pod
+
+		BeforeEach(func() {
+			Expect(manager.CreatePod(context.TODO(), k8sClient, pod)).NotTo(HaveOccurred())
+
+			statefulSet = &appsv1.StatefulSet{}
+			Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(pod), statefulSet)).NotTo(HaveOccurred())
+
+			createdPod = &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        pod.Name + "-0",
+					Namespace:   pod.Namespace,
+					Labels:      statefulSet.Spec.Template.Labels,
+					Annotations: statefulSet.Spec.Template.Annotations,
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							APIVersion: "apps/v1",
+							Kind:       "StatefulSet",
+							Name:       statefulSet.Name,
+							UID:        statefulSet.UID,
+							Controller: pointer.Bool(true),
+						},
+					},
+				},
+				Spec: statefulSet.Spec.Template.Spec,
+			}
+			Expect(k8sClient.Create(context.TODO(), createdPod)).NotTo(HaveOccurred())
+
+			// A Pod that is not managed by any StatefulSet.
+			otherPod, err := internal.GetPod(cluster, fdbv1beta2.ProcessClassStorage, 2)
+			Expect(err).NotTo(HaveOccurred())
+			otherPod.OwnerReferences = nil
+			Expect(k8sClient.Create(context.TODO(), otherPod)).NotTo(HaveOccurred())
+		})
+
+		When("the Pods are not filtered by owner references", func() {
+			It("should return all Pods", func() {
+				pods, err := manager.GetPods(context.TODO(), k8sClient, cluster, internal.GetPodListOptions(cluster, "", "")...)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pods).To(HaveLen(2))
+			})
+		})
+
+		When("the Pods are filtered by owner references", func() {
+			BeforeEach(func() {
+				cluster.Spec.LabelConfig.FilterOnOwnerReferences = pointer.Bool(true)
+			})
+
+			It("should only return the Pods of the owned StatefulSets", func() {
+				pods, err := manager.GetPods(context.TODO(), k8sClient, cluster, internal.GetPodListOptions(cluster, "", "")...)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pods).To(HaveLen(1))
+				Expect(pods[0].Name).To(Equal(createdPod.Name))
+			})
+		})
+
+		When("deleting the Pod", func() {
+			BeforeEach(func() {
+				Expect(manager.DeletePod(context.TODO(), k8sClient, createdPod)).NotTo(HaveOccurred())
+			})
+
+			It("should delete the StatefulSet and the Pod", func() {
+				err := k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(statefulSet), &appsv1.StatefulSet{})
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+
+				err = k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(createdPod), &corev1.Pod{})
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+
+		When("updating the image of a container", func() {
+			BeforeEach(func() {
+				Expect(manager.UpdateImageVersion(context.TODO(), k8sClient, cluster, createdPod, 1, "foundationdb/foundationdb-kubernetes-sidecar:7.1.26-2")).NotTo(HaveOccurred())
+			})
+
+			It("should update the Pod and the StatefulSet template", func() {
+				Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(createdPod), createdPod)).NotTo(HaveOccurred())
+				Expect(createdPod.Spec.Containers[1].Image).To(Equal("foundationdb/foundationdb-kubernetes-sidecar:7.1.26-2"))
+
+				Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(statefulSet), statefulSet)).NotTo(HaveOccurred())
+				Expect(statefulSet.Spec.Template.Spec.Containers[1].Image).To(Equal("foundationdb/foundationdb-kubernetes-sidecar:7.1.26-2"))
+				Expect(statefulSet.Spec.Template.Spec.Containers[0].Image).To(Equal(createdPod.Spec.Containers[0].Image))
+			})
+		})
+
+		When("updating the metadata", func() {
+			BeforeEach(func() {
+				createdPod.Labels["foo"] = "bar"
+				Expect(manager.UpdateMetadata(context.TODO(), k8sClient, cluster, createdPod)).NotTo(HaveOccurred())
+			})
+
+			It("should update the Pod and the StatefulSet template", func() {
+				Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(createdPod), createdPod)).NotTo(HaveOccurred())
+				Expect(createdPod.Labels).To(HaveKeyWithValue("foo", "bar"))
+
+				Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(statefulSet), statefulSet)).NotTo(HaveOccurred())
+				Expect(statefulSet.Spec.Template.Labels).To(HaveKeyWithValue("foo", "bar"))
+			})
+		})
+	})
+
+	DescribeTable("getting the pod lifecycle manager",
+		func(name string, expected PodLifecycleManager, expectedErr bool) {
+			podLifecycleManager, err := GetPodLifecycleManager(name)
+			if expectedErr {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(podLifecycleManager).To(Equal(expected))
+		},
+		Entry("the standard pod lifecycle manager",
+			StandardPodLifecycleManagerName,
+			StandardPodLifecycleManager{},
+			false,
+		),
+		Entry("the StatefulSet pod lifecycle manager",
+			StatefulSetPodLifecycleManagerName,
+			StatefulSetPodLifecycleManager{},
+			false,
+		),
+		Entry("an unknown pod lifecycle manager",
+			"Unknown",
+			nil,
+			true,
+		),
+	)
+})
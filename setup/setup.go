@@ -38,6 +38,8 @@ import (
 	"github.com/FoundationDB/fdb-kubernetes-operator/controllers"
 	"github.com/FoundationDB/fdb-kubernetes-operator/fdbclient"
 	"github.com/FoundationDB/fdb-kubernetes-operator/internal"
+	"github.com/FoundationDB/fdb-kubernetes-operator/internal/webhooks"
+	"github.com/FoundationDB/fdb-kubernetes-operator/pkg/podmanager"
 	"gopkg.in/natefinch/lumberjack.v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -59,6 +61,9 @@ type Options struct {
 	EnableRestartIncompatibleProcesses bool
 	ServerSideApply                    bool
 	EnableRecoveryState                bool
+	EnableNodeWatch                    bool
+	PodLifecycleManager                string
+	EnableWebhooks                     bool
 	MetricsAddr                        string
 	LeaderElectionID                   string
 	LogFile                            string
@@ -66,6 +71,7 @@ type Options struct {
 	LabelSelector                      string
 	WatchNamespace                     string
 	CliTimeout                         int
+	BackupDataCliTimeout               int
 	MaxConcurrentReconciles            int
 	LogFileMaxSize                     int
 	LogFileMaxAge                      int
@@ -74,6 +80,16 @@ type Options struct {
 	GetTimeout                         time.Duration
 	PostTimeout                        time.Duration
 	DeprecationOptions                 internal.DeprecationOptions
+	AdditionalReconcilers              AdditionalReconcilers
+}
+
+// AdditionalReconcilers provides the reconcilers that will be started in addition to the cluster, backup and restore
+// reconcilers. Reconcilers that are nil will not be started.
+type AdditionalReconcilers struct {
+	MultiRegionClusterReconciler *controllers.FoundationDBMultiRegionClusterReconciler
+	FailoverReconciler           *controllers.FoundationDBFailoverReconciler
+	DisasterRecoveryReconciler   *controllers.FoundationDBDisasterRecoveryReconciler
+	AutoscalerReconciler         *controllers.FoundationDBAutoscalerReconciler
 }
 
 // BindFlags will parse the given flagset for the operator option flags
@@ -88,6 +104,7 @@ func (o *Options) BindFlags(fs *flag.FlagSet) {
 	)
 	fs.StringVar(&o.LogFile, "log-file", "", "The path to a file to write logs to.")
 	fs.IntVar(&o.CliTimeout, "cli-timeout", 10, "The timeout to use for CLI commands in seconds.")
+	fs.IntVar(&o.BackupDataCliTimeout, "backup-data-cli-timeout", 600, "The timeout to use for CLI commands that describe, expire or delete the data of a backup in seconds.")
 	fs.IntVar(&o.MaxConcurrentReconciles, "max-concurrent-reconciles", 1, "Defines the maximum number of concurrent reconciles for all controllers.")
 	fs.BoolVar(&o.CleanUpOldLogFile, "cleanup-old-cli-logs", true, "Defines if the operator should delete old fdbcli log files.")
 	fs.DurationVar(&o.LogFileMinAge, "log-file-min-age", 5*time.Minute, "Defines the minimum age of fdbcli log files before removing when \"--cleanup-old-cli-logs\" is set.")
@@ -105,6 +122,9 @@ func (o *Options) BindFlags(fs *flag.FlagSet) {
 	fs.BoolVar(&o.EnableRestartIncompatibleProcesses, "enable-restart-incompatible-processes", true, "This flag enables/disables in the operator to restart incompatible fdbserver processes.")
 	fs.BoolVar(&o.ServerSideApply, "server-side-apply", false, "This flag enables server side apply.")
 	fs.BoolVar(&o.EnableRecoveryState, "enable-recovery-state", true, "This flag enables the use of the recovery state for the minimum uptime between bounced if the FDB version supports it.")
+	fs.BoolVar(&o.EnableNodeWatch, "enable-node-watch", false, "This flag enables the operator to watch nodes for taint changes and to trigger a reconciliation of the clusters with Pods on a tainted node. The operator requires a ClusterRole to read nodes.")
+	fs.StringVar(&o.PodLifecycleManager, "pod-lifecycle-manager", "", "Defines the pod lifecycle manager that manages the FDB Pods, supported values are \"Standard\" and \"StatefulSet\". With \"StatefulSet\" the Pods are named after their StatefulSet with the suffix \"-0\". If unset the pod lifecycle manager of the cluster reconciler will be used.")
+	fs.BoolVar(&o.EnableWebhooks, "enable-webhooks", false, "This flag enables the conversion webhooks for the custom resources and the defaulting and validating webhook for the FoundationDBCluster. The webhook server listens on port 9443 and requires a TLS certificate in the default certificate directory.")
 }
 
 // StartManager will start the FoundationDB operator manager.
@@ -139,6 +159,7 @@ func StartManager(
 
 	setupLog := logger.WithName("setup")
 	fdbclient.DefaultCLITimeout = time.Duration(operatorOpts.CliTimeout) * time.Second
+	fdbclient.BackupDataCLITimeout = time.Duration(operatorOpts.BackupDataCliTimeout) * time.Second
 
 	options := ctrl.Options{
 		Scheme:             scheme,
@@ -183,6 +204,16 @@ func StartManager(
 		clusterReconciler.EnableRestartIncompatibleProcesses = operatorOpts.EnableRestartIncompatibleProcesses
 		clusterReconciler.ServerSideApply = operatorOpts.ServerSideApply
 		clusterReconciler.EnableRecoveryState = operatorOpts.EnableRecoveryState
+		clusterReconciler.EnableNodeWatch = operatorOpts.EnableNodeWatch
+
+		if operatorOpts.PodLifecycleManager != "" {
+			podLifecycleManager, err := podmanager.GetPodLifecycleManager(operatorOpts.PodLifecycleManager)
+			if err != nil {
+				setupLog.Error(err, "unable to select pod lifecycle manager")
+				os.Exit(1)
+			}
+			clusterReconciler.PodLifecycleManager = podLifecycleManager
+		}
 
 		if err := clusterReconciler.SetupWithManager(mgr, operatorOpts.MaxConcurrentReconciles, *labelSelector, watchedObjects...); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "FoundationDBCluster")
@@ -220,6 +251,80 @@ func StartManager(
 		}
 	}
 
+	multiRegionClusterReconciler := operatorOpts.AdditionalReconcilers.MultiRegionClusterReconciler
+	if multiRegionClusterReconciler != nil {
+		multiRegionClusterReconciler.Client = mgr.GetClient()
+		multiRegionClusterReconciler.Recorder = mgr.GetEventRecorderFor("foundationdbmultiregioncluster-controller")
+		multiRegionClusterReconciler.Log = logr.WithName("controllers").WithName("FoundationDBMultiRegionCluster")
+		multiRegionClusterReconciler.ServerSideApply = operatorOpts.ServerSideApply
+		multiRegionClusterReconciler.DeprecationOptions = operatorOpts.DeprecationOptions
+		multiRegionClusterReconciler.DatabaseClientProvider = fdbclient.NewDatabaseClientProvider(logger)
+
+		if err := multiRegionClusterReconciler.SetupWithManager(mgr, operatorOpts.MaxConcurrentReconciles, *labelSelector); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "FoundationDBMultiRegionCluster")
+			os.Exit(1)
+		}
+	}
+
+	failoverReconciler := operatorOpts.AdditionalReconcilers.FailoverReconciler
+	if failoverReconciler != nil {
+		failoverReconciler.Client = mgr.GetClient()
+		failoverReconciler.Recorder = mgr.GetEventRecorderFor("foundationdbfailover-controller")
+		failoverReconciler.DatabaseClientProvider = fdbclient.NewDatabaseClientProvider(logger)
+		failoverReconciler.Log = logr.WithName("controllers").WithName("FoundationDBFailover")
+		failoverReconciler.ServerSideApply = operatorOpts.ServerSideApply
+
+		if err := failoverReconciler.SetupWithManager(mgr, operatorOpts.MaxConcurrentReconciles, *labelSelector); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "FoundationDBFailover")
+			os.Exit(1)
+		}
+	}
+
+	disasterRecoveryReconciler := operatorOpts.AdditionalReconcilers.DisasterRecoveryReconciler
+	if disasterRecoveryReconciler != nil {
+		disasterRecoveryReconciler.Client = mgr.GetClient()
+		disasterRecoveryReconciler.Recorder = mgr.GetEventRecorderFor("foundationdbdisasterrecovery-controller")
+		disasterRecoveryReconciler.DatabaseClientProvider = fdbclient.NewDatabaseClientProvider(logger)
+		disasterRecoveryReconciler.Log = logr.WithName("controllers").WithName("FoundationDBDisasterRecovery")
+		disasterRecoveryReconciler.ServerSideApply = operatorOpts.ServerSideApply
+
+		if err := disasterRecoveryReconciler.SetupWithManager(mgr, operatorOpts.MaxConcurrentReconciles, *labelSelector); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "FoundationDBDisasterRecovery")
+			os.Exit(1)
+		}
+	}
+
+	autoscalerReconciler := operatorOpts.AdditionalReconcilers.AutoscalerReconciler
+	if autoscalerReconciler != nil {
+		autoscalerReconciler.Client = mgr.GetClient()
+		autoscalerReconciler.Recorder = mgr.GetEventRecorderFor("foundationdbautoscaler-controller")
+		autoscalerReconciler.DatabaseClientProvider = fdbclient.NewDatabaseClientProvider(logger)
+		autoscalerReconciler.Log = logr.WithName("controllers").WithName("FoundationDBAutoscaler")
+		autoscalerReconciler.ServerSideApply = operatorOpts.ServerSideApply
+
+		if err := autoscalerReconciler.SetupWithManager(mgr, operatorOpts.MaxConcurrentReconciles, *labelSelector); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "FoundationDBAutoscaler")
+			os.Exit(1)
+		}
+	}
+
+	if operatorOpts.EnableWebhooks {
+		clusterWebhook := &webhooks.ClusterWebhook{
+			DeprecationOptions: operatorOpts.DeprecationOptions,
+		}
+		if err := clusterWebhook.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "FoundationDBCluster")
+			os.Exit(1)
+		}
+
+		for _, object := range []client.Object{&v1beta2.FoundationDBBackup{}, &v1beta2.FoundationDBRestore{}} {
+			if err := ctrl.NewWebhookManagedBy(mgr).For(object).Complete(); err != nil {
+				setupLog.Error(err, "unable to create webhook", "webhook", fmt.Sprintf("%T", object))
+				os.Exit(1)
+			}
+		}
+	}
+
 	if operatorOpts.CleanUpOldLogFile {
 		setupLog.V(1).Info("setup log file cleaner", "LogFileMinAge", operatorOpts.LogFileMinAge.String())
 		cleaner := internal.NewCliLogFileCleaner(logger, operatorOpts.LogFileMinAge)